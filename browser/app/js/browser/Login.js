@@ -31,7 +31,8 @@ export class Login extends React.Component {
       accessKey: "",
       secretKey: "",
       discoveryDoc: {},
-      clientId: ""
+      clientId: "",
+      codeFlow: false
     }
   }
 
@@ -86,9 +87,10 @@ export class Login extends React.Component {
   }
 
   componentDidMount() {
-    web.GetDiscoveryDoc().then(({ DiscoveryDoc, clientId }) => {
+    web.GetDiscoveryDoc().then(({ DiscoveryDoc, clientId, codeFlow }) => {
       this.setState({
         clientId,
+        codeFlow,
         discoveryDoc: DiscoveryDoc
       })
     })
@@ -144,7 +146,12 @@ export class Login extends React.Component {
             <div className="openid-login">
               <div className="or">or</div>
               {
-                this.state.clientId ? (
+                this.state.codeFlow ? (
+                  // The server redirects to the provider and back
+                  <a href={"/minio/openid/authorize"} className="btn openid-btn">
+                    Log in with OpenID
+                  </a>
+                ) : this.state.clientId ? (
                   <OpenIDLoginButton
                     className="btn openid-btn"
                     clientId={this.state.clientId}
@@ -95,6 +95,13 @@ export class OpenIDLogin extends React.Component {
       return
     }
 
+    // Token of the server side authorization code flow
+    if (values.token) {
+      web.SetToken(values.token)
+      this.forceUpdate()
+      return
+    }
+
     if (values.id_token) {
       // Check nonce on the token to prevent replay attacks
       const tokenJSON = jwtDecode(values.id_token)
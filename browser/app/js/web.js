@@ -75,6 +75,9 @@ class Web {
   GetToken() {
     return storage.getItem('token')
   }
+  SetToken(token) {
+    storage.setItem('token', token)
+  }
   GetDiscoveryDoc() {
     return this.makeCall("GetDiscoveryDoc")
   }
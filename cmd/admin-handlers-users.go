@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"io"
@@ -26,6 +27,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/policy"
 	iampolicy "github.com/minio/minio/pkg/iam/policy"
 	"github.com/minio/minio/pkg/madmin"
 )
@@ -378,6 +380,394 @@ func (a adminAPIHandlers) AddUser(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func validateAdminServiceAccountReq(ctx context.Context, w http.ResponseWriter, r *http.Request) (ObjectLayer, auth.Credentials, map[string]interface{}, bool) {
+	// Get current object layer instance.
+	objectAPI := newObjectLayerWithoutSafeModeFn()
+	if objectAPI == nil || globalNotificationSys == nil || globalIAMSys == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return nil, auth.Credentials{}, nil, false
+	}
+
+	// Validate request signature.
+	cred, claims, owner, s3Err := validateAdminSignature(ctx, r, "")
+	if s3Err != ErrNone {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
+		return nil, cred, nil, false
+	}
+
+	// Service accounts can't manage service accounts.
+	if cred.IsServiceAccount() {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+		return nil, cred, nil, false
+	}
+
+	return objectAPI, cred, claims, owner
+}
+
+// isServiceAccountReqAllowed - users manage their own service accounts,
+// managing the service accounts of other users needs the admin action.
+func isServiceAccountReqAllowed(r *http.Request, cred auth.Credentials, claims map[string]interface{}, owner bool,
+	action iampolicy.AdminAction, parentUser string) bool {
+	if !owner && parentUser == cred.AccessKey {
+		return true
+	}
+	return globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName:     cred.AccessKey,
+		Action:          iampolicy.Action(action),
+		ConditionValues: getConditionValues(r, "", cred.AccessKey, claims),
+		IsOwner:         owner,
+		Claims:          claims,
+	})
+}
+
+// parseServiceAccountPolicy - parses the session policy of a service
+// account, an empty session policy is not set.
+func parseServiceAccountPolicy(policyBuf []byte) (*iampolicy.Policy, error) {
+	if len(policyBuf) == 0 {
+		return nil, nil
+	}
+
+	// The session policy is carried by the session token of the
+	// service account, just like the session policies of STS
+	// credentials it shouldn't exceed 2048 characters.
+	if len(policyBuf) > 2048 {
+		return nil, iampolicy.Errorf("session policy shouldn't exceed 2048 characters")
+	}
+
+	sessionPolicy, err := iampolicy.ParseConfig(bytes.NewReader(policyBuf))
+	if err != nil {
+		return nil, err
+	}
+
+	// Policy without Version string value reject it.
+	if sessionPolicy.Version == "" {
+		return nil, iampolicy.Errorf("session policy is missing its version")
+	}
+
+	return sessionPolicy, nil
+}
+
+// AddServiceAccount - PUT /minio/admin/v2/add-service-account
+func (a adminAPIHandlers) AddServiceAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AddServiceAccount")
+
+	objectAPI, cred, claims, owner := validateAdminServiceAccountReq(ctx, w, r)
+	if objectAPI == nil {
+		return
+	}
+
+	// Deny if WORM is enabled
+	if globalWORMEnabled {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrMethodNotAllowed), r.URL)
+		return
+	}
+
+	if r.ContentLength > maxEConfigJSONSize || r.ContentLength == -1 {
+		// More than maxConfigSize bytes were available
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigTooLarge), r.URL)
+		return
+	}
+
+	password := cred.SecretKey
+	reqBytes, err := madmin.DecryptData(password, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
+		return
+	}
+
+	var createReq madmin.AddServiceAccountReq
+	if err = json.Unmarshal(reqBytes, &createReq); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
+		return
+	}
+
+	// Service accounts are created for the requesting user
+	// unless another user is given.
+	parentUser := createReq.TargetUser
+	if parentUser == "" {
+		parentUser = cred.AccessKey
+	}
+
+	// Service accounts not allowed for admin user.
+	if parentUser == globalActiveCred.AccessKey {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	if !isServiceAccountReqAllowed(r, cred, claims, owner, iampolicy.CreateServiceAccountAdminAction, parentUser) {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+		return
+	}
+
+	sessionPolicy, err := parseServiceAccountPolicy(createReq.Policy)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	newCred, err := globalIAMSys.NewServiceAccount(parentUser, sessionPolicy)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Notify all other Minio peers to reload the service account
+	for _, nerr := range globalNotificationSys.LoadServiceAccount(newCred.AccessKey) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+
+	// The session token of the service account is never
+	// returned, it is only used by the server.
+	data, err := json.Marshal(madmin.AddServiceAccountResp{
+		Credentials: auth.Credentials{
+			AccessKey: newCred.AccessKey,
+			SecretKey: newCred.SecretKey,
+		},
+	})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	econfigData, err := madmin.EncryptData(password, data)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, econfigData)
+}
+
+// UpdateServiceAccount - POST /minio/admin/v2/update-service-account?accessKey=<access_key>
+func (a adminAPIHandlers) UpdateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "UpdateServiceAccount")
+
+	objectAPI, cred, claims, owner := validateAdminServiceAccountReq(ctx, w, r)
+	if objectAPI == nil {
+		return
+	}
+
+	// Deny if WORM is enabled
+	if globalWORMEnabled {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrMethodNotAllowed), r.URL)
+		return
+	}
+
+	accessKey := mux.Vars(r)["accessKey"]
+	svcCred, err := globalIAMSys.GetServiceAccount(accessKey)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if !isServiceAccountReqAllowed(r, cred, claims, owner, iampolicy.UpdateServiceAccountAdminAction, svcCred.ParentUser) {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+		return
+	}
+
+	if r.ContentLength > maxEConfigJSONSize || r.ContentLength == -1 {
+		// More than maxConfigSize bytes were available
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigTooLarge), r.URL)
+		return
+	}
+
+	reqBytes, err := madmin.DecryptData(cred.SecretKey, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
+		return
+	}
+
+	var updateReq madmin.UpdateServiceAccountReq
+	if err = json.Unmarshal(reqBytes, &updateReq); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
+		return
+	}
+
+	sessionPolicy, err := parseServiceAccountPolicy(updateReq.NewPolicy)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err = globalIAMSys.UpdateServiceAccount(accessKey, updateServiceAccountOpts{
+		sessionPolicy: sessionPolicy,
+		secretKey:     updateReq.NewSecretKey,
+		status:        updateReq.NewStatus,
+	}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Notify all other Minio peers to reload the service account
+	for _, nerr := range globalNotificationSys.LoadServiceAccount(accessKey) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+}
+
+// ListServiceAccounts - GET /minio/admin/v2/list-service-accounts?user=<user>
+func (a adminAPIHandlers) ListServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListServiceAccounts")
+
+	objectAPI, cred, claims, owner := validateAdminServiceAccountReq(ctx, w, r)
+	if objectAPI == nil {
+		return
+	}
+
+	// Service accounts of the requesting user are listed
+	// unless another user is given.
+	parentUser := r.URL.Query().Get("user")
+	if parentUser == "" {
+		parentUser = cred.AccessKey
+	}
+
+	if !isServiceAccountReqAllowed(r, cred, claims, owner, iampolicy.ListServiceAccountsAdminAction, parentUser) {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+		return
+	}
+
+	serviceAccounts, err := globalIAMSys.ListServiceAccounts(parentUser)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(madmin.ListServiceAccountsResp{Accounts: serviceAccounts})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// DeleteServiceAccount - DELETE /minio/admin/v2/delete-service-account?accessKey=<access_key>
+func (a adminAPIHandlers) DeleteServiceAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteServiceAccount")
+
+	objectAPI, cred, claims, owner := validateAdminServiceAccountReq(ctx, w, r)
+	if objectAPI == nil {
+		return
+	}
+
+	// Deny if WORM is enabled
+	if globalWORMEnabled {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrMethodNotAllowed), r.URL)
+		return
+	}
+
+	accessKey := mux.Vars(r)["accessKey"]
+	svcCred, err := globalIAMSys.GetServiceAccount(accessKey)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if !isServiceAccountReqAllowed(r, cred, claims, owner, iampolicy.RemoveServiceAccountAdminAction, svcCred.ParentUser) {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+		return
+	}
+
+	if err = globalIAMSys.DeleteServiceAccount(accessKey); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Notify all other MinIO peers to delete the service account.
+	for _, nerr := range globalNotificationSys.DeleteServiceAccount(accessKey) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+}
+
+// ListSTSSessions - GET /minio/admin/v2/list-sts-sessions?user=<parent_user>
+func (a adminAPIHandlers) ListSTSSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListSTSSessions")
+
+	objectAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.ListSTSSessionsAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	sessions, err := globalIAMSys.ListSTSSessions(r.URL.Query().Get("user"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(madmin.ListSTSSessionsResp{Sessions: sessions})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// RevokeSTSSessions - POST /minio/admin/v2/revoke-sts-sessions?accessKey=<access_key>
+// revokes temporary credentials, or POST /minio/admin/v2/revoke-sts-sessions?user=<parent_user>
+// revokes all temporary credentials of the user.
+func (a adminAPIHandlers) RevokeSTSSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RevokeSTSSessions")
+
+	objectAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.RevokeSTSSessionsAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	query := r.URL.Query()
+	accessKey, parentUser := query.Get("accessKey"), query.Get("user")
+
+	var accessKeys []string
+	switch {
+	case accessKey != "" && parentUser == "":
+		cred, ok := globalIAMSys.GetUser(accessKey)
+		if !ok || !cred.IsTemp() {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errNoSuchUser), r.URL)
+			return
+		}
+		accessKeys = append(accessKeys, accessKey)
+	case accessKey == "" && parentUser != "":
+		sessions, err := globalIAMSys.ListSTSSessions(parentUser)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+		for _, session := range sessions {
+			accessKeys = append(accessKeys, session.AccessKey)
+		}
+	default:
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	for _, accessKey := range accessKeys {
+		if err := globalIAMSys.RevokeSTSSession(accessKey); err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+
+		// Notify all other MinIO peers to revoke the temporary credentials.
+		for _, nerr := range globalNotificationSys.RevokeSTSSession(accessKey) {
+			if nerr.Err != nil {
+				logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+				logger.LogIf(ctx, nerr.Err)
+			}
+		}
+	}
+}
+
 // InfoCannedPolicy - GET /minio/admin/v2/info-canned-policy?name={policyName}
 func (a adminAPIHandlers) InfoCannedPolicy(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "InfoCannedPolicy")
@@ -553,3 +943,108 @@ func (a adminAPIHandlers) SetPolicyForUserOrGroup(w http.ResponseWriter, r *http
 		}
 	}
 }
+
+// SimulatePolicy - POST /minio/admin/v2/simulate-policy
+//
+// Evaluates a request against the policies of the server and returns
+// the decision along with the statements which apply to the request.
+func (a adminAPIHandlers) SimulatePolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SimulatePolicy")
+
+	objectAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.SimulatePolicyAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	var args madmin.PolicySimulationArgs
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBucketPolicySize)).Decode(&args); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
+		return
+	}
+
+	conditionValues := make(map[string][]string, len(args.ConditionValues))
+	for k, v := range args.ConditionValues {
+		conditionValues[k] = v
+	}
+
+	var result madmin.PolicySimulationResult
+	if args.AccountName == "" {
+		action := policy.Action(args.Action)
+		if !action.IsValid() {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminInvalidArgument), r.URL)
+			return
+		}
+		setSimulatedConditionValues(conditionValues, "", nil)
+		result = globalPolicySys.SimulatePolicy(policy.Args{
+			Action:          action,
+			BucketName:      args.BucketName,
+			ConditionValues: conditionValues,
+			ObjectName:      args.ObjectName,
+		})
+	} else {
+		action := iampolicy.Action(args.Action)
+		if !action.IsValid() && !iampolicy.AdminAction(args.Action).IsValid() {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminInvalidArgument), r.URL)
+			return
+		}
+
+		owner := args.AccountName == globalActiveCred.AccessKey
+		var claims map[string]interface{}
+		if !owner {
+			cred, ok := globalIAMSys.GetUser(args.AccountName)
+			if !ok {
+				writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errNoSuchUser), r.URL)
+				return
+			}
+			if cred.IsTemp() {
+				// Temporary credentials are evaluated against the
+				// claims of the session token issued for them.
+				var err error
+				if claims, err = getClaimsFromSessionToken(cred.SessionToken); err != nil {
+					writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+					return
+				}
+			}
+		}
+		setSimulatedConditionValues(conditionValues, args.AccountName, claims)
+		result = globalIAMSys.SimulatePolicy(iampolicy.Args{
+			AccountName:     args.AccountName,
+			Action:          action,
+			BucketName:      args.BucketName,
+			ConditionValues: conditionValues,
+			ObjectName:      args.ObjectName,
+			IsOwner:         owner,
+			Claims:          claims,
+		})
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// setSimulatedConditionValues - sets the condition values derived from
+// the credentials of a request, unless they are given for the simulation.
+func setSimulatedConditionValues(conditionValues map[string][]string, username string, claims map[string]interface{}) {
+	principalType := "Anonymous"
+	if username != "" {
+		principalType = "User"
+	}
+	values := map[string][]string{
+		"principaltype": {principalType},
+		"userid":        {username},
+		"username":      {username},
+	}
+	for k, v := range getClaimConditionValues(claims) {
+		values[k] = v
+	}
+	for k, v := range values {
+		if _, ok := conditionValues[k]; !ok {
+			conditionValues[k] = v
+		}
+	}
+}
@@ -0,0 +1,164 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// serviceAccountAdminRequest - sends an admin request on the service
+// accounts signed with the given credentials, the request body is
+// encrypted with the secret key as done by madmin.
+func serviceAccountAdminRequest(t *testing.T, router http.Handler, cred auth.Credentials, method, path string, queryVal url.Values, body interface{}) *httptest.ResponseRecorder {
+	var data []byte
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data, err = madmin.EncryptData(cred.SecretKey, buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req, err := newTestRequest(method, adminPathPrefix+adminAPIVersionPrefix+path+"?"+queryVal.Encode(),
+		int64(len(data)), bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = signRequestV4(req, cred.AccessKey, cred.SecretKey); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServiceAccountHandlers(t *testing.T) {
+	atb, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer atb.TearDown()
+
+	for _, user := range []string{"alice", "bob"} {
+		if err = globalIAMSys.SetUser(user, madmin.UserInfo{SecretKey: user + "-secret-key", Status: madmin.AccountEnabled}); err != nil {
+			t.Fatal(err)
+		}
+		if err = globalIAMSys.PolicyDBSet(user, "readwrite", false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	alice := auth.Credentials{AccessKey: "alice", SecretKey: "alice-secret-key"}
+
+	listServiceAccounts := func(cred auth.Credentials, user string) []string {
+		rec := serviceAccountAdminRequest(t, atb.router, cred, http.MethodGet, "/list-service-accounts", url.Values{"user": []string{user}}, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected list of service accounts to succeed, got %d", rec.Code)
+		}
+		var resp madmin.ListServiceAccountsResp
+		if err = json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp.Accounts
+	}
+
+	// Users create their own service accounts.
+	rec := serviceAccountAdminRequest(t, atb.router, alice, http.MethodPut, "/add-service-account", url.Values{}, madmin.AddServiceAccountReq{})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected service account creation to succeed, got %d", rec.Code)
+	}
+	data, err := madmin.DecryptData(alice.SecretKey, bytes.NewReader(rec.Body.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var addResp madmin.AddServiceAccountResp
+	if err = json.Unmarshal(data, &addResp); err != nil {
+		t.Fatal(err)
+	}
+	svcCred := addResp.Credentials
+	if svcCred.AccessKey == "" || svcCred.SecretKey == "" || svcCred.SessionToken != "" {
+		t.Fatalf("Expected service account credentials without session token, got %v", svcCred)
+	}
+
+	// Users can't create service accounts of other users.
+	rec = serviceAccountAdminRequest(t, atb.router, alice, http.MethodPut, "/add-service-account", url.Values{}, madmin.AddServiceAccountReq{TargetUser: "bob"})
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected service account creation for another user to be denied, got %d", rec.Code)
+	}
+
+	// Service accounts can't manage service accounts.
+	rec = serviceAccountAdminRequest(t, atb.router, svcCred, http.MethodPut, "/add-service-account", url.Values{}, madmin.AddServiceAccountReq{})
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected service account creation by a service account to be denied, got %d", rec.Code)
+	}
+
+	// The admin creates service accounts of other users but not of itself.
+	rec = serviceAccountAdminRequest(t, atb.router, globalActiveCred, http.MethodPut, "/add-service-account", url.Values{}, madmin.AddServiceAccountReq{TargetUser: "bob"})
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected service account creation by the admin to succeed, got %d", rec.Code)
+	}
+	rec = serviceAccountAdminRequest(t, atb.router, globalActiveCred, http.MethodPut, "/add-service-account", url.Values{}, madmin.AddServiceAccountReq{})
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected service account creation for the admin to fail, got %d", rec.Code)
+	}
+
+	if accounts := listServiceAccounts(alice, ""); len(accounts) != 1 || accounts[0] != svcCred.AccessKey {
+		t.Errorf("Expected service accounts [%s], got %v", svcCred.AccessKey, accounts)
+	}
+	if accounts := listServiceAccounts(globalActiveCred, "bob"); len(accounts) != 1 {
+		t.Errorf("Expected one service account of bob, got %v", accounts)
+	}
+	rec = serviceAccountAdminRequest(t, atb.router, alice, http.MethodGet, "/list-service-accounts", url.Values{"user": []string{"bob"}}, nil)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected list of the service accounts of another user to be denied, got %d", rec.Code)
+	}
+
+	// Disabling the service account.
+	rec = serviceAccountAdminRequest(t, atb.router, alice, http.MethodPost, "/update-service-account",
+		url.Values{"accessKey": []string{svcCred.AccessKey}}, madmin.UpdateServiceAccountReq{NewStatus: madmin.AccountDisabled})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected service account update to succeed, got %d", rec.Code)
+	}
+	if _, ok := globalIAMSys.GetUser(svcCred.AccessKey); ok {
+		t.Errorf("Expected the disabled service account to be denied")
+	}
+
+	// Deleting the service account.
+	rec = serviceAccountAdminRequest(t, atb.router, alice, http.MethodDelete, "/delete-service-account",
+		url.Values{"accessKey": []string{svcCred.AccessKey}}, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected service account deletion to succeed, got %d", rec.Code)
+	}
+	if accounts := listServiceAccounts(alice, ""); len(accounts) != 0 {
+		t.Errorf("Expected no service accounts, got %v", accounts)
+	}
+	rec = serviceAccountAdminRequest(t, atb.router, alice, http.MethodDelete, "/delete-service-account",
+		url.Values{"accessKey": []string{svcCred.AccessKey}}, nil)
+	if rec.Code == http.StatusOK {
+		t.Errorf("Expected deletion of a deleted service account to fail")
+	}
+}
@@ -0,0 +1,198 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/logger"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+// ZonesStatusHandler - GET /minio/admin/v3/zones
+func (a adminAPIHandlers) ZonesStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ZonesStatus")
+
+	objectAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.StorageInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	z, ok := objectAPI.(*xlZones)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	zones, err := z.ZonesStatus(ctx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(zones)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// DecommissionZoneHandler - POST /minio/admin/v3/zones/decommission?zone=<index>
+func (a adminAPIHandlers) DecommissionZoneHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DecommissionZone")
+
+	a.updateZoneDecommission(ctx, w, r, true)
+}
+
+// CancelDecommissionZoneHandler - DELETE /minio/admin/v3/zones/decommission?zone=<index>
+func (a adminAPIHandlers) CancelDecommissionZoneHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelDecommissionZone")
+
+	a.updateZoneDecommission(ctx, w, r, false)
+}
+
+// updateZoneDecommission - starts or cancels the decommissioning of the
+// zone given in the request and notifies the peers of the change.
+func (a adminAPIHandlers) updateZoneDecommission(ctx context.Context, w http.ResponseWriter, r *http.Request, start bool) {
+	objectAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.DecommissionZoneAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	z, ok := objectAPI.(*xlZones)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	idx, err := strconv.Atoi(vars["zone"])
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminInvalidArgument), r.URL)
+		return
+	}
+
+	if start {
+		err = z.StartDecommission(ctx, idx)
+	} else {
+		err = z.CancelDecommission(ctx, idx)
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Notify all other MinIO peers to reload the status of the zones
+	for _, nerr := range globalNotificationSys.LoadZonesMeta() {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+}
+
+// RebalanceZonesHandler - POST /minio/admin/v3/zones/rebalance?threshold=<percent>
+func (a adminAPIHandlers) RebalanceZonesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RebalanceZones")
+
+	a.updateZonesRebalance(ctx, w, r, true)
+}
+
+// CancelRebalanceZonesHandler - DELETE /minio/admin/v3/zones/rebalance
+func (a adminAPIHandlers) CancelRebalanceZonesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelRebalanceZones")
+
+	a.updateZonesRebalance(ctx, w, r, false)
+}
+
+// RebalanceStatusHandler - GET /minio/admin/v3/zones/rebalance
+func (a adminAPIHandlers) RebalanceStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RebalanceStatus")
+
+	objectAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.StorageInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	z, ok := objectAPI.(*xlZones)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	info, err := z.RebalanceStatus(ctx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// updateZonesRebalance - starts or cancels the rebalancing of the zones
+// and notifies the peers of the change.
+func (a adminAPIHandlers) updateZonesRebalance(ctx context.Context, w http.ResponseWriter, r *http.Request, start bool) {
+	objectAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.RebalanceZonesAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	z, ok := objectAPI.(*xlZones)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	var err error
+	if start {
+		threshold := defaultRebalanceThreshold
+		if value := r.URL.Query().Get("threshold"); value != "" {
+			threshold, err = strconv.ParseFloat(value, 64)
+			if err != nil || threshold <= 0 || threshold >= 100 {
+				writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminInvalidArgument), r.URL)
+				return
+			}
+		}
+		err = z.StartRebalance(ctx, threshold)
+	} else {
+		err = z.CancelRebalance(ctx)
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Notify all other MinIO peers to reload the status of the zones
+	for _, nerr := range globalNotificationSys.LoadZonesMeta() {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -98,12 +99,16 @@ type allHealState struct {
 
 	// map of heal path to heal sequence
 	healSeqMap map[string]*healSequence
+
+	// map of endpoint to the progress of healing the local disk
+	healLocalDisks map[string]madmin.HealingDisk
 }
 
 // initHealState - initialize healing apparatus
 func initHealState() *allHealState {
 	healState := &allHealState{
-		healSeqMap: make(map[string]*healSequence),
+		healSeqMap:     make(map[string]*healSequence),
+		healLocalDisks: make(map[string]madmin.HealingDisk),
 	}
 
 	go healState.periodicHealSeqsClean()
@@ -148,6 +153,36 @@ func (ahs *allHealState) getHealSequenceByToken(token string) (h *healSequence,
 	return nil, false
 }
 
+// updateHealLocalDisk - records the progress of healing a local disk.
+func (ahs *allHealState) updateHealLocalDisk(disk madmin.HealingDisk) {
+	ahs.Lock()
+	defer ahs.Unlock()
+	disk.QueuedBuckets = append([]string(nil), disk.QueuedBuckets...)
+	disk.HealedBuckets = append([]string(nil), disk.HealedBuckets...)
+	ahs.healLocalDisks[disk.Endpoint] = disk
+}
+
+// popHealLocalDisk - forgets a local disk once healed.
+func (ahs *allHealState) popHealLocalDisk(endpoint string) {
+	ahs.Lock()
+	defer ahs.Unlock()
+	delete(ahs.healLocalDisks, endpoint)
+}
+
+// getHealLocalDisks - returns the progress of healing the local disks.
+func (ahs *allHealState) getHealLocalDisks() []madmin.HealingDisk {
+	ahs.Lock()
+	defer ahs.Unlock()
+	disks := make([]madmin.HealingDisk, 0, len(ahs.healLocalDisks))
+	for _, disk := range ahs.healLocalDisks {
+		disks = append(disks, disk)
+	}
+	sort.Slice(disks, func(i, j int) bool {
+		return disks[i].Endpoint < disks[j].Endpoint
+	})
+	return disks
+}
+
 // getHealSequence - Retrieve a heal sequence by path. The second
 // argument returns if a heal sequence actually exists.
 func (ahs *allHealState) getHealSequence(path string) (h *healSequence, exists bool) {
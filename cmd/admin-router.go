@@ -130,6 +130,16 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 		// User info
 		adminRouter.Methods(http.MethodGet).Path(adminAPIVersionPrefix+"/user-info").HandlerFunc(httpTraceHdrs(adminAPI.GetUserInfo)).Queries("accessKey", "{accessKey:.*}")
 
+		// Service accounts ops
+		adminRouter.Methods(http.MethodPut).Path(adminAPIVersionPrefix + "/add-service-account").HandlerFunc(httpTraceHdrs(adminAPI.AddServiceAccount))
+		adminRouter.Methods(http.MethodPost).Path(adminAPIVersionPrefix+"/update-service-account").HandlerFunc(httpTraceHdrs(adminAPI.UpdateServiceAccount)).Queries("accessKey", "{accessKey:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminAPIVersionPrefix + "/list-service-accounts").HandlerFunc(httpTraceHdrs(adminAPI.ListServiceAccounts))
+		adminRouter.Methods(http.MethodDelete).Path(adminAPIVersionPrefix+"/delete-service-account").HandlerFunc(httpTraceHdrs(adminAPI.DeleteServiceAccount)).Queries("accessKey", "{accessKey:.*}")
+
+		// STS sessions ops
+		adminRouter.Methods(http.MethodGet).Path(adminAPIVersionPrefix + "/list-sts-sessions").HandlerFunc(httpTraceHdrs(adminAPI.ListSTSSessions))
+		adminRouter.Methods(http.MethodPost).Path(adminAPIVersionPrefix + "/revoke-sts-sessions").HandlerFunc(httpTraceHdrs(adminAPI.RevokeSTSSessions))
+
 		// Add/Remove members from group
 		adminRouter.Methods(http.MethodPut).Path(adminAPIVersionPrefix + "/update-group-members").HandlerFunc(httpTraceHdrs(adminAPI.UpdateGroupMembers))
 
@@ -144,6 +154,45 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 
 		// List policies
 		adminRouter.Methods(http.MethodGet).Path(adminAPIVersionPrefix + "/list-canned-policies").HandlerFunc(httpTraceHdrs(adminAPI.ListCannedPolicies))
+
+		// Simulate the evaluation of policies
+		adminRouter.Methods(http.MethodPost).Path(adminAPIVersionPrefix + "/simulate-policy").HandlerFunc(httpTraceHdrs(adminAPI.SimulatePolicy))
+	}
+
+	// -- Tier APIs --
+	if globalIsDistXL || globalIsXL {
+		// Add, list and remove remote tiers of lifecycle transition
+		adminRouter.Methods(http.MethodPut).Path(adminAPIVersionPrefix + "/tier").HandlerFunc(httpTraceHdrs(adminAPI.AddTierHandler))
+		adminRouter.Methods(http.MethodGet).Path(adminAPIVersionPrefix + "/tier").HandlerFunc(httpTraceHdrs(adminAPI.ListTierHandler))
+		adminRouter.Methods(http.MethodDelete).Path(adminAPIVersionPrefix+"/tier").HandlerFunc(httpTraceHdrs(adminAPI.RemoveTierHandler)).Queries("name", "{name:.*}")
+	}
+
+	// -- Bucket Target APIs --
+	if globalIsDistXL || globalIsXL {
+		// Add, list and remove remote bucket targets of bucket replication
+		adminRouter.Methods(http.MethodPut).Path(adminAPIVersionPrefix + "/bucket-target").HandlerFunc(httpTraceHdrs(adminAPI.SetBucketTargetHandler))
+		adminRouter.Methods(http.MethodGet).Path(adminAPIVersionPrefix + "/bucket-target").HandlerFunc(httpTraceHdrs(adminAPI.ListBucketTargetsHandler))
+		adminRouter.Methods(http.MethodDelete).Path(adminAPIVersionPrefix+"/bucket-target").HandlerFunc(httpTraceHdrs(adminAPI.RemoveBucketTargetHandler)).Queries("name", "{name:.*}")
+	}
+
+	// -- Zone APIs --
+	if globalIsDistXL || globalIsXL {
+		// Zones status, start and cancel the decommissioning of a zone
+		adminRouter.Methods(http.MethodGet).Path(adminAPIVersionPrefix + "/zones").HandlerFunc(httpTraceHdrs(adminAPI.ZonesStatusHandler))
+		adminRouter.Methods(http.MethodPost).Path(adminAPIVersionPrefix+"/zones/decommission").HandlerFunc(httpTraceHdrs(adminAPI.DecommissionZoneHandler)).Queries("zone", "{zone:.*}")
+		adminRouter.Methods(http.MethodDelete).Path(adminAPIVersionPrefix+"/zones/decommission").HandlerFunc(httpTraceHdrs(adminAPI.CancelDecommissionZoneHandler)).Queries("zone", "{zone:.*}")
+
+		// Start, cancel and get the status of the rebalancing of the zones
+		adminRouter.Methods(http.MethodPost).Path(adminAPIVersionPrefix + "/zones/rebalance").HandlerFunc(httpTraceHdrs(adminAPI.RebalanceZonesHandler))
+		adminRouter.Methods(http.MethodGet).Path(adminAPIVersionPrefix + "/zones/rebalance").HandlerFunc(httpTraceHdrs(adminAPI.RebalanceStatusHandler))
+		adminRouter.Methods(http.MethodDelete).Path(adminAPIVersionPrefix + "/zones/rebalance").HandlerFunc(httpTraceHdrs(adminAPI.CancelRebalanceZonesHandler))
+	}
+
+	// -- Bucket Quota APIs --
+	if !globalIsGateway {
+		// Set and get bucket quota
+		adminRouter.Methods(http.MethodPut).Path(adminAPIVersionPrefix+"/set-bucket-quota").HandlerFunc(httpTraceHdrs(adminAPI.PutBucketQuotaConfigHandler)).Queries("bucket", "{bucket:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminAPIVersionPrefix+"/get-bucket-quota").HandlerFunc(httpTraceHdrs(adminAPI.GetBucketQuotaConfigHandler)).Queries("bucket", "{bucket:.*}")
 	}
 
 	// -- Top APIs --
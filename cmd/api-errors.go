@@ -33,10 +33,14 @@ import (
 	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/cors"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/bucket/logging"
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
 	"github.com/minio/minio/pkg/bucket/object/tagging"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/bucket/website"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/hash"
 )
@@ -101,6 +105,14 @@ const (
 	ErrNoSuchBucketLifecycle
 	ErrNoSuchLifecycleConfiguration
 	ErrNoSuchBucketSSEConfig
+	ErrReplicationConfigurationNotFoundError
+	ErrReplicationTargetNotFound
+	ErrBucketTaggingNotFound
+	ErrNoSuchCORSConfiguration
+	ErrCORSForbidden
+	ErrNoSuchWebsiteConfiguration
+	ErrInvalidTargetBucketForLogging
+	ErrBucketQuotaExceeded
 	ErrNoSuchKey
 	ErrNoSuchUpload
 	ErrNoSuchVersion
@@ -220,6 +232,7 @@ const (
 
 	ErrMalformedJSON
 	ErrAdminNoSuchUser
+	ErrAdminNoSuchServiceAccount
 	ErrAdminNoSuchGroup
 	ErrAdminGroupNotEmpty
 	ErrAdminNoSuchPolicy
@@ -231,6 +244,22 @@ const (
 	ErrAdminConfigBadJSON
 	ErrAdminConfigDuplicateKeys
 	ErrAdminCredentialsMismatch
+	ErrAdminNoSuchTier
+	ErrAdminTierAlreadyExists
+	ErrAdminTierInUse
+	ErrAdminTierNotEmpty
+	ErrAdminTierBackendInvalid
+	ErrAdminNoSuchBucketTarget
+	ErrAdminBucketTargetAlreadyExists
+	ErrAdminBucketTargetInUse
+	ErrAdminBucketTargetBackendInvalid
+	ErrAdminNoSuchZone
+	ErrAdminZoneDecommissionInProgress
+	ErrAdminZoneNotDecommissioning
+	ErrAdminZoneLastActive
+	ErrAdminRebalanceInProgress
+	ErrAdminRebalanceNotInProgress
+	ErrAdminNoSuchQuotaConfiguration
 	ErrInsecureClientRequest
 	ErrObjectTampered
 
@@ -508,6 +537,46 @@ var errorCodes = errorCodeMap{
 		Description:    "The server side encryption configuration was not found",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrReplicationConfigurationNotFoundError: {
+		Code:           "ReplicationConfigurationNotFoundError",
+		Description:    "The replication configuration was not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrReplicationTargetNotFound: {
+		Code:           "XMinioReplicationTargetNotFound",
+		Description:    "The remote bucket target named by the replication role does not exist",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrBucketTaggingNotFound: {
+		Code:           "NoSuchTagSet",
+		Description:    "The TagSet does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrNoSuchCORSConfiguration: {
+		Code:           "NoSuchCORSConfiguration",
+		Description:    "The CORS configuration does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrCORSForbidden: {
+		Code:           "AccessForbidden",
+		Description:    "CORSResponse: This CORS request is not allowed. This is usually because the evalution of Origin, request method / Access-Control-Request-Method or Access-Control-Request-Headers are not whitelisted by the resource's CORS spec.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrNoSuchWebsiteConfiguration: {
+		Code:           "NoSuchWebsiteConfiguration",
+		Description:    "The specified bucket does not have a website configuration",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrInvalidTargetBucketForLogging: {
+		Code:           "InvalidTargetBucketForLogging",
+		Description:    "The target bucket for logging does not exist or is not a valid bucket",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrBucketQuotaExceeded: {
+		Code:           "XMinioBucketQuotaExceeded",
+		Description:    "Bucket quota exceeded",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrNoSuchKey: {
 		Code:           "NoSuchKey",
 		Description:    "The specified key does not exist.",
@@ -1002,6 +1071,11 @@ var errorCodes = errorCodeMap{
 		Description:    "The specified user does not exist.",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrAdminNoSuchServiceAccount: {
+		Code:           "XMinioAdminNoSuchServiceAccount",
+		Description:    "The specified service account does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
 	ErrAdminNoSuchGroup: {
 		Code:           "XMinioAdminNoSuchGroup",
 		Description:    "The specified group does not exist.",
@@ -1068,6 +1142,86 @@ var errorCodes = errorCodeMap{
 		Description:    "Credentials in config mismatch with server environment variables",
 		HTTPStatusCode: http.StatusServiceUnavailable,
 	},
+	ErrAdminNoSuchTier: {
+		Code:           "XMinioAdminNoSuchTier",
+		Description:    "The specified remote tier does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminTierAlreadyExists: {
+		Code:           "XMinioAdminTierAlreadyExists",
+		Description:    "The specified remote tier already exists.",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrAdminTierInUse: {
+		Code:           "XMinioAdminTierInUse",
+		Description:    "The specified remote tier is in use by lifecycle rules - cannot remove it.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminTierNotEmpty: {
+		Code:           "XMinioAdminTierNotEmpty",
+		Description:    "The specified remote tier still holds the data of transitioned objects - cannot remove it.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminTierBackendInvalid: {
+		Code:           "XMinioAdminTierBackendInvalid",
+		Description:    "Unable to access the bucket of the remote tier with the given credentials.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminNoSuchBucketTarget: {
+		Code:           "XMinioAdminNoSuchBucketTarget",
+		Description:    "The specified remote bucket target does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminBucketTargetAlreadyExists: {
+		Code:           "XMinioAdminBucketTargetAlreadyExists",
+		Description:    "The specified remote bucket target already exists.",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrAdminBucketTargetInUse: {
+		Code:           "XMinioAdminBucketTargetInUse",
+		Description:    "The specified remote bucket target is in use by bucket replication - cannot remove it.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminBucketTargetBackendInvalid: {
+		Code:           "XMinioAdminBucketTargetBackendInvalid",
+		Description:    "Unable to access the remote bucket target with the given credentials.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminNoSuchZone: {
+		Code:           "XMinioAdminNoSuchZone",
+		Description:    "The specified zone does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminZoneDecommissionInProgress: {
+		Code:           "XMinioAdminZoneDecommissionInProgress",
+		Description:    "The specified zone is already being decommissioned.",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrAdminZoneNotDecommissioning: {
+		Code:           "XMinioAdminZoneNotDecommissioning",
+		Description:    "The specified zone is not being decommissioned.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminZoneLastActive: {
+		Code:           "XMinioAdminZoneLastActive",
+		Description:    "The specified zone is the last zone not being decommissioned - cannot decommission it.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminRebalanceInProgress: {
+		Code:           "XMinioAdminRebalanceInProgress",
+		Description:    "The zones are already being rebalanced.",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrAdminRebalanceNotInProgress: {
+		Code:           "XMinioAdminRebalanceNotInProgress",
+		Description:    "The zones are not being rebalanced.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminNoSuchQuotaConfiguration: {
+		Code:           "XMinioAdminNoSuchQuotaConfiguration",
+		Description:    "The quota configuration does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
 	ErrInsecureClientRequest: {
 		Code:           "XMinioInsecureClientRequest",
 		Description:    "Cannot respond to plain-text request from TLS-encrypted server",
@@ -1594,12 +1748,44 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrAdminInvalidArgument
 	case errNoSuchUser:
 		apiErr = ErrAdminNoSuchUser
+	case errNoSuchServiceAccount:
+		apiErr = ErrAdminNoSuchServiceAccount
 	case errNoSuchGroup:
 		apiErr = ErrAdminNoSuchGroup
 	case errGroupNotEmpty:
 		apiErr = ErrAdminGroupNotEmpty
 	case errNoSuchPolicy:
 		apiErr = ErrAdminNoSuchPolicy
+	case errNoSuchTier:
+		apiErr = ErrAdminNoSuchTier
+	case errTierAlreadyExists:
+		apiErr = ErrAdminTierAlreadyExists
+	case errTierInUse:
+		apiErr = ErrAdminTierInUse
+	case errTierNotEmpty:
+		apiErr = ErrAdminTierNotEmpty
+	case errTierBackendInvalid:
+		apiErr = ErrAdminTierBackendInvalid
+	case errNoSuchBucketTarget:
+		apiErr = ErrAdminNoSuchBucketTarget
+	case errBucketTargetAlreadyExists:
+		apiErr = ErrAdminBucketTargetAlreadyExists
+	case errBucketTargetInUse:
+		apiErr = ErrAdminBucketTargetInUse
+	case errBucketTargetBackendInvalid:
+		apiErr = ErrAdminBucketTargetBackendInvalid
+	case errNoSuchZone:
+		apiErr = ErrAdminNoSuchZone
+	case errZoneDecommissionInProgress:
+		apiErr = ErrAdminZoneDecommissionInProgress
+	case errZoneNotDecommissioning:
+		apiErr = ErrAdminZoneNotDecommissioning
+	case errZoneLastActive:
+		apiErr = ErrAdminZoneLastActive
+	case errRebalanceInProgress:
+		apiErr = ErrAdminRebalanceInProgress
+	case errRebalanceNotInProgress:
+		apiErr = ErrAdminRebalanceNotInProgress
 	case errSignatureMismatch:
 		apiErr = ErrSignatureDoesNotMatch
 	case errInvalidRange:
@@ -1703,6 +1889,10 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrBucketAlreadyOwnedByYou
 	case ObjectNotFound:
 		apiErr = ErrNoSuchKey
+	case VersionNotFound:
+		apiErr = ErrNoSuchVersion
+	case MethodNotAllowed:
+		apiErr = ErrMethodNotAllowed
 	case ObjectAlreadyExists:
 		apiErr = ErrMethodNotAllowed
 	case ObjectNameInvalid:
@@ -1747,6 +1937,18 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrNoSuchLifecycleConfiguration
 	case BucketSSEConfigNotFound:
 		apiErr = ErrNoSuchBucketSSEConfig
+	case BucketReplicationConfigNotFound:
+		apiErr = ErrReplicationConfigurationNotFoundError
+	case BucketTaggingNotFound:
+		apiErr = ErrBucketTaggingNotFound
+	case BucketCorsConfigNotFound:
+		apiErr = ErrNoSuchCORSConfiguration
+	case BucketWebsiteConfigNotFound:
+		apiErr = ErrNoSuchWebsiteConfiguration
+	case BucketQuotaConfigNotFound:
+		apiErr = ErrAdminNoSuchQuotaConfiguration
+	case BucketQuotaExceeded:
+		apiErr = ErrBucketQuotaExceeded
 	case *event.ErrInvalidEventName:
 		apiErr = ErrEventNotification
 	case *event.ErrInvalidARN:
@@ -1837,6 +2039,30 @@ func toAPIError(ctx context.Context, err error) APIError {
 				Description:    e.Error(),
 				HTTPStatusCode: http.StatusBadRequest,
 			}
+		case replication.Error:
+			apiErr = APIError{
+				Code:           "InvalidRequest",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case cors.Error:
+			apiErr = APIError{
+				Code:           "InvalidRequest",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case website.Error:
+			apiErr = APIError{
+				Code:           "InvalidArgument",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case logging.Error:
+			apiErr = APIError{
+				Code:           "InvalidArgument",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
 		case tagging.Error:
 			apiErr = APIError{
 				Code:           e.Code(),
@@ -104,6 +104,11 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSp
 		w.Header().Set(xhttp.AmzTagCount, strconv.Itoa(tagCount))
 	}
 
+	// Set version id if the object is versioned.
+	if objInfo.VersionID != "" {
+		w.Header()[xhttp.AmzVersionID] = []string{objInfo.VersionID}
+	}
+
 	// Set all other user defined metadata.
 	for k, v := range objInfo.UserDefined {
 		if HasPrefix(k, ReservedMetadataPrefix) {
@@ -116,16 +121,16 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSp
 
 	var totalObjectSize int64
 	switch {
-	case crypto.IsEncrypted(objInfo.UserDefined):
-		totalObjectSize, err = objInfo.DecryptedSize()
-		if err != nil {
-			return err
-		}
 	case objInfo.IsCompressed():
 		totalObjectSize = objInfo.GetActualSize()
 		if totalObjectSize < 0 {
 			return errInvalidDecompressedSize
 		}
+	case crypto.IsEncrypted(objInfo.UserDefined):
+		totalObjectSize, err = objInfo.DecryptedSize()
+		if err != nil {
+			return err
+		}
 	default:
 		totalObjectSize = objInfo.Size
 	}
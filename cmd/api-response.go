@@ -79,6 +79,7 @@ type ListVersionsResponse struct {
 
 	CommonPrefixes []CommonPrefix
 	Versions       []ObjectVersion
+	DeleteMarkers  []DeleteMarkerVersion
 
 	// Encoding type used to encode object keys in the response.
 	EncodingType string `xml:"EncodingType,omitempty"`
@@ -239,6 +240,16 @@ type ObjectVersion struct {
 	IsLatest  bool
 }
 
+// DeleteMarkerVersion container for delete marker metadata
+type DeleteMarkerVersion struct {
+	XMLName      xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ DeleteMarker" json:"-"`
+	Key          string
+	VersionID    string `xml:"VersionId"`
+	IsLatest     bool
+	LastModified string // time string of format "2006-01-02T15:04:05.000Z"
+	Owner        Owner
+}
+
 // StringMap is a map[string]string.
 type StringMap map[string]string
 
@@ -456,6 +467,71 @@ func generateListVersionsResponse(bucket, prefix, marker, delimiter, encodingTyp
 	return data
 }
 
+// generates an ListObjectVersions response for the said bucket with versioning
+// configured, delete markers are listed separately from the object versions.
+func generateListObjectVersionsResponse(bucket, prefix, marker, versionIDMarker, delimiter, encodingType string, maxKeys int, resp ListObjectVersionsInfo) ListVersionsResponse {
+	var versions []ObjectVersion
+	var deleteMarkers []DeleteMarkerVersion
+	var prefixes []CommonPrefix
+	var owner = Owner{}
+	var data = ListVersionsResponse{}
+
+	owner.ID = globalMinioDefaultOwnerID
+	for _, object := range resp.Objects {
+		if object.Name == "" {
+			continue
+		}
+		versionID := object.VersionID
+		if versionID == "" {
+			versionID = nullVersionID
+		}
+		if object.DeleteMarker {
+			deleteMarkers = append(deleteMarkers, DeleteMarkerVersion{
+				Key:          s3EncodeName(object.Name, encodingType),
+				VersionID:    versionID,
+				IsLatest:     object.IsLatest,
+				LastModified: object.ModTime.UTC().Format(timeFormatAMZLong),
+				Owner:        owner,
+			})
+			continue
+		}
+		var content = ObjectVersion{}
+		content.Key = s3EncodeName(object.Name, encodingType)
+		content.LastModified = object.ModTime.UTC().Format(timeFormatAMZLong)
+		if object.ETag != "" {
+			content.ETag = "\"" + object.ETag + "\""
+		}
+		content.Size = object.Size
+		content.StorageClass = object.StorageClass
+		content.Owner = owner
+		content.VersionID = versionID
+		content.IsLatest = object.IsLatest
+		versions = append(versions, content)
+	}
+	data.Name = bucket
+	data.Versions = versions
+	data.DeleteMarkers = deleteMarkers
+
+	data.EncodingType = encodingType
+	data.Prefix = s3EncodeName(prefix, encodingType)
+	data.KeyMarker = s3EncodeName(marker, encodingType)
+	data.VersionIDMarker = versionIDMarker
+	data.Delimiter = s3EncodeName(delimiter, encodingType)
+	data.MaxKeys = maxKeys
+
+	data.NextKeyMarker = s3EncodeName(resp.NextMarker, encodingType)
+	data.NextVersionIDMarker = resp.NextVersionIDMarker
+	data.IsTruncated = resp.IsTruncated
+
+	for _, prefix := range resp.Prefixes {
+		var prefixItem = CommonPrefix{}
+		prefixItem.Prefix = s3EncodeName(prefix, encodingType)
+		prefixes = append(prefixes, prefixItem)
+	}
+	data.CommonPrefixes = prefixes
+	return data
+}
+
 // generates an ListObjectsV1 response for the said bucket with other enumerated options.
 func generateListObjectsV1Response(bucket, prefix, marker, delimiter, encodingType string, maxKeys int, resp ListObjectsInfo) ListObjectsResponse {
 	var contents []Object
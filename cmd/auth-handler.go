@@ -32,7 +32,9 @@ import (
 	xjwt "github.com/minio/minio/cmd/jwt"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/object/tagging"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/policy/condition"
 	"github.com/minio/minio/pkg/hash"
 	iampolicy "github.com/minio/minio/pkg/iam/policy"
 )
@@ -118,9 +120,10 @@ func getRequestAuthType(r *http.Request) authType {
 	return authTypeUnknown
 }
 
-// checkAdminRequestAuthType checks whether the request is a valid signature V2 or V4 request.
-// It does not accept presigned or JWT or anonymous requests.
-func checkAdminRequestAuthType(ctx context.Context, r *http.Request, action iampolicy.AdminAction, region string) (auth.Credentials, APIErrorCode) {
+// validateAdminSignature validates the signature of an admin request, which
+// must be a signature V4 request. It returns the credentials and the claims
+// of the request without evaluating any policies.
+func validateAdminSignature(ctx context.Context, r *http.Request, region string) (auth.Credentials, map[string]interface{}, bool, APIErrorCode) {
 	var cred auth.Credentials
 	var owner bool
 	s3Err := ErrAccessDenied
@@ -129,7 +132,7 @@ func checkAdminRequestAuthType(ctx context.Context, r *http.Request, action iamp
 		// We only support admin credentials to access admin APIs.
 		cred, owner, s3Err = getReqAccessKeyV4(r, region, serviceS3)
 		if s3Err != ErrNone {
-			return cred, s3Err
+			return cred, nil, owner, s3Err
 		}
 
 		// we only support V4 (no presign) with auth body
@@ -139,10 +142,21 @@ func checkAdminRequestAuthType(ctx context.Context, r *http.Request, action iamp
 		reqInfo := (&logger.ReqInfo{}).AppendTags("requestHeaders", dumpRequest(r))
 		ctx := logger.SetReqInfo(ctx, reqInfo)
 		logger.LogIf(ctx, errors.New(getAPIError(s3Err).Description), logger.Application)
+		return cred, nil, owner, s3Err
 	}
 
-	var claims map[string]interface{}
-	claims, s3Err = checkClaimsFromToken(r, cred)
+	claims, s3Err := checkClaimsFromToken(r, cred)
+	if s3Err != ErrNone {
+		return cred, nil, owner, s3Err
+	}
+
+	return cred, claims, owner, ErrNone
+}
+
+// checkAdminRequestAuthType checks whether the request is a valid signature V2 or V4 request.
+// It does not accept presigned or JWT or anonymous requests.
+func checkAdminRequestAuthType(ctx context.Context, r *http.Request, action iampolicy.AdminAction, region string) (auth.Credentials, APIErrorCode) {
+	cred, claims, owner, s3Err := validateAdminSignature(ctx, r, region)
 	if s3Err != ErrNone {
 		return cred, s3Err
 	}
@@ -179,9 +193,13 @@ func mustGetClaimsFromToken(r *http.Request) map[string]interface{} {
 
 // Fetch claims in the security token returned by the client.
 func getClaimsFromToken(r *http.Request) (map[string]interface{}, error) {
+	return getClaimsFromSessionToken(getSessionToken(r))
+}
+
+// Fetch claims in the given security token.
+func getClaimsFromSessionToken(token string) (map[string]interface{}, error) {
 	claims := xjwt.NewMapClaims()
 
-	token := getSessionToken(r)
 	if token == "" {
 		return claims.Map(), nil
 	}
@@ -241,6 +259,12 @@ func checkClaimsFromToken(r *http.Request, cred auth.Credentials) (map[string]in
 	if token != "" && cred.AccessKey == "" {
 		return nil, ErrNoAccessKey
 	}
+	if token == "" && cred.IsServiceAccount() {
+		// Service accounts sign requests with their access and
+		// secret keys only, their session token is never sent
+		// by clients.
+		return xjwt.NewMapClaims().Map(), ErrNone
+	}
 	if subtle.ConstantTimeCompare([]byte(token), []byte(cred.SessionToken)) != 1 {
 		return nil, ErrInvalidToken
 	}
@@ -321,12 +345,21 @@ func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, actio
 		r.Body = ioutil.NopCloser(bytes.NewReader(payload))
 	}
 
+	tagValues, s3Err := getObjectTagConditionValues(ctx, r, action, bucketName, objectName, cred, claims)
+	if s3Err != ErrNone {
+		return accessKey, owner, s3Err
+	}
+
 	if cred.AccessKey == "" {
+		conditionValues := getConditionValues(r, locationConstraint, "", nil)
+		for k, v := range tagValues {
+			conditionValues[k] = v
+		}
 		if globalPolicySys.IsAllowed(policy.Args{
 			AccountName:     cred.AccessKey,
 			Action:          action,
 			BucketName:      bucketName,
-			ConditionValues: getConditionValues(r, locationConstraint, "", nil),
+			ConditionValues: conditionValues,
 			IsOwner:         false,
 			ObjectName:      objectName,
 		}) {
@@ -335,11 +368,15 @@ func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, actio
 		}
 		return accessKey, owner, ErrAccessDenied
 	}
+	conditionValues := getConditionValues(r, "", cred.AccessKey, claims)
+	for k, v := range tagValues {
+		conditionValues[k] = v
+	}
 	if globalIAMSys.IsAllowed(iampolicy.Args{
 		AccountName:     cred.AccessKey,
 		Action:          iampolicy.Action(action),
 		BucketName:      bucketName,
-		ConditionValues: getConditionValues(r, "", cred.AccessKey, claims),
+		ConditionValues: conditionValues,
 		ObjectName:      objectName,
 		IsOwner:         owner,
 		Claims:          claims,
@@ -350,6 +387,77 @@ func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, actio
 	return accessKey, owner, ErrAccessDenied
 }
 
+// getObjectTagConditionValues - returns the values of the object tag condition
+// keys of the request. Tags of the existing object are only fetched if any
+// policy applying to the credentials has conditions on them.
+func getObjectTagConditionValues(ctx context.Context, r *http.Request, action policy.Action, bucketName, objectName string,
+	cred auth.Credentials, claims map[string]interface{}) (map[string][]string, APIErrorCode) {
+	values := make(map[string][]string)
+
+	var requestTags []tagging.Tag
+	switch action {
+	case policy.PutObjectAction:
+		if tagStr := r.Header.Get(xhttp.AmzObjectTagging); tagStr != "" {
+			// Invalid tags are rejected by the HTTP handler.
+			if tags, err := tagging.FromString(tagStr); err == nil {
+				requestTags = tags.TagSet.Tags
+			}
+		}
+	case policy.PutObjectTaggingAction:
+		// To extract tags from XML in request body, get copy of request body.
+		payload, err := ioutil.ReadAll(io.LimitReader(r.Body, maxObjectTaggingSize))
+		if err != nil {
+			logger.LogIf(ctx, err, logger.Application)
+			return nil, ErrMalformedXML
+		}
+
+		// Populate payload again to handle it in HTTP handler.
+		r.Body = ioutil.NopCloser(bytes.NewReader(payload))
+
+		// Invalid tags are rejected by the HTTP handler.
+		if tags, err := tagging.ParseTagging(bytes.NewReader(payload)); err == nil {
+			requestTags = tags.TagSet.Tags
+		}
+	}
+
+	for _, tag := range requestTags {
+		values[condition.S3RequestObjectTag.Name()+"/"+tag.Key] = []string{tag.Value}
+		values[condition.S3RequestObjectTagKeys.Name()] = append(values[condition.S3RequestObjectTagKeys.Name()], tag.Key)
+	}
+
+	switch action {
+	case policy.GetObjectAction, policy.GetObjectTaggingAction, policy.PutObjectTaggingAction, policy.DeleteObjectTaggingAction:
+		if objectName == "" {
+			break
+		}
+		if !globalPolicySys.hasConditionKey(bucketName, condition.S3ExistingObjectTag) &&
+			!globalIAMSys.hasConditionKey(condition.S3ExistingObjectTag, cred, claims) {
+			break
+		}
+		objAPI := newObjectLayerFn()
+		if objAPI == nil {
+			return nil, ErrServerNotInitialized
+		}
+		// Objects which don't exist have no tags, the HTTP handler
+		// replies with the appropriate error.
+		objInfo, err := objAPI.GetObjectInfo(ctx, bucketName, objectName, ObjectOptions{
+			VersionID: getRequestVersionID(r, bucketName),
+		})
+		if err != nil {
+			break
+		}
+		tags, err := tagging.FromString(objInfo.UserTags)
+		if err != nil {
+			break
+		}
+		for _, tag := range tags.TagSet.Tags {
+			values[condition.S3ExistingObjectTag.Name()+"/"+tag.Key] = []string{tag.Value}
+		}
+	}
+
+	return values, ErrNone
+}
+
 // Verify if request has valid AWS Signature Version '2'.
 func isReqAuthenticatedV2(r *http.Request) (s3Error APIErrorCode) {
 	if isRequestSignatureV2(r) {
@@ -27,7 +27,10 @@ import (
 	"testing"
 	"time"
 
+	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/versioning"
 	iampolicy "github.com/minio/minio/pkg/iam/policy"
 )
 
@@ -425,3 +428,127 @@ func TestCheckAdminRequestAuthType(t *testing.T) {
 		}
 	}
 }
+
+// Test the object tag condition values of requests.
+func TestGetObjectTagConditionValues(t *testing.T) {
+	putObjectReq, err := http.NewRequest(http.MethodPut, "http://127.0.0.1:9000/bucket/object", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	putObjectReq.Header.Set(xhttp.AmzObjectTagging, "classification=public")
+
+	tagging := `<Tagging><TagSet><Tag><Key>classification</Key><Value>public</Value></Tag></TagSet></Tagging>`
+	putTaggingReq, err := http.NewRequest(http.MethodPut, "http://127.0.0.1:9000/bucket/object?tagging", bytes.NewReader([]byte(tagging)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		req    *http.Request
+		action policy.Action
+	}{
+		{putObjectReq, policy.PutObjectAction},
+		{putTaggingReq, policy.PutObjectTaggingAction},
+	}
+
+	for i, testCase := range testCases {
+		values, s3Err := getObjectTagConditionValues(context.Background(), testCase.req, testCase.action, "bucket", "", auth.Credentials{}, nil)
+		if s3Err != ErrNone {
+			t.Fatalf("Test %d: Unexpected s3error returned %d", i+1, s3Err)
+		}
+		if v := values["RequestObjectTag/classification"]; len(v) != 1 || v[0] != "public" {
+			t.Errorf("Test %d: Unexpected request object tag values %v", i+1, v)
+		}
+		if v := values["RequestObjectTagKeys"]; len(v) != 1 || v[0] != "classification" {
+			t.Errorf("Test %d: Unexpected request object tag keys %v", i+1, v)
+		}
+	}
+
+	// The request body must be available to the handler.
+	body, err := ioutil.ReadAll(putTaggingReq.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != tagging {
+		t.Errorf("Unexpected request body %s", body)
+	}
+}
+
+func TestGetExistingObjectTagConditionValues(t *testing.T) {
+	obj, fsDirs, err := prepareXL(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	globalObjLayerMutex.Lock()
+	oldObjectAPI := globalObjectAPI
+	globalObjectAPI = obj
+	globalObjLayerMutex.Unlock()
+	defer func() {
+		globalObjLayerMutex.Lock()
+		globalObjectAPI = oldObjectAPI
+		globalObjLayerMutex.Unlock()
+	}()
+
+	defer func(sys *BucketVersioningSys) { globalBucketVersioningSys = sys }(globalBucketVersioningSys)
+	defer func(sys *PolicySys) { globalPolicySys = sys }(globalPolicySys)
+	defer func(sys *IAMSys) { globalIAMSys = sys }(globalIAMSys)
+	globalBucketVersioningSys = NewBucketVersioningSys()
+	globalPolicySys = NewPolicySys()
+	globalIAMSys = NewIAMSys()
+
+	ctx := context.Background()
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	globalBucketVersioningSys.Set(bucket, versioning.Versioning{Status: versioning.Enabled})
+
+	var versionIDs []string
+	for _, tags := range []string{"classification=public", "classification=private"} {
+		data := []byte("hello")
+		objInfo, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""),
+			ObjectOptions{UserDefined: map[string]string{xhttp.AmzObjectTagging: tags}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		versionIDs = append(versionIDs, objInfo.VersionID)
+	}
+
+	// Only the session policy has conditions on the tags of the object.
+	claims := map[string]interface{}{
+		iampolicy.SessionPolicyName: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::bucket/*"],` +
+			`"Condition":{"StringEquals":{"s3:ExistingObjectTag/classification":["public"]}}}]}`,
+	}
+
+	testCases := []struct {
+		versionID      string
+		claims         map[string]interface{}
+		expectedValues []string
+	}{
+		{versionIDs[0], claims, []string{"public"}},
+		{"", claims, []string{"private"}},
+		// Tags are not fetched when no policy has conditions on them.
+		{"", nil, nil},
+	}
+
+	for i, testCase := range testCases {
+		u := "http://127.0.0.1:9000/bucket/object"
+		if testCase.versionID != "" {
+			u += "?versionId=" + testCase.versionID
+		}
+		req, err := http.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		values, s3Err := getObjectTagConditionValues(ctx, req, policy.GetObjectAction, bucket, object, auth.Credentials{}, testCase.claims)
+		if s3Err != ErrNone {
+			t.Fatalf("Test %d: Unexpected s3error returned %d", i+1, s3Err)
+		}
+		v := values["ExistingObjectTag/classification"]
+		if len(v) != len(testCase.expectedValues) || (len(v) == 1 && v[0] != testCase.expectedValues[0]) {
+			t.Errorf("Test %d: Expected existing object tag values %v, got %v", i+1, testCase.expectedValues, v)
+		}
+	}
+}
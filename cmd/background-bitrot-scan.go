@@ -0,0 +1,292 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	bitrotScanMetaFile = "bitrot-scan.json"
+
+	// Interval at which the time of the next scan is checked.
+	bitrotScanCheckInterval = time.Minute
+)
+
+// bitrotScanMeta - the time the last complete scan for bitrot started,
+// saved so that restarting servers don't restart the scans.
+type bitrotScanMeta struct {
+	LastScan time.Time `json:"lastScan"`
+}
+
+// bitrotScanStats - counters of the shards of objects verified by the
+// scans for bitrot of this server.
+type bitrotScanStats struct {
+	verifiedShards uint64
+	corruptShards  uint64
+	healedShards   uint64
+}
+
+var globalBitrotScanStats bitrotScanStats
+
+// bitrotScanDelay - returns how long to wait, after verifying the given
+// number of bytes on each disk in elapsed time, to not exceed rate bytes
+// per second on each disk.
+func bitrotScanDelay(bytes, rate uint64, elapsed time.Duration) time.Duration {
+	if rate == 0 {
+		return 0
+	}
+	expected := time.Duration(float64(bytes) / float64(rate) * float64(time.Second))
+	if expected <= elapsed {
+		return 0
+	}
+	return expected - elapsed
+}
+
+// verifyObjectBitrot - verifies the checksums of the shards of all the
+// versions of the object on each disk holding it, and heals the object
+// when corrupt shards are found. Returns the number of bytes verified
+// on each disk.
+func verifyObjectBitrot(ctx context.Context, xlObj *xlObjects, bucket, object string) uint64 {
+	_, metaArr, onlineDisks, err := xlObj.readXLMetaQuorum(ctx, bucket, object)
+	if err != nil {
+		// Objects deleted meanwhile or without read quorum
+		// are left to the healing.
+		return 0
+	}
+
+	var size uint64
+	var verified, corrupt uint64
+	for i, disk := range onlineDisks {
+		if disk == nil {
+			continue
+		}
+		var diskSize uint64
+		for _, version := range metaArr[i].allVersions() {
+			if len(version.Parts) == 0 || version.isTransitioned() {
+				continue
+			}
+			erasureInfo := version.Erasure
+			erasure, err := NewErasure(ctx, erasureInfo.DataBlocks, erasureInfo.ParityBlocks, erasureInfo.BlockSize)
+			if err != nil {
+				logger.LogIf(ctx, err)
+				continue
+			}
+			for _, part := range version.Parts {
+				checksumInfo := erasureInfo.GetChecksumInfo(part.Number)
+				partPath := pathJoin(object, version.DataDir, fmt.Sprintf("part.%d", part.Number))
+				shardSize := erasure.ShardFileSize(part.Size)
+				err = disk.VerifyFile(bucket, partPath, shardSize, checksumInfo.Algorithm, checksumInfo.Hash, erasure.ShardSize())
+				switch err {
+				case nil:
+					verified++
+				case errFileCorrupt:
+					corrupt++
+				case errFileNotFound, errVolumeNotFound, errDiskNotFound, errFaultyDisk:
+					// Missing shards and offline disks are
+					// left to the healing.
+				default:
+					// Shards which can't be read, e.g. on I/O
+					// errors, are healed as corrupt ones.
+					logger.GetReqInfo(ctx).AppendTags("disk", disk.String())
+					logger.LogIf(ctx, err)
+					corrupt++
+				}
+				diskSize += uint64(shardSize)
+			}
+		}
+		if diskSize > size {
+			size = diskSize
+		}
+	}
+
+	atomic.AddUint64(&globalBitrotScanStats.verifiedShards, verified)
+	if corrupt == 0 {
+		return size
+	}
+	atomic.AddUint64(&globalBitrotScanStats.corruptShards, corrupt)
+
+	res, err := healTaskSync(pathJoin(bucket, object), madmin.HealOpts{ScanMode: madmin.HealDeepScan})
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to heal bitrot of %s: %w", pathJoin(bucket, object), err))
+		return size
+	}
+	var healed uint64
+	for i, drive := range res.Before.Drives {
+		if drive.State == madmin.DriveStateCorrupt && i < len(res.After.Drives) &&
+			res.After.Drives[i].State == madmin.DriveStateOk {
+			healed++
+		}
+	}
+	atomic.AddUint64(&globalBitrotScanStats.healedShards, healed)
+	return size
+}
+
+// scanErasureSetBitrot - verifies all the objects of the erasure set,
+// verifying at most rate bytes per second on each disk.
+func scanErasureSetBitrot(ctx context.Context, xlObj *xlObjects, rate uint64) error {
+	buckets, err := xlObj.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The metadata of the cluster is verified as well.
+	roots := []struct{ bucket, prefix string }{
+		{minioMetaBucket, minioConfigPrefix + SlashSeparator},
+		{minioMetaBucket, bucketConfigPrefix + SlashSeparator},
+	}
+	for _, bucket := range buckets {
+		roots = append(roots, struct{ bucket, prefix string }{bucket.Name, ""})
+	}
+
+	var scanned uint64
+	start := time.Now()
+	for _, root := range roots {
+		listDir := listDirFactory(ctx, xlObj.getLoadBalancedDisks()...)
+		endWalkCh := make(chan struct{})
+		walkResultCh := startTreeWalk(ctx, root.bucket, root.prefix, "", true, listDir, endWalkCh)
+		for walkEntry := range walkResultCh {
+			select {
+			case <-GlobalServiceDoneCh:
+				close(endWalkCh)
+				return nil
+			default:
+			}
+
+			// Wait and proceed if there are active requests
+			waitForLowHTTPReq(int32(len(xlObj.getDisks())))
+
+			scanned += verifyObjectBitrot(ctx, xlObj, root.bucket, walkEntry.entry)
+			if delay := bitrotScanDelay(scanned, rate, time.Since(start)); delay > 0 {
+				time.Sleep(delay)
+			}
+		}
+		close(endWalkCh)
+	}
+	return nil
+}
+
+// scanZonesBitrot - verifies all the objects of all the erasure sets,
+// the erasure sets are scanned in parallel.
+func scanZonesBitrot(ctx context.Context, z *xlZones, rate uint64) {
+	var wg sync.WaitGroup
+	for _, zone := range z.zones {
+		for _, set := range zone.sets {
+			wg.Add(1)
+			go func(set *xlObjects) {
+				defer wg.Done()
+				if err := scanErasureSetBitrot(ctx, set, rate); err != nil {
+					logger.LogIf(ctx, fmt.Errorf("Unable to scan erasure set for bitrot: %w", err))
+				}
+			}(set)
+		}
+	}
+	wg.Wait()
+}
+
+func readBitrotScanMeta(ctx context.Context, objAPI ObjectLayer) (bitrotScanMeta, error) {
+	var meta bitrotScanMeta
+	data, err := readConfig(ctx, objAPI, path.Join(backgroundOpsMetaPrefix, bitrotScanMetaFile))
+	if err != nil {
+		if err == errConfigNotFound {
+			return meta, nil
+		}
+		return meta, err
+	}
+	err = json.Unmarshal(data, &meta)
+	return meta, err
+}
+
+func saveBitrotScanMeta(ctx context.Context, objAPI ObjectLayer, meta bitrotScanMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, path.Join(backgroundOpsMetaPrefix, bitrotScanMetaFile), data)
+}
+
+func startBitrotScan() {
+	var objAPI ObjectLayer
+	for {
+		objAPI = newObjectLayerWithoutSafeModeFn()
+		if objAPI == nil {
+			time.Sleep(time.Second)
+			continue
+		}
+		break
+	}
+
+	zones, ok := objAPI.(*xlZones)
+	if !ok {
+		return
+	}
+
+	reqInfo := &logger.ReqInfo{API: "BitrotScan"}
+	ctx := logger.SetReqInfo(context.Background(), reqInfo)
+
+	// Hold a lock so only one server scans for bitrot
+	leaderLock := zones.NewNSLock(ctx, minioMetaBucket, "leader-bitrot-scan")
+	for {
+		err := leaderLock.GetLock(leaderLockTimeout)
+		if err == nil {
+			break
+		}
+		time.Sleep(leaderTick)
+	}
+
+	ticker := time.NewTicker(bitrotScanCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		meta, err := readBitrotScanMeta(ctx, objAPI)
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to read the bitrot scan metadata: %w", err))
+		} else if time.Since(meta.LastScan) >= globalBitrotScanConfig.Interval {
+			meta.LastScan = UTCNow()
+			scanZonesBitrot(ctx, zones, globalBitrotScanConfig.Rate)
+			select {
+			case <-GlobalServiceDoneCh:
+				// An interrupted scan is started again.
+				return
+			default:
+			}
+			logger.LogIf(ctx, saveBitrotScanMeta(ctx, objAPI, meta))
+		}
+
+		select {
+		case <-GlobalServiceDoneCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func initBitrotScan() {
+	if !globalBitrotScanConfig.Enabled {
+		return
+	}
+	go startBitrotScan()
+}
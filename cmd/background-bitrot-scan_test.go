@@ -0,0 +1,172 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBitrotScanDelay(t *testing.T) {
+	testCases := []struct {
+		bytes, rate   uint64
+		elapsed       time.Duration
+		expectedDelay time.Duration
+	}{
+		{0, 1 << 20, 0, 0},
+		{1 << 20, 1 << 20, 0, time.Second},
+		{1 << 20, 1 << 20, 400 * time.Millisecond, 600 * time.Millisecond},
+		{1 << 20, 1 << 20, 2 * time.Second, 0},
+		{10 << 20, 2 << 20, time.Second, 4 * time.Second},
+		{1 << 20, 0, 0, 0},
+	}
+
+	for i, testCase := range testCases {
+		delay := bitrotScanDelay(testCase.bytes, testCase.rate, testCase.elapsed)
+		if delay != testCase.expectedDelay {
+			t.Errorf("Test %d: expected delay %s, got %s", i+1, testCase.expectedDelay, delay)
+		}
+	}
+}
+
+// verifyErrDisk - fails verifying the shards on the disk with err.
+type verifyErrDisk struct {
+	StorageAPI
+	err error
+}
+
+func (d verifyErrDisk) VerifyFile(volume, path string, size int64, algo BitrotAlgorithm, sum []byte, shardSize int64) error {
+	return d.err
+}
+
+func TestVerifyObjectBitrot(t *testing.T) {
+	ctx := context.Background()
+
+	z, fsDirs, err := prepareXLZones(1, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	globalObjLayerMutex.Lock()
+	oldObjectAPI := globalObjectAPI
+	globalObjectAPI = z
+	globalObjLayerMutex.Unlock()
+	defer func() {
+		globalObjLayerMutex.Lock()
+		globalObjectAPI = oldObjectAPI
+		globalObjLayerMutex.Unlock()
+	}()
+
+	defer func(h *healRoutine) { globalBackgroundHealRoutine = h }(globalBackgroundHealRoutine)
+	globalBackgroundHealRoutine = initHealRoutine()
+	go globalBackgroundHealRoutine.run()
+	defer close(globalBackgroundHealRoutine.doneCh)
+
+	bucket, object := "bucket", "object"
+	if err = z.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("abcdefgh"), 128*1024)
+	if _, err = z.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	xl := z.zones[0].sets[0]
+	checkStats := func(verified, corrupt, healed uint64) {
+		t.Helper()
+		stats := bitrotScanStats{
+			verifiedShards: atomic.LoadUint64(&globalBitrotScanStats.verifiedShards),
+			corruptShards:  atomic.LoadUint64(&globalBitrotScanStats.corruptShards),
+			healedShards:   atomic.LoadUint64(&globalBitrotScanStats.healedShards),
+		}
+		if stats.verifiedShards != verified || stats.corruptShards != corrupt || stats.healedShards != healed {
+			t.Fatalf("expected %d verified, %d corrupt and %d healed shards, got %d, %d and %d",
+				verified, corrupt, healed, stats.verifiedShards, stats.corruptShards, stats.healedShards)
+		}
+	}
+	checkData := func() {
+		t.Helper()
+		var buf bytes.Buffer
+		if err = z.GetObject(ctx, bucket, object, 0, -1, &buf, "", ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), data) {
+			t.Fatal("object content mismatch")
+		}
+	}
+
+	defer func(stats bitrotScanStats) { globalBitrotScanStats = stats }(globalBitrotScanStats)
+	globalBitrotScanStats = bitrotScanStats{}
+
+	verifyObjectBitrot(ctx, xl, bucket, object)
+	checkStats(4, 0, 0)
+
+	// Corrupt the shard of the first disk.
+	parts, err := filepath.Glob(filepath.Join(fsDirs[0], bucket, object, "*", "part.1"))
+	if err != nil || len(parts) != 1 {
+		t.Fatalf("expected the part of the object on the first disk, got %v, %v", parts, err)
+	}
+	shard, err := ioutil.ReadFile(parts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	corrupted := append([]byte(nil), shard...)
+	corrupted[len(corrupted)/2] ^= 0xff
+	if err = ioutil.WriteFile(parts[0], corrupted, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	verifyObjectBitrot(ctx, xl, bucket, object)
+	checkStats(7, 1, 1)
+	healedShard, err := ioutil.ReadFile(parts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(healedShard, shard) {
+		t.Fatal("expected the corrupt shard to be healed")
+	}
+	checkData()
+
+	// Shards which can't be read are healed as corrupt ones.
+	disks := xl.getDisks()
+	z.zones[0].xlDisksMu.Lock()
+	getDisks := xl.getDisks
+	xl.getDisks = func() []StorageAPI {
+		failing := append([]StorageAPI(nil), disks...)
+		failing[0] = verifyErrDisk{disks[0], io.ErrUnexpectedEOF}
+		return failing
+	}
+	z.zones[0].xlDisksMu.Unlock()
+
+	verifyObjectBitrot(ctx, xl, bucket, object)
+
+	z.zones[0].xlDisksMu.Lock()
+	xl.getDisks = getDisks
+	z.zones[0].xlDisksMu.Unlock()
+
+	checkStats(10, 2, 2)
+	verifyObjectBitrot(ctx, xl, bucket, object)
+	checkStats(14, 2, 2)
+	checkData()
+}
@@ -17,21 +17,261 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
 )
 
-const defaultMonitorNewDiskInterval = time.Minute * 10
+const (
+	defaultMonitorNewDiskInterval = time.Minute * 10
+
+	// Tracks the progress of healing a disk, saved on the disk itself.
+	healingTrackerFilename = "healing.json"
+
+	// Interval at which the progress of healing a disk is saved.
+	healingTrackerSaveInterval = 30 * time.Second
+)
+
+// healingTracker - the progress of healing a replaced disk, saved on
+// the disk for healing to resume from the last object healed after a
+// restart of the server.
+type healingTracker struct {
+	madmin.HealingDisk
+
+	disk StorageAPI
+}
+
+// newHealingTracker - returns a tracker of healing the disk, found at
+// the given position of the zone at index zoneIdx.
+func newHealingTracker(disk StorageAPI, endpoint Endpoint, format *formatXLV3, zoneIdx, setIdx, diskIdx int) *healingTracker {
+	return &healingTracker{
+		HealingDisk: madmin.HealingDisk{
+			ID:        format.XL.This,
+			ZoneIndex: zoneIdx,
+			SetIndex:  setIdx,
+			DiskIndex: diskIdx,
+			Endpoint:  endpoint.String(),
+			Started:   UTCNow(),
+		},
+		disk: disk,
+	}
+}
+
+// loadHealingTracker - reads the healing tracker saved on the disk,
+// errFileNotFound is returned when the disk is not being healed.
+func loadHealingTracker(disk StorageAPI) (*healingTracker, error) {
+	data, err := disk.ReadAll(minioMetaBucket, healingTrackerFilename)
+	if err != nil {
+		return nil, err
+	}
+	h := &healingTracker{disk: disk}
+	if err = json.Unmarshal(data, &h.HealingDisk); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// save - saves the progress of healing on the disk.
+func (h *healingTracker) save() error {
+	h.LastUpdate = UTCNow()
+	data, err := json.Marshal(h.HealingDisk)
+	if err != nil {
+		return err
+	}
+	if err = h.disk.WriteAll(minioMetaBucket, healingTrackerFilename, bytes.NewReader(data)); err != nil {
+		return err
+	}
+	globalBackgroundHealState.updateHealLocalDisk(h.HealingDisk)
+	return nil
+}
+
+// delete - removes the tracker from the disk once healed.
+func (h *healingTracker) delete() error {
+	if err := h.disk.DeleteFile(minioMetaBucket, healingTrackerFilename); err != nil {
+		return err
+	}
+	globalBackgroundHealState.popHealLocalDisk(h.Endpoint)
+	return nil
+}
+
+// healTaskSync - queues a heal task and waits for its result.
+func healTaskSync(path string, opts madmin.HealOpts) (madmin.HealResultItem, error) {
+	respCh := make(chan healResult)
+	globalBackgroundHealRoutine.queueHealTask(healTask{path: path, opts: opts, responseCh: respCh})
+	res := <-respCh
+	return res.result, res.err
+}
+
+// healErasureSet - heals all the objects of the erasure set of the disk,
+// bucket by bucket, resuming from the bucket and object last healed.
+func (h *healingTracker) healErasureSet(ctx context.Context, xlObj *xlObjects, opts madmin.HealOpts) error {
+	if len(h.QueuedBuckets) == 0 && len(h.HealedBuckets) == 0 {
+		buckets, err := xlObj.ListBuckets(ctx)
+		if err != nil {
+			return err
+		}
+		// The metadata of the cluster is healed first.
+		h.QueuedBuckets = []string{
+			pathJoin(minioMetaBucket, minioConfigPrefix),
+			pathJoin(minioMetaBucket, bucketConfigPrefix),
+			pathJoin(minioMetaBucket, backgroundOpsMetaPrefix),
+		}
+		for _, bucket := range buckets {
+			h.QueuedBuckets = append(h.QueuedBuckets, bucket.Name)
+		}
+		if err = h.save(); err != nil {
+			return err
+		}
+	}
+
+	lastSave := UTCNow()
+	for len(h.QueuedBuckets) > 0 {
+		queued := h.QueuedBuckets[0]
+		bucket, prefix := path2BucketObject(queued)
+		if prefix != "" {
+			prefix += SlashSeparator
+		}
+
+		var marker string
+		if h.Bucket == queued {
+			marker = h.Object
+		} else {
+			h.Bucket = queued
+			h.Object = ""
+			if prefix == "" {
+				if _, err := healTaskSync(bucket, opts); err != nil && !isErrBucketNotFound(err) {
+					logger.LogIf(ctx, fmt.Errorf("Unable to heal bucket %s on disk %s: %w", bucket, h.Endpoint, err))
+				}
+			}
+		}
+
+		// List all objects in the current bucket and heal them
+		endWalkCh := make(chan struct{})
+		listDir := listDirFactory(ctx, xlObj.getLoadBalancedDisks()...)
+		walkResultCh := startTreeWalk(ctx, bucket, prefix, marker, true, listDir, endWalkCh)
+		for walkEntry := range walkResultCh {
+			object := walkEntry.entry
+			res, err := healTaskSync(pathJoin(bucket, object), opts)
+			switch {
+			case err == nil:
+				// Objects found healthy on all the drives
+				// are not counted.
+				if before, after := res.GetOnlineCounts(); after > before {
+					h.ObjectsHealed++
+					h.BytesDone += uint64(res.ObjectSize)
+				}
+			case isErrObjectNotFound(err):
+				// Removed since it was listed.
+			default:
+				h.ObjectsFailed++
+				logger.LogIf(ctx, fmt.Errorf("Unable to heal %s on disk %s: %w", pathJoin(bucket, object), h.Endpoint, err))
+			}
+			h.Object = object
+
+			if UTCNow().Sub(lastSave) > healingTrackerSaveInterval {
+				if err = h.save(); err != nil {
+					close(endWalkCh)
+					return err
+				}
+				lastSave = UTCNow()
+			}
+		}
+		close(endWalkCh)
+
+		h.HealedBuckets = append(h.HealedBuckets, queued)
+		h.QueuedBuckets = h.QueuedBuckets[1:]
+		h.Bucket = ""
+		h.Object = ""
+		if err := h.save(); err != nil {
+			return err
+		}
+		lastSave = UTCNow()
+	}
+	return nil
+}
 
 func initLocalDisksAutoHeal() {
 	go monitorLocalDisksAndHeal()
 }
 
+// getLocalDisksToHeal - reformats the local disks which were replaced
+// and returns the trackers of healing them, along with the trackers of
+// the local disks whose healing was interrupted.
+func getLocalDisksToHeal(ctx context.Context, z *xlZones, bgSeq *healSequence) []*healingTracker {
+	// Look for the replaced disks first.
+	replaced := make(map[string]bool)
+	for _, ep := range globalEndpoints {
+		for _, endpoint := range ep.Endpoints {
+			if !endpoint.IsLocal {
+				continue
+			}
+			// Try to connect to the current endpoint
+			// and reformat if the current disk is not formatted
+			if _, _, err := connectEndpoint(endpoint); err == errUnformattedDisk {
+				replaced[endpoint.String()] = true
+			}
+		}
+	}
+
+	if len(replaced) > 0 {
+		// Reformat disks
+		bgSeq.sourceCh <- SlashSeparator
+
+		// Ensure that reformatting disks is finished
+		bgSeq.sourceCh <- nopHeal
+	}
+
+	var trackers []*healingTracker
+	for i, ep := range globalEndpoints {
+		for _, endpoint := range ep.Endpoints {
+			if !endpoint.IsLocal {
+				continue
+			}
+			disk, format, err := connectEndpoint(endpoint)
+			if err != nil {
+				continue
+			}
+			tracker, err := loadHealingTracker(disk)
+			switch {
+			case err == nil:
+			case err == errFileNotFound && replaced[endpoint.String()]:
+				// Calculate the set index where the current endpoint belongs
+				setIndex, diskIndex, err := findDiskIndex(z.zones[i].format, format)
+				if err != nil {
+					logger.LogIf(ctx, err)
+					disk.Close()
+					continue
+				}
+				tracker = newHealingTracker(disk, endpoint, format, i, setIndex, diskIndex)
+				if err = tracker.save(); err != nil {
+					logger.LogIf(ctx, err)
+					disk.Close()
+					continue
+				}
+			default:
+				if err != errFileNotFound {
+					logger.LogIf(ctx, err)
+				}
+				disk.Close()
+				continue
+			}
+			globalBackgroundHealState.updateHealLocalDisk(tracker.HealingDisk)
+			trackers = append(trackers, tracker)
+		}
+	}
+	return trackers
+}
+
 // monitorLocalDisksAndHeal - ensures that detected new disks are healed
 //  1. Only the concerned erasure set will be listed and healed
 //  2. Only the node hosting the disk is responsible to perform the heal
+//  3. The progress of healing is saved on the disk, healing resumes
+//     from the last object healed when the server restarts
 func monitorLocalDisksAndHeal() {
 	// Wait until the object layer is ready
 	var objAPI ObjectLayer
@@ -62,68 +302,22 @@ func monitorLocalDisksAndHeal() {
 		time.Sleep(time.Second)
 	}
 
-	// Perform automatic disk healing when a disk is replaced locally.
+	// Perform automatic disk healing when a disk is replaced locally,
+	// attempt a heal as the server starts-up first.
 	for {
-		time.Sleep(defaultMonitorNewDiskInterval)
-
-		// Attempt a heal as the server starts-up first.
-		localDisksInZoneHeal := make([]Endpoints, len(z.zones))
-		for i, ep := range globalEndpoints {
-			localDisksToHeal := Endpoints{}
-			for _, endpoint := range ep.Endpoints {
-				if !endpoint.IsLocal {
-					continue
-				}
-				// Try to connect to the current endpoint
-				// and reformat if the current disk is not formatted
-				_, _, err := connectEndpoint(endpoint)
-				if err == errUnformattedDisk {
-					localDisksToHeal = append(localDisksToHeal, endpoint)
-				}
-			}
-			if len(localDisksToHeal) == 0 {
+		for _, tracker := range getLocalDisksToHeal(ctx, z, bgSeq) {
+			set := z.zones[tracker.ZoneIndex].sets[tracker.SetIndex]
+			if err := tracker.healErasureSet(ctx, set, bgSeq.settings); err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to heal disk %s: %w", tracker.Endpoint, err))
+				tracker.disk.Close()
 				continue
 			}
-			localDisksInZoneHeal[i] = localDisksToHeal
+			logger.Info("Healing of disk %s is complete, %d objects healed, %d objects failed",
+				tracker.Endpoint, tracker.ObjectsHealed, tracker.ObjectsFailed)
+			logger.LogIf(ctx, tracker.delete())
+			tracker.disk.Close()
 		}
 
-		// Reformat disks
-		bgSeq.sourceCh <- SlashSeparator
-
-		// Ensure that reformatting disks is finished
-		bgSeq.sourceCh <- nopHeal
-
-		var erasureSetInZoneToHeal = make([][]int, len(localDisksInZoneHeal))
-		// Compute the list of erasure set to heal
-		for i, localDisksToHeal := range localDisksInZoneHeal {
-			var erasureSetToHeal []int
-			for _, endpoint := range localDisksToHeal {
-				// Load the new format of this passed endpoint
-				_, format, err := connectEndpoint(endpoint)
-				if err != nil {
-					logger.LogIf(ctx, err)
-					continue
-				}
-				// Calculate the set index where the current endpoint belongs
-				setIndex, _, err := findDiskIndex(z.zones[i].format, format)
-				if err != nil {
-					logger.LogIf(ctx, err)
-					continue
-				}
-
-				erasureSetToHeal = append(erasureSetToHeal, setIndex)
-			}
-			erasureSetInZoneToHeal[i] = erasureSetToHeal
-		}
-
-		// Heal all erasure sets that need
-		for i, erasureSetToHeal := range erasureSetInZoneToHeal {
-			for _, setIndex := range erasureSetToHeal {
-				err := healErasureSet(ctx, setIndex, z.zones[i].sets[setIndex])
-				if err != nil {
-					logger.LogIf(ctx, err)
-				}
-			}
-		}
+		time.Sleep(defaultMonitorNewDiskInterval)
 	}
 }
@@ -0,0 +1,204 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestHealingTracker(t *testing.T) {
+	if globalBackgroundHealState == nil {
+		globalBackgroundHealState = initHealState()
+	}
+
+	disk, diskPath, err := newPosixTestSetup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(diskPath)
+
+	if _, err = loadHealingTracker(disk); err != errFileNotFound {
+		t.Fatalf("expected %v, got %v", errFileNotFound, err)
+	}
+
+	format := &formatXLV3{}
+	format.XL.This = "da017d62-70e3-45f1-8a1a-587707e69ad1"
+	endpoint := mustGetNewEndpoints(diskPath)[0]
+	tracker := newHealingTracker(disk, endpoint, format, 0, 1, 2)
+	tracker.QueuedBuckets = []string{"bucket2", "bucket3"}
+	tracker.HealedBuckets = []string{"bucket1"}
+	tracker.Bucket = "bucket2"
+	tracker.Object = "prefix/object"
+	tracker.ObjectsHealed = 10
+	tracker.ObjectsFailed = 1
+	tracker.BytesDone = 1024
+	if err = tracker.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := loadHealingTracker(disk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Times are compared after a round trip through JSON.
+	tracker.Started, tracker.LastUpdate = loaded.Started, loaded.LastUpdate
+	if !reflect.DeepEqual(loaded.HealingDisk, tracker.HealingDisk) {
+		t.Fatalf("expected %#v, got %#v", tracker.HealingDisk, loaded.HealingDisk)
+	}
+
+	disks := globalBackgroundHealState.getHealLocalDisks()
+	if len(disks) != 1 || disks[0].Endpoint != endpoint.String() || disks[0].ObjectsHealed != 10 {
+		t.Fatalf("unexpected healing disks %#v", disks)
+	}
+
+	if err = loaded.delete(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = loadHealingTracker(disk); err != errFileNotFound {
+		t.Fatalf("expected %v, got %v", errFileNotFound, err)
+	}
+	if disks = globalBackgroundHealState.getHealLocalDisks(); len(disks) != 0 {
+		t.Fatalf("expected no healing disks, got %#v", disks)
+	}
+}
+
+func TestHealErasureSetResume(t *testing.T) {
+	ctx := context.Background()
+
+	if globalBackgroundHealState == nil {
+		globalBackgroundHealState = initHealState()
+	}
+
+	z, fsDirs, err := prepareXLZones(1, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	globalObjLayerMutex.Lock()
+	oldObjectAPI := globalObjectAPI
+	globalObjectAPI = z
+	globalObjLayerMutex.Unlock()
+	defer func() {
+		globalObjLayerMutex.Lock()
+		globalObjectAPI = oldObjectAPI
+		globalObjLayerMutex.Unlock()
+	}()
+
+	defer func(h *healRoutine) { globalBackgroundHealRoutine = h }(globalBackgroundHealRoutine)
+	globalBackgroundHealRoutine = initHealRoutine()
+	go globalBackgroundHealRoutine.run()
+	defer close(globalBackgroundHealRoutine.doneCh)
+
+	bucket := "bucket"
+	if err = z.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	var objects []string
+	sizes := map[string]uint64{}
+	for i := 0; i < 6; i++ {
+		object := fmt.Sprintf("object-%d", i)
+		data := bytes.Repeat([]byte{byte('a' + i)}, (i+1)*1024)
+		if _, err = z.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		objects = append(objects, object)
+		sizes[object] = uint64(len(data))
+	}
+
+	// The objects are missing on the replaced first disk.
+	for _, object := range objects {
+		if err = os.RemoveAll(pathJoin(fsDirs[0], bucket, object)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	isHealed := func(object string) bool {
+		_, err := os.Stat(pathJoin(fsDirs[0], bucket, object))
+		return err == nil
+	}
+
+	set := z.zones[0].sets[0]
+	disk := set.getDisks()[0]
+	endpoint := mustGetNewEndpoints(fsDirs[0])[0]
+	opts := madmin.HealOpts{ScanMode: madmin.HealNormalScan}
+
+	// The server restarted after healing the first objects
+	// of the bucket.
+	tracker := newHealingTracker(disk, endpoint, z.zones[0].format, 0, 0, 0)
+	tracker.QueuedBuckets = []string{bucket}
+	tracker.Bucket = bucket
+	tracker.Object = objects[2]
+	if err = tracker.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := loadHealingTracker(disk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = loaded.healErasureSet(ctx, set, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	var bytesDone uint64
+	for i, object := range objects {
+		if healed := isHealed(object); healed != (i > 2) {
+			t.Errorf("%s: expected healed %t, got %t", object, i > 2, healed)
+		}
+		if i > 2 {
+			bytesDone += sizes[object]
+		}
+	}
+	if loaded.ObjectsHealed != 3 || loaded.BytesDone != bytesDone || loaded.ObjectsFailed != 0 {
+		t.Errorf("expected 3 objects and %d bytes healed, got %d objects and %d bytes, %d failed",
+			bytesDone, loaded.ObjectsHealed, loaded.BytesDone, loaded.ObjectsFailed)
+	}
+	if len(loaded.QueuedBuckets) != 0 || !reflect.DeepEqual(loaded.HealedBuckets, []string{bucket}) ||
+		loaded.Bucket != "" || loaded.Object != "" {
+		t.Errorf("unexpected healing progress %#v", loaded.HealingDisk)
+	}
+
+	// Healing the whole erasure set only counts the objects
+	// which were left missing, healthy objects are skipped.
+	tracker = newHealingTracker(disk, endpoint, z.zones[0].format, 0, 0, 0)
+	if err = tracker.healErasureSet(ctx, set, opts); err != nil {
+		t.Fatal(err)
+	}
+	bytesDone = 0
+	for i, object := range objects {
+		if !isHealed(object) {
+			t.Errorf("%s: expected object to be healed", object)
+		}
+		if i <= 2 {
+			bytesDone += sizes[object]
+		}
+	}
+	if tracker.ObjectsHealed != 3 || tracker.BytesDone != bytesDone {
+		t.Errorf("expected 3 objects and %d bytes healed, got %d objects and %d bytes",
+			bytesDone, tracker.ObjectsHealed, tracker.BytesDone)
+	}
+	if err = tracker.delete(); err != nil {
+		t.Fatal(err)
+	}
+}
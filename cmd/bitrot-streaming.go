@@ -80,6 +80,34 @@ func newStreamingBitrotWriter(disk StorageAPI, volume, filePath string, length i
 	return bw
 }
 
+// Calculates bitrot in chunks and writes the hash along with the data
+// into memory, for the data of objects stored inline in `xl.json`.
+type inlineBitrotWriter struct {
+	buf bytes.Buffer
+	h   hash.Hash
+}
+
+func (b *inlineBitrotWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	b.h.Reset()
+	b.h.Write(p)
+	b.buf.Write(b.h.Sum(nil))
+	return b.buf.Write(p)
+}
+
+// Bytes returns the data written so far in the streaming bitrot format.
+func (b *inlineBitrotWriter) Bytes() []byte {
+	return b.buf.Bytes()
+}
+
+// Returns inline bitrot writer implementation, the data can be
+// read back with the streaming bitrot reader.
+func newInlineBitrotWriter(algo BitrotAlgorithm) *inlineBitrotWriter {
+	return &inlineBitrotWriter{h: algo.New()}
+}
+
 // ReadAt() implementation which verifies the bitrot hash available as part of the stream.
 type streamingBitrotReader struct {
 	disk       StorageAPI
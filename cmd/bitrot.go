@@ -156,6 +156,14 @@ func bitrotWriterSum(w io.Writer) []byte {
 	return nil
 }
 
+// Returns the written data for inline-bitrot, nil otherwise.
+func bitrotWriterData(w io.Writer) []byte {
+	if bw, ok := w.(*inlineBitrotWriter); ok {
+		return bw.Bytes()
+	}
+	return nil
+}
+
 // Returns the size of the file with bitrot protection
 func bitrotShardFileSize(size int64, shardSize int64, algo BitrotAlgorithm) int64 {
 	if algo != HighwayHash256S {
@@ -0,0 +1,351 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/cmd/logger/message/audit"
+	"github.com/minio/minio/pkg/bucket/logging"
+	"github.com/minio/minio/pkg/hash"
+)
+
+const (
+	// Interval at which buffered server access logs are written.
+	bucketAccessLogInterval = 5 * time.Minute
+
+	// Maximum number of buffered server access log records of a
+	// bucket, reaching it writes the access logs early.
+	bucketAccessLogMaxRecords = 10000
+
+	// Time format of the server access log records.
+	bucketAccessLogTimeFormat = "02/Jan/2006:15:04:05 -0700"
+
+	// Time format of the server access log object names.
+	bucketAccessLogObjectTimeFormat = "2006-01-02-15-04-05"
+)
+
+// bucketAccessLogger - buffers the server access log records of
+// buckets and periodically writes them as objects into the target
+// bucket of their logging configuration. Access logs are delivered
+// on a best effort basis, buffered records are lost on shutdown.
+type bucketAccessLogger struct {
+	sync.Mutex
+	records map[string][]string
+	flushCh chan struct{}
+}
+
+func newBucketAccessLogger() *bucketAccessLogger {
+	return &bucketAccessLogger{
+		records: make(map[string][]string),
+		flushCh: make(chan struct{}, 1),
+	}
+}
+
+// Enabled - returns true if server access logging is enabled on the bucket.
+func (l *bucketAccessLogger) Enabled(bucket string) bool {
+	config, ok := globalBucketLoggingSys.Get(bucket)
+	return ok && config.Enabled()
+}
+
+// Send - buffers the access log record of a request on the bucket.
+func (l *bucketAccessLogger) Send(r *http.Request, entry audit.Entry) {
+	record := formatAccessLogRecord(r, entry)
+
+	l.Lock()
+	l.records[entry.API.Bucket] = append(l.records[entry.API.Bucket], record)
+	full := len(l.records[entry.API.Bucket]) >= bucketAccessLogMaxRecords
+	l.Unlock()
+
+	if full {
+		select {
+		case l.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// flush - writes all buffered access log records into their target buckets.
+func (l *bucketAccessLogger) flush(ctx context.Context, objAPI ObjectLayer) {
+	l.Lock()
+	records := l.records
+	l.records = make(map[string][]string)
+	l.Unlock()
+
+	for bucket, lines := range records {
+		config, ok := globalBucketLoggingSys.Get(bucket)
+		if !ok || !config.Enabled() {
+			// Logging was disabled after the requests were made.
+			continue
+		}
+		if err := writeAccessLogObject(ctx, objAPI, *config.LoggingEnabled, lines); err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to write server access logs of bucket %s: %w", bucket, err))
+		}
+	}
+}
+
+func initBucketAccessLogging() {
+	l := newBucketAccessLogger()
+	logger.RegisterAccessLogger(l)
+	go startBucketAccessLogging(l)
+}
+
+func startBucketAccessLogging(l *bucketAccessLogger) {
+	var objAPI ObjectLayer
+	var ctx = context.Background()
+
+	// Wait until the object API is ready
+	for {
+		objAPI = newObjectLayerWithoutSafeModeFn()
+		if objAPI == nil {
+			time.Sleep(time.Second)
+			continue
+		}
+		break
+	}
+
+	ticker := time.NewTicker(bucketAccessLogInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-GlobalServiceDoneCh:
+			return
+		case <-ticker.C:
+			l.flush(ctx, objAPI)
+		case <-l.flushCh:
+			l.flush(ctx, objAPI)
+		}
+	}
+}
+
+// writeAccessLogObject - writes the access log records as a new object
+// under the target prefix of the target bucket.
+func writeAccessLogObject(ctx context.Context, objAPI ObjectLayer, target logging.LoggingEnabled, records []string) error {
+	var buf bytes.Buffer
+	for _, record := range records {
+		buf.WriteString(record)
+		buf.WriteByte('\n')
+	}
+	data := buf.Bytes()
+
+	hashReader, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", getSHA256Hash(data), int64(len(data)), globalCLIContext.StrictS3Compat)
+	if err != nil {
+		return err
+	}
+
+	object := accessLogObjectName(target.TargetPrefix, UTCNow())
+	opts := ObjectOptions{UserDefined: map[string]string{"content-type": "text/plain"}}
+	_, err = objAPI.PutObject(ctx, target.TargetBucket, object, NewPutObjReader(hashReader, nil, nil), opts)
+	return err
+}
+
+// accessLogObjectName - returns the name of an access log object written
+// at the given time, a random suffix keeps the names written by all the
+// servers at the same time unique.
+func accessLogObjectName(prefix string, t time.Time) string {
+	suffix := strings.ToUpper(strings.Replace(mustGetUUID(), "-", "", -1))[:16]
+	return prefix + t.Format(bucketAccessLogObjectTimeFormat) + "-" + suffix
+}
+
+// accessLogResources - the sub-resources of requests and their names
+// in the operation field of the access log records.
+var accessLogResources = []struct {
+	query string
+	name  string
+}{
+	{"uploads", "UPLOADS"},
+	{"acl", "ACL"},
+	{"policy", "BUCKETPOLICY"},
+	{"cors", "CORS"},
+	{"website", "WEBSITE"},
+	{"logging", "LOGGING_STATUS"},
+	{"lifecycle", "LIFECYCLE"},
+	{"versioning", "VERSIONING"},
+	{"versions", "BUCKETVERSIONS"},
+	{"location", "LOCATION"},
+	{"notification", "NOTIFICATION"},
+	{"encryption", "ENCRYPTION"},
+	{"replication", "REPLICATION"},
+	{"object-lock", "OBJECT_LOCK_CONFIGURATION"},
+	{"retention", "RETENTION"},
+	{"legal-hold", "LEGAL_HOLD"},
+	{"delete", "MULTI_OBJECT_DELETE"},
+	{"tagging", "TAGGING"},
+}
+
+// accessLogOperation - returns the operation of the request in the
+// form REST.HTTP_method.resource_type, e.g. REST.PUT.OBJECT
+func accessLogOperation(r *http.Request, object string) string {
+	method := r.Method
+	if method == http.MethodPut && r.Header.Get(xhttp.AmzCopySource) != "" {
+		method = "COPY"
+	}
+
+	resource := "BUCKET"
+	if object != "" {
+		resource = "OBJECT"
+	}
+
+	query := r.URL.Query()
+	switch {
+	case query.Get("uploadId") != "" && query.Get("partNumber") != "":
+		resource = "PART"
+	case query.Get("uploadId") != "":
+		resource = "UPLOAD"
+	default:
+		for _, res := range accessLogResources {
+			if _, ok := query[res.query]; ok {
+				resource = res.name
+				if object != "" && res.query == "tagging" {
+					resource = "OBJECT_TAGGING"
+				}
+				break
+			}
+		}
+	}
+
+	return "REST." + method + "." + resource
+}
+
+// formatAccessLogRecord - formats the audit entry of a request as a
+// record of the AWS S3 server access log format, values which are not
+// known are logged as "-".
+func formatAccessLogRecord(r *http.Request, entry audit.Entry) string {
+	t, err := time.Parse(time.RFC3339Nano, entry.Time)
+	if err != nil {
+		t = UTCNow()
+	}
+
+	requester := getReqAccessCred(r, globalServerRegion).AccessKey
+
+	var sigVersion, authType string
+	switch getRequestAuthType(r) {
+	case authTypeSigned, authTypeStreamingSigned:
+		sigVersion, authType = "SigV4", "AuthHeader"
+	case authTypePresigned:
+		sigVersion, authType = "SigV4", "QueryString"
+	case authTypeSignedV2:
+		sigVersion, authType = "SigV2", "AuthHeader"
+	case authTypePresignedV2:
+		sigVersion, authType = "SigV2", "QueryString"
+	case authTypePostPolicy:
+		sigVersion = "SigV4"
+	}
+
+	var bytesSent, objectSize string
+	if r.Method != http.MethodHead {
+		bytesSent = entry.RespHeader[xhttp.ContentLength]
+	}
+	if entry.API.Object != "" && entry.API.StatusCode < http.StatusMultipleChoices {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+			objectSize = entry.RespHeader[xhttp.ContentLength]
+			if contentRange := entry.RespHeader[xhttp.ContentRange]; contentRange != "" {
+				objectSize = contentRange[strings.LastIndex(contentRange, "/")+1:]
+			}
+		case http.MethodPut, http.MethodPost:
+			objectSize = r.Header.Get(xhttp.AmzDecodedContentLength)
+			if objectSize == "" && r.ContentLength > 0 {
+				objectSize = strconv.FormatInt(r.ContentLength, 10)
+			}
+		}
+	}
+
+	var tlsVersion string
+	if r.TLS != nil {
+		switch r.TLS.Version {
+		case tls.VersionTLS10:
+			tlsVersion = "TLSv1"
+		case tls.VersionTLS11:
+			tlsVersion = "TLSv1.1"
+		case tls.VersionTLS12:
+			tlsVersion = "TLSv1.2"
+		case tls.VersionTLS13:
+			tlsVersion = "TLSv1.3"
+		}
+	}
+
+	fields := []string{
+		accessLogValue(entry.DeploymentID),
+		accessLogValue(entry.API.Bucket),
+		"[" + t.Format(bucketAccessLogTimeFormat) + "]",
+		accessLogValue(entry.RemoteHost),
+		accessLogValue(requester),
+		accessLogValue(entry.RequestID),
+		accessLogOperation(r, entry.API.Object),
+		accessLogValue(s3URLEncode(entry.API.Object)),
+		accessLogQuote(r.Method + " " + r.RequestURI + " " + r.Proto),
+		strconv.Itoa(entry.API.StatusCode),
+		// The S3 error code is not known after the response is written.
+		"-",
+		accessLogValue(bytesSent),
+		accessLogValue(objectSize),
+		accessLogMillis(entry.API.TimeToResponse),
+		accessLogMillis(entry.API.TimeToFirstByte),
+		accessLogQuote(r.Referer()),
+		accessLogQuote(r.UserAgent()),
+		accessLogValue(entry.RespHeader[xhttp.AmzVersionID]),
+		// Host ID
+		"-",
+		accessLogValue(sigVersion),
+		// Cipher suite
+		"-",
+		accessLogValue(authType),
+		accessLogValue(r.Host),
+		accessLogValue(tlsVersion),
+	}
+	return strings.Join(fields, " ")
+}
+
+// accessLogValue - returns the value as a field of an access log
+// record, empty and zero values are logged as "-".
+func accessLogValue(value string) string {
+	if value == "" || value == "0" {
+		return "-"
+	}
+	return value
+}
+
+// accessLogQuote - returns the value as a quoted field of an access
+// log record.
+func accessLogQuote(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return `"` + strings.Replace(value, `"`, `\"`, -1) + `"`
+}
+
+// accessLogMillis - returns the duration in milliseconds as a field
+// of an access log record.
+func accessLogMillis(value string) string {
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return "-"
+	}
+	return strconv.FormatInt(int64(d/time.Millisecond), 10)
+}
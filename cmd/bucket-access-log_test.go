@@ -0,0 +1,117 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger/message/audit"
+)
+
+func TestAccessLogOperation(t *testing.T) {
+	testCases := []struct {
+		method     string
+		target     string
+		object     string
+		copySource string
+		expected   string
+	}{
+		{http.MethodGet, "/bucket", "", "", "REST.GET.BUCKET"},
+		{http.MethodGet, "/bucket/object", "object", "", "REST.GET.OBJECT"},
+		{http.MethodPut, "/bucket/object", "object", "/bucket/source", "REST.COPY.OBJECT"},
+		{http.MethodPut, "/bucket?cors", "", "", "REST.PUT.CORS"},
+		{http.MethodPut, "/bucket/object?tagging", "object", "", "REST.PUT.OBJECT_TAGGING"},
+		{http.MethodGet, "/bucket?tagging", "", "", "REST.GET.TAGGING"},
+		{http.MethodPost, "/bucket/object?uploads", "object", "", "REST.POST.UPLOADS"},
+		{http.MethodPut, "/bucket/object?uploadId=1&partNumber=1", "object", "", "REST.PUT.PART"},
+		{http.MethodPost, "/bucket/object?uploadId=1", "object", "", "REST.POST.UPLOAD"},
+		{http.MethodPost, "/bucket?delete", "", "", "REST.POST.MULTI_OBJECT_DELETE"},
+	}
+
+	for i, tc := range testCases {
+		r := httptest.NewRequest(tc.method, tc.target, nil)
+		if tc.copySource != "" {
+			r.Header.Set(xhttp.AmzCopySource, tc.copySource)
+		}
+		if operation := accessLogOperation(r, tc.object); operation != tc.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, tc.expected, operation)
+		}
+	}
+}
+
+func TestFormatAccessLogRecord(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/bucket/my%20object", nil)
+	r.Header.Set("User-Agent", "test-agent")
+
+	entry := audit.Entry{
+		DeploymentID: "deployment-id",
+		Time:         time.Date(2020, time.June, 1, 10, 30, 15, 0, time.UTC).Format(time.RFC3339Nano),
+		RemoteHost:   "192.0.2.1",
+		RequestID:    "REQUESTID",
+		RespHeader: map[string]string{
+			xhttp.ContentLength: "10",
+			xhttp.ContentRange:  "bytes 0-9/100",
+		},
+	}
+	entry.API.Bucket = "bucket"
+	entry.API.Object = "my object"
+	entry.API.StatusCode = http.StatusPartialContent
+	entry.API.TimeToResponse = (25 * time.Millisecond).String()
+	entry.API.TimeToFirstByte = (5 * time.Millisecond).String()
+
+	expected := []string{
+		"deployment-id",
+		"bucket",
+		"[01/Jun/2020:10:30:15", "+0000]",
+		"192.0.2.1",
+		"-",
+		"REQUESTID",
+		"REST.GET.OBJECT",
+		"my+object",
+		`"GET`, "/bucket/my%20object", `HTTP/1.1"`,
+		"206",
+		"-",
+		"10",
+		"100",
+		"25",
+		"5",
+		"-",
+		`"test-agent"`,
+		"-",
+		"-",
+		"-",
+		"-",
+		"-",
+		"example.com",
+		"-",
+	}
+
+	fields := strings.Split(formatAccessLogRecord(r, entry), " ")
+	if len(fields) != len(expected) {
+		t.Fatalf("expected %d fields, got %d: %v", len(expected), len(fields), fields)
+	}
+	for i := range expected {
+		if fields[i] != expected[i] {
+			t.Errorf("field %d: expected %s, got %s", i+1, expected[i], fields[i])
+		}
+	}
+}
@@ -0,0 +1,151 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"path"
+	"sync"
+
+	"github.com/minio/minio/pkg/bucket/cors"
+)
+
+const (
+	// Bucket CORS configuration file name.
+	bucketCorsConfig = "cors.xml"
+)
+
+// BucketCorsSys - in-memory cache of bucket CORS config
+type BucketCorsSys struct {
+	sync.RWMutex
+	bucketCorsMap map[string]cors.Config
+}
+
+// NewBucketCorsSys - Creates an empty in-memory bucket CORS configuration cache
+func NewBucketCorsSys() *BucketCorsSys {
+	return &BucketCorsSys{
+		bucketCorsMap: make(map[string]cors.Config),
+	}
+}
+
+// load - Loads the bucket CORS configuration for the given list of buckets
+func (sys *BucketCorsSys) load(buckets []BucketInfo, objAPI ObjectLayer) error {
+	for _, bucket := range buckets {
+		config, err := objAPI.GetBucketCorsConfig(context.Background(), bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketCorsConfigNotFound); ok {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+		sys.Set(bucket.Name, *config)
+	}
+
+	return nil
+}
+
+// Init - Initializes in-memory bucket CORS config cache for the given list of buckets
+func (sys *BucketCorsSys) Init(buckets []BucketInfo, objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	// We don't cache bucket CORS config in gateway mode, nothing to do.
+	if globalIsGateway {
+		return nil
+	}
+
+	// Load bucket CORS config cache once during boot.
+	return sys.load(buckets, objAPI)
+}
+
+// Get - gets bucket CORS config for the given bucket.
+func (sys *BucketCorsSys) Get(bucket string) (config cors.Config, ok bool) {
+	// Requests may be served before the sub-systems are
+	// initialized, buckets have no CORS config until then.
+	if sys == nil || globalIsGateway {
+		return
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+	config, ok = sys.bucketCorsMap[bucket]
+	return
+}
+
+// Set - sets bucket CORS config to given bucket name.
+func (sys *BucketCorsSys) Set(bucket string, config cors.Config) {
+	// We don't cache bucket CORS config in gateway mode.
+	if globalIsGateway {
+		return
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+	sys.bucketCorsMap[bucket] = config
+}
+
+// Remove - removes bucket CORS config for given bucket.
+func (sys *BucketCorsSys) Remove(bucket string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketCorsMap, bucket)
+}
+
+// saveBucketCorsConfig - save bucket CORS config for given bucket.
+func saveBucketCorsConfig(ctx context.Context, objAPI ObjectLayer, bucket string, config *cors.Config) error {
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Path to store bucket CORS config for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketCorsConfig)
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// getBucketCorsConfig - get bucket CORS config for given bucket.
+func getBucketCorsConfig(objAPI ObjectLayer, bucket string) (*cors.Config, error) {
+	// Path to cors.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketCorsConfig)
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketCorsConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+
+	return cors.ParseConfig(bytes.NewReader(configData))
+}
+
+// removeBucketCorsConfig - removes bucket CORS config for given bucket.
+func removeBucketCorsConfig(ctx context.Context, objAPI ObjectLayer, bucket string) error {
+	// Path to cors.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketCorsConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketCorsConfigNotFound{Bucket: bucket}
+		}
+		return err
+	}
+	return nil
+}
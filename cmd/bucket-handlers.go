@@ -37,6 +37,8 @@ import (
 	"github.com/minio/minio/cmd/logger"
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/bucket/versioning"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/handlers"
 	"github.com/minio/minio/pkg/hash"
@@ -380,6 +382,8 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 
 	var dErrs = make([]APIErrorCode, len(deleteObjects.Objects))
 
+	versioned := globalBucketVersioningSys.Configured(bucket)
+
 	for index, object := range deleteObjects.Objects {
 		if dErrs[index] = checkRequestAuthType(ctx, r, policy.DeleteObjectAction, bucket, object.ObjectName); dErrs[index] != ErrNone {
 			if dErrs[index] == ErrSignatureDoesNotMatch || dErrs[index] == ErrInvalidAccessKeyID {
@@ -388,9 +392,39 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 			}
 			continue
 		}
-		govBypassPerms := checkRequestAuthType(ctx, r, policy.BypassGovernanceRetentionAction, bucket, object.ObjectName)
-		if _, err := enforceRetentionBypassForDelete(ctx, r, bucket, object.ObjectName, getObjectInfoFn, govBypassPerms); err != ErrNone {
-			dErrs[index] = err
+		if object.VersionID != "" && object.VersionID != nullVersionID && !versioned {
+			dErrs[index] = ErrNoSuchVersion
+			continue
+		}
+		// Adding a delete marker leaves the data of a versioned object
+		// untouched, retention only protects the versions themselves.
+		if !versioned || object.VersionID != "" {
+			versionID := object.VersionID
+			getVersionInfoFn := func(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+				opts.VersionID = versionID
+				return getObjectInfoFn(ctx, bucket, object, opts)
+			}
+			govBypassPerms := checkRequestAuthType(ctx, r, policy.BypassGovernanceRetentionAction, bucket, object.ObjectName)
+			if _, err := enforceRetentionBypassForDelete(ctx, r, bucket, object.ObjectName, getVersionInfoFn, govBypassPerms); err != ErrNone {
+				dErrs[index] = err
+				continue
+			}
+		}
+		if versioned {
+			// Versions are deleted one at a time, a delete marker
+			// is added for objects without a version id.
+			opts := ObjectOptions{VersionID: object.VersionID}
+			size := globalBucketQuotaSys.removedObjectSize(ctx, objectAPI, bucket, object.ObjectName, opts)
+			objInfo, err := objectAPI.DeleteObjectVersion(ctx, bucket, object.ObjectName, opts)
+			if err != nil {
+				dErrs[index] = toAPIErrorCode(ctx, err)
+				continue
+			}
+			globalBucketQuotaSys.AccountUsage(bucket, -size)
+			if object.VersionID == "" {
+				scheduleReplicationDelete(ctx, bucket, object.ObjectName)
+			}
+			deleteObjects.Objects[index].VersionID = objInfo.VersionID
 			continue
 		}
 		// Avoid duplicate objects, we use map to filter them out.
@@ -409,16 +443,26 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 		return
 	}
 
-	deleteList := toNames(objectsToDelete)
-	errs, err := deleteObjectsFn(ctx, bucket, deleteList)
-	if err != nil {
-		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
-		return
-	}
+	if len(objectsToDelete) > 0 {
+		deleteList := toNames(objectsToDelete)
+		sizes := make([]int64, len(deleteList))
+		for i, objName := range deleteList {
+			sizes[i] = globalBucketQuotaSys.removedObjectSize(ctx, objectAPI, bucket, objName, ObjectOptions{})
+		}
+		errs, err := deleteObjectsFn(ctx, bucket, deleteList)
+		if err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
 
-	for i, objName := range deleteList {
-		dIdx := objectsToDelete[objName]
-		dErrs[dIdx] = toAPIErrorCode(ctx, errs[i])
+		for i, objName := range deleteList {
+			dIdx := objectsToDelete[objName]
+			dErrs[dIdx] = toAPIErrorCode(ctx, errs[i])
+			if errs[i] == nil {
+				globalBucketQuotaSys.AccountUsage(bucket, -sizes[i])
+				scheduleReplicationDelete(ctx, bucket, objName)
+			}
+		}
 	}
 
 	// Collect deleted objects and errors if any.
@@ -453,7 +497,8 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 			EventName:  event.ObjectRemovedDelete,
 			BucketName: bucket,
 			Object: ObjectInfo{
-				Name: dobj.ObjectName,
+				Name:      dobj.ObjectName,
+				VersionID: dobj.VersionID,
 			},
 			ReqParams:    extractReqParams(r),
 			RespElements: extractRespElements(w),
@@ -726,6 +771,11 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		}
 	}
 
+	if err = enforceBucketQuota(ctx, bucket, fileSize); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	// Extract metadata to be saved from received Form.
 	metadata := make(map[string]string)
 	err = extractMetadataFromMap(ctx, formValues, metadata)
@@ -750,6 +800,10 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 	if (globalAutoEncryption || encEnabled) && !crypto.SSEC.IsRequested(r.Header) {
 		r.Header.Add(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
 	}
+	if mustReplicate(bucket, object, "", false) {
+		metadata[xhttp.AmzBucketReplicationStatus] = replication.Pending.String()
+	}
+
 	// get gateway encryption options
 	var opts ObjectOptions
 	opts, err = putOpts(ctx, r, bucket, object, metadata)
@@ -794,6 +848,10 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		return
 	}
 
+	globalBucketQuotaSys.AccountUsage(bucket, objInfo.Size)
+
+	scheduleReplication(ctx, objInfo, objectAPI)
+
 	location := getObjectLocation(r, globalDomainNames, bucket, object)
 	w.Header()[xhttp.ETag] = []string{`"` + objInfo.ETag + `"`}
 	w.Header().Set(xhttp.Location, location)
@@ -913,7 +971,8 @@ func (api objectAPIHandlers) DeleteBucketHandler(w http.ResponseWriter, r *http.
 
 // PutBucketVersioningHandler - PUT Bucket Versioning.
 // ----------
-// No-op. Available for API compatibility.
+// Enables or suspends versioning of the objects in a bucket, once
+// enabled versioning can only be suspended on the bucket.
 func (api objectAPIHandlers) PutBucketVersioningHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "PutBucketVersioning")
 
@@ -928,19 +987,49 @@ func (api objectAPIHandlers) PutBucketVersioningHandler(w http.ResponseWriter, r
 		return
 	}
 
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketVersioningAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	getBucketInfo := objectAPI.GetBucketInfo
 	if _, err := getBucketInfo(ctx, bucket); err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
+	config, err := versioning.ParseConfig(io.LimitReader(r.Body, maxBucketVersioningConfigSize))
+	if err != nil {
+		logger.LogIf(ctx, err, logger.Application)
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMalformedXML), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err = objectAPI.SetBucketVersioning(ctx, bucket, config); err != nil {
+		// Suspending versioning is a no-op on backends
+		// without versioning, kept for API compatibility.
+		if _, ok := err.(NotImplemented); ok && config.Suspended() {
+			writeSuccessResponseHeadersOnly(w)
+			return
+		}
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Update the in-memory bucket versioning cache
+	globalBucketVersioningSys.Set(bucket, *config)
+
+	// Update peer MinIO servers of the updated bucket versioning config
+	globalNotificationSys.SetBucketVersioning(ctx, bucket, config)
+
 	// Write success response.
 	writeSuccessResponseHeadersOnly(w)
 }
 
 // GetBucketVersioningHandler - GET Bucket Versioning.
 // ----------
-// No-op. Available for API compatibility.
+// Returns the versioning state of a bucket, an empty configuration
+// is returned for buckets where versioning was never configured.
 func (api objectAPIHandlers) GetBucketVersioningHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "GetBucketVersioning")
 
@@ -955,14 +1044,37 @@ func (api objectAPIHandlers) GetBucketVersioningHandler(w http.ResponseWriter, r
 		return
 	}
 
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketVersioningAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	getBucketInfo := objectAPI.GetBucketInfo
 	if _, err := getBucketInfo(ctx, bucket); err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
+	config, err := objectAPI.GetBucketVersioning(ctx, bucket)
+	if err != nil {
+		switch err.(type) {
+		case BucketVersioningConfigNotFound, NotImplemented:
+			// Write success response.
+			writeSuccessResponseXML(w, []byte(getBucketVersioningResponse))
+		default:
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		}
+		return
+	}
+
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	// Write success response.
-	writeSuccessResponseXML(w, []byte(getBucketVersioningResponse))
+	writeSuccessResponseXML(w, configData)
 }
 
 // PutBucketObjectLockConfigHandler - PUT Bucket object lock configuration.
@@ -72,6 +72,17 @@ func (api objectAPIHandlers) PutBucketLifecycleHandler(w http.ResponseWriter, r
 		return
 	}
 
+	// Transition rules may only move objects to registered remote tiers.
+	for _, rule := range bucketLifecycle.Rules {
+		if rule.Transition.StorageClass == "" {
+			continue
+		}
+		if _, ok := globalTierSys.Get(rule.Transition.StorageClass); !ok {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidStorageClass), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+
 	if err = objAPI.SetBucketLifecycle(ctx, bucket, bucketLifecycle); err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
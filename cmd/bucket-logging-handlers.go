@@ -0,0 +1,167 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/logging"
+	"github.com/minio/minio/pkg/bucket/policy"
+)
+
+// PutBucketLoggingHandler - This HTTP handler sets the logging status of a bucket as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketLogging.html
+// an empty logging status disables server access logging of the bucket.
+func (api objectAPIHandlers) PutBucketLoggingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketLogging")
+
+	defer logger.AuditLog(w, r, "PutBucketLogging", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	// PutBucketLogging always needs a Content-Md5
+	if _, ok := r.Header[xhttp.ContentMD5]; !ok {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentMD5), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketLoggingAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	config, err := logging.ParseConfig(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if !config.Enabled() {
+		// Disabling logging of a bucket without a logging configuration is not an error.
+		if err = objAPI.DeleteBucketLoggingConfig(ctx, bucket); err != nil {
+			if _, ok := err.(BucketLoggingConfigNotFound); !ok {
+				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+				return
+			}
+		}
+
+		globalBucketLoggingSys.Remove(bucket)
+		globalNotificationSys.RemoveBucketLoggingConfig(ctx, bucket)
+
+		// Success.
+		writeSuccessResponseHeadersOnly(w)
+		return
+	}
+
+	// The target bucket must exist and can't be a reserved bucket.
+	targetBucket := config.LoggingEnabled.TargetBucket
+	if isMinioMetaBucketName(targetBucket) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidTargetBucketForLogging), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if _, err = objAPI.GetBucketInfo(ctx, targetBucket); err != nil {
+		apiErr := toAPIError(ctx, err)
+		if _, ok := err.(BucketNotFound); ok {
+			apiErr = errorCodes.ToAPIErr(ErrInvalidTargetBucketForLogging)
+		}
+		writeErrorResponse(ctx, w, apiErr, r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Access logs are written with the permissions of the server, the
+	// caller must be allowed to write the logs to the target as well.
+	targetPrefix := config.LoggingEnabled.TargetPrefix
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutObjectAction, targetBucket, targetPrefix); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err = objAPI.SetBucketLoggingConfig(ctx, bucket, config); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	globalBucketLoggingSys.Set(bucket, *config)
+	globalNotificationSys.SetBucketLoggingConfig(ctx, bucket, config)
+
+	// Success.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketLoggingHandler - This HTTP handler returns the logging status of a bucket,
+// an empty logging status is returned if server access logging is disabled.
+func (api objectAPIHandlers) GetBucketLoggingHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketLogging")
+
+	defer logger.AuditLog(w, r, "GetBucketLogging", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketLoggingAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	config, err := objAPI.GetBucketLoggingConfig(ctx, bucket)
+	if err != nil {
+		if _, ok := err.(BucketLoggingConfigNotFound); !ok {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		config = &logging.Config{}
+	}
+
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Write bucket logging status to client.
+	writeSuccessResponseXML(w, configData)
+}
@@ -0,0 +1,151 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"path"
+	"sync"
+
+	"github.com/minio/minio/pkg/bucket/logging"
+)
+
+const (
+	// Bucket logging configuration file name.
+	bucketLoggingConfig = "logging.xml"
+)
+
+// BucketLoggingSys - in-memory cache of bucket logging config
+type BucketLoggingSys struct {
+	sync.RWMutex
+	bucketLoggingMap map[string]logging.Config
+}
+
+// NewBucketLoggingSys - Creates an empty in-memory bucket logging configuration cache
+func NewBucketLoggingSys() *BucketLoggingSys {
+	return &BucketLoggingSys{
+		bucketLoggingMap: make(map[string]logging.Config),
+	}
+}
+
+// load - Loads the bucket logging configuration for the given list of buckets
+func (sys *BucketLoggingSys) load(buckets []BucketInfo, objAPI ObjectLayer) error {
+	for _, bucket := range buckets {
+		config, err := objAPI.GetBucketLoggingConfig(context.Background(), bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketLoggingConfigNotFound); ok {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+		sys.Set(bucket.Name, *config)
+	}
+
+	return nil
+}
+
+// Init - Initializes in-memory bucket logging config cache for the given list of buckets
+func (sys *BucketLoggingSys) Init(buckets []BucketInfo, objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	// We don't cache bucket logging config in gateway mode, nothing to do.
+	if globalIsGateway {
+		return nil
+	}
+
+	// Load bucket logging config cache once during boot.
+	return sys.load(buckets, objAPI)
+}
+
+// Get - gets bucket logging config for the given bucket.
+func (sys *BucketLoggingSys) Get(bucket string) (config logging.Config, ok bool) {
+	// Requests may be served before the sub-systems are
+	// initialized, buckets have no logging config until then.
+	if sys == nil || globalIsGateway {
+		return
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+	config, ok = sys.bucketLoggingMap[bucket]
+	return
+}
+
+// Set - sets bucket logging config to given bucket name.
+func (sys *BucketLoggingSys) Set(bucket string, config logging.Config) {
+	// We don't cache bucket logging config in gateway mode.
+	if globalIsGateway {
+		return
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+	sys.bucketLoggingMap[bucket] = config
+}
+
+// Remove - removes bucket logging config for given bucket.
+func (sys *BucketLoggingSys) Remove(bucket string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketLoggingMap, bucket)
+}
+
+// saveBucketLoggingConfig - save bucket logging config for given bucket.
+func saveBucketLoggingConfig(ctx context.Context, objAPI ObjectLayer, bucket string, config *logging.Config) error {
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Path to store bucket logging config for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketLoggingConfig)
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// getBucketLoggingConfig - get bucket logging config for given bucket.
+func getBucketLoggingConfig(objAPI ObjectLayer, bucket string) (*logging.Config, error) {
+	// Path to logging.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketLoggingConfig)
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketLoggingConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+
+	return logging.ParseConfig(bytes.NewReader(configData))
+}
+
+// removeBucketLoggingConfig - removes bucket logging config for given bucket.
+func removeBucketLoggingConfig(ctx context.Context, objAPI ObjectLayer, bucket string) error {
+	// Path to logging.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketLoggingConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketLoggingConfigNotFound{Bucket: bucket}
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,519 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"container/heap"
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Bucket quota configuration file name.
+	bucketQuotaConfigFile = "quota.json"
+
+	// Interval at which the data usage of the buckets is
+	// refreshed and FIFO quotas are enforced.
+	bucketQuotaInterval = 10 * time.Minute
+
+	// Max number of objects removed from a bucket with a FIFO quota
+	// in a single enforcement, the rest is removed by the next ones.
+	fifoQuotaMaxObjects = 10000
+)
+
+// BucketQuotaSys - in-memory cache of bucket quota config, along with
+// the data usage of the buckets the quotas are enforced against.
+type BucketQuotaSys struct {
+	sync.RWMutex
+	quotaMap map[string]madmin.BucketQuota
+
+	// Data usage of the buckets as last computed by the crawler.
+	dataUsage DataUsageInfo
+
+	// Bytes written to the buckets with a quota through this node
+	// since the last crawl, minus the bytes removed by FIFO quotas.
+	inflight map[string]int64
+}
+
+// NewBucketQuotaSys - Creates an empty in-memory bucket quota configuration cache
+func NewBucketQuotaSys() *BucketQuotaSys {
+	return &BucketQuotaSys{
+		quotaMap: make(map[string]madmin.BucketQuota),
+		inflight: make(map[string]int64),
+	}
+}
+
+// load - Loads the bucket quota configuration for the given list of buckets
+func (sys *BucketQuotaSys) load(buckets []BucketInfo, objAPI ObjectLayer) error {
+	for _, bucket := range buckets {
+		quota, err := getBucketQuotaConfig(context.Background(), objAPI, bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketQuotaConfigNotFound); ok {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+		sys.Set(bucket.Name, quota)
+	}
+
+	return nil
+}
+
+// Init - Initializes in-memory bucket quota config cache for the given
+// list of buckets, along with the data usage of the buckets.
+func (sys *BucketQuotaSys) Init(buckets []BucketInfo, objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	// Bucket quotas are not enforced in gateway mode, nothing to do.
+	if globalIsGateway {
+		return nil
+	}
+
+	// Data usage is only an estimate, do not fail the server
+	// startup when it cannot be read.
+	logger.LogIf(context.Background(), sys.refreshDataUsage(context.Background(), objAPI))
+
+	// Load bucket quota config cache once during boot.
+	return sys.load(buckets, objAPI)
+}
+
+// Get - gets bucket quota config for the given bucket.
+func (sys *BucketQuotaSys) Get(bucket string) (quota madmin.BucketQuota, ok bool) {
+	// Object layers may be used without the sub-systems
+	// initialized, such buckets never have a quota.
+	if sys == nil || globalIsGateway {
+		return
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+	quota, ok = sys.quotaMap[bucket]
+	return
+}
+
+// Set - sets bucket quota config to given bucket name.
+func (sys *BucketQuotaSys) Set(bucket string, quota madmin.BucketQuota) {
+	// We don't cache bucket quota config in gateway mode.
+	if globalIsGateway {
+		return
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+	sys.quotaMap[bucket] = quota
+}
+
+// Remove - removes bucket quota config for given bucket.
+func (sys *BucketQuotaSys) Remove(bucket string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.quotaMap, bucket)
+	delete(sys.inflight, bucket)
+}
+
+// AccountUsage - records size bytes written to or, when negative,
+// removed from the bucket since the last crawl.
+func (sys *BucketQuotaSys) AccountUsage(bucket string, size int64) {
+	if sys == nil || size == 0 {
+		return
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	// Only buckets with a quota are accounted for.
+	if _, ok := sys.quotaMap[bucket]; ok {
+		sys.inflight[bucket] += size
+	}
+}
+
+// removedObjectSize - returns the size of the object, or of its version
+// opts.VersionID, about to be removed from a bucket with a quota, so
+// that the bytes it frees can be accounted for. Zero is returned for
+// buckets without a quota and for deletes adding a delete marker.
+func (sys *BucketQuotaSys) removedObjectSize(ctx context.Context, objAPI ObjectLayer, bucket, object string, opts ObjectOptions) int64 {
+	if sys == nil {
+		return 0
+	}
+
+	sys.RLock()
+	_, ok := sys.quotaMap[bucket]
+	sys.RUnlock()
+	if !ok {
+		return 0
+	}
+
+	if opts.VersionID == "" && globalBucketVersioningSys.Configured(bucket) {
+		return 0
+	}
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, opts)
+	if err != nil || objInfo.DeleteMarker {
+		return 0
+	}
+	return objInfo.Size
+}
+
+// usage - returns the estimated data usage of the bucket in bytes.
+func (sys *BucketQuotaSys) usage(bucket string) uint64 {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	usage := int64(sys.dataUsage.BucketsSizes[bucket]) + sys.inflight[bucket]
+	if usage < 0 {
+		return 0
+	}
+	return uint64(usage)
+}
+
+// refreshDataUsage - reloads the data usage computed by the crawler,
+// bytes accounted before a newer crawl are part of its data usage.
+func (sys *BucketQuotaSys) refreshDataUsage(ctx context.Context, objAPI ObjectLayer) error {
+	dataUsage, err := loadDataUsageFromBackend(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	if !dataUsage.LastUpdate.After(sys.dataUsage.LastUpdate) {
+		return nil
+	}
+	sys.dataUsage = dataUsage
+	sys.inflight = make(map[string]int64)
+	return nil
+}
+
+// fifoQuotas - returns the buckets with a FIFO quota.
+func (sys *BucketQuotaSys) fifoQuotas() map[string]madmin.BucketQuota {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	quotas := make(map[string]madmin.BucketQuota)
+	for bucket, quota := range sys.quotaMap {
+		if quota.Type == madmin.FIFOQuota {
+			quotas[bucket] = quota
+		}
+	}
+	return quotas
+}
+
+// enforceBucketQuota - returns BucketQuotaExceeded when writing size
+// bytes to a bucket with a hard quota would exceed its quota.
+func enforceBucketQuota(ctx context.Context, bucket string, size int64) error {
+	quota, ok := globalBucketQuotaSys.Get(bucket)
+	if !ok || quota.Type != madmin.HardQuota {
+		return nil
+	}
+
+	if size < 0 {
+		size = 0
+	}
+	if globalBucketQuotaSys.usage(bucket)+uint64(size) > quota.Quota {
+		return BucketQuotaExceeded{Bucket: bucket}
+	}
+	return nil
+}
+
+// enforceBucketQuotaMultipart - returns BucketQuotaExceeded when
+// completing the multipart upload with the given parts would exceed
+// the hard quota of the bucket.
+func enforceBucketQuotaMultipart(ctx context.Context, objAPI ObjectLayer, bucket, object, uploadID string, parts []CompletePart) error {
+	quota, ok := globalBucketQuotaSys.Get(bucket)
+	if !ok || quota.Type != madmin.HardQuota {
+		return nil
+	}
+
+	completed := make(map[int]struct{}, len(parts))
+	for _, part := range parts {
+		completed[part.PartNumber] = struct{}{}
+	}
+
+	var size int64
+	var partNumberMarker int
+	for {
+		listPartsInfo, err := objAPI.ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, maxPartsList, ObjectOptions{})
+		if err != nil {
+			return err
+		}
+		for _, part := range listPartsInfo.Parts {
+			if _, ok := completed[part.PartNumber]; ok {
+				size += part.Size
+			}
+		}
+		if !listPartsInfo.IsTruncated {
+			break
+		}
+		partNumberMarker = listPartsInfo.NextPartNumberMarker
+	}
+
+	return enforceBucketQuota(ctx, bucket, size)
+}
+
+// saveBucketQuotaConfig - save bucket quota config for given bucket.
+func saveBucketQuotaConfig(ctx context.Context, objAPI ObjectLayer, bucket string, quota madmin.BucketQuota) error {
+	data, err := json.Marshal(quota)
+	if err != nil {
+		return err
+	}
+
+	// Path to store bucket quota config for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketQuotaConfigFile)
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// getBucketQuotaConfig - get bucket quota config for given bucket.
+func getBucketQuotaConfig(ctx context.Context, objAPI ObjectLayer, bucket string) (quota madmin.BucketQuota, err error) {
+	// Path to quota.json for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketQuotaConfigFile)
+	configData, err := readConfig(ctx, objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketQuotaConfigNotFound{Bucket: bucket}
+		}
+		return quota, err
+	}
+
+	err = json.Unmarshal(configData, &quota)
+	return quota, err
+}
+
+// removeBucketQuotaConfig - removes bucket quota config for given bucket.
+func removeBucketQuotaConfig(ctx context.Context, objAPI ObjectLayer, bucket string) error {
+	// Path to quota.json for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketQuotaConfigFile)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketQuotaConfigNotFound{Bucket: bucket}
+		}
+		return err
+	}
+	return nil
+}
+
+// initBucketQuotaEnforcement starts the routine that periodically
+// refreshes the data usage of the buckets and enforces FIFO quotas.
+func initBucketQuotaEnforcement() {
+	go startBucketQuotaEnforcement()
+}
+
+func startBucketQuotaEnforcement() {
+	var objAPI ObjectLayer
+	var ctx = context.Background()
+
+	// Wait until the object API is ready
+	for {
+		objAPI = newObjectLayerWithoutSafeModeFn()
+		if objAPI == nil {
+			time.Sleep(time.Second)
+			continue
+		}
+		break
+	}
+
+	ticker := time.NewTicker(bucketQuotaInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-GlobalServiceDoneCh:
+			return
+		case <-ticker.C:
+			if err := globalBucketQuotaSys.refreshDataUsage(ctx, objAPI); err != nil {
+				logger.LogIf(ctx, err)
+				continue
+			}
+
+			err := enforceFIFOQuota(ctx, objAPI)
+			switch err.(type) {
+			case nil:
+			// Unable to hold a lock means there is another
+			// instance enforcing the FIFO quotas.
+			case OperationTimedOut:
+			default:
+				logger.LogIf(ctx, err)
+			}
+		}
+	}
+}
+
+// enforceFIFOQuota - removes the oldest objects of the buckets with a
+// FIFO quota until their usage is below their quota.
+func enforceFIFOQuota(ctx context.Context, objAPI ObjectLayer) error {
+	quotas := globalBucketQuotaSys.fifoQuotas()
+	if len(quotas) == 0 {
+		return nil
+	}
+
+	// Lock to avoid concurrent enforcement from other nodes
+	quotaLock := objAPI.NewNSLock(ctx, "system", "bucket-quota-fifo")
+	if err := quotaLock.GetLock(lifecycleLockTimeout); err != nil {
+		return err
+	}
+	defer quotaLock.Unlock()
+
+	for bucket, quota := range quotas {
+		usage := globalBucketQuotaSys.usage(bucket)
+		if usage <= quota.Quota {
+			continue
+		}
+		if err := enforceFIFOQuotaBucket(ctx, objAPI, bucket, usage-quota.Quota); err != nil {
+			logger.LogIf(ctx, err)
+		}
+	}
+
+	return nil
+}
+
+// enforceFIFOQuotaBucket - removes the oldest objects of the bucket,
+// or their oldest versions when versioning was configured on the
+// bucket, until toFree bytes were removed.
+func enforceFIFOQuotaBucket(ctx context.Context, objAPI ObjectLayer, bucket string, toFree uint64) error {
+	versioned := globalBucketVersioningSys.Configured(bucket)
+
+	candidates := newFIFOQuotaCandidates(toFree, fifoQuotaMaxObjects)
+	if versioned {
+		var marker, versionMarker string
+		for {
+			loi, err := objAPI.ListObjectVersions(ctx, bucket, "", marker, versionMarker, "", maxObjectList)
+			if err != nil {
+				return err
+			}
+			for _, obj := range loi.Objects {
+				// Delete markers take no space.
+				if !obj.DeleteMarker {
+					candidates.add(obj)
+				}
+			}
+			if !loi.IsTruncated {
+				break
+			}
+			marker, versionMarker = loi.NextMarker, loi.NextVersionIDMarker
+		}
+	} else {
+		objInfoCh := make(chan ObjectInfo)
+		if err := objAPI.Walk(ctx, bucket, "", objInfoCh); err != nil {
+			return err
+		}
+		for obj := range objInfoCh {
+			candidates.add(obj)
+		}
+	}
+
+	var freed uint64
+	for _, obj := range candidates.oldest() {
+		if freed >= toFree {
+			break
+		}
+
+		waitForLowHTTPReq(int32(globalEndpoints.Nodes()))
+
+		var err error
+		if versioned {
+			_, err = objAPI.DeleteObjectVersion(ctx, bucket, obj.Name, ObjectOptions{VersionID: obj.VersionID})
+		} else {
+			err = objAPI.DeleteObject(ctx, bucket, obj.Name)
+		}
+		if err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+
+		freed += uint64(obj.Size)
+		globalBucketQuotaSys.AccountUsage(bucket, -obj.Size)
+
+		// Notify object deleted event.
+		sendEvent(eventArgs{
+			EventName:  event.ObjectRemovedDelete,
+			BucketName: bucket,
+			Object:     obj,
+			Host:       "Internal: [FIFO-QUOTA-EXPIRY]",
+		})
+	}
+
+	return nil
+}
+
+// fifoQuotaHeap - max-heap of objects ordered by their modification
+// time, the most recent object being at the top.
+type fifoQuotaHeap []ObjectInfo
+
+func (h fifoQuotaHeap) Len() int            { return len(h) }
+func (h fifoQuotaHeap) Less(i, j int) bool  { return h[i].ModTime.After(h[j].ModTime) }
+func (h fifoQuotaHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *fifoQuotaHeap) Push(x interface{}) { *h = append(*h, x.(ObjectInfo)) }
+func (h *fifoQuotaHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// fifoQuotaCandidates - keeps, while the objects of a bucket are
+// listed, only the oldest ones needed to free toFree bytes, so that
+// the memory used does not grow with the number of objects.
+type fifoQuotaCandidates struct {
+	objects    fifoQuotaHeap
+	size       uint64
+	toFree     uint64
+	maxObjects int
+}
+
+func newFIFOQuotaCandidates(toFree uint64, maxObjects int) *fifoQuotaCandidates {
+	return &fifoQuotaCandidates{
+		toFree:     toFree,
+		maxObjects: maxObjects,
+	}
+}
+
+// add - adds obj to the candidates, dropping the most recent
+// candidates which are not needed anymore to free toFree bytes.
+func (c *fifoQuotaCandidates) add(obj ObjectInfo) {
+	heap.Push(&c.objects, obj)
+	c.size += uint64(obj.Size)
+	for c.objects.Len() > 0 {
+		newest := c.objects[0]
+		if c.objects.Len() <= c.maxObjects && c.size-uint64(newest.Size) < c.toFree {
+			break
+		}
+		heap.Pop(&c.objects)
+		c.size -= uint64(newest.Size)
+	}
+}
+
+// oldest - returns the candidates, oldest first.
+func (c *fifoQuotaCandidates) oldest() []ObjectInfo {
+	objects := make([]ObjectInfo, len(c.objects))
+	copy(objects, c.objects)
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].ModTime.Before(objects[j].ModTime)
+	})
+	return objects
+}
@@ -0,0 +1,119 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestEnforceBucketQuota(t *testing.T) {
+	defer func(sys *BucketQuotaSys) { globalBucketQuotaSys = sys }(globalBucketQuotaSys)
+
+	globalBucketQuotaSys = NewBucketQuotaSys()
+	globalBucketQuotaSys.dataUsage = DataUsageInfo{
+		BucketsSizes: map[string]uint64{
+			"hard":    900,
+			"fifo":    900,
+			"noquota": 900,
+		},
+	}
+	globalBucketQuotaSys.Set("hard", madmin.BucketQuota{Quota: 1000, Type: madmin.HardQuota})
+	globalBucketQuotaSys.Set("fifo", madmin.BucketQuota{Quota: 1000, Type: madmin.FIFOQuota})
+
+	testCases := []struct {
+		bucket      string
+		inflight    int64
+		size        int64
+		expectedErr error
+	}{
+		{"hard", 0, 100, nil},
+		{"hard", 0, 101, BucketQuotaExceeded{Bucket: "hard"}},
+		{"hard", 100, 0, nil},
+		{"hard", 100, 1, BucketQuotaExceeded{Bucket: "hard"}},
+		// Bytes removed since the last crawl free up the quota.
+		{"hard", -100, 200, nil},
+		// FIFO quotas never reject writes.
+		{"fifo", 0, 1000, nil},
+		{"noquota", 0, 1000, nil},
+	}
+
+	for i, testCase := range testCases {
+		globalBucketQuotaSys.inflight = make(map[string]int64)
+		globalBucketQuotaSys.AccountUsage(testCase.bucket, testCase.inflight)
+
+		err := enforceBucketQuota(context.Background(), testCase.bucket, testCase.size)
+		if err != testCase.expectedErr {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expectedErr, err)
+		}
+	}
+
+	// Buckets without a quota are not accounted for.
+	globalBucketQuotaSys.AccountUsage("noquota", 100)
+	if _, ok := globalBucketQuotaSys.inflight["noquota"]; ok {
+		t.Errorf("Expected no usage to be accounted for a bucket without quota")
+	}
+
+	// The quota no longer applies once removed.
+	globalBucketQuotaSys.Remove("hard")
+	if err := enforceBucketQuota(context.Background(), "hard", 1000); err != nil {
+		t.Errorf("Expected no error after removing the quota, got %v", err)
+	}
+}
+
+func TestFIFOQuotaCandidates(t *testing.T) {
+	now := time.Now()
+	// Objects listed in name order, "e" being the oldest one.
+	objects := []ObjectInfo{
+		{Name: "a", Size: 10, ModTime: now.Add(-1 * time.Hour)},
+		{Name: "b", Size: 10, ModTime: now.Add(-3 * time.Hour)},
+		{Name: "c", Size: 10, ModTime: now.Add(-2 * time.Hour)},
+		{Name: "d", Size: 10, ModTime: now.Add(-4 * time.Hour)},
+		{Name: "e", Size: 10, ModTime: now.Add(-5 * time.Hour)},
+	}
+
+	testCases := []struct {
+		toFree     uint64
+		maxObjects int
+		expected   []string
+	}{
+		{1, 10, []string{"e"}},
+		{15, 10, []string{"e", "d"}},
+		{30, 10, []string{"e", "d", "b"}},
+		{30, 2, []string{"e", "d"}},
+		{100, 10, []string{"e", "d", "b", "c", "a"}},
+	}
+
+	for i, testCase := range testCases {
+		candidates := newFIFOQuotaCandidates(testCase.toFree, testCase.maxObjects)
+		for _, obj := range objects {
+			candidates.add(obj)
+		}
+		oldest := candidates.oldest()
+		if len(oldest) != len(testCase.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, oldest)
+		}
+		for j, obj := range oldest {
+			if obj.Name != testCase.expected[j] {
+				t.Errorf("Test %d: expected %s at %d, got %s", i+1, testCase.expected[j], j, obj.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,201 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// Max number of replication entries queued on a node.
+	replicationQueueLimit = 100000
+
+	replicationEntryExt = ".replication"
+)
+
+var errReplicationQueueFull = errors.New("the replication queue is full")
+
+// replicationOp - operation to be replayed on the remote bucket.
+type replicationOp string
+
+const (
+	replicationPutOp    replicationOp = "put"
+	replicationDeleteOp replicationOp = "delete"
+)
+
+// replicationEntry - an object version written or deleted locally,
+// waiting to be replicated to the remote bucket target.
+type replicationEntry struct {
+	Op        replicationOp `json:"op"`
+	Bucket    string        `json:"bucket"`
+	Object    string        `json:"object"`
+	VersionID string        `json:"versionId,omitempty"`
+	ETag      string        `json:"etag,omitempty"`
+}
+
+// replicationQueue - persists replication entries as files of a local
+// directory until they are replicated, so that they survive restarts
+// and outages of the remote bucket target.
+type replicationQueue struct {
+	sync.RWMutex
+	currentEntries uint64
+	entryLimit     uint64
+	directory      string
+
+	// Sequence number of the last queued entry, entries are named
+	// after their sequence number to be replayed in order.
+	sequence uint64
+
+	// Signals the replication worker of new entries.
+	notifyCh chan struct{}
+}
+
+// newReplicationQueue - creates a replication queue stored in directory.
+func newReplicationQueue(directory string) *replicationQueue {
+	return &replicationQueue{
+		directory:  directory,
+		entryLimit: replicationQueueLimit,
+		notifyCh:   make(chan struct{}, 1),
+	}
+}
+
+// Open - creates the directory if not present.
+func (q *replicationQueue) Open() error {
+	q.Lock()
+	defer q.Unlock()
+
+	if err := os.MkdirAll(q.directory, os.FileMode(0770)); err != nil {
+		return err
+	}
+
+	keys, err := q.list()
+	if err != nil {
+		return err
+	}
+
+	q.currentEntries = uint64(len(keys))
+	// Sequence numbers go on from the last queued entry.
+	for _, key := range keys {
+		if sequence, err := strconv.ParseUint(key, 10, 64); err == nil && sequence > q.sequence {
+			q.sequence = sequence
+		}
+	}
+	return nil
+}
+
+// Put - queues a replication entry.
+func (q *replicationQueue) Put(entry replicationEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	q.Lock()
+	if q.currentEntries >= q.entryLimit {
+		q.Unlock()
+		return errReplicationQueueFull
+	}
+	// Zero padded so that the names sort in sequence order.
+	path := filepath.Join(q.directory, fmt.Sprintf("%020d", q.sequence+1)+replicationEntryExt)
+	if err = ioutil.WriteFile(path, data, os.FileMode(0660)); err != nil {
+		q.Unlock()
+		return err
+	}
+	q.sequence++
+	q.currentEntries++
+	q.Unlock()
+
+	// Wake up the replication worker, unless it is already awake.
+	select {
+	case q.notifyCh <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Get - returns a queued replication entry.
+func (q *replicationQueue) Get(key string) (entry replicationEntry, err error) {
+	q.RLock()
+	defer q.RUnlock()
+
+	data, err := ioutil.ReadFile(filepath.Join(q.directory, key+replicationEntryExt))
+	if err != nil {
+		return entry, err
+	}
+	if len(data) == 0 {
+		return entry, os.ErrNotExist
+	}
+	err = json.Unmarshal(data, &entry)
+	return entry, err
+}
+
+// Del - removes a replication entry from the queue.
+func (q *replicationQueue) Del(key string) error {
+	q.Lock()
+	defer q.Unlock()
+
+	if err := os.Remove(filepath.Join(q.directory, key+replicationEntryExt)); err != nil {
+		return err
+	}
+
+	// Decrement the current entries count.
+	q.currentEntries--
+
+	// Protect against underflow, entries may be removed
+	// by a previous instance of the worker.
+	if q.currentEntries == math.MaxUint64 {
+		q.currentEntries = 0
+	}
+	return nil
+}
+
+// List - returns the keys of all the queued entries, oldest first.
+func (q *replicationQueue) List() ([]string, error) {
+	q.RLock()
+	defer q.RUnlock()
+	return q.list()
+}
+
+// list lock less.
+func (q *replicationQueue) list() ([]string, error) {
+	files, err := ioutil.ReadDir(q.directory)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(files))
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), replicationEntryExt) {
+			continue
+		}
+		keys = append(keys, strings.TrimSuffix(file.Name(), replicationEntryExt))
+	}
+
+	// Replay the entries in the order they were queued.
+	sort.Strings(keys)
+	return keys, nil
+}
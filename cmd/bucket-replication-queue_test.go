@@ -0,0 +1,88 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestReplicationQueueOrder(t *testing.T) {
+	dir, err := ioutil.TempDir(globalTestTmpDir, "minio-replication-queue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	q := newReplicationQueue(dir)
+	if err = q.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Entries written within the same mtime tick are
+	// still replayed in the order they were queued.
+	var expected []replicationEntry
+	for i := 0; i < 20; i++ {
+		op := replicationPutOp
+		if i%2 == 1 {
+			op = replicationDeleteOp
+		}
+		entry := replicationEntry{Op: op, Bucket: "bucket", Object: fmt.Sprintf("object-%d", i/2)}
+		if err = q.Put(entry); err != nil {
+			t.Fatal(err)
+		}
+		expected = append(expected, entry)
+	}
+
+	// Entries keep their order across restarts, including
+	// the entries queued after the restart.
+	q = newReplicationQueue(dir)
+	if err = q.Open(); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := q.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = q.Del(keys[0]); err != nil {
+		t.Fatal(err)
+	}
+	expected = expected[1:]
+	entry := replicationEntry{Op: replicationPutOp, Bucket: "bucket", Object: "object-last"}
+	if err = q.Put(entry); err != nil {
+		t.Fatal(err)
+	}
+	expected = append(expected, entry)
+
+	if keys, err = q.List(); err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != len(expected) {
+		t.Fatalf("expected %d entries, got %d", len(expected), len(keys))
+	}
+	for i, key := range keys {
+		entry, err := q.Get(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if entry != expected[i] {
+			t.Errorf("entry %d: expected %+v, got %+v", i, expected[i], entry)
+		}
+	}
+}
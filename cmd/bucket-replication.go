@@ -0,0 +1,460 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	miniogo "github.com/minio/minio-go/v6"
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/replication"
+	xnet "github.com/minio/minio/pkg/net"
+)
+
+const (
+	// Bucket replication configuration file name.
+	bucketReplicationConfig = "replication.xml"
+
+	// Directory of the replication queue, under the config directory.
+	replicationQueueDir = "replication"
+
+	// Interval at which replication of queued entries is retried
+	// while the remote bucket target is unreachable.
+	replicationRetryInterval = time.Minute
+)
+
+// BucketReplicationSys - in-memory cache of bucket replication config
+type BucketReplicationSys struct {
+	sync.RWMutex
+	bucketReplicationMap map[string]replication.Config
+
+	// Local queue of the objects to be replicated,
+	// nil when replication is not supported.
+	queue *replicationQueue
+}
+
+// NewBucketReplicationSys - Creates an empty in-memory bucket replication configuration cache
+func NewBucketReplicationSys() *BucketReplicationSys {
+	return &BucketReplicationSys{
+		bucketReplicationMap: make(map[string]replication.Config),
+	}
+}
+
+// load - Loads the bucket replication configuration for the given list of buckets
+func (sys *BucketReplicationSys) load(buckets []BucketInfo, objAPI ObjectLayer) error {
+	for _, bucket := range buckets {
+		config, err := objAPI.GetBucketReplicationConfig(context.Background(), bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketReplicationConfigNotFound); ok {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+		sys.Set(bucket.Name, *config)
+	}
+
+	return nil
+}
+
+// Init - Initializes in-memory bucket replication config cache for the
+// given list of buckets, along with the local replication queue.
+func (sys *BucketReplicationSys) Init(buckets []BucketInfo, objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	// Buckets are only replicated in erasure coded mode, nothing to do.
+	if globalIsGateway || !globalIsXL {
+		return nil
+	}
+
+	queue := newReplicationQueue(filepath.Join(globalConfigDir.Get(), replicationQueueDir))
+	if err := queue.Open(); err != nil {
+		return err
+	}
+	sys.queue = queue
+
+	// Load bucket replication config cache once during boot.
+	return sys.load(buckets, objAPI)
+}
+
+// Get - gets bucket replication config for the given bucket.
+func (sys *BucketReplicationSys) Get(bucket string) (config replication.Config, ok bool) {
+	// Object layers may be used without the sub-systems
+	// initialized, such buckets are never replicated.
+	if sys == nil || globalIsGateway {
+		return
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+	config, ok = sys.bucketReplicationMap[bucket]
+	return
+}
+
+// Set - sets bucket replication config to given bucket name.
+func (sys *BucketReplicationSys) Set(bucket string, config replication.Config) {
+	// We don't cache bucket replication config in gateway mode.
+	if globalIsGateway {
+		return
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+	sys.bucketReplicationMap[bucket] = config
+}
+
+// Remove - removes bucket replication config for given bucket.
+func (sys *BucketReplicationSys) Remove(bucket string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketReplicationMap, bucket)
+}
+
+// saveBucketReplicationConfig - save bucket replication config for given bucket.
+func saveBucketReplicationConfig(ctx context.Context, objAPI ObjectLayer, bucket string, config *replication.Config) error {
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Path to store bucket replication config for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketReplicationConfig)
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// getBucketReplicationConfig - get bucket replication config for given bucket.
+func getBucketReplicationConfig(objAPI ObjectLayer, bucket string) (*replication.Config, error) {
+	// Path to replication.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketReplicationConfig)
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketReplicationConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+
+	return replication.ParseConfig(bytes.NewReader(configData))
+}
+
+// removeBucketReplicationConfig - removes bucket replication config for given bucket.
+func removeBucketReplicationConfig(ctx context.Context, objAPI ObjectLayer, bucket string) error {
+	// Path to replication.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketReplicationConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketReplicationConfigNotFound{Bucket: bucket}
+		}
+		return err
+	}
+	return nil
+}
+
+// mustReplicate - returns true if the given object written to or
+// deleted from the bucket is to be replicated to a remote bucket.
+func mustReplicate(bucket, object, userTags string, delete bool) bool {
+	// Object layers may be used without the sub-systems initialized.
+	if globalBucketReplicationSys == nil || globalBucketReplicationSys.queue == nil {
+		return false
+	}
+	config, ok := globalBucketReplicationSys.Get(bucket)
+	if !ok {
+		return false
+	}
+	_, ok = config.GetDestination(replication.ObjectOpts{
+		Name:     object,
+		UserTags: userTags,
+		Delete:   delete,
+	})
+	return ok
+}
+
+// scheduleReplication - queues the replication of an object version
+// written with a pending replication status, the version is marked as
+// failed to replicate when it cannot be queued.
+func scheduleReplication(ctx context.Context, objInfo ObjectInfo, objAPI ObjectLayer) {
+	if globalBucketReplicationSys == nil || globalBucketReplicationSys.queue == nil {
+		return
+	}
+	if objInfo.UserDefined[xhttp.AmzBucketReplicationStatus] != replication.Pending.String() {
+		return
+	}
+
+	err := globalBucketReplicationSys.queue.Put(replicationEntry{
+		Op:        replicationPutOp,
+		Bucket:    objInfo.Bucket,
+		Object:    objInfo.Name,
+		VersionID: objInfo.VersionID,
+		ETag:      objInfo.ETag,
+	})
+	if err != nil {
+		logger.LogIf(ctx, err)
+		setReplicationStatus(ctx, objAPI, objInfo, replication.Failed)
+	}
+}
+
+// scheduleReplicationDelete - queues the replication of the deletion
+// of an object, when the bucket replicates deletes.
+func scheduleReplicationDelete(ctx context.Context, bucket, object string) {
+	if !mustReplicate(bucket, object, "", true) {
+		return
+	}
+
+	logger.LogIf(ctx, globalBucketReplicationSys.queue.Put(replicationEntry{
+		Op:     replicationDeleteOp,
+		Bucket: bucket,
+		Object: object,
+	}))
+}
+
+// setReplicationStatus - records the replication status of an object
+// version, unless the version was overwritten meanwhile.
+func setReplicationStatus(ctx context.Context, objAPI ObjectLayer, objInfo ObjectInfo, status replication.StatusType) {
+	opts := ObjectOptions{
+		VersionID: objInfo.VersionID,
+		UserDefined: map[string]string{
+			xhttp.AmzBucketReplicationStatus: status.String(),
+		},
+		CheckCopyPrecondFn: func(oi ObjectInfo, encETag string) bool {
+			return oi.ETag != objInfo.ETag
+		},
+	}
+	err := objAPI.PutObjectMetadata(ctx, objInfo.Bucket, objInfo.Name, opts)
+	switch err.(type) {
+	case nil, PreConditionFailed, ObjectNotFound, VersionNotFound:
+	default:
+		logger.LogIf(ctx, err)
+	}
+}
+
+// initBackgroundReplication starts the routine replicating the
+// queued objects to their remote bucket targets.
+func initBackgroundReplication() {
+	go startBackgroundReplication()
+}
+
+func startBackgroundReplication() {
+	var objAPI ObjectLayer
+	var ctx = context.Background()
+
+	// Wait until the object API is ready
+	for {
+		objAPI = newObjectLayerWithoutSafeModeFn()
+		if objAPI == nil {
+			time.Sleep(time.Second)
+			continue
+		}
+		break
+	}
+
+	queue := globalBucketReplicationSys.queue
+	if queue == nil {
+		return
+	}
+
+	ticker := time.NewTicker(replicationRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		replayReplicationQueue(ctx, objAPI, queue)
+
+		select {
+		case <-GlobalServiceDoneCh:
+			return
+		case <-queue.notifyCh:
+		case <-ticker.C:
+		}
+	}
+}
+
+// replayReplicationQueue - replicates all the queued entries in order,
+// stops at the first entry which cannot be replicated for now.
+func replayReplicationQueue(ctx context.Context, objAPI ObjectLayer, queue *replicationQueue) {
+	keys, err := queue.List()
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	for _, key := range keys {
+		entry, err := queue.Get(key)
+		if err == nil {
+			switch entry.Op {
+			case replicationPutOp:
+				err = replicateObject(ctx, objAPI, entry)
+			case replicationDeleteOp:
+				err = replicateDelete(ctx, entry)
+			}
+			if err != nil {
+				logger.LogIf(ctx, err)
+				if xnet.IsNetworkOrHostDown(err) {
+					// The remote bucket target is unreachable, the
+					// entry is kept to be retried later.
+					return
+				}
+				// Other failures don't hold the later entries back,
+				// retrying the entry later would replay it out of order.
+			}
+		}
+		logger.LogIf(ctx, queue.Del(key))
+	}
+}
+
+// getReplicationTarget - returns the client of the remote bucket
+// target and the destination of the given object, ok is false when
+// the object is no longer to be replicated.
+func getReplicationTarget(ctx context.Context, bucket string, obj replication.ObjectOpts) (client *miniogo.Core, dest replication.Destination, ok bool) {
+	config, ok := globalBucketReplicationSys.Get(bucket)
+	if !ok {
+		return nil, dest, false
+	}
+	if dest, ok = config.GetDestination(obj); !ok {
+		return nil, dest, false
+	}
+	if client, ok = globalBucketTargetSys.GetClient(config.Role); !ok {
+		logger.LogIf(ctx, errNoSuchBucketTarget)
+		return nil, dest, false
+	}
+	return client, dest, true
+}
+
+// replicateObject - copies an object version to the remote bucket and
+// records the outcome as its replication status. An error is only
+// returned when the remote bucket target is unreachable.
+func replicateObject(ctx context.Context, objAPI ObjectLayer, entry replicationEntry) error {
+	opts := ObjectOptions{VersionID: entry.VersionID}
+	objInfo, err := objAPI.GetObjectInfo(ctx, entry.Bucket, entry.Object, opts)
+	if err != nil {
+		if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+			// Removed meanwhile, nothing to replicate.
+			return nil
+		}
+		return err
+	}
+
+	// The object was overwritten meanwhile, its latest
+	// content is replicated by a later entry.
+	if objInfo.ETag != entry.ETag {
+		return nil
+	}
+
+	client, dest, ok := getReplicationTarget(ctx, entry.Bucket, replication.ObjectOpts{
+		Name:     objInfo.Name,
+		UserTags: objInfo.UserTags,
+	})
+	if !ok {
+		setReplicationStatus(ctx, objAPI, objInfo, replication.Failed)
+		return nil
+	}
+
+	// Objects encrypted with client provided keys cannot be read back.
+	if crypto.SSEC.IsEncrypted(objInfo.UserDefined) {
+		setReplicationStatus(ctx, objAPI, objInfo, replication.Failed)
+		return nil
+	}
+
+	size := objInfo.Size
+	switch {
+	case objInfo.IsCompressed():
+		size = objInfo.GetActualSize()
+	case crypto.IsEncrypted(objInfo.UserDefined):
+		if size, err = objInfo.DecryptedSize(); err != nil {
+			logger.LogIf(ctx, err)
+			setReplicationStatus(ctx, objAPI, objInfo, replication.Failed)
+			return nil
+		}
+	}
+
+	// The object is replicated decrypted and decompressed,
+	// the remote bucket applies its own settings.
+	gr, err := objAPI.GetObjectNInfo(ctx, entry.Bucket, entry.Object, nil, http.Header{}, readLock, opts)
+	if err != nil {
+		if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	putOpts := miniogo.PutObjectOptions{
+		UserMetadata: getReplicationMetadata(objInfo),
+		StorageClass: dest.StorageClass,
+	}
+	_, err = client.PutObjectWithContext(ctx, dest.BucketName(), objInfo.Name, gr, size, putOpts)
+	gr.Close()
+
+	status := replication.Complete
+	if err != nil {
+		if xnet.IsNetworkOrHostDown(err) {
+			return err
+		}
+		logger.LogIf(ctx, err)
+		status = replication.Failed
+	}
+	setReplicationStatus(ctx, objAPI, objInfo, status)
+	return nil
+}
+
+// replicateDelete - removes an object from the remote bucket. An error
+// is only returned when the remote bucket target is unreachable.
+func replicateDelete(ctx context.Context, entry replicationEntry) error {
+	client, dest, ok := getReplicationTarget(ctx, entry.Bucket, replication.ObjectOpts{
+		Name:   entry.Object,
+		Delete: true,
+	})
+	if !ok {
+		return nil
+	}
+
+	if err := client.RemoveObject(dest.BucketName(), entry.Object); err != nil {
+		if xnet.IsNetworkOrHostDown(err) {
+			return err
+		}
+		logger.LogIf(ctx, err)
+	}
+	return nil
+}
+
+// getReplicationMetadata - returns the metadata of an object to be
+// set on its replica, internal, encryption and replication metadata
+// are left out.
+func getReplicationMetadata(objInfo ObjectInfo) map[string]string {
+	meta := make(map[string]string, len(objInfo.UserDefined))
+	for k, v := range objInfo.UserDefined {
+		switch {
+		case HasPrefix(k, ReservedMetadataPrefix):
+		case strings.HasPrefix(strings.ToLower(k), strings.ToLower(crypto.SSEHeader)):
+		case strings.EqualFold(k, "etag"):
+		case strings.EqualFold(k, xhttp.AmzBucketReplicationStatus):
+		default:
+			meta[k] = v
+		}
+	}
+	return meta
+}
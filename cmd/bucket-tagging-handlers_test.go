@@ -0,0 +1,169 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/object/tagging"
+)
+
+// getBucketTaggingXML - returns a tagging document with n tags.
+func getBucketTaggingXML(n int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("<Tagging><TagSet>")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&buf, "<Tag><Key>key%d</Key><Value>value%d</Value></Tag>", i, i)
+	}
+	buf.WriteString("</TagSet></Tagging>")
+	return buf.Bytes()
+}
+
+// Wrapper for calling Put/Get BucketTagging HTTP handler tests for both XL multiple disks and single node setup.
+func TestBucketTaggingHandlers(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketTaggingHandlers, []string{"PutBucketTagging", "GetBucketTagging"})
+}
+
+func testBucketTaggingHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+
+	// test cases with sample input and expected output.
+	testCases := []struct {
+		bucketName string
+		data       []byte
+		withMD5    bool
+		accessKey  string
+		secretKey  string
+		// expected Response.
+		expectedRespStatus int
+		expectedTags       int
+	}{
+		// Test case - 1.
+		// More tags than allowed on objects are accepted on buckets.
+		{
+			bucketName:         bucketName,
+			data:               getBucketTaggingXML(20),
+			withMD5:            true,
+			accessKey:          credentials.AccessKey,
+			secretKey:          credentials.SecretKey,
+			expectedRespStatus: http.StatusOK,
+			expectedTags:       20,
+		},
+		// Test case - 2.
+		// Maximum number of tags allowed on buckets.
+		{
+			bucketName:         bucketName,
+			data:               getBucketTaggingXML(50),
+			withMD5:            true,
+			accessKey:          credentials.AccessKey,
+			secretKey:          credentials.SecretKey,
+			expectedRespStatus: http.StatusOK,
+			expectedTags:       50,
+		},
+		// Test case - 3.
+		// Too many tags, previous tags are kept.
+		{
+			bucketName:         bucketName,
+			data:               getBucketTaggingXML(51),
+			withMD5:            true,
+			accessKey:          credentials.AccessKey,
+			secretKey:          credentials.SecretKey,
+			expectedRespStatus: http.StatusBadRequest,
+			expectedTags:       50,
+		},
+		// Test case - 4.
+		// Missing Content-Md5 header.
+		{
+			bucketName:         bucketName,
+			data:               getBucketTaggingXML(1),
+			accessKey:          credentials.AccessKey,
+			secretKey:          credentials.SecretKey,
+			expectedRespStatus: http.StatusBadRequest,
+			expectedTags:       50,
+		},
+		// Test case - 5.
+		// Non-existent bucket name.
+		{
+			bucketName:         "non-existent-bucket",
+			data:               getBucketTaggingXML(1),
+			withMD5:            true,
+			accessKey:          credentials.AccessKey,
+			secretKey:          credentials.SecretKey,
+			expectedRespStatus: http.StatusNotFound,
+		},
+		// Test case - 6.
+		// Testing for signature mismatch error.
+		{
+			bucketName:         bucketName,
+			data:               getBucketTaggingXML(1),
+			withMD5:            true,
+			accessKey:          "abcd",
+			secretKey:          "abcd",
+			expectedRespStatus: http.StatusForbidden,
+			expectedTags:       50,
+		},
+	}
+
+	for i, testCase := range testCases {
+		var headers map[string]string
+		if testCase.withMD5 {
+			headers = map[string]string{xhttp.ContentMD5: getMD5HashBase64(testCase.data)}
+		}
+		// initialize HTTP NewRecorder, this records any mutations to response writer inside the handler.
+		rec := httptest.NewRecorder()
+		// construct HTTP request for PUT bucket tagging.
+		req, err := newTestSignedRequestV4("PUT", getBucketTaggingURL("", testCase.bucketName),
+			int64(len(testCase.data)), bytes.NewReader(testCase.data), testCase.accessKey, testCase.secretKey, headers)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for PutBucketTaggingHandler: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+
+		if testCase.expectedTags == 0 {
+			continue
+		}
+
+		// Verify the tags of the bucket with GET bucket tagging.
+		rec = httptest.NewRecorder()
+		req, err = newTestSignedRequestV4("GET", getBucketTaggingURL("", testCase.bucketName),
+			0, nil, credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for GetBucketTaggingHandler: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, http.StatusOK, rec.Code)
+		}
+		var tags tagging.Tagging
+		if err = xml.Unmarshal(rec.Body.Bytes(), &tags); err != nil {
+			t.Fatalf("Test %d: %s: Unable to parse bucket tags: <ERROR> %v", i+1, instanceType, err)
+		}
+		if len(tags.TagSet.Tags) != testCase.expectedTags {
+			t.Errorf("Test %d: %s: Expected %d tags, but instead found %d", i+1, instanceType, testCase.expectedTags, len(tags.TagSet.Tags))
+		}
+	}
+}
@@ -0,0 +1,72 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"path"
+
+	"github.com/minio/minio/pkg/bucket/object/tagging"
+)
+
+const (
+	// Bucket tagging configuration file name.
+	bucketTaggingConfig = "tagging.xml"
+)
+
+// saveBucketTaggingConfig - save bucket tagging for given bucket.
+func saveBucketTaggingConfig(ctx context.Context, objAPI ObjectLayer, bucket string, tags *tagging.Tagging) error {
+	data, err := xml.Marshal(tags)
+	if err != nil {
+		return err
+	}
+
+	// Path to store bucket tagging for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketTaggingConfig)
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// getBucketTaggingConfig - get bucket tagging for given bucket.
+func getBucketTaggingConfig(objAPI ObjectLayer, bucket string) (*tagging.Tagging, error) {
+	// Path to tagging.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketTaggingConfig)
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketTaggingNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+
+	return tagging.ParseBucketTagging(bytes.NewReader(configData))
+}
+
+// removeBucketTaggingConfig - removes bucket tagging for given bucket.
+func removeBucketTaggingConfig(ctx context.Context, objAPI ObjectLayer, bucket string) error {
+	// Path to tagging.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketTaggingConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketTaggingNotFound{Bucket: bucket}
+		}
+		return err
+	}
+	return nil
+}
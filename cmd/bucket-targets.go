@@ -0,0 +1,258 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+	"sync"
+
+	miniogo "github.com/minio/minio-go/v6"
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Remote bucket targets configuration file name.
+	bucketTargetsConfigFile = "bucket-targets.json"
+
+	// Remote bucket targets configuration format version.
+	bucketTargetsConfigVersion = "1"
+)
+
+// bucketTargetsConfigV1 - on disk format of the remote bucket targets
+// configuration, stored along with the server config so that the
+// credentials of the targets are encrypted the same way.
+type bucketTargetsConfigV1 struct {
+	Version string                         `json:"version"`
+	Targets map[string]madmin.BucketTarget `json:"targets"`
+}
+
+// BucketTargetSys - remote bucket targets subsystem, buckets are
+// replicated to the target named by the role of their replication
+// configuration.
+type BucketTargetSys struct {
+	sync.RWMutex
+	targets map[string]madmin.BucketTarget
+	clients map[string]*miniogo.Core
+}
+
+// NewBucketTargetSys - creates new remote bucket targets subsystem.
+func NewBucketTargetSys() *BucketTargetSys {
+	return &BucketTargetSys{
+		targets: make(map[string]madmin.BucketTarget),
+		clients: make(map[string]*miniogo.Core),
+	}
+}
+
+// Init - initializes the remote bucket targets subsystem from the stored config.
+func (sys *BucketTargetSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	// Buckets are not replicated in gateway mode, nothing to do.
+	if globalIsGateway {
+		return nil
+	}
+
+	return sys.Load(objAPI)
+}
+
+// Load - reloads the remote bucket targets from the stored config.
+func (sys *BucketTargetSys) Load(objAPI ObjectLayer) error {
+	cfg, err := readBucketTargetsConfig(context.Background(), objAPI)
+	if err != nil {
+		return err
+	}
+
+	clients := make(map[string]*miniogo.Core, len(cfg.Targets))
+	for name, target := range cfg.Targets {
+		client, err := newRemoteS3Client(target.Endpoint, target.AccessKey, target.SecretKey, target.Region)
+		if err != nil {
+			// Do not fail the server startup on a bad target,
+			// buckets replicated to other targets are unaffected.
+			logger.LogIf(context.Background(), err)
+			continue
+		}
+		clients[name] = client
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+	sys.targets = cfg.Targets
+	sys.clients = clients
+	return nil
+}
+
+// GetClient - returns the client of the given remote bucket target.
+func (sys *BucketTargetSys) GetClient(name string) (client *miniogo.Core, ok bool) {
+	if sys == nil {
+		return nil, false
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+	client, ok = sys.clients[name]
+	return client, ok
+}
+
+// List - returns all the remote bucket targets sorted by name, their
+// secret keys are left out.
+func (sys *BucketTargetSys) List() []madmin.BucketTarget {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	targets := make([]madmin.BucketTarget, 0, len(sys.targets))
+	for _, target := range sys.targets {
+		target.SecretKey = ""
+		targets = append(targets, target)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Name < targets[j].Name
+	})
+	return targets
+}
+
+// Add - validates and stores a new remote bucket target.
+func (sys *BucketTargetSys) Add(ctx context.Context, objAPI ObjectLayer, target madmin.BucketTarget) error {
+	if target.Name == "" || target.Endpoint == "" {
+		return errInvalidArgument
+	}
+
+	client, err := newRemoteS3Client(target.Endpoint, target.AccessKey, target.SecretKey, target.Region)
+	if err == nil {
+		_, err = client.ListBuckets()
+	}
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return errBucketTargetBackendInvalid
+	}
+
+	return sys.update(ctx, objAPI, func(cfg *bucketTargetsConfigV1) error {
+		if _, ok := cfg.Targets[target.Name]; ok {
+			return errBucketTargetAlreadyExists
+		}
+		cfg.Targets[target.Name] = target
+		return nil
+	})
+}
+
+// Remove - removes a remote bucket target which is not referred to by
+// any bucket replication configuration.
+func (sys *BucketTargetSys) Remove(ctx context.Context, objAPI ObjectLayer, name string) error {
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+	for _, bucket := range buckets {
+		if cfg, ok := globalBucketReplicationSys.Get(bucket.Name); ok && cfg.Role == name {
+			return errBucketTargetInUse
+		}
+	}
+
+	return sys.update(ctx, objAPI, func(cfg *bucketTargetsConfigV1) error {
+		if _, ok := cfg.Targets[name]; !ok {
+			return errNoSuchBucketTarget
+		}
+		delete(cfg.Targets, name)
+		return nil
+	})
+}
+
+// update - applies fn to the stored config and reloads the remote
+// bucket targets from it, the config is locked for the whole cluster
+// meanwhile.
+func (sys *BucketTargetSys) update(ctx context.Context, objAPI ObjectLayer, fn func(cfg *bucketTargetsConfigV1) error) error {
+	// The config file itself is locked while it is read and saved,
+	// hence a separate lock is held for the whole update.
+	configLock := objAPI.NewNSLock(ctx, minioMetaBucket, path.Join(minioConfigPrefix, bucketTargetsConfigFile+".lock"))
+	if err := configLock.GetLock(globalOperationTimeout); err != nil {
+		return err
+	}
+	defer configLock.Unlock()
+
+	cfg, err := readBucketTargetsConfig(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	if err = fn(&cfg); err != nil {
+		return err
+	}
+	if err = saveBucketTargetsConfig(ctx, objAPI, cfg); err != nil {
+		return err
+	}
+	return sys.Load(objAPI)
+}
+
+// readBucketTargetsConfig - reads the remote bucket targets config, an
+// empty config is returned when none was stored yet.
+func readBucketTargetsConfig(ctx context.Context, objAPI ObjectLayer) (bucketTargetsConfigV1, error) {
+	cfg := bucketTargetsConfigV1{
+		Version: bucketTargetsConfigVersion,
+		Targets: make(map[string]madmin.BucketTarget),
+	}
+
+	configFile := path.Join(minioConfigPrefix, bucketTargetsConfigFile)
+	data, err := readConfig(ctx, objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+
+	if globalConfigEncrypted {
+		data, err = madmin.DecryptData(globalActiveCred.String(), bytes.NewReader(data))
+		if err != nil {
+			if err == madmin.ErrMaliciousData {
+				return cfg, config.ErrInvalidCredentialsBackendEncrypted(nil)
+			}
+			return cfg, err
+		}
+	}
+
+	if err = json.Unmarshal(data, &cfg); err != nil {
+		return cfg, err
+	}
+	if cfg.Targets == nil {
+		cfg.Targets = make(map[string]madmin.BucketTarget)
+	}
+	return cfg, nil
+}
+
+// saveBucketTargetsConfig - stores the remote bucket targets config.
+func saveBucketTargetsConfig(ctx context.Context, objAPI ObjectLayer, cfg bucketTargetsConfigV1) error {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	if globalConfigEncrypted {
+		data, err = madmin.EncryptData(globalActiveCred.String(), data)
+		if err != nil {
+			return err
+		}
+	}
+
+	configFile := path.Join(minioConfigPrefix, bucketTargetsConfigFile)
+	return saveConfig(ctx, objAPI, configFile, data)
+}
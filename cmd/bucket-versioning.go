@@ -0,0 +1,199 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"path"
+	"sync"
+
+	"github.com/minio/minio/pkg/bucket/versioning"
+)
+
+const (
+	// Bucket versioning configuration file name.
+	bucketVersioningConfig = "versioning.xml"
+
+	// Version ID of objects written while versioning
+	// is unset or suspended on a bucket.
+	nullVersionID = "null"
+)
+
+// BucketVersioningSys - in-memory cache of bucket versioning config
+type BucketVersioningSys struct {
+	sync.RWMutex
+	bucketVersioningMap map[string]versioning.Versioning
+}
+
+// NewBucketVersioningSys - Creates an empty in-memory bucket versioning configuration cache
+func NewBucketVersioningSys() *BucketVersioningSys {
+	return &BucketVersioningSys{
+		bucketVersioningMap: make(map[string]versioning.Versioning),
+	}
+}
+
+// load - Loads the bucket versioning configuration for the given list of buckets
+func (sys *BucketVersioningSys) load(buckets []BucketInfo, objAPI ObjectLayer) error {
+	for _, bucket := range buckets {
+		config, err := objAPI.GetBucketVersioning(context.Background(), bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketVersioningConfigNotFound); ok {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+		sys.Set(bucket.Name, *config)
+	}
+
+	return nil
+}
+
+// Init - Initializes in-memory bucket versioning config cache for the given list of buckets
+func (sys *BucketVersioningSys) Init(buckets []BucketInfo, objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	// We don't cache bucket versioning config in gateway mode, nothing to do.
+	if globalIsGateway {
+		return nil
+	}
+
+	// Load bucket versioning config cache once during boot.
+	return sys.load(buckets, objAPI)
+}
+
+// Get - gets bucket versioning config for the given bucket.
+func (sys *BucketVersioningSys) Get(bucket string) (config versioning.Versioning, ok bool) {
+	// Object layers may be used without the sub-systems
+	// initialized, such buckets are never versioned.
+	if sys == nil {
+		return
+	}
+
+	// We don't cache bucket versioning config in gateway mode.
+	if globalIsGateway {
+		objAPI := newObjectLayerWithoutSafeModeFn()
+		if objAPI == nil {
+			return
+		}
+
+		cfg, err := objAPI.GetBucketVersioning(context.Background(), bucket)
+		if err != nil {
+			return
+		}
+		return *cfg, true
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+	config, ok = sys.bucketVersioningMap[bucket]
+	return
+}
+
+// Enabled - returns true if versioning is enabled on the given bucket.
+func (sys *BucketVersioningSys) Enabled(bucket string) bool {
+	config, ok := sys.Get(bucket)
+	return ok && config.Enabled()
+}
+
+// Suspended - returns true if versioning is suspended on the given bucket.
+func (sys *BucketVersioningSys) Suspended(bucket string) bool {
+	config, ok := sys.Get(bucket)
+	return ok && config.Suspended()
+}
+
+// Configured - returns true if versioning was ever configured on the
+// given bucket, objects in such buckets may carry multiple versions.
+func (sys *BucketVersioningSys) Configured(bucket string) bool {
+	_, ok := sys.Get(bucket)
+	return ok
+}
+
+// Set - sets bucket versioning config to given bucket name.
+func (sys *BucketVersioningSys) Set(bucket string, config versioning.Versioning) {
+	// We don't cache bucket versioning config in gateway mode.
+	if globalIsGateway {
+		return
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+	sys.bucketVersioningMap[bucket] = config
+}
+
+// Remove - removes bucket versioning config for given bucket.
+func (sys *BucketVersioningSys) Remove(bucket string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketVersioningMap, bucket)
+}
+
+// saveBucketVersioningConfig - save bucket versioning config for given bucket.
+func saveBucketVersioningConfig(ctx context.Context, objAPI ObjectLayer, bucket string, config *versioning.Versioning) error {
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Path to store bucket versioning config for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketVersioningConfig)
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// getBucketVersioningConfig - get bucket versioning config for given bucket.
+func getBucketVersioningConfig(objAPI ObjectLayer, bucket string) (*versioning.Versioning, error) {
+	// Path to versioning.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketVersioningConfig)
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketVersioningConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+
+	return versioning.ParseConfig(bytes.NewReader(configData))
+}
+
+// removeBucketVersioningConfig - removes bucket versioning config for given bucket.
+func removeBucketVersioningConfig(ctx context.Context, objAPI ObjectLayer, bucket string) error {
+	// Path to versioning.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketVersioningConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketVersioningConfigNotFound{Bucket: bucket}
+		}
+		return err
+	}
+	return nil
+}
+
+// getRequestVersionID - returns the version id requested with the
+// `versionId` query parameter, only honored when versioning was ever
+// configured on the bucket.
+func getRequestVersionID(r *http.Request, bucket string) string {
+	if !globalBucketVersioningSys.Configured(bucket) {
+		return ""
+	}
+	return r.URL.Query().Get("versionId")
+}
@@ -0,0 +1,151 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"path"
+	"sync"
+
+	"github.com/minio/minio/pkg/bucket/website"
+)
+
+const (
+	// Bucket website configuration file name.
+	bucketWebsiteConfig = "website.xml"
+)
+
+// BucketWebsiteSys - in-memory cache of bucket website config
+type BucketWebsiteSys struct {
+	sync.RWMutex
+	bucketWebsiteMap map[string]website.Config
+}
+
+// NewBucketWebsiteSys - Creates an empty in-memory bucket website configuration cache
+func NewBucketWebsiteSys() *BucketWebsiteSys {
+	return &BucketWebsiteSys{
+		bucketWebsiteMap: make(map[string]website.Config),
+	}
+}
+
+// load - Loads the bucket website configuration for the given list of buckets
+func (sys *BucketWebsiteSys) load(buckets []BucketInfo, objAPI ObjectLayer) error {
+	for _, bucket := range buckets {
+		config, err := objAPI.GetBucketWebsiteConfig(context.Background(), bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketWebsiteConfigNotFound); ok {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+		sys.Set(bucket.Name, *config)
+	}
+
+	return nil
+}
+
+// Init - Initializes in-memory bucket website config cache for the given list of buckets
+func (sys *BucketWebsiteSys) Init(buckets []BucketInfo, objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	// We don't cache bucket website config in gateway mode, nothing to do.
+	if globalIsGateway {
+		return nil
+	}
+
+	// Load bucket website config cache once during boot.
+	return sys.load(buckets, objAPI)
+}
+
+// Get - gets bucket website config for the given bucket.
+func (sys *BucketWebsiteSys) Get(bucket string) (config website.Config, ok bool) {
+	// Requests may be served before the sub-systems are
+	// initialized, buckets have no website config until then.
+	if sys == nil || globalIsGateway {
+		return
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+	config, ok = sys.bucketWebsiteMap[bucket]
+	return
+}
+
+// Set - sets bucket website config to given bucket name.
+func (sys *BucketWebsiteSys) Set(bucket string, config website.Config) {
+	// We don't cache bucket website config in gateway mode.
+	if globalIsGateway {
+		return
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+	sys.bucketWebsiteMap[bucket] = config
+}
+
+// Remove - removes bucket website config for given bucket.
+func (sys *BucketWebsiteSys) Remove(bucket string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketWebsiteMap, bucket)
+}
+
+// saveBucketWebsiteConfig - save bucket website config for given bucket.
+func saveBucketWebsiteConfig(ctx context.Context, objAPI ObjectLayer, bucket string, config *website.Config) error {
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Path to store bucket website config for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketWebsiteConfig)
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// getBucketWebsiteConfig - get bucket website config for given bucket.
+func getBucketWebsiteConfig(objAPI ObjectLayer, bucket string) (*website.Config, error) {
+	// Path to website.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketWebsiteConfig)
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketWebsiteConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+
+	return website.ParseConfig(bytes.NewReader(configData))
+}
+
+// removeBucketWebsiteConfig - removes bucket website config for given bucket.
+func removeBucketWebsiteConfig(ctx context.Context, objAPI ObjectLayer, bucket string) error {
+	// Path to website.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucket, bucketWebsiteConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketWebsiteConfigNotFound{Bucket: bucket}
+		}
+		return err
+	}
+	return nil
+}
@@ -187,6 +187,24 @@ func handleCommonEnvVars() {
 		}
 	}
 
+	websiteDomains := env.Get(config.EnvWebsiteDomain, "")
+	if len(websiteDomains) != 0 {
+		for _, domainName := range strings.Split(websiteDomains, config.ValueSeparator) {
+			if _, ok := dns2.IsDomainName(domainName); !ok {
+				logger.Fatal(config.ErrInvalidDomainValue(nil).Msg("Unknown value `%s`", domainName),
+					"Invalid MINIO_WEBSITE_DOMAIN value in environment variable")
+			}
+			// Website hosts must never be taken for S3 virtual hosts.
+			for _, domain := range globalDomainNames {
+				if domainName == domain || strings.HasSuffix(domainName, "."+domain) {
+					logger.Fatal(config.ErrInvalidDomainValue(nil).Msg("`%s` overlaps with MINIO_DOMAIN `%s`", domainName, domain),
+						"Invalid MINIO_WEBSITE_DOMAIN value in environment variable")
+				}
+			}
+			globalWebsiteDomainNames = append(globalWebsiteDomainNames, domainName)
+		}
+	}
+
 	publicIPs := env.Get(config.EnvPublicIPs, "")
 	if len(publicIPs) != 0 {
 		minioEndpoints := strings.Split(publicIPs, config.ValueSeparator)
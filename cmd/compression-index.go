@@ -0,0 +1,200 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/sio"
+)
+
+const (
+	// Interval of uncompressed data after which the compressed stream
+	// is flushed, decompression of a range can start at any of the
+	// flushed offsets of the compressed stream.
+	compressionIndexInterval = 4 << 20
+
+	// Metadata key of the compression index of an object.
+	compressionIndexKey = ReservedMetadataPrefix + "compression-index"
+
+	// S2 stream identifier, which has to precede the compressed
+	// blocks read from a flushed offset of the compressed stream.
+	s2StreamIdentifier = "\xff\x06\x00\x00S2sTwO"
+)
+
+var errInvalidCompressionIndex = errors.New("invalid compression index")
+
+// compressionIndexEntry - an uncompressed offset and the offset of
+// the compressed stream at which its decompression can start.
+type compressionIndexEntry struct {
+	uncompressed int64
+	compressed   int64
+}
+
+// compressionIndex - the flushed offsets of a compressed stream, in
+// increasing order of the offsets.
+type compressionIndex []compressionIndexEntry
+
+// marshal - encodes the index as the varint deltas of its offsets.
+func (c compressionIndex) marshal() []byte {
+	if len(c) == 0 {
+		return nil
+	}
+	b := make([]byte, 0, len(c)*2*binary.MaxVarintLen32)
+	var prev compressionIndexEntry
+	tmp := make([]byte, binary.MaxVarintLen64)
+	for _, e := range c {
+		n := binary.PutUvarint(tmp, uint64(e.uncompressed-prev.uncompressed))
+		b = append(b, tmp[:n]...)
+		n = binary.PutUvarint(tmp, uint64(e.compressed-prev.compressed))
+		b = append(b, tmp[:n]...)
+		prev = e
+	}
+	return b
+}
+
+// parseCompressionIndex - decodes an index encoded by marshal.
+func parseCompressionIndex(b []byte) (compressionIndex, error) {
+	var c compressionIndex
+	var prev compressionIndexEntry
+	r := bytes.NewReader(b)
+	for r.Len() > 0 {
+		uncompressed, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, errInvalidCompressionIndex
+		}
+		compressed, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, errInvalidCompressionIndex
+		}
+		if uncompressed == 0 || compressed == 0 {
+			return nil, errInvalidCompressionIndex
+		}
+		prev = compressionIndexEntry{
+			uncompressed: prev.uncompressed + int64(uncompressed),
+			compressed:   prev.compressed + int64(compressed),
+		}
+		c = append(c, prev)
+	}
+	return c, nil
+}
+
+// compressedRange - returns the range of the compressed stream of
+// compSize bytes to decompress for the uncompressed range at offset
+// of length bytes, along with the uncompressed bytes to skip.
+func (c compressionIndex) compressedRange(offset, length, compSize int64) (compOff, compLength, skipLen int64) {
+	var start compressionIndexEntry
+	if i := sort.Search(len(c), func(i int) bool { return c[i].uncompressed > offset }); i > 0 {
+		start = c[i-1]
+	}
+	end := compSize
+	if i := sort.Search(len(c), func(i int) bool { return c[i].uncompressed >= offset+length }); i < len(c) {
+		end = c[i].compressed
+	}
+	if start.compressed > end || end > compSize {
+		// Ignore an index which doesn't match the compressed stream.
+		return 0, compSize, offset
+	}
+	return start.compressed, end - start.compressed, offset - start.uncompressed
+}
+
+// deriveCompressionIndexKey - derives the key sealing the compression index
+// of an encrypted object from the object encryption key.
+func deriveCompressionIndexKey(objectKey crypto.ObjectKey) []byte {
+	mac := hmac.New(sha256.New, objectKey[:])
+	mac.Write([]byte("compression-index"))
+	return mac.Sum(nil)
+}
+
+// sealCompressionIndex - encrypts the compression index of an encrypted
+// object, the index reveals the compression ratio of parts of the object.
+func sealCompressionIndex(objectKey crypto.ObjectKey, index []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	_, err := sio.Encrypt(&buf, bytes.NewReader(index), sio.Config{
+		Key:        deriveCompressionIndexKey(objectKey),
+		MinVersion: sio.Version20,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// openCompressionIndex - decrypts the sealed compression index of an
+// encrypted object.
+func openCompressionIndex(objectKey crypto.ObjectKey, sealedIndex []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	_, err := sio.Decrypt(&buf, bytes.NewReader(sealedIndex), sio.Config{
+		Key: deriveCompressionIndexKey(objectKey),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// getCompressionIndex - returns the compression index of the object, if
+// it has one. The object encryption key of encrypted objects is derived
+// from the request headers. Objects uploaded in multiple parts have no
+// index, since each part is a separate compressed stream.
+func getCompressionIndex(oi ObjectInfo, h http.Header) compressionIndex {
+	encoded, ok := oi.UserDefined[compressionIndexKey]
+	if !ok || len(oi.Parts) > 1 {
+		return nil
+	}
+	b, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil
+	}
+	if crypto.IsEncrypted(oi.UserDefined) {
+		key, err := getObjectEncryptionKey(h, oi, h.Get(crypto.SSECopyAlgorithm) != "")
+		if err != nil {
+			return nil
+		}
+		var objectKey crypto.ObjectKey
+		copy(objectKey[:], key)
+		if b, err = openCompressionIndex(objectKey, b); err != nil {
+			return nil
+		}
+	}
+	index, err := parseCompressionIndex(b)
+	if err != nil {
+		return nil
+	}
+	return index
+}
+
+// compressedSizeWriter - counts the bytes of a compressed stream.
+type compressedSizeWriter struct {
+	io.Writer
+	n int64
+}
+
+func (w *compressedSizeWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,205 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/sio"
+)
+
+func TestCompressionIndexMarshal(t *testing.T) {
+	index := compressionIndex{
+		{uncompressed: 4 << 20, compressed: 1000},
+		{uncompressed: 8 << 20, compressed: 1 << 20},
+		{uncompressed: 12 << 20, compressed: 3 << 20},
+	}
+	parsed, err := parseCompressionIndex(index.marshal())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(index, parsed) {
+		t.Fatalf("expected %v, got %v", index, parsed)
+	}
+
+	if _, err = parseCompressionIndex([]byte{0x80}); err != errInvalidCompressionIndex {
+		t.Fatalf("expected %v, got %v", errInvalidCompressionIndex, err)
+	}
+	if _, err = parseCompressionIndex([]byte{0x01, 0x00}); err != errInvalidCompressionIndex {
+		t.Fatalf("expected %v, got %v", errInvalidCompressionIndex, err)
+	}
+}
+
+func TestCompressionIndexCompressedRange(t *testing.T) {
+	index := compressionIndex{
+		{uncompressed: 100, compressed: 10},
+		{uncompressed: 200, compressed: 30},
+		{uncompressed: 300, compressed: 35},
+	}
+	testCases := []struct {
+		offset, length      int64
+		compOff, compLength int64
+		skipLen             int64
+	}{
+		{offset: 0, length: 400, compOff: 0, compLength: 50, skipLen: 0},
+		{offset: 0, length: 100, compOff: 0, compLength: 10, skipLen: 0},
+		{offset: 50, length: 100, compOff: 0, compLength: 30, skipLen: 50},
+		{offset: 100, length: 1, compOff: 10, compLength: 20, skipLen: 0},
+		{offset: 250, length: 10, compOff: 30, compLength: 5, skipLen: 50},
+		{offset: 350, length: 50, compOff: 35, compLength: 15, skipLen: 50},
+	}
+	for i, tc := range testCases {
+		compOff, compLength, skipLen := index.compressedRange(tc.offset, tc.length, 50)
+		if compOff != tc.compOff || compLength != tc.compLength || skipLen != tc.skipLen {
+			t.Errorf("Test %d: expected (%d, %d, %d), got (%d, %d, %d)", i+1,
+				tc.compOff, tc.compLength, tc.skipLen, compOff, compLength, skipLen)
+		}
+	}
+}
+
+func TestSealCompressionIndex(t *testing.T) {
+	var objectKey, otherKey crypto.ObjectKey
+	objectKey[0], otherKey[0] = 1, 2
+
+	index := compressionIndex{{uncompressed: 4 << 20, compressed: 1000}}.marshal()
+	sealedIndex, err := sealCompressionIndex(objectKey, index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(index, sealedIndex) {
+		t.Fatal("sealed index must not be the plain index")
+	}
+	openedIndex, err := openCompressionIndex(objectKey, sealedIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(index, openedIndex) {
+		t.Fatalf("expected %v, got %v", index, openedIndex)
+	}
+	if _, err = openCompressionIndex(otherKey, sealedIndex); err == nil {
+		t.Fatal("opening the index with another key must fail")
+	}
+}
+
+// Tests range reads of compressed objects, with and without
+// encryption of the compressed data.
+func TestGetObjectReaderCompressedRange(t *testing.T) {
+	data := make([]byte, 3*compressionIndexInterval+12345)
+	rand.New(rand.NewSource(1)).Read(data[:len(data)/2])
+	for i := len(data) / 2; i < len(data); i++ {
+		data[i] = byte(i % 7)
+	}
+
+	s2c := newS2CompressReader(bytes.NewReader(data))
+	compressed, err := ioutil.ReadAll(s2c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	index := s2c.Index()
+	if parsed, _ := parseCompressionIndex(index); len(parsed) != 3 {
+		t.Fatalf("expected 3 index entries, got %d", len(parsed))
+	}
+
+	h := http.Header{
+		crypto.SSECAlgorithm: []string{"AES256"},
+		crypto.SSECKey:       []string{"MzJieXRlc2xvbmdzZWNyZXRrZXltdXN0cHJvdmlkZWQ="},
+		crypto.SSECKeyMD5:    []string{"7PpPLAK26ONlVUGOWlusfg=="},
+	}
+	ranges := []*HTTPRangeSpec{
+		nil,
+		{Start: 0, End: 10},
+		{Start: 100, End: compressionIndexInterval + 100},
+		{Start: compressionIndexInterval, End: compressionIndexInterval},
+		{Start: 2*compressionIndexInterval + 5, End: -1},
+		{IsSuffixLength: true, Start: -1000},
+	}
+
+	for _, encrypted := range []bool{false, true} {
+		metadata := map[string]string{
+			ReservedMetadataPrefix + "compression": compressionAlgorithmV2,
+			ReservedMetadataPrefix + "actual-size": strconv.Itoa(len(data)),
+		}
+		stored, storedIndex := compressed, index
+		if encrypted {
+			key, err := ParseSSECustomerHeader(h)
+			if err != nil {
+				t.Fatal(err)
+			}
+			objectKey, err := newEncryptMetadata(key, "bucket", "object", metadata, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			reader, err := sio.EncryptReader(bytes.NewReader(compressed), sio.Config{Key: objectKey, MinVersion: sio.Version20})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored, err = ioutil.ReadAll(reader); err != nil {
+				t.Fatal(err)
+			}
+			var key32 crypto.ObjectKey
+			copy(key32[:], objectKey)
+			if storedIndex, err = sealCompressionIndex(key32, index); err != nil {
+				t.Fatal(err)
+			}
+		}
+		metadata[compressionIndexKey] = base64.StdEncoding.EncodeToString(storedIndex)
+		oi := ObjectInfo{
+			Bucket:      "bucket",
+			Name:        "object",
+			ETag:        "d41d8cd98f00b204e9800998ecf8427e",
+			Size:        int64(len(stored)),
+			UserDefined: metadata,
+		}
+
+		for i, rs := range ranges {
+			t.Run(fmt.Sprintf("encrypted=%t/%d", encrypted, i+1), func(t *testing.T) {
+				start, length, err := rs.GetOffsetLength(int64(len(data)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				fn, off, readLength, err := NewGetObjectReader(rs, oi, h, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if rs != nil && rs.Start >= compressionIndexInterval && off == 0 {
+					t.Errorf("expected the read to start after the first index entry")
+				}
+				gr, err := fn(bytes.NewReader(stored[off:off+readLength]), h, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer gr.Close()
+				got, err := ioutil.ReadAll(gr)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(got, data[start:start+length]) {
+					t.Errorf("range %d-%d doesn't match the data", start, start+length)
+				}
+			})
+		}
+	}
+}
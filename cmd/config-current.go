@@ -23,6 +23,7 @@ import (
 	"sync"
 
 	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/config/bitrot"
 	"github.com/minio/minio/cmd/config/cache"
 	"github.com/minio/minio/cmd/config/compress"
 	"github.com/minio/minio/cmd/config/etcd"
@@ -33,6 +34,7 @@ import (
 	"github.com/minio/minio/cmd/config/notify"
 	"github.com/minio/minio/cmd/config/policy/opa"
 	"github.com/minio/minio/cmd/config/storageclass"
+	"github.com/minio/minio/cmd/config/throttle"
 	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
@@ -46,6 +48,8 @@ func initHelp() {
 		config.EtcdSubSys:           etcd.DefaultKVS,
 		config.CacheSubSys:          cache.DefaultKVS,
 		config.CompressionSubSys:    compress.DefaultKVS,
+		config.ThrottleSubSys:       throttle.DefaultKVS,
+		config.BitrotScanSubSys:     bitrot.DefaultKVS,
 		config.IdentityLDAPSubSys:   xldap.DefaultKVS,
 		config.IdentityOpenIDSubSys: openid.DefaultKVS,
 		config.PolicyOPASubSys:      opa.DefaultKVS,
@@ -78,6 +82,14 @@ func initHelp() {
 			Key:         config.CompressionSubSys,
 			Description: "enable server side compression of objects",
 		},
+		config.HelpKV{
+			Key:         config.ThrottleSubSys,
+			Description: "limit request rates and bandwidth of users and buckets",
+		},
+		config.HelpKV{
+			Key:         config.BitrotScanSubSys,
+			Description: "enable scheduled deep scanning of objects for bitrot",
+		},
 		config.HelpKV{
 			Key:         config.EtcdSubSys,
 			Description: "federate multiple clusters for IAM and Bucket DNS",
@@ -180,6 +192,8 @@ func initHelp() {
 		config.EtcdSubSys:           etcd.Help,
 		config.CacheSubSys:          cache.Help,
 		config.CompressionSubSys:    compress.Help,
+		config.ThrottleSubSys:       throttle.Help,
+		config.BitrotScanSubSys:     bitrot.Help,
 		config.IdentityOpenIDSubSys: openid.Help,
 		config.IdentityLDAPSubSys:   xldap.Help,
 		config.PolicyOPASubSys:      opa.Help,
@@ -238,6 +252,14 @@ func validateConfig(s config.Config) error {
 		return err
 	}
 
+	if _, err := throttle.LookupConfig(s[config.ThrottleSubSys][config.Default]); err != nil {
+		return err
+	}
+
+	if _, err := bitrot.LookupConfig(s[config.BitrotScanSubSys][config.Default]); err != nil {
+		return err
+	}
+
 	{
 		etcdCfg, err := etcd.LookupConfig(s[config.EtcdSubSys][config.Default], globalRootCAs)
 		if err != nil {
@@ -381,6 +403,17 @@ func lookupConfigs(s config.Config) {
 		logger.LogIf(ctx, fmt.Errorf("Unable to setup Compression: %w", err))
 	}
 
+	throttleCfg, err := throttle.LookupConfig(s[config.ThrottleSubSys][config.Default])
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to setup throttling: %w", err))
+	}
+	setRequestThrottler(newRequestThrottler(throttleCfg))
+
+	globalBitrotScanConfig, err = bitrot.LookupConfig(s[config.BitrotScanSubSys][config.Default])
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to setup bitrot scanning: %w", err))
+	}
+
 	globalOpenIDConfig, err = openid.LookupConfig(s[config.IdentityOpenIDSubSys][config.Default],
 		NewCustomHTTPTransport(), xhttp.DrainBody)
 	if err != nil {
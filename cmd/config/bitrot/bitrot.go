@@ -0,0 +1,102 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bitrot
+
+import (
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/env"
+)
+
+// Bitrot scan config constants.
+const (
+	Rate     = "rate"
+	Interval = "interval"
+
+	EnvBitrotScanEnable   = "MINIO_BITROT_SCAN_ENABLE"
+	EnvBitrotScanRate     = "MINIO_BITROT_SCAN_RATE"
+	EnvBitrotScanInterval = "MINIO_BITROT_SCAN_INTERVAL"
+
+	DefaultRate     = "8MiB"
+	DefaultInterval = "720h"
+)
+
+// DefaultKVS - default KV config for bitrot scanning, which is off.
+var (
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   Rate,
+			Value: DefaultRate,
+		},
+		config.KV{
+			Key:   Interval,
+			Value: DefaultInterval,
+		},
+	}
+)
+
+// Config - scheduled deep scanning of all objects for bitrot.
+type Config struct {
+	Enabled bool `json:"enabled"`
+	// Bytes per second verified on each disk.
+	Rate uint64 `json:"rate"`
+	// Time between the start of two scans.
+	Interval time.Duration `json:"interval"`
+}
+
+// LookupConfig - lookup bitrot scan config.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.BitrotScanSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+
+	cfg.Enabled, err = config.ParseBool(env.Get(EnvBitrotScanEnable, kvs.Get(config.Enable)))
+	if err != nil {
+		// Parsing failures happen due to empty KVS, ignore it.
+		if kvs.Empty() {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+	if !cfg.Enabled {
+		return cfg, nil
+	}
+
+	rate := env.Get(EnvBitrotScanRate, kvs.Get(Rate))
+	if rate == "" {
+		rate = DefaultRate
+	}
+	if cfg.Rate, err = humanize.ParseBytes(rate); err != nil || cfg.Rate == 0 {
+		return cfg, config.Errorf("Invalid %s value '%s', expected bytes per second", Rate, rate)
+	}
+
+	interval := env.Get(EnvBitrotScanInterval, kvs.Get(Interval))
+	if interval == "" {
+		interval = DefaultInterval
+	}
+	if cfg.Interval, err = time.ParseDuration(interval); err != nil || cfg.Interval <= 0 {
+		return cfg, config.Errorf("Invalid %s value '%s', expected a positive duration", Interval, interval)
+	}
+
+	return cfg, nil
+}
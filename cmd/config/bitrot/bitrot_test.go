@@ -0,0 +1,81 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bitrot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/minio/cmd/config"
+)
+
+func TestLookupConfig(t *testing.T) {
+	testCases := []struct {
+		kvs         config.KVS
+		expectedCfg Config
+		expectedErr bool
+	}{
+		{kvs: config.KVS{}},
+		{kvs: DefaultKVS},
+		{
+			kvs: config.KVS{
+				config.KV{Key: config.Enable, Value: config.EnableOn},
+			},
+			expectedCfg: Config{Enabled: true, Rate: 8 << 20, Interval: 720 * time.Hour},
+		},
+		{
+			kvs: config.KVS{
+				config.KV{Key: config.Enable, Value: config.EnableOn},
+				config.KV{Key: Rate, Value: "1MB"},
+				config.KV{Key: Interval, Value: "24h"},
+			},
+			expectedCfg: Config{Enabled: true, Rate: 1000 * 1000, Interval: 24 * time.Hour},
+		},
+		{
+			kvs: config.KVS{
+				config.KV{Key: config.Enable, Value: config.EnableOn},
+				config.KV{Key: Rate, Value: "0"},
+			},
+			expectedErr: true,
+		},
+		{
+			kvs: config.KVS{
+				config.KV{Key: config.Enable, Value: config.EnableOn},
+				config.KV{Key: Interval, Value: "-1h"},
+			},
+			expectedErr: true,
+		},
+		{
+			kvs:         config.KVS{config.KV{Key: config.Enable, Value: "maybe"}},
+			expectedErr: true,
+		},
+		{
+			kvs:         config.KVS{config.KV{Key: "speed", Value: "1MiB"}},
+			expectedErr: true,
+		},
+	}
+
+	for i, testCase := range testCases {
+		cfg, err := LookupConfig(testCase.kvs)
+		if (err != nil) != testCase.expectedErr {
+			t.Fatalf("Test %d: expected error %t, got %v", i+1, testCase.expectedErr, err)
+		}
+		if err == nil && cfg != testCase.expectedCfg {
+			t.Errorf("Test %d: expected %+v, got %+v", i+1, testCase.expectedCfg, cfg)
+		}
+	}
+}
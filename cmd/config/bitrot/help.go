@@ -0,0 +1,43 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bitrot
+
+import "github.com/minio/minio/cmd/config"
+
+// Help template for bitrot scan feature.
+var (
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         Rate,
+			Description: `bytes per second verified on each disk e.g. "8MiB"`,
+			Optional:    true,
+			Type:        "size",
+		},
+		config.HelpKV{
+			Key:         Interval,
+			Description: `interval between scans of all objects in s,m,h,d. Default is "720h"`,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+)
@@ -33,6 +33,12 @@ var (
 			Optional:    true,
 			Type:        "csv",
 		},
+		config.HelpKV{
+			Key:         AllowEncryption,
+			Description: `set to 'on' to compress objects which are encrypted, defaults to 'off'`,
+			Optional:    true,
+			Type:        "on|off",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
@@ -75,6 +75,8 @@ const (
 	KmsKesSubSys         = "kms_kes"
 	LoggerWebhookSubSys  = "logger_webhook"
 	AuditWebhookSubSys   = "audit_webhook"
+	ThrottleSubSys       = "throttle"
+	BitrotScanSubSys     = "bitrot_scan"
 
 	// Add new constants here if you add new fields to config.
 )
@@ -103,6 +105,8 @@ var SubSystems = set.CreateStringSet([]string{
 	CacheSubSys,
 	StorageClassSubSys,
 	CompressionSubSys,
+	ThrottleSubSys,
+	BitrotScanSubSys,
 	KmsVaultSubSys,
 	KmsKesSubSys,
 	LoggerWebhookSubSys,
@@ -130,6 +134,8 @@ var SubSystemsSingleTargets = set.CreateStringSet([]string{
 	CacheSubSys,
 	StorageClassSubSys,
 	CompressionSubSys,
+	ThrottleSubSys,
+	BitrotScanSubSys,
 	KmsVaultSubSys,
 	KmsKesSubSys,
 	PolicyOPASubSys,
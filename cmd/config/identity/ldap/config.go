@@ -30,7 +30,8 @@ import (
 )
 
 const (
-	defaultLDAPExpiry = time.Hour * 1
+	defaultLDAPExpiry       = time.Hour * 1
+	defaultLDAPSyncInterval = time.Hour * 1
 )
 
 // Config contains AD/LDAP server connectivity information.
@@ -46,11 +47,28 @@ type Config struct {
 	// Format string for usernames
 	UsernameFormat string `json:"usernameFormat"`
 
+	// Lookup bind user, used to look up user DNs and to search
+	// groups on behalf of users.
+	LookupBindDN       string `json:"lookupBindDN"`
+	LookupBindPassword string `json:"lookupBindPassword"`
+
+	// User DN search, used instead of the username format when set.
+	UserDNSearchBaseDN string `json:"userDNSearchBaseDN"`
+	UserDNSearchFilter string `json:"userDNSearchFilter"`
+
 	GroupSearchBaseDN  string `json:"groupSearchBaseDN"`
 	GroupSearchFilter  string `json:"groupSearchFilter"`
 	GroupNameAttribute string `json:"groupNameAttribute"`
 
+	// Resolve the groups which the groups of users are members of.
+	GroupSearchNested bool `json:"groupSearchNested"`
+
+	// Interval of the synchronization of group memberships of users
+	// holding temporary credentials.
+	SyncInterval string `json:"syncInterval"`
+
 	stsExpiryDuration time.Duration // contains converted value
+	syncInterval      time.Duration // contains converted value
 	tlsSkipVerify     bool          // allows skipping TLS verification
 	rootCAs           *x509.CertPool
 }
@@ -64,6 +82,12 @@ const (
 	GroupNameAttribute = "group_name_attribute"
 	GroupSearchBaseDN  = "group_search_base_dn"
 	TLSSkipVerify      = "tls_skip_verify"
+	LookupBindDN       = "lookup_bind_dn"
+	LookupBindPassword = "lookup_bind_password"
+	UserDNSearchBaseDN = "user_dn_search_base_dn"
+	UserDNSearchFilter = "user_dn_search_filter"
+	GroupSearchNested  = "group_search_nested"
+	SyncInterval       = "sync_interval"
 
 	EnvServerAddr         = "MINIO_IDENTITY_LDAP_SERVER_ADDR"
 	EnvSTSExpiry          = "MINIO_IDENTITY_LDAP_STS_EXPIRY"
@@ -72,6 +96,12 @@ const (
 	EnvGroupSearchFilter  = "MINIO_IDENTITY_LDAP_GROUP_SEARCH_FILTER"
 	EnvGroupNameAttribute = "MINIO_IDENTITY_LDAP_GROUP_NAME_ATTRIBUTE"
 	EnvGroupSearchBaseDN  = "MINIO_IDENTITY_LDAP_GROUP_SEARCH_BASE_DN"
+	EnvLookupBindDN       = "MINIO_IDENTITY_LDAP_LOOKUP_BIND_DN"
+	EnvLookupBindPassword = "MINIO_IDENTITY_LDAP_LOOKUP_BIND_PASSWORD"
+	EnvUserDNSearchBaseDN = "MINIO_IDENTITY_LDAP_USER_DN_SEARCH_BASE_DN"
+	EnvUserDNSearchFilter = "MINIO_IDENTITY_LDAP_USER_DN_SEARCH_FILTER"
+	EnvGroupSearchNested  = "MINIO_IDENTITY_LDAP_GROUP_SEARCH_NESTED"
+	EnvSyncInterval       = "MINIO_IDENTITY_LDAP_SYNC_INTERVAL"
 )
 
 // DefaultKVS - default config for LDAP config
@@ -105,6 +135,30 @@ var (
 			Key:   TLSSkipVerify,
 			Value: config.EnableOff,
 		},
+		config.KV{
+			Key:   LookupBindDN,
+			Value: "",
+		},
+		config.KV{
+			Key:   LookupBindPassword,
+			Value: "",
+		},
+		config.KV{
+			Key:   UserDNSearchBaseDN,
+			Value: "",
+		},
+		config.KV{
+			Key:   UserDNSearchFilter,
+			Value: "",
+		},
+		config.KV{
+			Key:   GroupSearchNested,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   SyncInterval,
+			Value: "1h",
+		},
 	}
 )
 
@@ -125,6 +179,18 @@ func (l Config) GetExpiryDuration() time.Duration {
 	return l.stsExpiryDuration
 }
 
+// GetSyncInterval - return parsed sync interval.
+func (l Config) GetSyncInterval() time.Duration {
+	return l.syncInterval
+}
+
+// SyncEnabled - returns whether group memberships of users holding
+// temporary credentials can be synchronized, which requires a lookup
+// bind user to search the directory on behalf of users.
+func (l Config) SyncEnabled() bool {
+	return l.Enabled && l.LookupBindDN != ""
+}
+
 // Enabled returns if jwks is enabled.
 func Enabled(kvs config.KVS) bool {
 	return kvs.Get(ServerAddr) != ""
@@ -160,6 +226,48 @@ func Lookup(kvs config.KVS, rootCAs *x509.CertPool) (l Config, err error) {
 			return l, err
 		}
 	}
+	l.syncInterval = defaultLDAPSyncInterval
+	if v := env.Get(EnvSyncInterval, kvs.Get(SyncInterval)); v != "" {
+		syncDur, err := time.ParseDuration(v)
+		if err != nil {
+			return l, errors.New("LDAP sync interval err:" + err.Error())
+		}
+		if syncDur <= 0 {
+			return l, errors.New("LDAP sync interval has to be positive")
+		}
+		l.SyncInterval = v
+		l.syncInterval = syncDur
+	}
+	if v := env.Get(EnvGroupSearchNested, kvs.Get(GroupSearchNested)); v != "" {
+		l.GroupSearchNested, err = config.ParseBool(v)
+		if err != nil {
+			return l, err
+		}
+	}
+
+	l.LookupBindDN = env.Get(EnvLookupBindDN, kvs.Get(LookupBindDN))
+	l.LookupBindPassword = env.Get(EnvLookupBindPassword, kvs.Get(LookupBindPassword))
+
+	userDNSearchBaseDN := env.Get(EnvUserDNSearchBaseDN, kvs.Get(UserDNSearchBaseDN))
+	userDNSearchFilter := env.Get(EnvUserDNSearchFilter, kvs.Get(UserDNSearchFilter))
+	if userDNSearchBaseDN != "" || userDNSearchFilter != "" {
+		if userDNSearchBaseDN == "" || userDNSearchFilter == "" {
+			return l, errors.New("Both user DN search parameters must be set")
+		}
+		if l.LookupBindDN == "" {
+			return l, fmt.Errorf("'%s' must be set to search user DNs", LookupBindDN)
+		}
+		subs, err := NewSubstituter("username", "test")
+		if err != nil {
+			return l, err
+		}
+		if _, err := subs.Substitute(userDNSearchFilter); err != nil {
+			return l, fmt.Errorf("Only username may be substituted in the user DN search filter string: %s", err)
+		}
+		l.UserDNSearchBaseDN = userDNSearchBaseDN
+		l.UserDNSearchFilter = userDNSearchFilter
+	}
+
 	if v := env.Get(EnvUsernameFormat, kvs.Get(UsernameFormat)); v != "" {
 		subs, err := NewSubstituter("username", "test")
 		if err != nil {
@@ -169,7 +277,7 @@ func Lookup(kvs config.KVS, rootCAs *x509.CertPool) (l Config, err error) {
 			return l, err
 		}
 		l.UsernameFormat = v
-	} else {
+	} else if l.UserDNSearchFilter == "" {
 		return l, fmt.Errorf("'%s' cannot be empty and must have a value", UsernameFormat)
 	}
 
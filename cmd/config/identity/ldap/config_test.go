@@ -18,6 +18,9 @@ package ldap
 
 import (
 	"testing"
+	"time"
+
+	"github.com/minio/minio/cmd/config"
 )
 
 func TestSubstituter(t *testing.T) {
@@ -91,3 +94,55 @@ func TestSubstituter(t *testing.T) {
 		})
 	}
 }
+
+func TestLookup(t *testing.T) {
+	withDefaults := func(kvs ...config.KV) config.KVS {
+		all := append(config.KVS{}, DefaultKVS...)
+		for _, kv := range kvs {
+			for i := range all {
+				if all[i].Key == kv.Key {
+					all[i].Value = kv.Value
+				}
+			}
+		}
+		return all
+	}
+	server := config.KV{Key: ServerAddr, Value: "ldap.example.com:636"}
+	usernameFormat := config.KV{Key: UsernameFormat, Value: "uid={username},dc=example,dc=com"}
+	lookupBindDN := config.KV{Key: LookupBindDN, Value: "cn=minio,dc=example,dc=com"}
+	searchBaseDN := config.KV{Key: UserDNSearchBaseDN, Value: "dc=example,dc=com"}
+	searchFilter := config.KV{Key: UserDNSearchFilter, Value: "(uid={username})"}
+
+	testCases := []struct {
+		kvs         config.KVS
+		expectedErr bool
+	}{
+		// Username format or user DN search is required.
+		{withDefaults(server), true},
+		{withDefaults(server, usernameFormat), false},
+		{withDefaults(server, lookupBindDN, searchBaseDN, searchFilter), false},
+		// User DN search requires both parameters and a lookup bind user.
+		{withDefaults(server, lookupBindDN, searchFilter), true},
+		{withDefaults(server, searchBaseDN, searchFilter), true},
+		{withDefaults(server, lookupBindDN, searchBaseDN, config.KV{Key: UserDNSearchFilter, Value: "(uid={usernamedn})"}), true},
+		{withDefaults(server, usernameFormat, config.KV{Key: SyncInterval, Value: "-1h"}), true},
+		{withDefaults(server, usernameFormat, config.KV{Key: GroupSearchNested, Value: "maybe"}), true},
+	}
+
+	for i, testCase := range testCases {
+		_, err := Lookup(testCase.kvs, nil)
+		if (err != nil) != testCase.expectedErr {
+			t.Errorf("Test %d: expected error %t, got %v", i+1, testCase.expectedErr, err)
+		}
+	}
+
+	l, err := Lookup(withDefaults(server, usernameFormat, lookupBindDN,
+		config.KV{Key: SyncInterval, Value: "10m"},
+		config.KV{Key: GroupSearchNested, Value: config.EnableOn}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.GetSyncInterval() != 10*time.Minute || !l.GroupSearchNested || !l.SyncEnabled() {
+		t.Errorf("unexpected config %#v", l)
+	}
+}
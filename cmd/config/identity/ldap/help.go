@@ -29,6 +29,31 @@ var (
 		config.HelpKV{
 			Key:         UsernameFormat,
 			Description: `username bind DNs e.g. "uid=%s,cn=accounts,dc=myldapserver,dc=com"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         LookupBindDN,
+			Description: `DN of the user looking up user DNs and groups e.g. "cn=minio,cn=accounts,dc=myldapserver,dc=com"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         LookupBindPassword,
+			Description: `password of the lookup bind user`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         UserDNSearchBaseDN,
+			Description: `user DN search base DN e.g. "cn=accounts,dc=myldapserver,dc=com"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         UserDNSearchFilter,
+			Description: `user DN search filter, used instead of username_format e.g. "(uid={username})"`,
+			Optional:    true,
 			Type:        "string",
 		},
 		config.HelpKV{
@@ -49,6 +74,18 @@ var (
 			Optional:    true,
 			Type:        "string",
 		},
+		config.HelpKV{
+			Key:         GroupSearchNested,
+			Description: `resolve nested groups by repeating the group search for each group, not needed with AD "(member:1.2.840.113556.1.4.1941:={usernamedn})" filters`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         SyncInterval,
+			Description: `interval of the sync of group memberships with the lookup bind user in s,m,h,d. Default is "1h"`,
+			Optional:    true,
+			Type:        "duration",
+		},
 		config.HelpKV{
 			Key:         STSExpiry,
 			Description: `temporary credentials validity duration in s,m,h,d. Default is "1h"`,
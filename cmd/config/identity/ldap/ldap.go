@@ -0,0 +1,197 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ldap
+
+import (
+	"errors"
+	"fmt"
+
+	ldap "gopkg.in/ldap.v3"
+)
+
+// Nested groups are resolved up to this depth, which also guards
+// against cycles in group memberships.
+const maxNestedGroupDepth = 16
+
+// ErrUserNotFound - the user doesn't exist in the directory.
+var ErrUserNotFound = errors.New("LDAP user not found")
+
+// lookupBind - binds with the lookup bind user.
+func (l *Config) lookupBind(conn *ldap.Conn) error {
+	if err := conn.Bind(l.LookupBindDN, l.LookupBindPassword); err != nil {
+		return fmt.Errorf("LDAP lookup bind failure: %w", err)
+	}
+	return nil
+}
+
+// LookupUserDN - returns the DN of the user, searched with the user DN
+// search filter, or else formatted with the username format. The
+// connection has to be bound with the lookup bind user to search.
+func (l *Config) LookupUserDN(conn *ldap.Conn, username string) (string, error) {
+	if l.UserDNSearchFilter == "" {
+		subs, _ := NewSubstituter("username", username)
+		// We ignore error below as we already validated the username
+		// format string at startup.
+		return subs.Substitute(l.UsernameFormat)
+	}
+
+	subs, _ := NewSubstituter("username", ldap.EscapeFilter(username))
+	filter, _ := subs.Substitute(l.UserDNSearchFilter)
+	searchRequest := ldap.NewSearchRequest(
+		l.UserDNSearchBaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter,
+		[]string{}, // only the DN is needed
+		nil,
+	)
+	sr, err := conn.Search(searchRequest)
+	if err != nil {
+		return "", fmt.Errorf("LDAP user DN search failure: %w", err)
+	}
+	switch len(sr.Entries) {
+	case 0:
+		return "", ErrUserNotFound
+	case 1:
+		return sr.Entries[0].DN, nil
+	default:
+		return "", fmt.Errorf("LDAP user DN search returned multiple users for %s", username)
+	}
+}
+
+// Bind - validates the password of the user by binding with the
+// credentials of the user, returns the DN of the user.
+func (l *Config) Bind(conn *ldap.Conn, username, password string) (string, error) {
+	if l.UserDNSearchFilter != "" {
+		if err := l.lookupBind(conn); err != nil {
+			return "", err
+		}
+	}
+	userDN, err := l.LookupUserDN(conn, username)
+	if err != nil {
+		return "", err
+	}
+	if err = conn.Bind(userDN, password); err != nil {
+		return "", fmt.Errorf("LDAP authentication failure: %w", err)
+	}
+	return userDN, nil
+}
+
+// LookupUser - returns the DN of the user if the user still exists in
+// the directory, ErrUserNotFound otherwise. Requires a lookup bind user.
+func (l *Config) LookupUser(conn *ldap.Conn, username string) (string, error) {
+	if err := l.lookupBind(conn); err != nil {
+		return "", err
+	}
+	userDN, err := l.LookupUserDN(conn, username)
+	if err != nil || l.UserDNSearchFilter != "" {
+		return userDN, err
+	}
+
+	// A formatted DN has to be looked up to know if it exists.
+	searchRequest := ldap.NewSearchRequest(
+		userDN,
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=*)",
+		[]string{}, // only the DN is needed
+		nil,
+	)
+	if _, err = conn.Search(searchRequest); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return "", ErrUserNotFound
+		}
+		return "", fmt.Errorf("LDAP user lookup failure: %w", err)
+	}
+	return userDN, nil
+}
+
+// GetGroups - returns the names of the groups which the user is a member
+// of. With nested group search the groups which these groups are members
+// of are resolved as well, by searching again with the DN of each group as
+// the user DN. Searches are done as the lookup bind user when configured,
+// as the bound user otherwise.
+func (l *Config) GetGroups(conn *ldap.Conn, username, userDN string) ([]string, error) {
+	groups := []string{}
+	if l.GroupSearchFilter == "" {
+		return groups, nil
+	}
+	if l.LookupBindDN != "" {
+		if err := l.lookupBind(conn); err != nil {
+			return nil, err
+		}
+	}
+
+	seenDNs := map[string]bool{userDN: true}
+	seenGroups := map[string]bool{}
+	type member struct{ name, dn string }
+	members := []member{{username, userDN}}
+	for depth := 0; len(members) > 0 && depth < maxNestedGroupDepth; depth++ {
+		var next []member
+		for _, m := range members {
+			entries, err := l.searchGroups(conn, m.name, m.dn)
+			if err != nil {
+				return nil, err
+			}
+			for _, entry := range entries {
+				names := entry.GetAttributeValues(l.GroupNameAttribute)
+				for _, name := range names {
+					if !seenGroups[name] {
+						seenGroups[name] = true
+						groups = append(groups, name)
+					}
+				}
+				if !seenDNs[entry.DN] && len(names) > 0 {
+					seenDNs[entry.DN] = true
+					next = append(next, member{names[0], entry.DN})
+				}
+			}
+		}
+		if !l.GroupSearchNested {
+			break
+		}
+		members = next
+	}
+	return groups, nil
+}
+
+// searchGroups - returns the groups which the member with the given name
+// and DN is a member of.
+func (l *Config) searchGroups(conn *ldap.Conn, name, dn string) ([]*ldap.Entry, error) {
+	// We ignore errors below as we already validated the search
+	// strings at startup.
+	filterSubs, _ := NewSubstituter(
+		"username", ldap.EscapeFilter(name),
+		"usernamedn", ldap.EscapeFilter(dn),
+	)
+	groupSearchFilter, _ := filterSubs.Substitute(l.GroupSearchFilter)
+	baseDNSubs, _ := NewSubstituter(
+		"username", name,
+		"usernamedn", dn,
+	)
+	baseDN, _ := baseDNSubs.Substitute(l.GroupSearchBaseDN)
+	searchRequest := ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		groupSearchFilter,
+		[]string{l.GroupNameAttribute},
+		nil,
+	)
+	sr, err := conn.Search(searchRequest)
+	if err != nil {
+		return nil, fmt.Errorf("LDAP search failure: %w", err)
+	}
+	return sr.Entries, nil
+}
@@ -0,0 +1,175 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openid
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrNoIDToken - the token endpoint of the provider returned no ID token.
+var ErrNoIDToken = errors.New("no id_token in token response")
+
+// CodeFlowEnabled - returns whether the authorization code flow can be
+// used to log in, which requires a client id along with the authorization
+// and token endpoints of the discovery document.
+func (r Config) CodeFlowEnabled() bool {
+	return r.ClientID != "" && r.DiscoveryDoc.AuthEndpoint != "" && r.DiscoveryDoc.TokenEndpoint != ""
+}
+
+// NewRandomValue - returns a new random URL safe value, used for the
+// state, nonce and PKCE code verifier of an authorization request.
+func NewRandomValue() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CodeChallenge - returns the S256 PKCE code challenge of a code verifier
+// as specified by https://tools.ietf.org/html/rfc7636#section-4.2
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// scopes - returns the scopes of authorization requests, "openid" is
+// always requested.
+func (r Config) scopes() []string {
+	scopes := []string{"openid"}
+	for _, scope := range r.Scopes {
+		if scope = strings.TrimSpace(scope); scope != "" && scope != "openid" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// AuthCodeURL - returns the URL of the authorization endpoint which the
+// browser is redirected to, to log in with the provider.
+func (r Config) AuthCodeURL(redirectURI, state, nonce, codeVerifier string) string {
+	v := url.Values{}
+	v.Set("response_type", "code")
+	v.Set("client_id", r.ClientID)
+	v.Set("redirect_uri", redirectURI)
+	v.Set("scope", strings.Join(r.scopes(), " "))
+	v.Set("state", state)
+	v.Set("nonce", nonce)
+	v.Set("code_challenge", CodeChallenge(codeVerifier))
+	v.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(r.DiscoveryDoc.AuthEndpoint, "?") {
+		sep = "&"
+	}
+	return r.DiscoveryDoc.AuthEndpoint + sep + v.Encode()
+}
+
+// tokenResponse - successful and error responses of the token endpoint.
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// ExchangeCode - exchanges the authorization code returned to the
+// redirect URI for the ID token of the user.
+func (r Config) ExchangeCode(code, codeVerifier, redirectURI string) (string, error) {
+	v := url.Values{}
+	v.Set("grant_type", "authorization_code")
+	v.Set("code", code)
+	v.Set("redirect_uri", redirectURI)
+	v.Set("client_id", r.ClientID)
+	v.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequest(http.MethodPost, r.DiscoveryDoc.TokenEndpoint, strings.NewReader(v.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if r.ClientSecret != "" {
+		// https://tools.ietf.org/html/rfc6749#section-2.3.1
+		req.SetBasicAuth(url.QueryEscape(r.ClientID), url.QueryEscape(r.ClientSecret))
+	}
+
+	transport := http.DefaultTransport
+	if r.transport != nil {
+		transport = r.transport
+	}
+	clnt := &http.Client{
+		Transport: transport,
+	}
+	resp, err := clnt.Do(req)
+	if err != nil {
+		clnt.CloseIdleConnections()
+		return "", err
+	}
+	defer r.closeRespFn(resp.Body)
+
+	var t tokenResponse
+	if err = json.NewDecoder(resp.Body).Decode(&t); err != nil && resp.StatusCode == http.StatusOK {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		if t.Error != "" {
+			return "", fmt.Errorf("%s: %s", t.Error, t.ErrorDescription)
+		}
+		return "", errors.New(resp.Status)
+	}
+	if t.IDToken == "" {
+		return "", ErrNoIDToken
+	}
+	return t.IDToken, nil
+}
+
+// ValidateIDTokenClaims - validates the claims of an ID token returned by
+// the token endpoint, which must be issued for the client and carry the
+// nonce of the authorization request.
+func (r Config) ValidateIDTokenClaims(claims map[string]interface{}, nonce string) error {
+	if v, _ := claims["nonce"].(string); v == "" || v != nonce {
+		return errors.New("id_token nonce doesn't match the authorization request")
+	}
+
+	var audiences []string
+	switch aud := claims["aud"].(type) {
+	case string:
+		audiences = []string{aud}
+	case []interface{}:
+		for _, a := range aud {
+			if s, ok := a.(string); ok {
+				audiences = append(audiences, s)
+			}
+		}
+	}
+	for _, aud := range audiences {
+		if aud == r.ClientID {
+			return nil
+		}
+	}
+	return errors.New("id_token is not issued for the client")
+}
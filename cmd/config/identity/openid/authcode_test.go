@@ -0,0 +1,152 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openid
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/minio/minio/cmd/config"
+)
+
+// mockIDP - a minimal OpenID provider issuing a fixed ID token for a
+// single authorization code.
+type mockIDP struct {
+	*httptest.Server
+	code          string
+	codeChallenge string
+	idToken       string
+}
+
+func newMockIDP(t *testing.T) *mockIDP {
+	idp := &mockIDP{code: "auth-code", idToken: "id-token"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(DiscoveryDoc{
+			Issuer:        idp.URL,
+			AuthEndpoint:  idp.URL + "/authorize",
+			TokenEndpoint: idp.URL + "/token",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+			return
+		}
+		clientID, clientSecret, _ := r.BasicAuth()
+		if r.Form.Get("grant_type") != "authorization_code" || r.Form.Get("code") != idp.code ||
+			CodeChallenge(r.Form.Get("code_verifier")) != idp.codeChallenge ||
+			clientID != "minio" || clientSecret != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(tokenResponse{Error: "invalid_grant", ErrorDescription: "invalid code"})
+			return
+		}
+		json.NewEncoder(w).Encode(tokenResponse{AccessToken: "access-token", TokenType: "Bearer", IDToken: idp.idToken})
+	})
+	idp.Server = httptest.NewServer(mux)
+	return idp
+}
+
+func TestAuthCodeFlow(t *testing.T) {
+	idp := newMockIDP(t)
+	defer idp.Close()
+
+	kvs := config.KVS{
+		config.KV{Key: ConfigURL, Value: idp.URL + "/.well-known/openid-configuration"},
+		config.KV{Key: ClientID, Value: "minio"},
+		config.KV{Key: ClientSecret, Value: "secret"},
+		config.KV{Key: Scopes, Value: "email,groups"},
+	}
+	c, err := LookupConfig(kvs, &http.Transport{}, func(rc io.ReadCloser) { rc.Close() })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.CodeFlowEnabled() {
+		t.Fatal("expected the authorization code flow to be enabled")
+	}
+
+	verifier, err := NewRandomValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	redirectURI := "http://localhost:9000/minio/openid/callback"
+
+	u, err := url.Parse(c.AuthCodeURL(redirectURI, "state", "nonce", verifier))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Path != "/authorize" {
+		t.Errorf("expected the authorization endpoint, got %s", u.Path)
+	}
+	query := u.Query()
+	expected := map[string]string{
+		"response_type":         "code",
+		"client_id":             "minio",
+		"redirect_uri":          redirectURI,
+		"scope":                 "openid email groups",
+		"state":                 "state",
+		"nonce":                 "nonce",
+		"code_challenge":        CodeChallenge(verifier),
+		"code_challenge_method": "S256",
+	}
+	for k, v := range expected {
+		if query.Get(k) != v {
+			t.Errorf("%s: expected %s, got %s", k, v, query.Get(k))
+		}
+	}
+
+	idp.codeChallenge = query.Get("code_challenge")
+	idToken, err := c.ExchangeCode(idp.code, verifier, redirectURI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if idToken != idp.idToken {
+		t.Errorf("expected %s, got %s", idp.idToken, idToken)
+	}
+
+	// The code can't be exchanged without its code verifier.
+	otherVerifier, _ := NewRandomValue()
+	if _, err = c.ExchangeCode(idp.code, otherVerifier, redirectURI); err == nil {
+		t.Error("expected the exchange with a wrong code verifier to fail")
+	}
+}
+
+func TestValidateIDTokenClaims(t *testing.T) {
+	c := Config{ClientID: "minio"}
+	testCases := []struct {
+		claims      map[string]interface{}
+		expectedErr bool
+	}{
+		{map[string]interface{}{"nonce": "nonce", "aud": "minio"}, false},
+		{map[string]interface{}{"nonce": "nonce", "aud": []interface{}{"other", "minio"}}, false},
+		{map[string]interface{}{"nonce": "other", "aud": "minio"}, true},
+		{map[string]interface{}{"aud": "minio"}, true},
+		{map[string]interface{}{"nonce": "nonce", "aud": "other"}, true},
+		{map[string]interface{}{"nonce": "nonce"}, true},
+	}
+
+	for i, testCase := range testCases {
+		err := c.ValidateIDTokenClaims(testCase.claims, "nonce")
+		if (err != nil) != testCase.expectedErr {
+			t.Errorf("Test %d: expected error %t, got %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
@@ -32,6 +32,24 @@ var (
 			Type:        "string",
 			Optional:    true,
 		},
+		config.HelpKV{
+			Key:         ClientSecret,
+			Description: `client secret of confidential apps, used by the browser login`,
+			Type:        "string",
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         RedirectURI,
+			Description: `browser login callback URL registered with the provider e.g. "https://minio.example.com/minio/openid/callback"`,
+			Type:        "url",
+			Optional:    true,
+		},
+		config.HelpKV{
+			Key:         Scopes,
+			Description: `comma separated scopes requested by the browser login, defaults to "openid"`,
+			Type:        "csv",
+			Optional:    true,
+		},
 		config.HelpKV{
 			Key:         ClaimName,
 			Description: `JWT canned policy claim name, defaults to "policy"`,
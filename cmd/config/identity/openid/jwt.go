@@ -24,6 +24,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	jwtgo "github.com/dgrijalva/jwt-go"
@@ -45,6 +46,9 @@ type Config struct {
 	ClaimName    string    `json:"claimName,omitempty"`
 	DiscoveryDoc DiscoveryDoc
 	ClientID     string
+	ClientSecret string
+	RedirectURI  string
+	Scopes       []string
 	publicKeys   map[string]crypto.PublicKey
 	transport    *http.Transport
 	closeRespFn  func(io.ReadCloser)
@@ -209,17 +213,23 @@ func (p *JWT) ID() ID {
 
 // OpenID keys and envs.
 const (
-	JwksURL     = "jwks_url"
-	ConfigURL   = "config_url"
-	ClaimName   = "claim_name"
-	ClaimPrefix = "claim_prefix"
-	ClientID    = "client_id"
-
-	EnvIdentityOpenIDClientID    = "MINIO_IDENTITY_OPENID_CLIENT_ID"
-	EnvIdentityOpenIDJWKSURL     = "MINIO_IDENTITY_OPENID_JWKS_URL"
-	EnvIdentityOpenIDURL         = "MINIO_IDENTITY_OPENID_CONFIG_URL"
-	EnvIdentityOpenIDClaimName   = "MINIO_IDENTITY_OPENID_CLAIM_NAME"
-	EnvIdentityOpenIDClaimPrefix = "MINIO_IDENTITY_OPENID_CLAIM_PREFIX"
+	JwksURL      = "jwks_url"
+	ConfigURL    = "config_url"
+	ClaimName    = "claim_name"
+	ClaimPrefix  = "claim_prefix"
+	ClientID     = "client_id"
+	ClientSecret = "client_secret"
+	RedirectURI  = "redirect_uri"
+	Scopes       = "scopes"
+
+	EnvIdentityOpenIDClientID     = "MINIO_IDENTITY_OPENID_CLIENT_ID"
+	EnvIdentityOpenIDClientSecret = "MINIO_IDENTITY_OPENID_CLIENT_SECRET"
+	EnvIdentityOpenIDRedirectURI  = "MINIO_IDENTITY_OPENID_REDIRECT_URI"
+	EnvIdentityOpenIDScopes       = "MINIO_IDENTITY_OPENID_SCOPES"
+	EnvIdentityOpenIDJWKSURL      = "MINIO_IDENTITY_OPENID_JWKS_URL"
+	EnvIdentityOpenIDURL          = "MINIO_IDENTITY_OPENID_CONFIG_URL"
+	EnvIdentityOpenIDClaimName    = "MINIO_IDENTITY_OPENID_CLAIM_NAME"
+	EnvIdentityOpenIDClaimPrefix  = "MINIO_IDENTITY_OPENID_CLAIM_PREFIX"
 )
 
 // DiscoveryDoc - parses the output from openid-configuration
@@ -276,6 +286,18 @@ var (
 			Key:   ClientID,
 			Value: "",
 		},
+		config.KV{
+			Key:   ClientSecret,
+			Value: "",
+		},
+		config.KV{
+			Key:   RedirectURI,
+			Value: "",
+		},
+		config.KV{
+			Key:   Scopes,
+			Value: "",
+		},
 		config.KV{
 			Key:   ClaimName,
 			Value: iampolicy.PolicyName,
@@ -308,12 +330,24 @@ func LookupConfig(kvs config.KVS, transport *http.Transport, closeRespFn func(io
 	}
 
 	c = Config{
-		ClaimName:   env.Get(EnvIdentityOpenIDClaimName, kvs.Get(ClaimName)),
-		ClaimPrefix: env.Get(EnvIdentityOpenIDClaimPrefix, kvs.Get(ClaimPrefix)),
-		publicKeys:  make(map[string]crypto.PublicKey),
-		ClientID:    env.Get(EnvIdentityOpenIDClientID, kvs.Get(ClientID)),
-		transport:   transport,
-		closeRespFn: closeRespFn,
+		ClaimName:    env.Get(EnvIdentityOpenIDClaimName, kvs.Get(ClaimName)),
+		ClaimPrefix:  env.Get(EnvIdentityOpenIDClaimPrefix, kvs.Get(ClaimPrefix)),
+		publicKeys:   make(map[string]crypto.PublicKey),
+		ClientID:     env.Get(EnvIdentityOpenIDClientID, kvs.Get(ClientID)),
+		ClientSecret: env.Get(EnvIdentityOpenIDClientSecret, kvs.Get(ClientSecret)),
+		RedirectURI:  env.Get(EnvIdentityOpenIDRedirectURI, kvs.Get(RedirectURI)),
+		transport:    transport,
+		closeRespFn:  closeRespFn,
+	}
+
+	if scopes := env.Get(EnvIdentityOpenIDScopes, kvs.Get(Scopes)); scopes != "" {
+		c.Scopes = strings.Split(scopes, ",")
+	}
+
+	if c.RedirectURI != "" {
+		if _, err = xnet.ParseHTTPURL(c.RedirectURI); err != nil {
+			return c, err
+		}
 	}
 
 	configURL := env.Get(EnvIdentityOpenIDURL, kvs.Get(ConfigURL))
@@ -22,6 +22,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/dustin/go-humanize"
 	"github.com/minio/minio/cmd/config"
 	"github.com/minio/minio/pkg/env"
 )
@@ -36,13 +37,16 @@ const (
 
 // Standard constats for config info storage class
 const (
-	ClassStandard = "standard"
-	ClassRRS      = "rrs"
+	ClassStandard        = "standard"
+	ClassRRS             = "rrs"
+	ClassInlineThreshold = "inline_threshold"
 
 	// Reduced redundancy storage class environment variable
 	RRSEnv = "MINIO_STORAGE_CLASS_RRS"
 	// Standard storage class environment variable
 	StandardEnv = "MINIO_STORAGE_CLASS_STANDARD"
+	// Inline threshold environment variable
+	InlineThresholdEnv = "MINIO_STORAGE_CLASS_INLINE_THRESHOLD"
 
 	// Supported storage class scheme is EC
 	schemePrefix = "EC"
@@ -52,6 +56,10 @@ const (
 
 	// Default RRS parity is always minimum parity.
 	defaultRRSParity = minParityDisks
+
+	// Max size of objects stored inline with their metadata, larger
+	// values would bloat the metadata read by every object operation.
+	maxInlineThreshold = 1 << 20
 )
 
 // DefaultKVS - default storage class config
@@ -65,6 +73,10 @@ var (
 			Key:   ClassRRS,
 			Value: "EC:2",
 		},
+		config.KV{
+			Key:   ClassInlineThreshold,
+			Value: "",
+		},
 	}
 )
 
@@ -77,6 +89,9 @@ type StorageClass struct {
 type Config struct {
 	Standard StorageClass `json:"standard"`
 	RRS      StorageClass `json:"rrs"`
+	// Objects smaller than this size are stored inline with
+	// their metadata, disabled when zero.
+	InlineThreshold int64 `json:"inlineThreshold,omitempty"`
 }
 
 // UnmarshalJSON - Validate SS and RRS parity when unmarshalling JSON.
@@ -96,6 +111,12 @@ func IsValid(sc string) bool {
 	return sc == RRS || sc == STANDARD
 }
 
+// Inline - returns true if objects of the given size are
+// stored inline with their metadata.
+func (sCfg Config) Inline(size int64) bool {
+	return size >= 0 && size < sCfg.InlineThreshold
+}
+
 // UnmarshalText unmarshals storage class from its textual form into
 // storageClass structure.
 func (sc *StorageClass) UnmarshalText(b []byte) error {
@@ -216,7 +237,8 @@ func (sCfg Config) GetParityForSC(sc string) (parity int) {
 func Enabled(kvs config.KVS) bool {
 	ssc := kvs.Get(ClassStandard)
 	rrsc := kvs.Get(ClassRRS)
-	return ssc != "" || rrsc != ""
+	inline := kvs.Get(ClassInlineThreshold)
+	return ssc != "" || rrsc != "" || inline != ""
 }
 
 // LookupConfig - lookup storage class config and override with valid environment settings if any.
@@ -258,5 +280,17 @@ func LookupConfig(kvs config.KVS, drivesPerSet int) (cfg Config, err error) {
 		return cfg, err
 	}
 
+	if inline := env.Get(InlineThresholdEnv, kvs.Get(ClassInlineThreshold)); inline != "" {
+		threshold, err := humanize.ParseBytes(inline)
+		if err != nil {
+			return cfg, config.ErrStorageClassValue(err).Msg("Invalid inline threshold " + inline)
+		}
+		if threshold > maxInlineThreshold {
+			return cfg, config.ErrStorageClassValue(nil).Msg(fmt.Sprintf("Inline threshold %s exceeds the maximum of %s",
+				inline, humanize.IBytes(maxInlineThreshold)))
+		}
+		cfg.InlineThreshold = int64(threshold)
+	}
+
 	return cfg, nil
 }
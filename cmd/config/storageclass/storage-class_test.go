@@ -20,6 +20,8 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+
+	"github.com/minio/minio/cmd/config"
 )
 
 func TestParseStorageClass(t *testing.T) {
@@ -161,3 +163,37 @@ func TestIsValidStorageClassKind(t *testing.T) {
 		}
 	}
 }
+
+func TestLookupInlineThreshold(t *testing.T) {
+	tests := []struct {
+		threshold     string
+		want          int64
+		expectedError bool
+	}{
+		{"", 0, false},
+		{"128KiB", 128 << 10, false},
+		{"1MiB", 1 << 20, false},
+		{"2MiB", 0, true},
+		{"abc", 0, true},
+	}
+	for i, tt := range tests {
+		kvs := config.KVS{
+			config.KV{Key: ClassStandard, Value: ""},
+			config.KV{Key: ClassRRS, Value: "EC:2"},
+			config.KV{Key: ClassInlineThreshold, Value: tt.threshold},
+		}
+		cfg, err := LookupConfig(kvs, 16)
+		if (err != nil) != tt.expectedError {
+			t.Errorf("Test %d, Expected error %t, got %v", i+1, tt.expectedError, err)
+			continue
+		}
+		if err == nil && cfg.InlineThreshold != tt.want {
+			t.Errorf("Test %d, Expected %d, got %d", i+1, tt.want, cfg.InlineThreshold)
+		}
+	}
+
+	cfg := Config{InlineThreshold: 1024}
+	if !cfg.Inline(0) || !cfg.Inline(1023) || cfg.Inline(1024) || cfg.Inline(-1) {
+		t.Error("Unexpected inline decision for threshold 1024")
+	}
+}
@@ -0,0 +1,91 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package throttle
+
+import "github.com/minio/minio/cmd/config"
+
+// Help template for throttle feature.
+var (
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         UserReadRequests,
+			Description: `max GET/HEAD object and bucket config requests per second of each access key`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         UserWriteRequests,
+			Description: `max PUT/POST/DELETE requests per second of each access key`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         UserListRequests,
+			Description: `max list requests per second of each access key`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         BucketReadRequests,
+			Description: `max GET/HEAD object and bucket config requests per second on each bucket`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         BucketWriteRequests,
+			Description: `max PUT/POST/DELETE requests per second on each bucket`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         BucketListRequests,
+			Description: `max list requests per second on each bucket`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         UserGetBandwidth,
+			Description: `max bandwidth per second of GET object requests of each access key e.g. "10MiB"`,
+			Optional:    true,
+			Type:        "size",
+		},
+		config.HelpKV{
+			Key:         UserPutBandwidth,
+			Description: `max bandwidth per second of PUT object requests of each access key e.g. "10MiB"`,
+			Optional:    true,
+			Type:        "size",
+		},
+		config.HelpKV{
+			Key:         BucketGetBandwidth,
+			Description: `max bandwidth per second of GET object requests on each bucket e.g. "100MiB"`,
+			Optional:    true,
+			Type:        "size",
+		},
+		config.HelpKV{
+			Key:         BucketPutBandwidth,
+			Description: `max bandwidth per second of PUT object requests on each bucket e.g. "100MiB"`,
+			Optional:    true,
+			Type:        "size",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+)
@@ -0,0 +1,161 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package throttle
+
+import (
+	"strconv"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/env"
+)
+
+// Throttle config constants.
+const (
+	UserReadRequests    = "user_read_requests"
+	UserWriteRequests   = "user_write_requests"
+	UserListRequests    = "user_list_requests"
+	BucketReadRequests  = "bucket_read_requests"
+	BucketWriteRequests = "bucket_write_requests"
+	BucketListRequests  = "bucket_list_requests"
+	UserGetBandwidth    = "user_get_bandwidth"
+	UserPutBandwidth    = "user_put_bandwidth"
+	BucketGetBandwidth  = "bucket_get_bandwidth"
+	BucketPutBandwidth  = "bucket_put_bandwidth"
+
+	EnvUserReadRequests    = "MINIO_THROTTLE_USER_READ_REQUESTS"
+	EnvUserWriteRequests   = "MINIO_THROTTLE_USER_WRITE_REQUESTS"
+	EnvUserListRequests    = "MINIO_THROTTLE_USER_LIST_REQUESTS"
+	EnvBucketReadRequests  = "MINIO_THROTTLE_BUCKET_READ_REQUESTS"
+	EnvBucketWriteRequests = "MINIO_THROTTLE_BUCKET_WRITE_REQUESTS"
+	EnvBucketListRequests  = "MINIO_THROTTLE_BUCKET_LIST_REQUESTS"
+	EnvUserGetBandwidth    = "MINIO_THROTTLE_USER_GET_BANDWIDTH"
+	EnvUserPutBandwidth    = "MINIO_THROTTLE_USER_PUT_BANDWIDTH"
+	EnvBucketGetBandwidth  = "MINIO_THROTTLE_BUCKET_GET_BANDWIDTH"
+	EnvBucketPutBandwidth  = "MINIO_THROTTLE_BUCKET_PUT_BANDWIDTH"
+)
+
+// DefaultKVS - default KV config for throttling, all limits are off.
+var (
+	DefaultKVS = config.KVS{
+		config.KV{Key: UserReadRequests, Value: ""},
+		config.KV{Key: UserWriteRequests, Value: ""},
+		config.KV{Key: UserListRequests, Value: ""},
+		config.KV{Key: BucketReadRequests, Value: ""},
+		config.KV{Key: BucketWriteRequests, Value: ""},
+		config.KV{Key: BucketListRequests, Value: ""},
+		config.KV{Key: UserGetBandwidth, Value: ""},
+		config.KV{Key: UserPutBandwidth, Value: ""},
+		config.KV{Key: BucketGetBandwidth, Value: ""},
+		config.KV{Key: BucketPutBandwidth, Value: ""},
+	}
+)
+
+// Requests - limits of requests per second by API class, zero is
+// unlimited.
+type Requests struct {
+	Read  uint64 `json:"read"`
+	Write uint64 `json:"write"`
+	List  uint64 `json:"list"`
+}
+
+// Bandwidth - limits of bytes per second transferred by GET and PUT
+// requests of objects, zero is unlimited.
+type Bandwidth struct {
+	Get uint64 `json:"get"`
+	Put uint64 `json:"put"`
+}
+
+// Config - throttling limits per access key and per bucket.
+type Config struct {
+	UserRequests    Requests  `json:"userRequests"`
+	BucketRequests  Requests  `json:"bucketRequests"`
+	UserBandwidth   Bandwidth `json:"userBandwidth"`
+	BucketBandwidth Bandwidth `json:"bucketBandwidth"`
+}
+
+// Enabled - returns whether any limit is set.
+func (c Config) Enabled() bool {
+	return c.UserRequests != Requests{} || c.BucketRequests != Requests{} ||
+		c.UserBandwidth != Bandwidth{} || c.BucketBandwidth != Bandwidth{}
+}
+
+// parseRequests - parses a request rate, empty is unlimited.
+func parseRequests(key, value string) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	rate, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, config.Errorf("Invalid %s value '%s', expected requests per second", key, value)
+	}
+	return rate, nil
+}
+
+// parseBandwidth - parses a bandwidth such as '10MiB' in bytes per
+// second, empty is unlimited.
+func parseBandwidth(key, value string) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	rate, err := humanize.ParseBytes(value)
+	if err != nil {
+		return 0, config.Errorf("Invalid %s value '%s', expected bytes per second", key, value)
+	}
+	return rate, nil
+}
+
+// LookupConfig - lookup throttle config.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.ThrottleSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+
+	requests := []struct {
+		key, env string
+		rate     *uint64
+	}{
+		{UserReadRequests, EnvUserReadRequests, &cfg.UserRequests.Read},
+		{UserWriteRequests, EnvUserWriteRequests, &cfg.UserRequests.Write},
+		{UserListRequests, EnvUserListRequests, &cfg.UserRequests.List},
+		{BucketReadRequests, EnvBucketReadRequests, &cfg.BucketRequests.Read},
+		{BucketWriteRequests, EnvBucketWriteRequests, &cfg.BucketRequests.Write},
+		{BucketListRequests, EnvBucketListRequests, &cfg.BucketRequests.List},
+	}
+	for _, r := range requests {
+		if *r.rate, err = parseRequests(r.key, env.Get(r.env, kvs.Get(r.key))); err != nil {
+			return cfg, err
+		}
+	}
+
+	bandwidths := []struct {
+		key, env string
+		rate     *uint64
+	}{
+		{UserGetBandwidth, EnvUserGetBandwidth, &cfg.UserBandwidth.Get},
+		{UserPutBandwidth, EnvUserPutBandwidth, &cfg.UserBandwidth.Put},
+		{BucketGetBandwidth, EnvBucketGetBandwidth, &cfg.BucketBandwidth.Get},
+		{BucketPutBandwidth, EnvBucketPutBandwidth, &cfg.BucketBandwidth.Put},
+	}
+	for _, b := range bandwidths {
+		if *b.rate, err = parseBandwidth(b.key, env.Get(b.env, kvs.Get(b.key))); err != nil {
+			return cfg, err
+		}
+	}
+
+	return cfg, nil
+}
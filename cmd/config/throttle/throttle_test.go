@@ -0,0 +1,73 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package throttle
+
+import (
+	"testing"
+
+	"github.com/minio/minio/cmd/config"
+)
+
+func TestLookupConfig(t *testing.T) {
+	testCases := []struct {
+		kvs         config.KVS
+		expectedCfg Config
+		expectedErr bool
+	}{
+		{kvs: config.KVS{}},
+		{kvs: DefaultKVS},
+		{
+			kvs: config.KVS{
+				config.KV{Key: UserReadRequests, Value: "100"},
+				config.KV{Key: BucketListRequests, Value: "10"},
+				config.KV{Key: UserGetBandwidth, Value: "10MiB"},
+				config.KV{Key: BucketPutBandwidth, Value: "1GB"},
+			},
+			expectedCfg: Config{
+				UserRequests:    Requests{Read: 100},
+				BucketRequests:  Requests{List: 10},
+				UserBandwidth:   Bandwidth{Get: 10 << 20},
+				BucketBandwidth: Bandwidth{Put: 1000 * 1000 * 1000},
+			},
+		},
+		{
+			kvs:         config.KVS{config.KV{Key: UserWriteRequests, Value: "-1"}},
+			expectedErr: true,
+		},
+		{
+			kvs:         config.KVS{config.KV{Key: BucketGetBandwidth, Value: "fast"}},
+			expectedErr: true,
+		},
+		{
+			kvs:         config.KVS{config.KV{Key: "user_requests", Value: "1"}},
+			expectedErr: true,
+		},
+	}
+
+	for i, testCase := range testCases {
+		cfg, err := LookupConfig(testCase.kvs)
+		if (err != nil) != testCase.expectedErr {
+			t.Fatalf("Test %d: expected error %t, got %v", i+1, testCase.expectedErr, err)
+		}
+		if err == nil && cfg != testCase.expectedCfg {
+			t.Errorf("Test %d: expected %+v, got %+v", i+1, testCase.expectedCfg, cfg)
+		}
+		if err == nil && cfg.Enabled() != (testCase.expectedCfg != Config{}) {
+			t.Errorf("Test %d: unexpected enabled state %t", i+1, cfg.Enabled())
+		}
+	}
+}
@@ -18,6 +18,9 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"sync"
 	"time"
 
 	"github.com/minio/minio/cmd/logger"
@@ -31,18 +34,47 @@ const (
 )
 
 type lifecycleOps struct {
+	sync.RWMutex
 	LastActivity time.Time
+
+	// Number of objects, noncurrent versions and delete
+	// markers removed by lifecycle rules on this node.
+	ExpiredObjects       uint64
+	ExpiredVersions      uint64
+	ExpiredDeleteMarkers uint64
+
+	// Number of objects moved to remote tiers by lifecycle
+	// transition rules on this node.
+	TransitionedObjects uint64
 }
 
 // Register to the daily objects listing
 var globalLifecycleOps = &lifecycleOps{}
 
 func getLocalBgLifecycleOpsStatus() BgLifecycleOpsStatus {
+	globalLifecycleOps.RLock()
+	defer globalLifecycleOps.RUnlock()
+
 	return BgLifecycleOpsStatus{
-		LastActivity: globalLifecycleOps.LastActivity,
+		LastActivity:         globalLifecycleOps.LastActivity,
+		ExpiredObjects:       globalLifecycleOps.ExpiredObjects,
+		ExpiredVersions:      globalLifecycleOps.ExpiredVersions,
+		ExpiredDeleteMarkers: globalLifecycleOps.ExpiredDeleteMarkers,
+		TransitionedObjects:  globalLifecycleOps.TransitionedObjects,
 	}
 }
 
+// updateLifecycleOps - records the objects, versions and delete
+// markers removed during a lifecycle round.
+func updateLifecycleOps(objects, versions, deleteMarkers uint64) {
+	globalLifecycleOps.Lock()
+	defer globalLifecycleOps.Unlock()
+
+	globalLifecycleOps.ExpiredObjects += objects
+	globalLifecycleOps.ExpiredVersions += versions
+	globalLifecycleOps.ExpiredDeleteMarkers += deleteMarkers
+}
+
 // initDailyLifecycle starts the routine that receives the daily
 // listing of all objects and applies any matching bucket lifecycle
 // rules.
@@ -90,6 +122,11 @@ func startDailyLifecycle() {
 
 		// Perform one lifecycle operation
 		err := lifecycleRound(ctx, objAPI)
+		if err == nil {
+			globalLifecycleOps.Lock()
+			globalLifecycleOps.LastActivity = UTCNow()
+			globalLifecycleOps.Unlock()
+		}
 		switch err.(type) {
 		// Unable to hold a lock means there is another
 		// instance doing the lifecycle round round
@@ -133,6 +170,16 @@ func lifecycleRound(ctx context.Context, objAPI ObjectLayer) error {
 		}
 		commonPrefix := lcp(prefixes)
 
+		// Objects in buckets on which versioning was configured may
+		// carry noncurrent versions and delete markers to expire.
+		if globalBucketVersioningSys.Configured(bucket.Name) {
+			if err := lifecycleVersionsRound(ctx, objAPI, bucket.Name, l, commonPrefix); err != nil {
+				// Expiry of the remaining buckets goes on.
+				logger.LogIf(ctx, fmt.Errorf("Unable to expire versions of bucket %s: %w", bucket.Name, err))
+			}
+			continue
+		}
+
 		// Allocate new results channel to receive ObjectInfo.
 		objInfoCh := make(chan ObjectInfo)
 
@@ -143,6 +190,7 @@ func lifecycleRound(ctx context.Context, objAPI ObjectLayer) error {
 
 		for {
 			var objects []string
+			var sizes []int64
 			for obj := range objInfoCh {
 				if len(objects) == maxObjectList {
 					// Reached maximum delete requests, attempt a delete for now.
@@ -150,8 +198,12 @@ func lifecycleRound(ctx context.Context, objAPI ObjectLayer) error {
 				}
 
 				// Find the action that need to be executed
-				if l.ComputeAction(obj.Name, obj.UserTags, obj.ModTime) == lifecycle.DeleteAction {
+				switch l.ComputeAction(obj.Name, obj.UserTags, obj.ModTime) {
+				case lifecycle.DeleteAction:
 					objects = append(objects, obj.Name)
+					sizes = append(sizes, obj.Size)
+				case lifecycle.TransitionAction:
+					transitionObject(ctx, objAPI, bucket.Name, obj.Name, "", l)
 				}
 			}
 
@@ -172,6 +224,8 @@ func lifecycleRound(ctx context.Context, objAPI ObjectLayer) error {
 						logger.LogIf(ctx, deleteErrs[i])
 						continue
 					}
+					updateLifecycleOps(1, 0, 0)
+					globalBucketQuotaSys.AccountUsage(bucket.Name, -sizes[i])
 					// Notify object deleted event.
 					sendEvent(eventArgs{
 						EventName:  event.ObjectRemovedDelete,
@@ -188,3 +242,161 @@ func lifecycleRound(ctx context.Context, objAPI ObjectLayer) error {
 
 	return nil
 }
+
+// lifecycleVersionsRound - applies the lifecycle rules to all the
+// versions of the objects of a bucket on which versioning was configured.
+func lifecycleVersionsRound(ctx context.Context, objAPI ObjectLayer, bucket string, l lifecycle.Lifecycle, prefix string) error {
+	var marker, versionMarker string
+
+	// Versions of the object being listed, they are only processed
+	// once all of them were listed since removing the version used
+	// as the version marker would restart the listing of the object.
+	var versions []ObjectInfo
+
+	for {
+		loi, err := objAPI.ListObjectVersions(ctx, bucket, prefix, marker, versionMarker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+
+		for _, obj := range loi.Objects {
+			if len(versions) > 0 && versions[0].Name != obj.Name {
+				applyLifecycleVersions(ctx, objAPI, bucket, l, versions)
+				versions = nil
+			}
+			versions = append(versions, obj)
+		}
+
+		if !loi.IsTruncated {
+			break
+		}
+		marker, versionMarker = loi.NextMarker, loi.NextVersionIDMarker
+	}
+
+	if len(versions) > 0 {
+		applyLifecycleVersions(ctx, objAPI, bucket, l, versions)
+	}
+	return nil
+}
+
+// applyLifecycleVersions - applies the lifecycle rules to the versions
+// of an object, latest first. Noncurrent versions are evaluated first
+// so that a delete marker left without any other version is removed
+// in the same round.
+func applyLifecycleVersions(ctx context.Context, objAPI ObjectLayer, bucket string, l lifecycle.Lifecycle, versions []ObjectInfo) {
+	remaining := len(versions)
+	for i := len(versions) - 1; i >= 0; i-- {
+		version := versions[i]
+		opts := lifecycle.ObjectOpts{
+			Name:         version.Name,
+			UserTags:     version.UserTags,
+			ModTime:      version.ModTime,
+			IsLatest:     version.IsLatest,
+			DeleteMarker: version.DeleteMarker,
+			NumVersions:  remaining,
+		}
+		if i > 0 {
+			// A version becomes noncurrent when its successor is created.
+			opts.SuccessorModTime = versions[i-1].ModTime
+		}
+
+		var delOpts ObjectOptions
+		switch l.ComputeVersionAction(opts) {
+		case lifecycle.DeleteAction:
+			// Expire the current version by adding a delete marker.
+		case lifecycle.DeleteVersionAction:
+			delOpts.VersionID = version.VersionID
+		case lifecycle.TransitionAction:
+			transitionObject(ctx, objAPI, bucket, version.Name, version.VersionID, l)
+			continue
+		default:
+			continue
+		}
+
+		waitForLowHTTPReq(int32(globalEndpoints.Nodes()))
+
+		objInfo, err := objAPI.DeleteObjectVersion(ctx, bucket, version.Name, delOpts)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+
+		switch {
+		case delOpts.VersionID == "":
+			updateLifecycleOps(1, 0, 0)
+		case version.DeleteMarker:
+			remaining--
+			updateLifecycleOps(0, 0, 1)
+		default:
+			remaining--
+			updateLifecycleOps(0, 1, 0)
+			globalBucketQuotaSys.AccountUsage(bucket, -version.Size)
+		}
+
+		// Notify object deleted event.
+		sendEvent(eventArgs{
+			EventName:  event.ObjectRemovedDelete,
+			BucketName: bucket,
+			Object:     objInfo,
+			Host:       "Internal: [ILM-EXPIRY]",
+		})
+	}
+}
+
+// transitionObject - moves the data of a version of an object to the
+// remote tier named by the storage class of the matching transition
+// rule. The data is copied to the remote tier first, the version is
+// marked as transitioned only if it was not modified meanwhile.
+func transitionObject(ctx context.Context, objAPI ObjectLayer, bucket, object, versionID string, l lifecycle.Lifecycle) {
+	opts := ObjectOptions{VersionID: versionID}
+
+	// Listings do not tell whether an object was transitioned already.
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, opts)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	if objInfo.TransitionTier != "" || objInfo.DeleteMarker {
+		return
+	}
+
+	_, trn := l.FilterRuleActions(object, objInfo.UserTags)
+	backend, ok := globalTierSys.Get(trn.StorageClass)
+	if !ok {
+		logger.LogIf(ctx, errNoSuchTier)
+		return
+	}
+
+	waitForLowHTTPReq(int32(globalEndpoints.Nodes()))
+
+	// Data of the object is copied as stored, encrypted and
+	// compressed objects remain so on the remote tier.
+	remoteObject := backend.objectName(bucket, object)
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(objAPI.GetObject(ctx, bucket, object, 0, objInfo.Size, pw, objInfo.ETag, opts))
+	}()
+	err = backend.Put(ctx, remoteObject, pr, objInfo.Size)
+	pr.CloseWithError(err)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	opts.Transition = TransitionOptions{
+		Tier:         trn.StorageClass,
+		RemoteObject: remoteObject,
+		ETag:         objInfo.ETag,
+	}
+	if err = objAPI.TransitionObject(ctx, bucket, object, opts); err != nil {
+		if _, ok := err.(PreConditionFailed); !ok {
+			logger.LogIf(ctx, err)
+		}
+		logger.LogIf(ctx, backend.Remove(ctx, remoteObject))
+		return
+	}
+
+	globalLifecycleOps.Lock()
+	globalLifecycleOps.TransitionedObjects++
+	globalLifecycleOps.Unlock()
+}
@@ -852,7 +852,7 @@ func (c *diskCache) Get(ctx context.Context, bucket, object string, rs *HTTPRang
 		return gr, numHits, gerr
 	}
 
-	fn, off, length, nErr := NewGetObjectReader(rs, objInfo, opts.CheckCopyPrecondFn, nsUnlocker)
+	fn, off, length, nErr := NewGetObjectReader(rs, objInfo, h, opts.CheckCopyPrecondFn, nsUnlocker)
 	if nErr != nil {
 		return nil, numHits, nErr
 	}
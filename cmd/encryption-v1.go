@@ -315,6 +315,25 @@ func decryptObjectInfo(key []byte, bucket, object string, metadata map[string]st
 	}
 }
 
+// getObjectEncryptionKey - returns the object encryption key of the
+// object, the client key of SSE-C encrypted objects is read from the
+// SSE-C headers, or the SSE-C copy headers for copy sources.
+func getObjectEncryptionKey(h http.Header, oi ObjectInfo, copySource bool) ([]byte, error) {
+	var key []byte
+	if crypto.SSEC.IsEncrypted(oi.UserDefined) {
+		var err error
+		if copySource {
+			key, err = ParseSSECopyCustomerRequest(h, oi.UserDefined)
+		} else {
+			key, err = ParseSSECustomerHeader(h)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return decryptObjectInfo(key, oi.Bucket, oi.Name, oi.UserDefined)
+}
+
 func newDecryptWriter(client io.Writer, key []byte, bucket, object string, seqNumber uint32, metadata map[string]string) (io.WriteCloser, error) {
 	objectEncryptionKey, err := decryptObjectInfo(key, bucket, object, metadata)
 	if err != nil {
@@ -810,8 +829,12 @@ func (o *ObjectInfo) GetDecryptedRange(rs *HTTPRangeSpec) (encOff, encLength, sk
 
 // EncryptedSize returns the size of the object after encryption.
 // An encrypted object is always larger than a plain object
-// except for zero size objects.
+// except for zero size objects. An unknown size, e.g. the size
+// of compressed data, remains unknown after encryption.
 func (o *ObjectInfo) EncryptedSize() int64 {
+	if o.Size < 0 {
+		return -1
+	}
 	size, err := sio.EncryptedSize(uint64(o.Size))
 	if err != nil {
 		// This cannot happen since AWS S3 allows parts to be 5GB at most
@@ -947,10 +970,15 @@ func getOpts(ctx context.Context, r *http.Request, bucket, object string) (Objec
 		derivedKey := deriveClientKey(key, bucket, object)
 		encryption, err = encrypt.NewSSEC(derivedKey[:])
 		logger.CriticalIf(ctx, err)
-		return ObjectOptions{ServerSideEncryption: encryption}, nil
+		return ObjectOptions{ServerSideEncryption: encryption, VersionID: getRequestVersionID(r, bucket)}, nil
 	}
 	// default case of passing encryption headers to backend
-	return getDefaultOpts(r.Header, false, nil)
+	opts, err := getDefaultOpts(r.Header, false, nil)
+	if err != nil {
+		return opts, err
+	}
+	opts.VersionID = getRequestVersionID(r, bucket)
+	return opts, nil
 }
 
 // get ObjectOptions for PUT calls from encryption headers and metadata
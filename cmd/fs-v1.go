@@ -19,6 +19,7 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -38,10 +39,15 @@ import (
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 
+	"github.com/minio/minio/pkg/bucket/cors"
 	bucketsse "github.com/minio/minio/pkg/bucket/encryption"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/bucket/logging"
 	"github.com/minio/minio/pkg/bucket/object/tagging"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/bucket/versioning"
+	"github.com/minio/minio/pkg/bucket/website"
 
 	"github.com/minio/minio/pkg/lock"
 	"github.com/minio/minio/pkg/madmin"
@@ -551,7 +557,7 @@ func (fs *FSObjects) GetObjectNInfo(ctx context.Context, bucket, object string,
 		rwPoolUnlocker = func() { fs.rwPool.Close(fsMetaPath) }
 	}
 
-	objReaderFn, off, length, rErr := NewGetObjectReader(rs, objInfo, opts.CheckCopyPrecondFn, nsUnlocker, rwPoolUnlocker)
+	objReaderFn, off, length, rErr := NewGetObjectReader(rs, objInfo, h, opts.CheckCopyPrecondFn, nsUnlocker, rwPoolUnlocker)
 	if rErr != nil {
 		return nil, rErr
 	}
@@ -949,6 +955,9 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 		return ObjectInfo{}, toObjectErr(err, bucket, object)
 	}
 	fsMeta.Meta["etag"] = r.MD5CurrentHexString()
+	if index := r.CompressionIndex(); len(index) > 0 {
+		fsMeta.Meta[compressionIndexKey] = base64.StdEncoding.EncodeToString(index)
+	}
 
 	// Should return IncompleteBody{} error when reader has fewer
 	// bytes than specified in request header.
@@ -1318,6 +1327,111 @@ func (fs *FSObjects) DeleteBucketSSEConfig(ctx context.Context, bucket string) e
 	return removeBucketSSEConfig(ctx, fs, bucket)
 }
 
+// SetBucketTagging sets bucket tagging on given bucket
+func (fs *FSObjects) SetBucketTagging(ctx context.Context, bucket string, tags *tagging.Tagging) error {
+	return saveBucketTaggingConfig(ctx, fs, bucket, tags)
+}
+
+// GetBucketTagging returns bucket tagging on given bucket
+func (fs *FSObjects) GetBucketTagging(ctx context.Context, bucket string) (*tagging.Tagging, error) {
+	return getBucketTaggingConfig(fs, bucket)
+}
+
+// DeleteBucketTagging deletes bucket tagging on given bucket
+func (fs *FSObjects) DeleteBucketTagging(ctx context.Context, bucket string) error {
+	return removeBucketTaggingConfig(ctx, fs, bucket)
+}
+
+// SetBucketCorsConfig sets bucket CORS config on given bucket
+func (fs *FSObjects) SetBucketCorsConfig(ctx context.Context, bucket string, config *cors.Config) error {
+	return saveBucketCorsConfig(ctx, fs, bucket, config)
+}
+
+// GetBucketCorsConfig returns bucket CORS config on given bucket
+func (fs *FSObjects) GetBucketCorsConfig(ctx context.Context, bucket string) (*cors.Config, error) {
+	return getBucketCorsConfig(fs, bucket)
+}
+
+// DeleteBucketCorsConfig deletes bucket CORS config on given bucket
+func (fs *FSObjects) DeleteBucketCorsConfig(ctx context.Context, bucket string) error {
+	return removeBucketCorsConfig(ctx, fs, bucket)
+}
+
+// SetBucketWebsiteConfig sets bucket website config on given bucket
+func (fs *FSObjects) SetBucketWebsiteConfig(ctx context.Context, bucket string, config *website.Config) error {
+	return saveBucketWebsiteConfig(ctx, fs, bucket, config)
+}
+
+// GetBucketWebsiteConfig returns bucket website config on given bucket
+func (fs *FSObjects) GetBucketWebsiteConfig(ctx context.Context, bucket string) (*website.Config, error) {
+	return getBucketWebsiteConfig(fs, bucket)
+}
+
+// DeleteBucketWebsiteConfig deletes bucket website config on given bucket
+func (fs *FSObjects) DeleteBucketWebsiteConfig(ctx context.Context, bucket string) error {
+	return removeBucketWebsiteConfig(ctx, fs, bucket)
+}
+
+// SetBucketLoggingConfig sets bucket logging config on given bucket
+func (fs *FSObjects) SetBucketLoggingConfig(ctx context.Context, bucket string, config *logging.Config) error {
+	return saveBucketLoggingConfig(ctx, fs, bucket, config)
+}
+
+// GetBucketLoggingConfig returns bucket logging config on given bucket
+func (fs *FSObjects) GetBucketLoggingConfig(ctx context.Context, bucket string) (*logging.Config, error) {
+	return getBucketLoggingConfig(fs, bucket)
+}
+
+// DeleteBucketLoggingConfig deletes bucket logging config on given bucket
+func (fs *FSObjects) DeleteBucketLoggingConfig(ctx context.Context, bucket string) error {
+	return removeBucketLoggingConfig(ctx, fs, bucket)
+}
+
+// SetBucketVersioning sets bucket versioning config on given bucket
+func (fs *FSObjects) SetBucketVersioning(ctx context.Context, bucket string, config *versioning.Versioning) error {
+	return NotImplemented{}
+}
+
+// GetBucketVersioning returns bucket versioning config on given bucket
+func (fs *FSObjects) GetBucketVersioning(ctx context.Context, bucket string) (*versioning.Versioning, error) {
+	return nil, NotImplemented{}
+}
+
+// SetBucketReplicationConfig sets bucket replication config on given bucket
+func (fs *FSObjects) SetBucketReplicationConfig(ctx context.Context, bucket string, config *replication.Config) error {
+	return NotImplemented{}
+}
+
+// GetBucketReplicationConfig returns bucket replication config on given bucket
+func (fs *FSObjects) GetBucketReplicationConfig(ctx context.Context, bucket string) (*replication.Config, error) {
+	return nil, NotImplemented{}
+}
+
+// DeleteBucketReplicationConfig deletes bucket replication config on given bucket
+func (fs *FSObjects) DeleteBucketReplicationConfig(ctx context.Context, bucket string) error {
+	return NotImplemented{}
+}
+
+// ListObjectVersions - versioning is not supported, not implemented stub
+func (fs *FSObjects) ListObjectVersions(ctx context.Context, bucket, prefix, marker, versionMarker, delimiter string, maxKeys int) (ListObjectVersionsInfo, error) {
+	return ListObjectVersionsInfo{}, NotImplemented{}
+}
+
+// DeleteObjectVersion - versioning is not supported, not implemented stub
+func (fs *FSObjects) DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	return ObjectInfo{}, NotImplemented{}
+}
+
+// TransitionObject - transitioning objects is not supported, not implemented stub
+func (fs *FSObjects) TransitionObject(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	return NotImplemented{}
+}
+
+// PutObjectMetadata - updating object metadata in place is not supported, not implemented stub
+func (fs *FSObjects) PutObjectMetadata(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	return NotImplemented{}
+}
+
 // ListObjectsV2 lists all blobs in bucket filtered by prefix
 func (fs *FSObjects) ListObjectsV2(ctx context.Context, bucket, prefix, continuationToken, delimiter string, maxKeys int, fetchOwner bool, startAfter string) (result ListObjectsV2Info, err error) {
 	marker := continuationToken
@@ -74,18 +74,18 @@ func AnonErrToObjectErr(statusCode int, params ...string) error {
 	switch statusCode {
 	case http.StatusNotFound:
 		if object != "" {
-			return ObjectNotFound{bucket, object}
+			return ObjectNotFound{Bucket: bucket, Object: object}
 		}
 		return BucketNotFound{Bucket: bucket}
 	case http.StatusBadRequest:
 		if object != "" {
-			return ObjectNameInvalid{bucket, object}
+			return ObjectNameInvalid{Bucket: bucket, Object: object}
 		}
 		return BucketNameInvalid{Bucket: bucket}
 	case http.StatusForbidden:
 		fallthrough
 	case http.StatusUnauthorized:
-		return AllAccessDisabled{bucket, object}
+		return AllAccessDisabled{Bucket: bucket, Object: object}
 	}
 
 	return errUnexpected
@@ -22,10 +22,15 @@ import (
 
 	"github.com/minio/minio/cmd/logger"
 
+	"github.com/minio/minio/pkg/bucket/cors"
 	bucketsse "github.com/minio/minio/pkg/bucket/encryption"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/bucket/logging"
 	"github.com/minio/minio/pkg/bucket/object/tagging"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/bucket/versioning"
+	"github.com/minio/minio/pkg/bucket/website"
 
 	"github.com/minio/minio/pkg/madmin"
 )
@@ -146,6 +151,111 @@ func (a GatewayUnsupported) DeleteBucketSSEConfig(ctx context.Context, bucket st
 	return NotImplemented{}
 }
 
+// SetBucketVersioning sets bucket versioning config on given bucket
+func (a GatewayUnsupported) SetBucketVersioning(ctx context.Context, bucket string, config *versioning.Versioning) error {
+	return NotImplemented{}
+}
+
+// GetBucketVersioning returns bucket versioning config on given bucket
+func (a GatewayUnsupported) GetBucketVersioning(ctx context.Context, bucket string) (*versioning.Versioning, error) {
+	return nil, NotImplemented{}
+}
+
+// SetBucketReplicationConfig sets bucket replication config on given bucket
+func (a GatewayUnsupported) SetBucketReplicationConfig(ctx context.Context, bucket string, config *replication.Config) error {
+	return NotImplemented{}
+}
+
+// GetBucketReplicationConfig returns bucket replication config on given bucket
+func (a GatewayUnsupported) GetBucketReplicationConfig(ctx context.Context, bucket string) (*replication.Config, error) {
+	return nil, NotImplemented{}
+}
+
+// DeleteBucketReplicationConfig deletes bucket replication config on given bucket
+func (a GatewayUnsupported) DeleteBucketReplicationConfig(ctx context.Context, bucket string) error {
+	return NotImplemented{}
+}
+
+// SetBucketTagging sets bucket tagging on given bucket
+func (a GatewayUnsupported) SetBucketTagging(ctx context.Context, bucket string, tags *tagging.Tagging) error {
+	return NotImplemented{}
+}
+
+// GetBucketTagging returns bucket tagging on given bucket
+func (a GatewayUnsupported) GetBucketTagging(ctx context.Context, bucket string) (*tagging.Tagging, error) {
+	return nil, NotImplemented{}
+}
+
+// DeleteBucketTagging deletes bucket tagging on given bucket
+func (a GatewayUnsupported) DeleteBucketTagging(ctx context.Context, bucket string) error {
+	return NotImplemented{}
+}
+
+// SetBucketCorsConfig sets bucket CORS config on given bucket
+func (a GatewayUnsupported) SetBucketCorsConfig(ctx context.Context, bucket string, config *cors.Config) error {
+	return NotImplemented{}
+}
+
+// GetBucketCorsConfig returns bucket CORS config on given bucket
+func (a GatewayUnsupported) GetBucketCorsConfig(ctx context.Context, bucket string) (*cors.Config, error) {
+	return nil, NotImplemented{}
+}
+
+// DeleteBucketCorsConfig deletes bucket CORS config on given bucket
+func (a GatewayUnsupported) DeleteBucketCorsConfig(ctx context.Context, bucket string) error {
+	return NotImplemented{}
+}
+
+// SetBucketWebsiteConfig sets bucket website config on given bucket
+func (a GatewayUnsupported) SetBucketWebsiteConfig(ctx context.Context, bucket string, config *website.Config) error {
+	return NotImplemented{}
+}
+
+// GetBucketWebsiteConfig returns bucket website config on given bucket
+func (a GatewayUnsupported) GetBucketWebsiteConfig(ctx context.Context, bucket string) (*website.Config, error) {
+	return nil, NotImplemented{}
+}
+
+// DeleteBucketWebsiteConfig deletes bucket website config on given bucket
+func (a GatewayUnsupported) DeleteBucketWebsiteConfig(ctx context.Context, bucket string) error {
+	return NotImplemented{}
+}
+
+// SetBucketLoggingConfig sets bucket logging config on given bucket
+func (a GatewayUnsupported) SetBucketLoggingConfig(ctx context.Context, bucket string, config *logging.Config) error {
+	return NotImplemented{}
+}
+
+// GetBucketLoggingConfig returns bucket logging config on given bucket
+func (a GatewayUnsupported) GetBucketLoggingConfig(ctx context.Context, bucket string) (*logging.Config, error) {
+	return nil, NotImplemented{}
+}
+
+// DeleteBucketLoggingConfig deletes bucket logging config on given bucket
+func (a GatewayUnsupported) DeleteBucketLoggingConfig(ctx context.Context, bucket string) error {
+	return NotImplemented{}
+}
+
+// ListObjectVersions - versioning is not supported, not implemented stub
+func (a GatewayUnsupported) ListObjectVersions(ctx context.Context, bucket, prefix, marker, versionMarker, delimiter string, maxKeys int) (ListObjectVersionsInfo, error) {
+	return ListObjectVersionsInfo{}, NotImplemented{}
+}
+
+// DeleteObjectVersion - versioning is not supported, not implemented stub
+func (a GatewayUnsupported) DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	return ObjectInfo{}, NotImplemented{}
+}
+
+// TransitionObject - transitioning objects is not supported, not implemented stub
+func (a GatewayUnsupported) TransitionObject(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	return NotImplemented{}
+}
+
+// PutObjectMetadata - updating object metadata in place is not supported, not implemented stub
+func (a GatewayUnsupported) PutObjectMetadata(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	return NotImplemented{}
+}
+
 // ReloadFormat - Not implemented stub.
 func (a GatewayUnsupported) ReloadFormat(ctx context.Context, dryRun bool) error {
 	return NotImplemented{}
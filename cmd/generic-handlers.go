@@ -17,8 +17,12 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,6 +33,9 @@ import (
 	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
+	bucketcors "github.com/minio/minio/pkg/bucket/cors"
+	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/website"
 	"github.com/minio/minio/pkg/handlers"
 	"github.com/rs/cors"
 )
@@ -422,7 +429,342 @@ func setCorsHandler(h http.Handler) http.Handler {
 		AllowCredentials: true,
 	})
 
-	return c.Handler(h)
+	return bucketCorsHandler{handler: c.Handler(h), next: h}
+}
+
+// bucketCorsHandler evaluates the CORS configuration of the bucket
+// of the request, requests on buckets without a CORS configuration
+// are handled by the server wide CORS settings.
+type bucketCorsHandler struct {
+	handler http.Handler
+	next    http.Handler
+}
+
+func (h bucketCorsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get(xhttp.Origin)
+	if origin == "" {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	resource, err := getResource(r.URL.Path, r.Host, globalDomainNames)
+	if err != nil {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	bucket, _ := path2BucketObject(resource)
+	if bucket == "" || isMinioReservedBucket(bucket) || isMinioMetaBucketName(bucket) {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	config, ok := globalBucketCorsSys.Get(bucket)
+	if !ok {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	header := w.Header()
+	header.Add(xhttp.Vary, xhttp.Origin)
+
+	// Preflight requests are answered here, as they are
+	// never authenticated nor routed to any API handler.
+	method := r.Header.Get(xhttp.AccessControlRequestMethod)
+	if r.Method == http.MethodOptions && method != "" {
+		header.Add(xhttp.Vary, xhttp.AccessControlRequestMethod)
+		header.Add(xhttp.Vary, xhttp.AccessControlRequestHeaders)
+
+		var reqHeaders []string
+		for _, reqHeader := range strings.Split(r.Header.Get(xhttp.AccessControlRequestHeaders), ",") {
+			if reqHeader = strings.ToLower(strings.TrimSpace(reqHeader)); reqHeader != "" {
+				reqHeaders = append(reqHeaders, reqHeader)
+			}
+		}
+
+		rule, ok := config.Match(origin, method, reqHeaders)
+		if !ok {
+			writeErrorResponse(context.Background(), w, errorCodes.ToAPIErr(ErrCORSForbidden), r.URL, guessIsBrowserReq(r))
+			return
+		}
+
+		setBucketCorsHeaders(header, rule, origin)
+		header.Set(xhttp.AccessControlAllowMethods, strings.Join(rule.AllowedMethods, ", "))
+		if len(reqHeaders) > 0 {
+			header.Set(xhttp.AccessControlAllowHeaders, strings.Join(reqHeaders, ", "))
+		}
+		if rule.MaxAgeSeconds > 0 {
+			header.Set(xhttp.AccessControlMaxAge, strconv.Itoa(rule.MaxAgeSeconds))
+		}
+		writeSuccessResponseHeadersOnly(w)
+		return
+	}
+
+	// Requests not allowed by any rule are served without
+	// CORS headers, leaving it to the browser to reject them.
+	if rule, ok := config.Match(origin, r.Method, nil); ok {
+		setBucketCorsHeaders(header, rule, origin)
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+// setBucketCorsHeaders sets the CORS response headers for the given
+// origin allowed by the bucket CORS rule.
+func setBucketCorsHeaders(header http.Header, rule bucketcors.Rule, origin string) {
+	if allowed, _ := rule.MatchOrigin(origin); allowed == "*" {
+		header.Set(xhttp.AccessControlAllowOrigin, "*")
+	} else {
+		header.Set(xhttp.AccessControlAllowOrigin, origin)
+		header.Set(xhttp.AccessControlAllowCredentials, "true")
+	}
+	if len(rule.ExposeHeaders) > 0 {
+		header.Set(xhttp.AccessControlExposeHeaders, strings.Join(rule.ExposeHeaders, ", "))
+	}
+}
+
+// setBucketWebsiteHandler serves the buckets with a website
+// configuration as static websites on the website domains.
+func setBucketWebsiteHandler(h http.Handler) http.Handler {
+	return bucketWebsiteHandler{handler: h}
+}
+
+type bucketWebsiteHandler struct {
+	handler http.Handler
+}
+
+// websiteBucket returns the bucket addressed by the host of a website
+// request, websites are served in virtual-host style on the website
+// domains only, so that the S3 API endpoint is never affected.
+func websiteBucket(host string) (bucket string, ok bool) {
+	if len(globalWebsiteDomainNames) == 0 {
+		return "", false
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	for _, domain := range globalWebsiteDomainNames {
+		if bucket = strings.TrimSuffix(host, "."+domain); bucket != host && bucket != "" {
+			return bucket, true
+		}
+	}
+	return "", false
+}
+
+// ServeHTTP serves the requests on the website domains as website
+// requests, all other requests are regular S3 API requests. Objects
+// of the website are served by the GetObject API, as such they must
+// be readable anonymously as per the bucket policy.
+func (h bucketWebsiteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bucket, ok := websiteBucket(r.Host)
+	if !ok {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	ctx := newContext(r, w, "BucketWebsite")
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMethodNotAllowed), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	config, ok := globalBucketWebsiteSys.Get(bucket)
+	if !ok || isMinioReservedBucket(bucket) || isMinioMetaBucketName(bucket) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchWebsiteConfiguration), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Website requests are anonymous, query parameters
+	// and credentials of the request are ignored.
+	r.Header.Del(xhttp.Authorization)
+	r.URL.RawQuery = ""
+
+	// Objects are addressed relative to the host.
+	key := strings.TrimPrefix(r.URL.Path, SlashSeparator)
+	objectPath := SlashSeparator + bucket + SlashSeparator
+
+	if redirect := config.RedirectAllRequestsTo; redirect != nil {
+		location := websiteLocation(r, redirect.Protocol, redirect.HostName, SlashSeparator+key)
+		http.Redirect(w, r, location, http.StatusMovedPermanently)
+		return
+	}
+
+	if rule, ok := config.RoutingRule(key, 0); ok {
+		writeWebsiteRedirect(w, r, rule, key)
+		return
+	}
+
+	indexKey := config.IndexKey(key)
+	if !isWebsiteObjectReadable(r, bucket, indexKey) {
+		serveWebsiteObject(h.handler, w, r, objectPath+indexKey)
+		return
+	}
+
+	// The object is served right away, the response is only held
+	// back when the object does not exist.
+	ow := &websiteObjectWriter{ResponseWriter: w, header: make(http.Header)}
+	serveWebsiteObject(h.handler, ow, r, objectPath+indexKey)
+	if !ow.notFound {
+		return
+	}
+
+	// Requests on a directory without the trailing slash
+	// are redirected to the directory, as browsers resolve
+	// relative links of the index document from there. The
+	// redirect would reveal the existence of a private index
+	// document, only readable ones are looked up.
+	if key != "" && !strings.HasSuffix(key, SlashSeparator) {
+		dirKey := key + SlashSeparator
+		objAPI := newObjectLayerWithoutSafeModeFn()
+		if objAPI != nil && isWebsiteObjectReadable(r, bucket, config.IndexKey(dirKey)) {
+			if _, err := objAPI.GetObjectInfo(ctx, bucket, config.IndexKey(dirKey), ObjectOptions{}); err == nil {
+				http.Redirect(w, r, (&url.URL{Path: SlashSeparator + dirKey}).EscapedPath(), http.StatusFound)
+				return
+			}
+		}
+	}
+
+	if rule, ok := config.RoutingRule(key, http.StatusNotFound); ok {
+		writeWebsiteRedirect(w, r, rule, key)
+		return
+	}
+
+	if errorKey, ok := config.ErrorKey(); ok && isWebsiteObjectReadable(r, bucket, errorKey) {
+		serveWebsiteObject(h.handler, &websiteErrorWriter{ResponseWriter: w, statusCode: http.StatusNotFound}, r, objectPath+errorKey)
+		return
+	}
+
+	ow.writeNotFound()
+}
+
+// isWebsiteObjectReadable returns true if the object of the website may
+// be read anonymously, websites are only served as per the bucket policy.
+func isWebsiteObjectReadable(r *http.Request, bucket, object string) bool {
+	return globalPolicySys.IsAllowed(policy.Args{
+		Action:          policy.GetObjectAction,
+		BucketName:      bucket,
+		ConditionValues: getConditionValues(r, "", "", nil),
+		IsOwner:         false,
+		ObjectName:      object,
+	})
+}
+
+// serveWebsiteObject serves the object at the given path to the request
+// on the website, by routing the request to the GetObject API.
+func serveWebsiteObject(h http.Handler, w http.ResponseWriter, r *http.Request, objectPath string) {
+	r.URL.Path = objectPath
+	r.URL.RawPath = ""
+	h.ServeHTTP(w, r)
+}
+
+// websiteLocation returns the location of the given path on the host, the
+// protocol and the host of the request are used when not specified.
+func websiteLocation(r *http.Request, protocol, host, path string) string {
+	if protocol == "" {
+		protocol = getURLScheme(globalIsSSL)
+	}
+	if host == "" {
+		host = r.Host
+	}
+	return (&url.URL{Scheme: protocol, Host: host, Path: path}).String()
+}
+
+// writeWebsiteRedirect redirects the request on the given object of the
+// website as per the routing rule.
+func writeWebsiteRedirect(w http.ResponseWriter, r *http.Request, rule website.RoutingRule, key string) {
+	path := SlashSeparator + rule.RedirectKey(key)
+	location := websiteLocation(r, rule.Redirect.Protocol, rule.Redirect.HostName, path)
+	http.Redirect(w, r, location, rule.RedirectCode())
+}
+
+// websiteErrorWriter serves the error document of a website with
+// the status code of the error, instead of the status code of the
+// successful read of the error document.
+type websiteErrorWriter struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+}
+
+func (w *websiteErrorWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if code == http.StatusOK {
+		code = w.statusCode
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *websiteErrorWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *websiteErrorWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// websiteObjectWriter holds back the response of a website object
+// which does not exist, so that the error document or a redirect may
+// be served instead, all other responses are written as is.
+type websiteObjectWriter struct {
+	http.ResponseWriter
+	header      http.Header
+	body        bytes.Buffer
+	notFound    bool
+	wroteHeader bool
+}
+
+func (w *websiteObjectWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *websiteObjectWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if code == http.StatusNotFound {
+		w.notFound = true
+		return
+	}
+	for k, v := range w.header {
+		w.ResponseWriter.Header()[k] = v
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *websiteObjectWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.notFound {
+		return w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *websiteObjectWriter) Flush() {
+	if w.notFound {
+		return
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// writeNotFound writes the held back response of the missing object.
+func (w *websiteObjectWriter) writeNotFound() {
+	for k, v := range w.header {
+		w.ResponseWriter.Header()[k] = v
+	}
+	w.ResponseWriter.WriteHeader(http.StatusNotFound)
+	w.ResponseWriter.Write(w.body.Bytes())
 }
 
 // setIgnoreResourcesHandler -
@@ -436,28 +778,16 @@ func setIgnoreResourcesHandler(h http.Handler) http.Handler {
 // Checks requests for not implemented Bucket resources
 func ignoreNotImplementedBucketResources(req *http.Request) bool {
 	for name := range req.URL.Query() {
-		// Enable PutBucketACL, GetBucketACL, GetBucketCors,
-		// GetBucketWebsite, GetBucketAcccelerate,
-		// GetBucketRequestPayment, GetBucketLogging,
-		// GetBucketLifecycle, GetBucketReplication,
-		// GetBucketTagging, GetBucketVersioning,
-		// DeleteBucketTagging, and DeleteBucketWebsite
-		// dummy calls specifically.
+		// Enable PutBucketACL, GetBucketACL,
+		// GetBucketAcccelerate, GetBucketRequestPayment
+		// and GetBucketLifecycle dummy calls specifically.
 		if name == "acl" && req.Method == http.MethodPut {
 			return false
 		}
-		if ((name == "acl" ||
-			name == "cors" ||
-			name == "website" ||
+		if (name == "acl" ||
 			name == "accelerate" ||
 			name == "requestPayment" ||
-			name == "logging" ||
-			name == "lifecycle" ||
-			name == "replication" ||
-			name == "tagging" ||
-			name == "versioning") && req.Method == http.MethodGet) ||
-			((name == "tagging" ||
-				name == "website") && req.Method == http.MethodDelete) {
+			name == "lifecycle") && req.Method == http.MethodGet {
 			return false
 		}
 
@@ -485,15 +815,9 @@ func ignoreNotImplementedObjectResources(req *http.Request) bool {
 // List of not implemented bucket queries
 var notImplementedBucketResourceNames = map[string]bool{
 	"accelerate":     true,
-	"cors":           true,
 	"inventory":      true,
-	"logging":        true,
 	"metrics":        true,
-	"replication":    true,
 	"requestPayment": true,
-	"tagging":        true,
-	"versioning":     true,
-	"website":        true,
 }
 
 // List of not implemented object queries
@@ -17,13 +17,21 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	bucketcors "github.com/minio/minio/pkg/bucket/cors"
+	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/policy/condition"
+	"github.com/minio/minio/pkg/bucket/website"
 )
 
 // Tests getRedirectLocation function for all its criteria.
@@ -245,3 +253,248 @@ func TestSSETLSHandler(t *testing.T) {
 		}
 	}
 }
+
+func TestBucketCorsHandler(t *testing.T) {
+	defer func(sys *BucketCorsSys) { globalBucketCorsSys = sys }(globalBucketCorsSys)
+
+	config, err := bucketcors.ParseConfig(strings.NewReader(`<CORSConfiguration>` +
+		`<CORSRule><AllowedOrigin>https://*.example.com</AllowedOrigin><AllowedMethod>PUT</AllowedMethod><AllowedHeader>*</AllowedHeader><ExposeHeader>ETag</ExposeHeader><MaxAgeSeconds>600</MaxAgeSeconds></CORSRule>` +
+		`<CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule>` +
+		`</CORSConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	globalBucketCorsSys = NewBucketCorsSys()
+	globalBucketCorsSys.Set("cors-bucket", *config)
+
+	var okHandler http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	testCases := []struct {
+		method              string
+		path                string
+		origin              string
+		requestMethod       string
+		expectedStatus      int
+		expectedAllowOrigin string
+	}{
+		// Preflight allowed by the first rule.
+		{http.MethodOptions, "/cors-bucket/object", "https://app.example.com", http.MethodPut, http.StatusOK, "https://app.example.com"},
+		// Preflight not allowed by any rule.
+		{http.MethodOptions, "/cors-bucket/object", "https://app.example.org", http.MethodPut, http.StatusForbidden, ""},
+		// Request allowed from any origin.
+		{http.MethodGet, "/cors-bucket/object", "https://app.example.org", "", http.StatusOK, "*"},
+		// Request not allowed by any rule is served without CORS headers.
+		{http.MethodDelete, "/cors-bucket/object", "https://app.example.org", "", http.StatusOK, ""},
+	}
+
+	for i, testCase := range testCases {
+		r := httptest.NewRequest(testCase.method, "http://localhost:9000"+testCase.path, nil)
+		r.Header.Set(xhttp.Origin, testCase.origin)
+		if testCase.requestMethod != "" {
+			r.Header.Set(xhttp.AccessControlRequestMethod, testCase.requestMethod)
+		}
+		w := httptest.NewRecorder()
+
+		setCorsHandler(okHandler).ServeHTTP(w, r)
+
+		if w.Code != testCase.expectedStatus {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.expectedStatus, w.Code)
+		}
+		if allowOrigin := w.Header().Get(xhttp.AccessControlAllowOrigin); allowOrigin != testCase.expectedAllowOrigin {
+			t.Errorf("Test %d: expected allowed origin %q, got %q", i+1, testCase.expectedAllowOrigin, allowOrigin)
+		}
+	}
+
+	// Buckets without CORS configuration use the server wide settings.
+	r := httptest.NewRequest(http.MethodGet, "http://localhost:9000/other-bucket/object", nil)
+	r.Header.Set(xhttp.Origin, "https://app.example.org")
+	w := httptest.NewRecorder()
+	setCorsHandler(okHandler).ServeHTTP(w, r)
+	if w.Header().Get(xhttp.AccessControlAllowOrigin) == "" {
+		t.Errorf("Expected server wide CORS headers for a bucket without CORS configuration")
+	}
+}
+
+func TestBucketWebsiteHandler(t *testing.T) {
+	defer func(sys *BucketWebsiteSys) { globalBucketWebsiteSys = sys }(globalBucketWebsiteSys)
+	defer func(sys *PolicySys) { globalPolicySys = sys }(globalPolicySys)
+	defer func(domains []string) { globalWebsiteDomainNames = domains }(globalWebsiteDomainNames)
+
+	globalWebsiteDomainNames = []string{"website.localhost"}
+	globalPolicySys = NewPolicySys()
+	globalBucketWebsiteSys = NewBucketWebsiteSys()
+	for bucket, input := range map[string]string{
+		"redirect-bucket": `<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName><Protocol>https</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`,
+		"website-bucket": `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><ErrorDocument><Key>error.html</Key></ErrorDocument><RoutingRules>` +
+			`<RoutingRule><Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition><Redirect><ReplaceKeyPrefixWith>documents/</ReplaceKeyPrefixWith></Redirect></RoutingRule>` +
+			`</RoutingRules></WebsiteConfiguration>`,
+	} {
+		config, err := website.ParseConfig(strings.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		globalBucketWebsiteSys.Set(bucket, *config)
+		globalPolicySys.Set(bucket, *getAnonReadOnlyObjectPolicy(bucket, ""))
+	}
+
+	// Serves the objects of the bucket as the GetObject API would.
+	objects := map[string]string{
+		"/website-bucket/index.html": "index",
+		"/website-bucket/error.html": "error",
+	}
+	var objectHandler http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		data, ok := objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("NoSuchKey"))
+			return
+		}
+		w.Write([]byte(data))
+	}
+
+	testCases := []struct {
+		method           string
+		url              string
+		header           http.Header
+		expectedStatus   int
+		expectedLocation string
+		expectedBody     string
+	}{
+		// All requests are redirected to another host.
+		{http.MethodGet, "http://redirect-bucket.website.localhost:9000/docs/intro.html", nil, http.StatusMovedPermanently, "https://example.com/docs/intro.html", ""},
+		// Objects matching a routing rule are redirected.
+		{http.MethodGet, "http://website-bucket.website.localhost:9000/docs/intro.html", nil, http.StatusMovedPermanently, "http://website-bucket.website.localhost:9000/documents/intro.html", ""},
+		// The index document is served for directories.
+		{http.MethodGet, "http://website-bucket.website.localhost:9000/", nil, http.StatusOK, "", "index"},
+		// Credentials and query parameters are ignored.
+		{http.MethodGet, "http://website-bucket.website.localhost:9000/index.html?tagging", http.Header{xhttp.Authorization: []string{"AWS4-HMAC-SHA256 Credential=minio"}}, http.StatusOK, "", "index"},
+		// The error document is served for missing objects.
+		{http.MethodGet, "http://website-bucket.website.localhost:9000/missing.html", nil, http.StatusNotFound, "", "error"},
+		// Missing objects without error document.
+		{http.MethodGet, "http://other-bucket.website.localhost:9000/missing.html", nil, http.StatusNotFound, "", ""},
+		// Only GET and HEAD requests are allowed on websites.
+		{http.MethodPut, "http://website-bucket.website.localhost:9000/index.html", nil, http.StatusMethodNotAllowed, "", ""},
+		// Requests on the S3 API endpoint are regular S3 API requests.
+		{http.MethodGet, "http://localhost:9000/website-bucket/missing.html", nil, http.StatusNotFound, "", "NoSuchKey"},
+		{http.MethodGet, "http://localhost:9000/redirect-bucket/docs/intro.html", nil, http.StatusNotFound, "", "NoSuchKey"},
+	}
+
+	for i, testCase := range testCases {
+		r := httptest.NewRequest(testCase.method, testCase.url, nil)
+		for k, v := range testCase.header {
+			r.Header[k] = v
+		}
+		w := httptest.NewRecorder()
+
+		setBucketWebsiteHandler(objectHandler).ServeHTTP(w, r)
+
+		if w.Code != testCase.expectedStatus {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.expectedStatus, w.Code)
+		}
+		if location := w.Header().Get(xhttp.Location); location != testCase.expectedLocation {
+			t.Errorf("Test %d: expected location %q, got %q", i+1, testCase.expectedLocation, location)
+		}
+		if testCase.expectedBody != "" && w.Body.String() != testCase.expectedBody {
+			t.Errorf("Test %d: expected body %q, got %q", i+1, testCase.expectedBody, w.Body.String())
+		}
+	}
+}
+
+func TestBucketWebsiteHandlerDirectoryRedirect(t *testing.T) {
+	obj, fsDirs, err := prepareXL(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	defer func(sys *BucketWebsiteSys) { globalBucketWebsiteSys = sys }(globalBucketWebsiteSys)
+	defer func(sys *PolicySys) { globalPolicySys = sys }(globalPolicySys)
+	defer func(domains []string) { globalWebsiteDomainNames = domains }(globalWebsiteDomainNames)
+
+	globalObjLayerMutex.Lock()
+	oldObjectAPI := globalObjectAPI
+	globalObjectAPI = obj
+	globalObjLayerMutex.Unlock()
+	defer func() {
+		globalObjLayerMutex.Lock()
+		globalObjectAPI = oldObjectAPI
+		globalObjLayerMutex.Unlock()
+	}()
+
+	ctx := context.Background()
+	bucket := "website-bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	for _, object := range []string{"public/index.html", "private/index.html"} {
+		data := []byte("index")
+		if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	globalWebsiteDomainNames = []string{"website.localhost"}
+	globalBucketWebsiteSys = NewBucketWebsiteSys()
+	config, err := website.ParseConfig(strings.NewReader(`<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument></WebsiteConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	globalBucketWebsiteSys.Set(bucket, *config)
+
+	// The index document of the private directory is not readable
+	// anonymously, the directory itself is.
+	globalPolicySys = NewPolicySys()
+	globalPolicySys.Set(bucket, policy.Policy{
+		Version: policy.DefaultVersion,
+		Statements: []policy.Statement{policy.NewStatement(
+			policy.Allow,
+			policy.NewPrincipal("*"),
+			policy.NewActionSet(policy.GetObjectAction),
+			policy.NewResourceSet(policy.NewResource(bucket, "public*"), policy.NewResource(bucket, "private")),
+			condition.NewFunctions(),
+		)},
+	})
+
+	var objectHandler http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}
+
+	testCases := []struct {
+		url              string
+		expectedStatus   int
+		expectedLocation string
+	}{
+		{"http://website-bucket.website.localhost:9000/public", http.StatusFound, "/public/"},
+		// The existence of a private index document is not revealed.
+		{"http://website-bucket.website.localhost:9000/private", http.StatusNotFound, ""},
+		{"http://website-bucket.website.localhost:9000/missing", http.StatusNotFound, ""},
+	}
+
+	for i, testCase := range testCases {
+		r := httptest.NewRequest(http.MethodGet, testCase.url, nil)
+		w := httptest.NewRecorder()
+
+		setBucketWebsiteHandler(objectHandler).ServeHTTP(w, r)
+
+		if w.Code != testCase.expectedStatus {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.expectedStatus, w.Code)
+		}
+		if location := w.Header().Get(xhttp.Location); location != testCase.expectedLocation {
+			t.Errorf("Test %d: expected location %q, got %q", i+1, testCase.expectedLocation, location)
+		}
+	}
+}
+
+func TestWebsiteErrorWriter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := &websiteErrorWriter{ResponseWriter: rec, statusCode: http.StatusNotFound}
+	if _, err := w.Write([]byte("not found")); err != nil {
+		t.Fatal(err)
+	}
+	w.Flush()
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
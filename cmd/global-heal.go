@@ -67,14 +67,16 @@ func newBgHealSequence(numDisks int) *healSequence {
 }
 
 func getLocalBackgroundHealStatus() madmin.BgHealState {
+	healDisks := globalBackgroundHealState.getHealLocalDisks()
 	bgSeq, ok := globalBackgroundHealState.getHealSequenceByToken(bgHealingUUID)
 	if !ok {
-		return madmin.BgHealState{}
+		return madmin.BgHealState{HealDisks: healDisks}
 	}
 
 	return madmin.BgHealState{
 		ScannedItemsCount: bgSeq.scannedItemsCount,
 		LastHealActivity:  bgSeq.lastHealActivity,
+		HealDisks:         healDisks,
 	}
 }
 
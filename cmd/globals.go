@@ -25,6 +25,7 @@ import (
 
 	etcd "github.com/coreos/etcd/clientv3"
 	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/config/bitrot"
 	"github.com/minio/minio/cmd/config/cache"
 	"github.com/minio/minio/cmd/config/compress"
 	"github.com/minio/minio/cmd/config/etcd/dns"
@@ -98,6 +99,12 @@ const (
 
 	// Maximum size of default bucket encryption configuration allowed
 	maxBucketSSEConfigSize = 1 * humanize.MiByte
+
+	// Maximum size of bucket versioning configuration allowed
+	maxBucketVersioningConfigSize = 1 * humanize.MiByte
+
+	// Limit of object tagging XML read to evaluate policies on request object tags.
+	maxObjectTaggingSize = 1 * humanize.MiByte
 )
 
 var globalCLIContext = struct {
@@ -147,8 +154,16 @@ var (
 	globalPolicySys        *PolicySys
 	globalIAMSys           *IAMSys
 
-	globalLifecycleSys       *LifecycleSys
-	globalBucketSSEConfigSys *BucketSSEConfigSys
+	globalLifecycleSys         *LifecycleSys
+	globalBucketSSEConfigSys   *BucketSSEConfigSys
+	globalBucketVersioningSys  *BucketVersioningSys
+	globalBucketReplicationSys *BucketReplicationSys
+	globalBucketQuotaSys       *BucketQuotaSys
+	globalBucketCorsSys        *BucketCorsSys
+	globalBucketWebsiteSys     *BucketWebsiteSys
+	globalBucketLoggingSys     *BucketLoggingSys
+	globalBucketTargetSys      *BucketTargetSys
+	globalTierSys              *TierSys
 
 	globalStorageClass storageclass.Config
 	globalLDAPConfig   xldap.Config
@@ -198,6 +213,8 @@ var (
 	globalDomainNames []string      // Root domains for virtual host style requests
 	globalDomainIPs   set.StringSet // Root domain IP address(s) for a distributed MinIO deployment
 
+	globalWebsiteDomainNames []string // Root domains for the websites of the buckets
+
 	globalListingTimeout   = newDynamicTimeout( /*30*/ 600*time.Second /*5*/, 600*time.Second) // timeout for listing related ops
 	globalObjectTimeout    = newDynamicTimeout( /*1*/ 10*time.Minute /*10*/, 600*time.Second)  // timeout for Object API related ops
 	globalOperationTimeout = newDynamicTimeout(10*time.Minute /*30*/, 600*time.Second)         // default timeout for general ops
@@ -240,6 +257,9 @@ var (
 	// Is compression enabled?
 	globalCompressConfig compress.Config
 
+	// Scheduled deep scanning of objects for bitrot.
+	globalBitrotScanConfig bitrot.Config
+
 	// Some standard object extensions which we strictly dis-allow for compression.
 	standardExcludeCompressExtensions = []string{".gz", ".bz2", ".rar", ".zip", ".7z", ".xz", ".mp4", ".mkv", ".mov"}
 
@@ -40,6 +40,20 @@ const (
 	Action             = "Action"
 )
 
+// Standard CORS HTTP header constants
+const (
+	Origin                        = "Origin"
+	Vary                          = "Vary"
+	AccessControlRequestMethod    = "Access-Control-Request-Method"
+	AccessControlRequestHeaders   = "Access-Control-Request-Headers"
+	AccessControlAllowOrigin      = "Access-Control-Allow-Origin"
+	AccessControlAllowMethods     = "Access-Control-Allow-Methods"
+	AccessControlAllowHeaders     = "Access-Control-Allow-Headers"
+	AccessControlAllowCredentials = "Access-Control-Allow-Credentials"
+	AccessControlExposeHeaders    = "Access-Control-Expose-Headers"
+	AccessControlMaxAge           = "Access-Control-Max-Age"
+)
+
 // Non standard S3 HTTP response constants
 const (
 	XCache       = "X-Cache"
@@ -61,6 +75,13 @@ const (
 	AmzTagCount      = "X-Amz-Tag-Count"
 	AmzTagDirective  = "X-Amz-Tagging-Directive"
 
+	// S3 object versioning
+	AmzVersionID    = "X-Amz-Version-Id"
+	AmzDeleteMarker = "X-Amz-Delete-Marker"
+
+	// S3 bucket replication
+	AmzBucketReplicationStatus = "X-Amz-Replication-Status"
+
 	// S3 extensions
 	AmzCopySourceIfModifiedSince   = "x-amz-copy-source-if-modified-since"
 	AmzCopySourceIfUnmodifiedSince = "x-amz-copy-source-if-unmodified-since"
@@ -0,0 +1,124 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	xldap "github.com/minio/minio/cmd/config/identity/ldap"
+	"github.com/minio/minio/cmd/logger"
+)
+
+// getLDAPTempUsers - returns the access keys of the temporary credentials
+// of each LDAP user.
+func (sys *IAMSys) getLDAPTempUsers() map[string][]string {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	users := make(map[string][]string)
+	for accessKey, cred := range sys.iamUsersMap {
+		if !cred.IsTemp() || cred.IsExpired() {
+			continue
+		}
+		claims, err := getClaimsFromSessionToken(cred.SessionToken)
+		if err != nil {
+			continue
+		}
+		if user, ok := claims[ldapUser].(string); ok {
+			users[user] = append(users[user], accessKey)
+		}
+	}
+	return users
+}
+
+// syncLDAPUsers - synchronizes the groups of the LDAP users holding
+// temporary credentials with the LDAP server, and revokes the temporary
+// credentials of users which were removed from the LDAP server.
+func syncLDAPUsers(ctx context.Context) error {
+	ldapConn, err := globalLDAPConfig.Connect()
+	if err != nil {
+		return fmt.Errorf("LDAP server connection failure: %w", err)
+	}
+	// Close ldap connection to avoid leaks.
+	defer ldapConn.Close()
+
+	users := globalIAMSys.getLDAPTempUsers()
+
+	// Groups of users without temporary credentials anymore,
+	// expired or revoked, are not needed anymore.
+	globalIAMSys.pruneLDAPGroups(users)
+
+	for user, accessKeys := range users {
+		userDN, err := globalLDAPConfig.LookupUser(ldapConn, user)
+		if err == xldap.ErrUserNotFound {
+			if err = globalIAMSys.DeleteLDAPUser(user, accessKeys); err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to revoke the credentials of LDAP user %s: %w", user, err))
+			}
+			continue
+		}
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to lookup LDAP user %s: %w", user, err))
+			continue
+		}
+
+		groups, err := globalLDAPConfig.GetGroups(ldapConn, user, userDN)
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to get the groups of LDAP user %s: %w", user, err))
+			continue
+		}
+		globalIAMSys.SetLDAPGroups(user, groups)
+	}
+	return nil
+}
+
+func initLDAPSync() {
+	if !globalLDAPConfig.SyncEnabled() {
+		return
+	}
+	go startLDAPSync()
+}
+
+// startLDAPSync - periodically synchronizes LDAP users on this server,
+// each server keeps the groups of LDAP users it evaluates policies with.
+func startLDAPSync() {
+	var ctx = context.Background()
+
+	// Wait until the object API is ready
+	for {
+		if newObjectLayerWithoutSafeModeFn() == nil {
+			time.Sleep(time.Second)
+			continue
+		}
+		break
+	}
+
+	ticker := time.NewTicker(globalLDAPConfig.GetSyncInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-GlobalServiceDoneCh:
+			return
+		case <-ticker.C:
+			if err := syncLDAPUsers(ctx); err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to sync LDAP users: %w", err))
+			}
+		}
+	}
+}
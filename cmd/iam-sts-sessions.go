@@ -0,0 +1,123 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/base64"
+	"sort"
+	"time"
+
+	xjwt "github.com/minio/minio/cmd/jwt"
+	"github.com/minio/minio/pkg/auth"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// getSTSSessionInfo - returns the information of temporary credentials
+// carried by the claims of their session token. Credentials issued before
+// the STS action and issue time were added to the claims lack these.
+func getSTSSessionInfo(cred auth.Credentials) (info madmin.STSSessionInfo, err error) {
+	claims := xjwt.NewMapClaims()
+	if err = xjwt.ParseWithClaims(cred.SessionToken, claims, func(*xjwt.MapClaims) ([]byte, error) {
+		return []byte(globalActiveCred.SecretKey), nil
+	}); err != nil {
+		return info, err
+	}
+
+	info.AccessKey = cred.AccessKey
+	info.Expiration = cred.Expiration
+	info.Source, _ = claims.Lookup(stsActionClaim)
+	if parentUser, ok := claims.Lookup(parentClaim); ok {
+		info.ParentUser = parentUser
+		if info.Source == "" {
+			info.Source = assumeRole
+		}
+	} else if user, ok := claims.Lookup(ldapUser); ok {
+		info.ParentUser = user
+		if info.Source == "" {
+			info.Source = ldapIdentity
+		}
+	} else {
+		info.ParentUser, _ = claims.Lookup(subClaim)
+	}
+
+	if issued, ok := claims.MapClaims[issuedClaim].(float64); ok {
+		info.IssuedAt = time.Unix(int64(issued), 0).UTC()
+	}
+	if sp, ok := claims.Lookup(iampolicy.SessionPolicyName); ok {
+		if info.SessionPolicy, err = base64.StdEncoding.DecodeString(sp); err != nil {
+			return info, err
+		}
+	}
+	return info, nil
+}
+
+// ListSTSSessions - lists the active temporary credentials of the
+// parent user, or of all users if no parent user is given.
+func (sys *IAMSys) ListSTSSessions(parentUser string) ([]madmin.STSSessionInfo, error) {
+	objectAPI := newObjectLayerWithoutSafeModeFn()
+	if objectAPI == nil {
+		return nil, errServerNotInitialized
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+
+	sessions := []madmin.STSSessionInfo{}
+	for _, cred := range sys.iamUsersMap {
+		if !cred.IsTemp() || cred.IsExpired() {
+			continue
+		}
+		info, err := getSTSSessionInfo(cred)
+		if err != nil {
+			// Credentials issued with previous admin
+			// credentials are no longer valid.
+			continue
+		}
+		if parentUser != "" && info.ParentUser != parentUser {
+			continue
+		}
+		sessions = append(sessions, info)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].Expiration.Before(sessions[j].Expiration)
+	})
+	return sessions, nil
+}
+
+// RevokeSTSSession - revokes temporary credentials before they expire,
+// revoking credentials which don't exist is not an error.
+func (sys *IAMSys) RevokeSTSSession(accessKey string) error {
+	objectAPI := newObjectLayerWithoutSafeModeFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	cred, ok := sys.iamUsersMap[accessKey]
+	if ok && !cred.IsTemp() {
+		return errNoSuchUser
+	}
+
+	if sys.store == nil {
+		return errServerNotInitialized
+	}
+
+	return sys.deleteTempUser(accessKey)
+}
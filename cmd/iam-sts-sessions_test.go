@@ -0,0 +1,103 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/auth"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+func TestGetSTSSessionInfo(t *testing.T) {
+	cred, err := auth.GetNewCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	globalActiveCred = cred
+
+	issued := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	exp := issued.Add(time.Hour).Unix()
+	sessionPolicy := `{"Version":"2012-10-17","Statement":[]}`
+
+	testCases := []struct {
+		claims             map[string]interface{}
+		expectedParentUser string
+		expectedSource     string
+		expectedIssuedAt   time.Time
+		expectedPolicy     string
+	}{
+		{
+			claims: map[string]interface{}{
+				expClaim: exp, parentClaim: "alice", stsActionClaim: assumeRole, issuedClaim: issued.Unix(),
+				iampolicy.SessionPolicyName: base64.StdEncoding.EncodeToString([]byte(sessionPolicy)),
+			},
+			expectedParentUser: "alice",
+			expectedSource:     assumeRole,
+			expectedIssuedAt:   issued,
+			expectedPolicy:     sessionPolicy,
+		},
+		{
+			claims:             map[string]interface{}{expClaim: exp, ldapUser: "bob", ldapGroups: []string{}},
+			expectedParentUser: "bob",
+			expectedSource:     ldapIdentity,
+		},
+		{
+			claims:             map[string]interface{}{expClaim: exp, subClaim: "carol", stsActionClaim: webIdentity, issuedClaim: issued.Unix()},
+			expectedParentUser: "carol",
+			expectedSource:     webIdentity,
+			expectedIssuedAt:   issued,
+		},
+	}
+
+	for i, testCase := range testCases {
+		tempCred, err := auth.GetNewCredentialsWithMetadata(testCase.claims, cred.SecretKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		info, err := getSTSSessionInfo(tempCred)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if info.AccessKey != tempCred.AccessKey || !info.Expiration.Equal(tempCred.Expiration) {
+			t.Errorf("Test %d: unexpected credentials %s, %s", i+1, info.AccessKey, info.Expiration)
+		}
+		if info.ParentUser != testCase.expectedParentUser {
+			t.Errorf("Test %d: expected parent user %s, got %s", i+1, testCase.expectedParentUser, info.ParentUser)
+		}
+		if info.Source != testCase.expectedSource {
+			t.Errorf("Test %d: expected source %s, got %s", i+1, testCase.expectedSource, info.Source)
+		}
+		if !info.IssuedAt.Equal(testCase.expectedIssuedAt) {
+			t.Errorf("Test %d: expected issue time %s, got %s", i+1, testCase.expectedIssuedAt, info.IssuedAt)
+		}
+		if string(info.SessionPolicy) != testCase.expectedPolicy {
+			t.Errorf("Test %d: expected session policy %s, got %s", i+1, testCase.expectedPolicy, info.SessionPolicy)
+		}
+	}
+
+	// Credentials signed with other admin credentials are rejected.
+	tempCred, err := auth.GetNewCredentialsWithMetadata(map[string]interface{}{expClaim: exp}, "other-secret-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = getSTSSessionInfo(tempCred); err == nil {
+		t.Error("expected session token signed with another key to be rejected")
+	}
+}
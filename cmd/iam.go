@@ -21,12 +21,14 @@ import (
 	"context"
 	"encoding/json"
 	"sync"
+	"time"
 
 	"github.com/minio/minio-go/v6/pkg/set"
 	"github.com/minio/minio/cmd/config"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/policy/condition"
 	iampolicy "github.com/minio/minio/pkg/iam/policy"
 	"github.com/minio/minio/pkg/madmin"
 )
@@ -45,6 +47,17 @@ const (
 	LDAPUsersSysType UsersSysType = "LDAPUsersSys"
 )
 
+// IAMUserType - defines the type of a user, which determines where
+// the identity of the user is stored.
+type IAMUserType int
+
+// Types of users stored in the IAM subsystem.
+const (
+	regularUser IAMUserType = iota
+	stsUser
+	srvAccUser
+)
+
 const (
 	// IAM configuration directory.
 	iamConfigPrefix = minioConfigPrefix + "/iam"
@@ -58,6 +71,9 @@ const (
 	// IAM policies directory.
 	iamConfigPoliciesPrefix = iamConfigPrefix + "/policies/"
 
+	// IAM service accounts directory.
+	iamConfigServiceAccountsPrefix = iamConfigPrefix + "/service-accounts/"
+
 	// IAM sts directory.
 	iamConfigSTSPrefix = iamConfigPrefix + "/sts/"
 
@@ -99,10 +115,15 @@ func getIAMFormatFilePath() string {
 	return iamConfigPrefix + SlashSeparator + iamFormatFile
 }
 
-func getUserIdentityPath(user string, isSTS bool) string {
-	basePath := iamConfigUsersPrefix
-	if isSTS {
+func getUserIdentityPath(user string, userType IAMUserType) string {
+	var basePath string
+	switch userType {
+	case srvAccUser:
+		basePath = iamConfigServiceAccountsPrefix
+	case stsUser:
 		basePath = iamConfigSTSPrefix
+	default:
+		basePath = iamConfigUsersPrefix
 	}
 	return pathJoin(basePath, user, iamIdentityFile)
 }
@@ -175,6 +196,9 @@ type IAMSys struct {
 	iamUserPolicyMap map[string]MappedPolicy
 	// map of group names to policy names
 	iamGroupPolicyMap map[string]MappedPolicy
+	// map of LDAP usernames to the groups they are a member of,
+	// synchronized with the LDAP server
+	ldapGroupsMap map[string]ldapSyncedGroups
 
 	// Persistence layer for IAM subsystem
 	store IAMStorageAPI
@@ -187,8 +211,8 @@ type IAMStorageAPI interface {
 	loadPolicyDoc(policy string, m map[string]iampolicy.Policy) error
 	loadPolicyDocs(m map[string]iampolicy.Policy) error
 
-	loadUser(user string, isSTS bool, m map[string]auth.Credentials) error
-	loadUsers(isSTS bool, m map[string]auth.Credentials) error
+	loadUser(user string, userType IAMUserType, m map[string]auth.Credentials) error
+	loadUsers(userType IAMUserType, m map[string]auth.Credentials) error
 
 	loadGroup(group string, m map[string]GroupInfo) error
 	loadGroups(m map[string]GroupInfo) error
@@ -204,12 +228,12 @@ type IAMStorageAPI interface {
 
 	savePolicyDoc(policyName string, p iampolicy.Policy) error
 	saveMappedPolicy(name string, isSTS, isGroup bool, mp MappedPolicy) error
-	saveUserIdentity(name string, isSTS bool, u UserIdentity) error
+	saveUserIdentity(name string, userType IAMUserType, u UserIdentity) error
 	saveGroupInfo(group string, gi GroupInfo) error
 
 	deletePolicyDoc(policyName string) error
 	deleteMappedPolicy(name string, isSTS, isGroup bool) error
-	deleteUserIdentity(name string, isSTS bool) error
+	deleteUserIdentity(name string, userType IAMUserType) error
 	deleteGroupInfo(name string) error
 
 	watch(*IAMSys)
@@ -314,7 +338,11 @@ func (sys *IAMSys) LoadUser(objAPI ObjectLayer, accessKey string, isSTS bool) er
 	}
 
 	if globalEtcdClient == nil {
-		err := sys.store.loadUser(accessKey, isSTS, sys.iamUsersMap)
+		userType := regularUser
+		if isSTS {
+			userType = stsUser
+		}
+		err := sys.store.loadUser(accessKey, userType, sys.iamUsersMap)
 		if err != nil {
 			return err
 		}
@@ -328,6 +356,22 @@ func (sys *IAMSys) LoadUser(objAPI ObjectLayer, accessKey string, isSTS bool) er
 	return nil
 }
 
+// LoadServiceAccount - reloads a specific service account from backend disks or etcd.
+func (sys *IAMSys) LoadServiceAccount(objAPI ObjectLayer, accessKey string) error {
+	sys.Lock()
+	defer sys.Unlock()
+
+	if objAPI == nil || sys.store == nil {
+		return errServerNotInitialized
+	}
+
+	if globalEtcdClient == nil {
+		return sys.store.loadUser(accessKey, srvAccUser, sys.iamUsersMap)
+	}
+	// When etcd is set, we use watch APIs so this code is not needed.
+	return nil
+}
+
 // Load - loads iam subsystem
 func (sys *IAMSys) Load() error {
 	// Pass nil objectlayer here - it will be loaded internally
@@ -522,9 +566,20 @@ func (sys *IAMSys) DeleteUser(accessKey string) error {
 		return errServerNotInitialized
 	}
 
+	// Revoke the service accounts of the user, ignoring
+	// service accounts which are already deleted.
+	for _, cred := range sys.iamUsersMap {
+		if cred.IsServiceAccount() && cred.ParentUser == accessKey {
+			if err := sys.store.deleteUserIdentity(cred.AccessKey, srvAccUser); err != nil && err != errNoSuchUser {
+				return err
+			}
+			delete(sys.iamUsersMap, cred.AccessKey)
+		}
+	}
+
 	// It is ok to ignore deletion error on the mapped policy
 	sys.store.deleteMappedPolicy(accessKey, false, false)
-	err := sys.store.deleteUserIdentity(accessKey, false)
+	err := sys.store.deleteUserIdentity(accessKey, regularUser)
 	switch err.(type) {
 	case ObjectNotFound:
 		// ignore if user is already deleted.
@@ -577,7 +632,211 @@ func (sys *IAMSys) SetTempUser(accessKey string, cred auth.Credentials, policyNa
 	}
 
 	u := newUserIdentity(cred)
-	if err := sys.store.saveUserIdentity(accessKey, true, u); err != nil {
+	if err := sys.store.saveUserIdentity(accessKey, stsUser, u); err != nil {
+		return err
+	}
+
+	sys.iamUsersMap[accessKey] = cred
+	return nil
+}
+
+// ldapSyncedGroups - the groups which an LDAP user is a member of, as
+// synchronized with the LDAP server at the given time.
+type ldapSyncedGroups struct {
+	groups []string
+	synced time.Time
+}
+
+// SetLDAPGroups - sets the groups which the LDAP user is a member of as
+// synchronized with the LDAP server, these are used instead of the groups
+// in the claims of the temporary credentials issued before. Groups are
+// only kept while the synchronization is enabled, which keeps them up to
+// date on every server.
+func (sys *IAMSys) SetLDAPGroups(user string, groups []string) {
+	if !globalLDAPConfig.SyncEnabled() {
+		return
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.ldapGroupsMap[user] = ldapSyncedGroups{groups: groups, synced: UTCNow()}
+}
+
+// pruneLDAPGroups - removes the groups of the LDAP users which are not
+// in the given users anymore.
+func (sys *IAMSys) pruneLDAPGroups(users map[string][]string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	for user := range sys.ldapGroupsMap {
+		if _, ok := users[user]; !ok {
+			delete(sys.ldapGroupsMap, user)
+		}
+	}
+}
+
+// ldapUserGroups - returns the groups which the LDAP user is a member of,
+// as last synchronized with the LDAP server if synchronized after the
+// temporary credentials were issued, or else as in their claims. Must be
+// called with the IAM lock held.
+func (sys *IAMSys) ldapUserGroups(user string, claims map[string]interface{}) ([]string, bool) {
+	if sg, ok := sys.ldapGroupsMap[user]; ok && globalLDAPConfig.SyncEnabled() {
+		// The claims are newer than synchronizations in the
+		// same second, issued is in seconds.
+		if issued, ok := claims[issuedClaim].(float64); ok && sg.synced.Unix() > int64(issued) {
+			return sg.groups, true
+		}
+	}
+	g, ok := claims[ldapGroups].([]interface{})
+	if !ok {
+		return nil, false
+	}
+	var groups []string
+	for _, eachG := range g {
+		if eachGStr, ok := eachG.(string); ok {
+			groups = append(groups, eachGStr)
+		}
+	}
+	return groups, true
+}
+
+// DeleteLDAPUser - revokes the temporary credentials of an LDAP user which
+// was removed from the LDAP server.
+func (sys *IAMSys) DeleteLDAPUser(user string, accessKeys []string) error {
+	sys.Lock()
+	defer sys.Unlock()
+
+	if sys.store == nil {
+		return errServerNotInitialized
+	}
+
+	for _, accessKey := range accessKeys {
+		if err := sys.deleteTempUser(accessKey); err != nil {
+			return err
+		}
+	}
+	delete(sys.ldapGroupsMap, user)
+	return nil
+}
+
+// deleteTempUser - deletes temporary credentials, deleting credentials
+// which don't exist is not an error. Must be called with the IAM lock held.
+func (sys *IAMSys) deleteTempUser(accessKey string) error {
+	// It is ok to ignore deletion error on the mapped policy
+	sys.store.deleteMappedPolicy(accessKey, true, false)
+	if err := sys.store.deleteUserIdentity(accessKey, stsUser); err != nil && err != errNoSuchUser {
+		return err
+	}
+	delete(sys.iamUsersMap, accessKey)
+	delete(sys.iamUserPolicyMap, accessKey)
+	return nil
+}
+
+// NewServiceAccount - creates a new service account of the parent user,
+// the service account inherits the policies of its parent user, which
+// are further restricted by the session policy if set.
+func (sys *IAMSys) NewServiceAccount(parentUser string, sessionPolicy *iampolicy.Policy) (auth.Credentials, error) {
+	objectAPI := newObjectLayerWithoutSafeModeFn()
+	if objectAPI == nil {
+		return auth.Credentials{}, errServerNotInitialized
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	if sys.usersSysType != MinIOUsersSysType {
+		return auth.Credentials{}, errIAMActionNotAllowed
+	}
+
+	if sys.store == nil {
+		return auth.Credentials{}, errServerNotInitialized
+	}
+
+	parent, ok := sys.iamUsersMap[parentUser]
+	if !ok {
+		return auth.Credentials{}, errNoSuchUser
+	}
+
+	// Only long term users can have service accounts.
+	if parent.IsTemp() || parent.IsServiceAccount() {
+		return auth.Credentials{}, errIAMActionNotAllowed
+	}
+
+	cred, err := auth.GetNewCredentials()
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+	cred.ParentUser = parentUser
+	cred.SessionToken, err = newServiceAccountToken(cred.AccessKey, parentUser, sessionPolicy)
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+
+	u := newUserIdentity(cred)
+	if err = sys.store.saveUserIdentity(cred.AccessKey, srvAccUser, u); err != nil {
+		return auth.Credentials{}, err
+	}
+
+	sys.iamUsersMap[cred.AccessKey] = cred
+	return cred, nil
+}
+
+// updateServiceAccountOpts - the changes to a service account, empty
+// values leave the service account unchanged.
+type updateServiceAccountOpts struct {
+	sessionPolicy *iampolicy.Policy
+	secretKey     string
+	status        madmin.AccountStatus
+}
+
+// UpdateServiceAccount - updates the session policy, the secret key or
+// the status of a service account.
+func (sys *IAMSys) UpdateServiceAccount(accessKey string, opts updateServiceAccountOpts) error {
+	objectAPI := newObjectLayerWithoutSafeModeFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	if sys.store == nil {
+		return errServerNotInitialized
+	}
+
+	cred, ok := sys.iamUsersMap[accessKey]
+	if !ok || !cred.IsServiceAccount() {
+		return errNoSuchServiceAccount
+	}
+
+	if opts.secretKey != "" {
+		if !auth.IsSecretKeyValid(opts.secretKey) {
+			return auth.ErrInvalidSecretKeyLength
+		}
+		cred.SecretKey = opts.secretKey
+	}
+
+	switch opts.status {
+	case "":
+	case madmin.AccountEnabled:
+		cred.Status = config.EnableOn
+	case madmin.AccountDisabled:
+		cred.Status = config.EnableOff
+	default:
+		return errInvalidArgument
+	}
+
+	if opts.sessionPolicy != nil {
+		token, err := newServiceAccountToken(accessKey, cred.ParentUser, opts.sessionPolicy)
+		if err != nil {
+			return err
+		}
+		cred.SessionToken = token
+	}
+
+	u := newUserIdentity(cred)
+	if err := sys.store.saveUserIdentity(accessKey, srvAccUser, u); err != nil {
 		return err
 	}
 
@@ -585,6 +844,77 @@ func (sys *IAMSys) SetTempUser(accessKey string, cred auth.Credentials, policyNa
 	return nil
 }
 
+// ListServiceAccounts - lists the service accounts of the parent user.
+func (sys *IAMSys) ListServiceAccounts(parentUser string) ([]string, error) {
+	objectAPI := newObjectLayerWithoutSafeModeFn()
+	if objectAPI == nil {
+		return nil, errServerNotInitialized
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+
+	if sys.usersSysType != MinIOUsersSysType {
+		return nil, errIAMActionNotAllowed
+	}
+
+	serviceAccounts := []string{}
+	for k, v := range sys.iamUsersMap {
+		if v.IsServiceAccount() && v.ParentUser == parentUser {
+			serviceAccounts = append(serviceAccounts, k)
+		}
+	}
+
+	return serviceAccounts, nil
+}
+
+// GetServiceAccount - gets the credentials of a service account.
+func (sys *IAMSys) GetServiceAccount(accessKey string) (auth.Credentials, error) {
+	objectAPI := newObjectLayerWithoutSafeModeFn()
+	if objectAPI == nil {
+		return auth.Credentials{}, errServerNotInitialized
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+
+	cred, ok := sys.iamUsersMap[accessKey]
+	if !ok || !cred.IsServiceAccount() {
+		return auth.Credentials{}, errNoSuchServiceAccount
+	}
+
+	return cred, nil
+}
+
+// DeleteServiceAccount - deletes a service account, deleting a service
+// account which doesn't exist is not an error.
+func (sys *IAMSys) DeleteServiceAccount(accessKey string) error {
+	objectAPI := newObjectLayerWithoutSafeModeFn()
+	if objectAPI == nil {
+		return errServerNotInitialized
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	cred, ok := sys.iamUsersMap[accessKey]
+	if ok && !cred.IsServiceAccount() {
+		return errNoSuchServiceAccount
+	}
+
+	if sys.store == nil {
+		return errServerNotInitialized
+	}
+
+	err := sys.store.deleteUserIdentity(accessKey, srvAccUser)
+	if err != nil && err != errNoSuchUser {
+		return err
+	}
+
+	delete(sys.iamUsersMap, accessKey)
+	return nil
+}
+
 // ListUsers - list all users.
 func (sys *IAMSys) ListUsers() (map[string]madmin.UserInfo, error) {
 	objectAPI := newObjectLayerWithoutSafeModeFn()
@@ -602,7 +932,7 @@ func (sys *IAMSys) ListUsers() (map[string]madmin.UserInfo, error) {
 	}
 
 	for k, v := range sys.iamUsersMap {
-		if !v.IsTemp() {
+		if !v.IsTemp() && !v.IsServiceAccount() {
 			users[k] = madmin.UserInfo{
 				PolicyName: sys.iamUserPolicyMap[k].Policy,
 				Status: func() madmin.AccountStatus {
@@ -658,7 +988,7 @@ func (sys *IAMSys) GetUserInfo(name string) (u madmin.UserInfo, err error) {
 		return u, errNoSuchUser
 	}
 
-	if creds.IsTemp() {
+	if creds.IsTemp() || creds.IsServiceAccount() {
 		return u, errIAMActionNotAllowed
 	}
 
@@ -698,7 +1028,7 @@ func (sys *IAMSys) SetUserStatus(accessKey string, status madmin.AccountStatus)
 		return errNoSuchUser
 	}
 
-	if cred.IsTemp() {
+	if cred.IsTemp() || cred.IsServiceAccount() {
 		return errIAMActionNotAllowed
 	}
 
@@ -717,7 +1047,7 @@ func (sys *IAMSys) SetUserStatus(accessKey string, status madmin.AccountStatus)
 		return errServerNotInitialized
 	}
 
-	if err := sys.store.saveUserIdentity(accessKey, false, uinfo); err != nil {
+	if err := sys.store.saveUserIdentity(accessKey, regularUser, uinfo); err != nil {
 		return err
 	}
 
@@ -750,11 +1080,11 @@ func (sys *IAMSys) SetUser(accessKey string, uinfo madmin.UserInfo) error {
 	}
 
 	cr, ok := sys.iamUsersMap[accessKey]
-	if cr.IsTemp() && ok {
+	if (cr.IsTemp() || cr.IsServiceAccount()) && ok {
 		return errIAMActionNotAllowed
 	}
 
-	if err := sys.store.saveUserIdentity(accessKey, false, u); err != nil {
+	if err := sys.store.saveUserIdentity(accessKey, regularUser, u); err != nil {
 		return err
 	}
 
@@ -786,13 +1116,17 @@ func (sys *IAMSys) SetUserSecretKey(accessKey string, secretKey string) error {
 		return errNoSuchUser
 	}
 
+	if cred.IsTemp() || cred.IsServiceAccount() {
+		return errIAMActionNotAllowed
+	}
+
 	if sys.store == nil {
 		return errServerNotInitialized
 	}
 
 	cred.SecretKey = secretKey
 	u := newUserIdentity(cred)
-	if err := sys.store.saveUserIdentity(accessKey, false, u); err != nil {
+	if err := sys.store.saveUserIdentity(accessKey, regularUser, u); err != nil {
 		return err
 	}
 
@@ -806,6 +1140,15 @@ func (sys *IAMSys) GetUser(accessKey string) (cred auth.Credentials, ok bool) {
 	defer sys.RUnlock()
 
 	cred, ok = sys.iamUsersMap[accessKey]
+	if ok && cred.IsServiceAccount() {
+		// Service accounts are revoked along with their
+		// parent user, disabling the parent user also
+		// disables its service accounts.
+		parent, found := sys.iamUsersMap[cred.ParentUser]
+		if !found || !parent.IsValid() {
+			return cred, false
+		}
+	}
 	return cred, ok && cred.IsValid()
 }
 
@@ -834,7 +1177,7 @@ func (sys *IAMSys) AddUsersToGroup(group string, members []string) error {
 		if !ok {
 			return errNoSuchUser
 		}
-		if cr.IsTemp() {
+		if cr.IsTemp() || cr.IsServiceAccount() {
 			return errIAMActionNotAllowed
 		}
 	}
@@ -899,7 +1242,7 @@ func (sys *IAMSys) RemoveUsersFromGroup(group string, members []string) error {
 		if !ok {
 			return errNoSuchUser
 		}
-		if cr.IsTemp() {
+		if cr.IsTemp() || cr.IsServiceAccount() {
 			return errIAMActionNotAllowed
 		}
 	}
@@ -1087,9 +1430,15 @@ func (sys *IAMSys) policyDBSet(name, policy string, isSTS, isGroup bool) error {
 
 	if sys.usersSysType == MinIOUsersSysType {
 		if !isGroup {
-			if _, ok := sys.iamUsersMap[name]; !ok {
+			cred, ok := sys.iamUsersMap[name]
+			if !ok {
 				return errNoSuchUser
 			}
+			if cred.IsServiceAccount() {
+				// Service accounts inherit the policies
+				// of their parent user.
+				return errIAMActionNotAllowed
+			}
 		} else {
 			if _, ok := sys.iamGroupsMap[name]; !ok {
 				return errNoSuchGroup
@@ -1166,6 +1515,9 @@ var iamAccountOtherAccessActions = iampolicy.NewActionSet(
 
 	iampolicy.PutBucketEncryptionAction,
 	iampolicy.GetBucketEncryptionAction,
+
+	iampolicy.PutBucketVersioningAction,
+	iampolicy.GetBucketVersioningAction,
 )
 
 // GetAccountAccess iterates over all policies documents associated to a user
@@ -1215,13 +1567,13 @@ func (sys *IAMSys) GetAccountAccess(accountName, bucket string) (rd, wr, o bool)
 			rd, wr, o = true, true, true
 			break
 		}
-		if !st.Actions.Intersection(iamAccountReadAccessActions).IsEmpty() {
+		if !statementActions(st, iamAccountReadAccessActions).IsEmpty() {
 			rd = true
 		}
-		if !st.Actions.Intersection(iamAccountWriteAccessActions).IsEmpty() {
+		if !statementActions(st, iamAccountWriteAccessActions).IsEmpty() {
 			wr = true
 		}
-		if !st.Actions.Intersection(iamAccountOtherAccessActions).IsEmpty() {
+		if !statementActions(st, iamAccountOtherAccessActions).IsEmpty() {
 			o = true
 		}
 	}
@@ -1229,6 +1581,22 @@ func (sys *IAMSys) GetAccountAccess(accountName, bucket string) (rd, wr, o bool)
 	return
 }
 
+// statementActions - returns the actions of the given set allowed by the
+// statement, a statement with NotAction allows all actions it doesn't list.
+func statementActions(st iampolicy.Statement, actions iampolicy.ActionSet) iampolicy.ActionSet {
+	if st.NotActions.IsEmpty() {
+		return st.Actions.Intersection(actions)
+	}
+
+	allowed := iampolicy.NewActionSet()
+	for action := range actions {
+		if !st.NotActions.Match(action) {
+			allowed.Add(action)
+		}
+	}
+	return allowed
+}
+
 // PolicyDBGet - gets policy set on a user or group. Since a user may
 // be a member of multiple groups, this function returns an array of
 // applicable policies (each group is mapped to at most one policy).
@@ -1308,21 +1676,14 @@ func (sys *IAMSys) IsAllowedSTS(args iampolicy.Args) bool {
 			return false
 		}
 
-		var groups []string
-		groupsVal := args.Claims[ldapGroups]
-		if g, ok := groupsVal.([]interface{}); ok {
-			for _, eachG := range g {
-				if eachGStr, ok := eachG.(string); ok {
-					groups = append(groups, eachGStr)
-				}
-			}
-		} else {
-			return false
-		}
-
 		sys.RLock()
 		defer sys.RUnlock()
 
+		groups, ok := sys.ldapUserGroups(user, args.Claims)
+		if !ok {
+			return false
+		}
+
 		// We look up the policy mapping directly to bypass
 		// users exists, group exists validations that do not
 		// apply here.
@@ -1418,6 +1779,89 @@ func (sys *IAMSys) IsAllowedSTS(args iampolicy.Args) bool {
 	return ok && p.IsAllowed(args)
 }
 
+// IsAllowedServiceAccount - checks if the given service account is allowed
+// to continue the Rest API. Service accounts are allowed what their parent
+// user is allowed, restricted by their session policy if they have one.
+func (sys *IAMSys) IsAllowedServiceAccount(args iampolicy.Args, cred auth.Credentials) bool {
+	parentUser, sessionPolicy, err := parseServiceAccountToken(cred.SessionToken)
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return false
+	}
+
+	// The session token must have been issued for the parent user.
+	if parentUser != cred.ParentUser {
+		return false
+	}
+
+	if !sys.isAllowedByUserPolicies(parentUser, args) {
+		return false
+	}
+
+	// Session policy not set, the service account inherits the
+	// policies of its parent user.
+	if sessionPolicy == nil {
+		return true
+	}
+
+	return sessionPolicy.IsAllowed(args)
+}
+
+// hasConditionKey - returns whether any of the canned policies, or the
+// session policy of the given credentials, has conditions on given
+// condition key.
+func (sys *IAMSys) hasConditionKey(key condition.Key, cred auth.Credentials, claims map[string]interface{}) bool {
+	if p := getSessionPolicy(cred, claims); p != nil && policyHasConditionKey(*p, key) {
+		return true
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+
+	for _, p := range sys.iamPolicyDocsMap {
+		if policyHasConditionKey(p, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getSessionPolicy - returns the session policy of the temporary
+// credentials or of the service account, if any.
+func getSessionPolicy(cred auth.Credentials, claims map[string]interface{}) *iampolicy.Policy {
+	if cred.IsServiceAccount() {
+		_, sessionPolicy, err := parseServiceAccountToken(cred.SessionToken)
+		if err != nil {
+			return nil
+		}
+		return sessionPolicy
+	}
+
+	// Session policies of STS credentials are decoded
+	// when the claims are checked.
+	spolicyStr, ok := claims[iampolicy.SessionPolicyName].(string)
+	if !ok {
+		return nil
+	}
+	sessionPolicy, err := iampolicy.ParseConfig(bytes.NewReader([]byte(spolicyStr)))
+	if err != nil {
+		return nil
+	}
+	return sessionPolicy
+}
+
+// policyHasConditionKey - returns whether the policy has conditions
+// on given condition key.
+func policyHasConditionKey(p iampolicy.Policy, key condition.Key) bool {
+	for _, statement := range p.Statements {
+		if statement.Conditions.Keys().Match(key) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsAllowed - checks given policy args is allowed to continue the Rest API.
 func (sys *IAMSys) IsAllowed(args iampolicy.Args) bool {
 	// If opa is configured, use OPA always.
@@ -1434,6 +1878,13 @@ func (sys *IAMSys) IsAllowed(args iampolicy.Args) bool {
 		return true
 	}
 
+	// If the credential is a service account, check the policies
+	// of its parent user and its session policy.
+	svcCred, err := sys.GetServiceAccount(args.AccountName)
+	if err == nil {
+		return sys.IsAllowedServiceAccount(args, svcCred)
+	}
+
 	// If the credential is temporary, perform STS related checks.
 	ok, err := sys.IsTempUser(args.AccountName)
 	if err != nil {
@@ -1444,7 +1895,13 @@ func (sys *IAMSys) IsAllowed(args iampolicy.Args) bool {
 		return sys.IsAllowedSTS(args)
 	}
 
-	policies, err := sys.PolicyDBGet(args.AccountName, false)
+	return sys.isAllowedByUserPolicies(args.AccountName, args)
+}
+
+// isAllowedByUserPolicies - evaluates the policies of the user and of
+// the groups the user is a member of.
+func (sys *IAMSys) isAllowedByUserPolicies(user string, args iampolicy.Args) bool {
+	policies, err := sys.PolicyDBGet(user, false)
 	if err != nil {
 		logger.LogIf(context.Background(), err)
 		return false
@@ -1477,6 +1934,169 @@ func (sys *IAMSys) IsAllowed(args iampolicy.Args) bool {
 	return combinedPolicy.IsAllowed(args)
 }
 
+// simulatedPolicy - a policy evaluated for a simulated request, along
+// with where the policy comes from.
+type simulatedPolicy struct {
+	source     madmin.PolicySource
+	policyName string
+	groupName  string
+	policy     iampolicy.Policy
+}
+
+// SimulatePolicy - evaluates given policy args like IsAllowed and also
+// returns the statements of the policies which apply to them.
+func (sys *IAMSys) SimulatePolicy(args iampolicy.Args) madmin.PolicySimulationResult {
+	result := madmin.PolicySimulationResult{Allowed: sys.IsAllowed(args)}
+
+	if globalPolicyOPA != nil {
+		result.DecidedBy = madmin.PolicySourceOPA
+		return result
+	}
+
+	if args.IsOwner {
+		result.DecidedBy = madmin.PolicySourceOwner
+		return result
+	}
+
+	var policies []simulatedPolicy
+	if svcCred, err := sys.GetServiceAccount(args.AccountName); err == nil {
+		parentUser, sessionPolicy, err := parseServiceAccountToken(svcCred.SessionToken)
+		if err != nil {
+			logger.LogIf(context.Background(), err)
+			return result
+		}
+		policies = sys.simulatedUserPolicies(parentUser)
+		if sessionPolicy != nil {
+			policies = append(policies, simulatedPolicy{
+				source: madmin.PolicySourceSession,
+				policy: *sessionPolicy,
+			})
+		}
+	} else if ok, _ := sys.IsTempUser(args.AccountName); ok {
+		policies = sys.simulatedSTSPolicies(args)
+	} else {
+		policies = sys.simulatedUserPolicies(args.AccountName)
+	}
+
+	for _, sp := range policies {
+		for _, i := range sp.policy.MatchedStatements(args) {
+			data, err := json.Marshal(sp.policy.Statements[i])
+			if err != nil {
+				logger.LogIf(context.Background(), err)
+				continue
+			}
+			result.Statements = append(result.Statements, madmin.PolicySimulationStatement{
+				Source:     sp.source,
+				PolicyName: sp.policyName,
+				GroupName:  sp.groupName,
+				Index:      i,
+				Effect:     string(sp.policy.Statements[i].Effect),
+				Statement:  data,
+			})
+		}
+	}
+	return result
+}
+
+// simulatedUserPolicies - returns the policies evaluated for the user by
+// isAllowedByUserPolicies.
+func (sys *IAMSys) simulatedUserPolicies(user string) (policies []simulatedPolicy) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	if u, ok := sys.iamUsersMap[user]; !ok || u.Status == statusDisabled {
+		return nil
+	}
+
+	if mp, ok := sys.iamUserPolicyMap[user]; ok {
+		if p, found := sys.iamPolicyDocsMap[mp.Policy]; found {
+			policies = append(policies, simulatedPolicy{
+				source:     madmin.PolicySourceUser,
+				policyName: mp.Policy,
+				policy:     p,
+			})
+		}
+	}
+	for _, group := range sys.iamUserGroupMemberships[user].ToSlice() {
+		// Skip missing or disabled groups
+		gi, ok := sys.iamGroupsMap[group]
+		if !ok || gi.Status == statusDisabled {
+			continue
+		}
+		mp, ok := sys.iamGroupPolicyMap[group]
+		if !ok {
+			continue
+		}
+		if p, found := sys.iamPolicyDocsMap[mp.Policy]; found {
+			policies = append(policies, simulatedPolicy{
+				source:     madmin.PolicySourceGroup,
+				policyName: mp.Policy,
+				groupName:  group,
+				policy:     p,
+			})
+		}
+	}
+	return policies
+}
+
+// simulatedSTSPolicies - returns the policies evaluated for temporary
+// credentials by IsAllowedSTS.
+func (sys *IAMSys) simulatedSTSPolicies(args iampolicy.Args) (policies []simulatedPolicy) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	if userIface, ok := args.Claims[ldapUser]; ok {
+		user, _ := userIface.(string)
+		if mp, ok := sys.iamUserPolicyMap[user]; ok {
+			if p, found := sys.iamPolicyDocsMap[mp.Policy]; found {
+				policies = append(policies, simulatedPolicy{
+					source:     madmin.PolicySourceUser,
+					policyName: mp.Policy,
+					policy:     p,
+				})
+			}
+		}
+		groups, _ := sys.ldapUserGroups(user, args.Claims)
+		for _, group := range groups {
+			mp, ok := sys.iamGroupPolicyMap[group]
+			if !ok {
+				continue
+			}
+			if p, found := sys.iamPolicyDocsMap[mp.Policy]; found {
+				policies = append(policies, simulatedPolicy{
+					source:     madmin.PolicySourceGroup,
+					policyName: mp.Policy,
+					groupName:  group,
+					policy:     p,
+				})
+			}
+		}
+		return policies
+	}
+
+	mp, ok := sys.iamUserPolicyMap[args.AccountName]
+	if !ok {
+		return nil
+	}
+	if p, found := sys.iamPolicyDocsMap[mp.Policy]; found {
+		policies = append(policies, simulatedPolicy{
+			source:     madmin.PolicySourceUser,
+			policyName: mp.Policy,
+			policy:     p,
+		})
+	}
+	if spolicyStr, ok := args.Claims[iampolicy.SessionPolicyName].(string); ok {
+		subPolicy, err := iampolicy.ParseConfig(bytes.NewReader([]byte(spolicyStr)))
+		if err == nil {
+			policies = append(policies, simulatedPolicy{
+				source: madmin.PolicySourceSession,
+				policy: *subPolicy,
+			})
+		}
+	}
+	return policies
+}
+
 // Set default canned policies only if not already overridden by users.
 func setDefaultCannedPolicies(policies map[string]iampolicy.Policy) {
 	_, ok := policies["writeonly"]
@@ -1555,5 +2175,6 @@ func NewIAMSys() *IAMSys {
 		iamUserPolicyMap:        make(map[string]MappedPolicy),
 		iamGroupsMap:            make(map[string]GroupInfo),
 		iamUserGroupMemberships: make(map[string]set.StringSet),
+		ldapGroupsMap:           make(map[string]ldapSyncedGroups),
 	}
 }
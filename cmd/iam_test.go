@@ -0,0 +1,304 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	xldap "github.com/minio/minio/cmd/config/identity/ldap"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/policy/condition"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestIAMSysServiceAccounts(t *testing.T) {
+	atb, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer atb.TearDown()
+
+	if err = globalIAMSys.SetUser("alice", madmin.UserInfo{SecretKey: "alice-secret-key", Status: madmin.AccountEnabled}); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.PolicyDBSet("alice", "readwrite", false); err != nil {
+		t.Fatal(err)
+	}
+
+	sessionPolicy, err := iampolicy.ParseConfig(strings.NewReader(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::mybucket/*"]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cred, err := globalIAMSys.NewServiceAccount("alice", sessionPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cred.IsServiceAccount() || cred.ParentUser != "alice" {
+		t.Fatalf("Expected a service account of alice, got %v", cred)
+	}
+
+	// Service accounts need an existing long term parent user.
+	if _, err = globalIAMSys.NewServiceAccount("bob", nil); err != errNoSuchUser {
+		t.Errorf("Expected %v, got %v", errNoSuchUser, err)
+	}
+	if _, err = globalIAMSys.NewServiceAccount(cred.AccessKey, nil); err != errIAMActionNotAllowed {
+		t.Errorf("Expected %v, got %v", errIAMActionNotAllowed, err)
+	}
+
+	accounts, err := globalIAMSys.ListServiceAccounts("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 1 || accounts[0] != cred.AccessKey {
+		t.Errorf("Expected service accounts [%s], got %v", cred.AccessKey, accounts)
+	}
+	users, err := globalIAMSys.ListUsers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := users[cred.AccessKey]; ok {
+		t.Errorf("Expected service accounts not to be listed as users")
+	}
+
+	// Service accounts are restricted by their session policy.
+	isAllowed := func(action iampolicy.Action) bool {
+		return globalIAMSys.IsAllowed(iampolicy.Args{
+			AccountName:     cred.AccessKey,
+			Action:          action,
+			BucketName:      "mybucket",
+			ObjectName:      "myobject",
+			ConditionValues: map[string][]string{},
+		})
+	}
+	if !isAllowed(iampolicy.GetObjectAction) {
+		t.Errorf("Expected the service account to be allowed GetObject")
+	}
+	if isAllowed(iampolicy.PutObjectAction) {
+		t.Errorf("Expected the service account to be denied PutObject")
+	}
+
+	// Service accounts are persisted.
+	iamSys := NewIAMSys()
+	if err = iamSys.Init(atb.objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = iamSys.GetServiceAccount(cred.AccessKey); err != nil {
+		t.Errorf("Expected the service account to be loaded, got %v", err)
+	}
+
+	// Updates of the service account.
+	if err = globalIAMSys.UpdateServiceAccount(cred.AccessKey, updateServiceAccountOpts{status: madmin.AccountDisabled}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := globalIAMSys.GetUser(cred.AccessKey); ok {
+		t.Errorf("Expected the disabled service account to be denied")
+	}
+	if err = globalIAMSys.UpdateServiceAccount(cred.AccessKey, updateServiceAccountOpts{
+		status:    madmin.AccountEnabled,
+		secretKey: "new-secret-key",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if svcCred, ok := globalIAMSys.GetUser(cred.AccessKey); !ok || svcCred.SecretKey != "new-secret-key" {
+		t.Errorf("Expected the enabled service account with its new secret key, got %v", svcCred)
+	}
+	if err = globalIAMSys.UpdateServiceAccount("alice", updateServiceAccountOpts{status: madmin.AccountDisabled}); err != errNoSuchServiceAccount {
+		t.Errorf("Expected %v, got %v", errNoSuchServiceAccount, err)
+	}
+
+	// Disabling the parent user revokes its service accounts.
+	if err = globalIAMSys.SetUserStatus("alice", madmin.AccountDisabled); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := globalIAMSys.GetUser(cred.AccessKey); ok {
+		t.Errorf("Expected the service account of a disabled user to be denied")
+	}
+	if err = globalIAMSys.SetUserStatus("alice", madmin.AccountEnabled); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := globalIAMSys.GetUser(cred.AccessKey); !ok {
+		t.Errorf("Expected the service account of an enabled user to be allowed")
+	}
+
+	other, err := globalIAMSys.NewServiceAccount("alice", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Deleting a service account.
+	if err = globalIAMSys.DeleteServiceAccount(cred.AccessKey); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = globalIAMSys.GetServiceAccount(cred.AccessKey); err != errNoSuchServiceAccount {
+		t.Errorf("Expected %v, got %v", errNoSuchServiceAccount, err)
+	}
+	if err = globalIAMSys.DeleteServiceAccount(cred.AccessKey); err != nil {
+		t.Errorf("Expected deleting a deleted service account to succeed, got %v", err)
+	}
+	if err = globalIAMSys.DeleteServiceAccount("alice"); err != errNoSuchServiceAccount {
+		t.Errorf("Expected %v, got %v", errNoSuchServiceAccount, err)
+	}
+
+	// Removing the parent user removes its service accounts.
+	if err = globalIAMSys.DeleteUser("alice"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := globalIAMSys.GetUser(other.AccessKey); ok {
+		t.Errorf("Expected the service account of a removed user to be denied")
+	}
+	if _, err = globalIAMSys.GetServiceAccount(other.AccessKey); err != errNoSuchServiceAccount {
+		t.Errorf("Expected %v, got %v", errNoSuchServiceAccount, err)
+	}
+	iamSys = NewIAMSys()
+	if err = iamSys.Init(atb.objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = iamSys.GetServiceAccount(other.AccessKey); err != errNoSuchServiceAccount {
+		t.Errorf("Expected the service account of a removed user not to be loaded, got %v", err)
+	}
+}
+
+func TestIAMSysHasConditionKey(t *testing.T) {
+	cred, err := auth.GetNewCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(cred auth.Credentials) { globalActiveCred = cred }(globalActiveCred)
+	globalActiveCred = cred
+
+	sessionPolicy, err := iampolicy.ParseConfig(strings.NewReader(`{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::mybucket/*"],` +
+		`"Condition":{"StringEquals":{"s3:ExistingObjectTag/classification":["private"]}}}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	svcCred, err := auth.GetNewCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	svcCred.ParentUser = "alice"
+	if svcCred.SessionToken, err = newServiceAccountToken(svcCred.AccessKey, "alice", sessionPolicy); err != nil {
+		t.Fatal(err)
+	}
+	policyBuf, err := json.Marshal(sessionPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		cred           auth.Credentials
+		claims         map[string]interface{}
+		expectedResult bool
+	}{
+		// Session policy of a service account.
+		{svcCred, nil, true},
+		// Session policy of temporary credentials.
+		{auth.Credentials{}, map[string]interface{}{iampolicy.SessionPolicyName: string(policyBuf)}, true},
+		{auth.Credentials{}, map[string]interface{}{}, false},
+	}
+
+	sys := NewIAMSys()
+	for i, testCase := range testCases {
+		if result := sys.hasConditionKey(condition.S3ExistingObjectTag, testCase.cred, testCase.claims); result != testCase.expectedResult {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestIAMSysPruneLDAPGroups(t *testing.T) {
+	defer func(cfg xldap.Config) { globalLDAPConfig = cfg }(globalLDAPConfig)
+	globalLDAPConfig = xldap.Config{Enabled: true, LookupBindDN: "cn=admin,dc=min,dc=io"}
+
+	sys := NewIAMSys()
+	sys.SetLDAPGroups("alice", []string{"dev"})
+	sys.SetLDAPGroups("bob", []string{"ops"})
+
+	// bob doesn't hold temporary credentials anymore.
+	sys.pruneLDAPGroups(map[string][]string{"alice": {"ACCESSKEY"}})
+
+	claims := map[string]interface{}{issuedClaim: float64(UTCNow().Add(-time.Hour).Unix())}
+	if groups, ok := sys.ldapUserGroups("alice", claims); !ok || len(groups) != 1 || groups[0] != "dev" {
+		t.Errorf("Expected the groups of alice to be kept, got %v", groups)
+	}
+	if _, ok := sys.ldapGroupsMap["bob"]; ok {
+		t.Errorf("Expected the groups of bob to be pruned")
+	}
+}
+
+func TestIAMSysLDAPUserGroups(t *testing.T) {
+	defer func(cfg xldap.Config) { globalLDAPConfig = cfg }(globalLDAPConfig)
+	globalLDAPConfig = xldap.Config{Enabled: true, LookupBindDN: "cn=admin,dc=min,dc=io"}
+
+	sys := NewIAMSys()
+	sys.SetLDAPGroups("alice", []string{"dev", "ops"})
+
+	ldapClaims := func(issued time.Time, groups ...string) map[string]interface{} {
+		var g []interface{}
+		for _, group := range groups {
+			g = append(g, group)
+		}
+		return map[string]interface{}{
+			ldapUser:    "alice",
+			ldapGroups:  g,
+			issuedClaim: float64(issued.Unix()),
+		}
+	}
+
+	// alice was removed from the ops group and logged in again,
+	// on another server after the last synchronization.
+	staleLogin := ldapClaims(UTCNow().Add(-time.Hour), "dev", "ops")
+	newLogin := ldapClaims(UTCNow().Add(time.Hour), "dev")
+
+	testCases := []struct {
+		claims         map[string]interface{}
+		syncEnabled    bool
+		expectedGroups []string
+	}{
+		// Groups synchronized after the login are used.
+		{ldapClaims(UTCNow().Add(-time.Hour), "ops"), true, []string{"dev", "ops"}},
+		// Groups of logins after the synchronization are newer.
+		{newLogin, true, []string{"dev"}},
+		{staleLogin, true, []string{"dev", "ops"}},
+		// Synchronized groups are ignored without synchronization.
+		{newLogin, false, []string{"dev"}},
+		{staleLogin, false, []string{"dev", "ops"}},
+	}
+
+	for i, testCase := range testCases {
+		globalLDAPConfig.LookupBindDN = ""
+		if testCase.syncEnabled {
+			globalLDAPConfig.LookupBindDN = "cn=admin,dc=min,dc=io"
+		}
+		groups, ok := sys.ldapUserGroups("alice", testCase.claims)
+		if !ok || !reflect.DeepEqual(groups, testCase.expectedGroups) {
+			t.Errorf("Test %d: expected groups %v, got %v", i+1, testCase.expectedGroups, groups)
+		}
+	}
+
+	// Groups are not kept without synchronization, they would
+	// never be updated.
+	sys = NewIAMSys()
+	sys.SetLDAPGroups("alice", []string{"dev"})
+	if _, ok := sys.ldapGroupsMap["alice"]; ok {
+		t.Error("Expected the groups not to be kept without synchronization")
+	}
+}
@@ -17,7 +17,10 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"time"
@@ -27,6 +30,7 @@ import (
 	xjwt "github.com/minio/minio/cmd/jwt"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
 )
 
 const (
@@ -167,3 +171,52 @@ func newAuthToken(audience string) string {
 	logger.CriticalIf(context.Background(), err)
 	return token
 }
+
+// newServiceAccountToken - returns the session token of a service account,
+// carrying its parent user and its optional session policy. Just like the
+// tokens of temporary credentials it is signed with the admin secret key.
+func newServiceAccountToken(accessKey, parentUser string, sessionPolicy *iampolicy.Policy) (string, error) {
+	claims := xjwt.NewMapClaims()
+	claims.SetAccessKey(accessKey)
+	claims.MapClaims[parentClaim] = parentUser
+	if sessionPolicy != nil {
+		policyBuf, err := json.Marshal(sessionPolicy)
+		if err != nil {
+			return "", err
+		}
+		claims.MapClaims[iampolicy.SessionPolicyName] = base64.StdEncoding.EncodeToString(policyBuf)
+	}
+
+	jwt := jwtgo.NewWithClaims(jwtgo.SigningMethodHS512, claims)
+	return jwt.SignedString([]byte(globalActiveCred.SecretKey))
+}
+
+// parseServiceAccountToken - returns the parent user and the session
+// policy, if any, carried by the session token of a service account.
+func parseServiceAccountToken(token string) (string, *iampolicy.Policy, error) {
+	claims := xjwt.NewMapClaims()
+	if err := xjwt.ParseWithClaims(token, claims, func(*xjwt.MapClaims) ([]byte, error) {
+		return []byte(globalActiveCred.SecretKey), nil
+	}); err != nil {
+		return "", nil, err
+	}
+
+	parentUser, ok := claims.Lookup(parentClaim)
+	if !ok {
+		return "", nil, errAuthentication
+	}
+
+	sp, ok := claims.Lookup(iampolicy.SessionPolicyName)
+	if !ok {
+		return parentUser, nil, nil
+	}
+	spBytes, err := base64.StdEncoding.DecodeString(sp)
+	if err != nil {
+		return "", nil, err
+	}
+	sessionPolicy, err := iampolicy.ParseConfig(bytes.NewReader(spBytes))
+	if err != nil {
+		return "", nil, err
+	}
+	return parentUser, sessionPolicy, nil
+}
@@ -130,7 +130,25 @@ func AddAuditTarget(t Target) {
 	AuditTargets = append(AuditTargets, t)
 }
 
-// AuditLog - logs audit logs to all audit targets.
+// AccessLogger - logs requests on buckets into their server access logs.
+type AccessLogger interface {
+	// Enabled returns true if requests on the bucket are logged.
+	Enabled(bucket string) bool
+	// Send logs the audit entry of a request on the bucket.
+	Send(r *http.Request, entry audit.Entry)
+}
+
+// accessLogger is the registered server access logger, if any.
+var accessLogger AccessLogger
+
+// RegisterAccessLogger registers the server access logger, requests on
+// buckets with access logging enabled are sent to it by AuditLog.
+func RegisterAccessLogger(l AccessLogger) {
+	accessLogger = l
+}
+
+// AuditLog - logs audit logs to all audit targets, and to the
+// server access logger if access logging is enabled on the bucket.
 func AuditLog(w http.ResponseWriter, r *http.Request, api string, reqClaims map[string]interface{}) {
 	var statusCode int
 	var timeToResponse time.Duration
@@ -149,16 +167,26 @@ func AuditLog(w http.ResponseWriter, r *http.Request, api string, reqClaims map[
 		object = vars["object"]
 	}
 
+	logAccess := accessLogger != nil && bucket != "" && accessLogger.Enabled(bucket)
+	if len(AuditTargets) == 0 && !logAccess {
+		return
+	}
+
+	entry := audit.ToEntry(w, r, reqClaims, globalDeploymentID)
+	entry.API.Name = api
+	entry.API.Bucket = bucket
+	entry.API.Object = object
+	entry.API.Status = http.StatusText(statusCode)
+	entry.API.StatusCode = statusCode
+	entry.API.TimeToFirstByte = timeToFirstByte.String()
+	entry.API.TimeToResponse = timeToResponse.String()
+
 	// Send audit logs only to http targets.
 	for _, t := range AuditTargets {
-		entry := audit.ToEntry(w, r, reqClaims, globalDeploymentID)
-		entry.API.Name = api
-		entry.API.Bucket = bucket
-		entry.API.Object = object
-		entry.API.Status = http.StatusText(statusCode)
-		entry.API.StatusCode = statusCode
-		entry.API.TimeToFirstByte = timeToFirstByte.String()
-		entry.API.TimeToResponse = timeToResponse.String()
 		_ = t.Send(entry, string(All))
 	}
+
+	if logAccess {
+		accessLogger.Send(r, entry)
+	}
 }
@@ -19,6 +19,7 @@ package cmd
 import (
 	"context"
 	"net/http"
+	"sync/atomic"
 
 	"github.com/minio/minio/cmd/logger"
 	"github.com/prometheus/client_golang/prometheus"
@@ -258,6 +259,34 @@ func (c *minioCollector) Collect(ch chan<- prometheus.Metric) {
 		)
 	}
 
+	// Bitrot scan related metrics
+	if globalIsXL && globalBitrotScanConfig.Enabled {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("bitrot", "shards", "verified_total"),
+				"Total number of object shards verified by the bitrot scans of current MinIO server instance",
+				nil, nil),
+			prometheus.CounterValue,
+			float64(atomic.LoadUint64(&globalBitrotScanStats.verifiedShards)),
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("bitrot", "shards", "corrupt_total"),
+				"Total number of corrupt object shards found by the bitrot scans of current MinIO server instance",
+				nil, nil),
+			prometheus.CounterValue,
+			float64(atomic.LoadUint64(&globalBitrotScanStats.corruptShards)),
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("bitrot", "shards", "healed_total"),
+				"Total number of corrupt object shards healed by the bitrot scans of current MinIO server instance",
+				nil, nil),
+			prometheus.CounterValue,
+			float64(atomic.LoadUint64(&globalBitrotScanStats.healedShards)),
+		)
+	}
+
 	if globalIsGateway && (globalGatewayName == "s3" || globalGatewayName == "azure" || globalGatewayName == "gcs") {
 		m, _ := globalObjectAPI.GetMetrics(context.Background())
 		ch <- prometheus.MustNewConstMetric(
@@ -33,10 +33,15 @@ import (
 	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
 
+	"github.com/minio/minio/pkg/bucket/cors"
 	bucketsse "github.com/minio/minio/pkg/bucket/encryption"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/bucket/logging"
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/bucket/versioning"
+	"github.com/minio/minio/pkg/bucket/website"
 
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/madmin"
@@ -223,6 +228,51 @@ func (sys *NotificationSys) LoadUser(accessKey string, temp bool) []Notification
 	return ng.Wait()
 }
 
+// DeleteServiceAccount - deletes a specific service account across all peers
+func (sys *NotificationSys) DeleteServiceAccount(accessKey string) []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(context.Background(), func() error {
+			return client.DeleteServiceAccount(accessKey)
+		}, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
+// RevokeSTSSession - revokes specific temporary credentials across all peers
+func (sys *NotificationSys) RevokeSTSSession(accessKey string) []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(context.Background(), func() error {
+			return client.RevokeSTSSession(accessKey)
+		}, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
+// LoadServiceAccount - reloads a specific service account across all peers
+func (sys *NotificationSys) LoadServiceAccount(accessKey string) []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(context.Background(), func() error {
+			return client.LoadServiceAccount(accessKey)
+		}, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
 // LoadUsers - calls LoadUsers RPC call on all peers.
 func (sys *NotificationSys) LoadUsers() []NotificationPeerErr {
 	ng := WithNPeers(len(sys.peerClients))
@@ -236,6 +286,45 @@ func (sys *NotificationSys) LoadUsers() []NotificationPeerErr {
 	return ng.Wait()
 }
 
+// LoadTierConfig - calls LoadTierConfig RPC call on all peers.
+func (sys *NotificationSys) LoadTierConfig() []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(context.Background(), client.LoadTierConfig, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
+// LoadBucketTargets - calls LoadBucketTargets RPC call on all peers.
+func (sys *NotificationSys) LoadBucketTargets() []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(context.Background(), client.LoadBucketTargets, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
+// LoadZonesMeta - calls LoadZonesMeta RPC call on all peers.
+func (sys *NotificationSys) LoadZonesMeta() []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(context.Background(), client.LoadZonesMeta, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
 // LoadGroup - loads a specific group on all peers.
 func (sys *NotificationSys) LoadGroup(group string) []NotificationPeerErr {
 	ng := WithNPeers(len(sys.peerClients))
@@ -494,6 +583,12 @@ func (sys *NotificationSys) DeleteBucket(ctx context.Context, bucketName string)
 	globalBucketObjectLockConfig.Remove(bucketName)
 	globalPolicySys.Remove(bucketName)
 	globalLifecycleSys.Remove(bucketName)
+	globalBucketVersioningSys.Remove(bucketName)
+	globalBucketReplicationSys.Remove(bucketName)
+	globalBucketQuotaSys.Remove(bucketName)
+	globalBucketCorsSys.Remove(bucketName)
+	globalBucketWebsiteSys.Remove(bucketName)
+	globalBucketLoggingSys.Remove(bucketName)
 
 	go func() {
 		ng := WithNPeers(len(sys.peerClients))
@@ -614,6 +709,199 @@ func (sys *NotificationSys) RemoveBucketSSEConfig(ctx context.Context, bucketNam
 	}()
 }
 
+// SetBucketVersioning - calls SetBucketVersioning on all peers.
+func (sys *NotificationSys) SetBucketVersioning(ctx context.Context, bucketName string,
+	config *versioning.Versioning) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.SetBucketVersioning(bucketName, config)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
+// SetBucketReplicationConfig - calls SetBucketReplicationConfig on all peers.
+func (sys *NotificationSys) SetBucketReplicationConfig(ctx context.Context, bucketName string,
+	config *replication.Config) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.SetBucketReplicationConfig(bucketName, config)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
+// RemoveBucketReplicationConfig - calls RemoveBucketReplicationConfig on all peers.
+func (sys *NotificationSys) RemoveBucketReplicationConfig(ctx context.Context, bucketName string) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.RemoveBucketReplicationConfig(bucketName)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
+// SetBucketQuotaConfig - calls SetBucketQuotaConfig on all peers.
+func (sys *NotificationSys) SetBucketQuotaConfig(ctx context.Context, bucketName string,
+	quota madmin.BucketQuota) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.SetBucketQuotaConfig(bucketName, quota)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
+// RemoveBucketQuotaConfig - calls RemoveBucketQuotaConfig on all peers.
+func (sys *NotificationSys) RemoveBucketQuotaConfig(ctx context.Context, bucketName string) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.RemoveBucketQuotaConfig(bucketName)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
+// SetBucketCorsConfig - calls SetBucketCorsConfig on all peers.
+func (sys *NotificationSys) SetBucketCorsConfig(ctx context.Context, bucketName string,
+	config *cors.Config) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.SetBucketCorsConfig(bucketName, config)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
+// RemoveBucketCorsConfig - calls RemoveBucketCorsConfig on all peers.
+func (sys *NotificationSys) RemoveBucketCorsConfig(ctx context.Context, bucketName string) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.RemoveBucketCorsConfig(bucketName)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
+// SetBucketWebsiteConfig - calls SetBucketWebsiteConfig on all peers.
+func (sys *NotificationSys) SetBucketWebsiteConfig(ctx context.Context, bucketName string,
+	config *website.Config) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.SetBucketWebsiteConfig(bucketName, config)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
+// RemoveBucketWebsiteConfig - calls RemoveBucketWebsiteConfig on all peers.
+func (sys *NotificationSys) RemoveBucketWebsiteConfig(ctx context.Context, bucketName string) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.RemoveBucketWebsiteConfig(bucketName)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
+// SetBucketLoggingConfig - calls SetBucketLoggingConfig on all peers.
+func (sys *NotificationSys) SetBucketLoggingConfig(ctx context.Context, bucketName string,
+	config *logging.Config) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.SetBucketLoggingConfig(bucketName, config)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
+// RemoveBucketLoggingConfig - calls RemoveBucketLoggingConfig on all peers.
+func (sys *NotificationSys) RemoveBucketLoggingConfig(ctx context.Context, bucketName string) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.RemoveBucketLoggingConfig(bucketName)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
 // PutBucketNotification - calls PutBucketNotification RPC call on all peers.
 func (sys *NotificationSys) PutBucketNotification(ctx context.Context, bucketName string, rulesMap event.RulesMap) {
 	go func() {
@@ -1152,12 +1440,12 @@ func (args eventArgs) ToEvent() event.Event {
 func sendEvent(args eventArgs) {
 	// remove sensitive encryption entries in metadata.
 	switch {
+	case args.Object.IsCompressed():
+		args.Object.Size = args.Object.GetActualSize()
 	case crypto.IsEncrypted(args.Object.UserDefined):
 		if totalObjectSize, err := args.Object.DecryptedSize(); err == nil {
 			args.Object.Size = totalObjectSize
 		}
-	case args.Object.IsCompressed():
-		args.Object.Size = args.Object.GetActualSize()
 	}
 
 	crypto.RemoveSensitiveEntries(args.Object.UserDefined)
@@ -91,6 +91,27 @@ func deleteBucketMetadata(ctx context.Context, bucket string, objAPI ObjectLayer
 
 	// Delete notification config, if present - ignore any errors.
 	removeNotificationConfig(ctx, objAPI, bucket)
+
+	// Delete bucket versioning config, if present - ignore any errors.
+	removeBucketVersioningConfig(ctx, objAPI, bucket)
+
+	// Delete bucket replication config, if present - ignore any errors.
+	removeBucketReplicationConfig(ctx, objAPI, bucket)
+
+	// Delete bucket quota config, if present - ignore any errors.
+	removeBucketQuotaConfig(ctx, objAPI, bucket)
+
+	// Delete bucket tagging, if present - ignore any errors.
+	removeBucketTaggingConfig(ctx, objAPI, bucket)
+
+	// Delete bucket CORS config, if present - ignore any errors.
+	removeBucketCorsConfig(ctx, objAPI, bucket)
+
+	// Delete bucket website config, if present - ignore any errors.
+	removeBucketWebsiteConfig(ctx, objAPI, bucket)
+
+	// Delete bucket logging config, if present - ignore any errors.
+	removeBucketLoggingConfig(ctx, objAPI, bucket)
 }
 
 // Depending on the disk type network or local, initialize storage API.
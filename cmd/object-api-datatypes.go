@@ -157,6 +157,20 @@ type ObjectInfo struct {
 	// User-Defined object tags
 	UserTags string
 
+	// VersionID of the object, empty for objects in
+	// buckets which never had versioning configured.
+	VersionID string
+
+	// IsLatest indicates if this is the current version of the object.
+	IsLatest bool
+
+	// DeleteMarker indicates if this version is a delete marker.
+	DeleteMarker bool
+
+	// TransitionTier is the remote tier holding the data of
+	// the object, empty unless the object was transitioned.
+	TransitionTier string
+
 	// List of individual parts, maximum size of upto 10,000
 	Parts []ObjectPartInfo `json:"-"`
 
@@ -291,6 +305,31 @@ type ListObjectsInfo struct {
 	Prefixes []string
 }
 
+// ListObjectVersionsInfo - container for list object versions.
+type ListObjectVersionsInfo struct {
+	// Indicates whether the returned list objects response is truncated. A
+	// value of true indicates that the list was truncated. The list can be truncated
+	// if the number of objects exceeds the limit allowed or specified
+	// by max keys.
+	IsTruncated bool
+
+	// When response is truncated (the IsTruncated element value in the response is true),
+	// you can use the key name in this field as marker in the subsequent
+	// request to get next set of objects.
+	NextMarker string
+
+	// When response is truncated (the IsTruncated element value in the response is true),
+	// you can use this field as version id marker in the subsequent
+	// request to get next set of object versions.
+	NextVersionIDMarker string
+
+	// List of object versions for this request, delete markers included.
+	Objects []ObjectInfo
+
+	// List of prefixes for this request.
+	Prefixes []string
+}
+
 // ListObjectsV2Info - container for list objects version 2.
 type ListObjectsV2Info struct {
 	// Indicates whether the returned list objects response is truncated. A
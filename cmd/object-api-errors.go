@@ -100,6 +100,34 @@ func toObjectErr(err error, params ...string) error {
 				Object: params[1],
 			}
 		}
+	case errFileVersionNotFound:
+		switch len(params) {
+		case 2:
+			err = VersionNotFound{
+				Bucket: params[0],
+				Object: params[1],
+			}
+		case 3:
+			err = VersionNotFound{
+				Bucket:    params[0],
+				Object:    params[1],
+				VersionID: params[2],
+			}
+		}
+	case errMethodNotAllowed:
+		switch len(params) {
+		case 2:
+			err = MethodNotAllowed{
+				Bucket: params[0],
+				Object: params[1],
+			}
+		case 3:
+			err = MethodNotAllowed{
+				Bucket:    params[0],
+				Object:    params[1],
+				VersionID: params[2],
+			}
+		}
 	case errXLReadQuorum:
 		err = InsufficientReadQuorum{}
 	case errXLWriteQuorum:
@@ -147,8 +175,9 @@ func (e InsufficientWriteQuorum) Error() string {
 
 // GenericError - generic object layer error.
 type GenericError struct {
-	Bucket string
-	Object string
+	Bucket    string
+	Object    string
+	VersionID string
 }
 
 // BucketNotFound bucket does not exist.
@@ -186,6 +215,20 @@ func (e ObjectNotFound) Error() string {
 	return "Object not found: " + e.Bucket + "#" + e.Object
 }
 
+// VersionNotFound object version does not exist.
+type VersionNotFound GenericError
+
+func (e VersionNotFound) Error() string {
+	return "Version not found: " + e.Bucket + "#" + e.Object + " (" + e.VersionID + ")"
+}
+
+// MethodNotAllowed the object version is a delete marker.
+type MethodNotAllowed GenericError
+
+func (e MethodNotAllowed) Error() string {
+	return "Method not allowed: " + e.Bucket + "#" + e.Object + " (" + e.VersionID + ") is a delete marker"
+}
+
 // ObjectAlreadyExists object already exists.
 type ObjectAlreadyExists GenericError
 
@@ -269,6 +312,62 @@ func (e BucketSSEConfigNotFound) Error() string {
 	return "No bucket encryption found for bucket: " + e.Bucket
 }
 
+// BucketVersioningConfigNotFound - no bucket versioning config found
+type BucketVersioningConfigNotFound GenericError
+
+func (e BucketVersioningConfigNotFound) Error() string {
+	return "No bucket versioning found for bucket: " + e.Bucket
+}
+
+// BucketReplicationConfigNotFound - no bucket replication config found
+type BucketReplicationConfigNotFound GenericError
+
+func (e BucketReplicationConfigNotFound) Error() string {
+	return "No bucket replication found for bucket: " + e.Bucket
+}
+
+// BucketTaggingNotFound - no bucket tags found
+type BucketTaggingNotFound GenericError
+
+func (e BucketTaggingNotFound) Error() string {
+	return "No bucket tags found for bucket: " + e.Bucket
+}
+
+// BucketCorsConfigNotFound - no bucket CORS config found
+type BucketCorsConfigNotFound GenericError
+
+func (e BucketCorsConfigNotFound) Error() string {
+	return "No bucket CORS configuration found for bucket: " + e.Bucket
+}
+
+// BucketWebsiteConfigNotFound - no bucket website config found
+type BucketWebsiteConfigNotFound GenericError
+
+func (e BucketWebsiteConfigNotFound) Error() string {
+	return "No bucket website configuration found for bucket: " + e.Bucket
+}
+
+// BucketLoggingConfigNotFound - no bucket logging config found
+type BucketLoggingConfigNotFound GenericError
+
+func (e BucketLoggingConfigNotFound) Error() string {
+	return "No bucket logging configuration found for bucket: " + e.Bucket
+}
+
+// BucketQuotaConfigNotFound - no bucket quota config found.
+type BucketQuotaConfigNotFound GenericError
+
+func (e BucketQuotaConfigNotFound) Error() string {
+	return "No quota config found for bucket : " + e.Bucket
+}
+
+// BucketQuotaExceeded - bucket quota exceeded.
+type BucketQuotaExceeded GenericError
+
+func (e BucketQuotaExceeded) Error() string {
+	return "Bucket quota exceeded for bucket: " + e.Bucket
+}
+
 /// Bucket related errors.
 
 // BucketNameInvalid - bucketname provided is invalid.
@@ -446,6 +545,12 @@ func isErrObjectNotFound(err error) bool {
 	return errors.As(err, &objNotFound)
 }
 
+// isErrVersionNotFound - Check if error type is VersionNotFound.
+func isErrVersionNotFound(err error) bool {
+	var versionNotFound VersionNotFound
+	return errors.As(err, &versionNotFound)
+}
+
 // PreConditionFailed - Check if copy precondition failed
 type PreConditionFailed struct{}
 
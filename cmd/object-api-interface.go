@@ -22,10 +22,15 @@ import (
 	"net/http"
 
 	"github.com/minio/minio-go/v6/pkg/encrypt"
+	"github.com/minio/minio/pkg/bucket/cors"
 	bucketsse "github.com/minio/minio/pkg/bucket/encryption"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/bucket/logging"
 	"github.com/minio/minio/pkg/bucket/object/tagging"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/bucket/versioning"
+	"github.com/minio/minio/pkg/bucket/website"
 
 	"github.com/minio/minio/pkg/madmin"
 )
@@ -41,6 +46,16 @@ type ObjectOptions struct {
 	ServerSideEncryption encrypt.ServerSide
 	UserDefined          map[string]string
 	CheckCopyPrecondFn   CheckCopyPreconditionFn
+	VersionID            string // empty refers to the latest version.
+	Transition           TransitionOptions
+}
+
+// TransitionOptions represents the details of the remote copy of an
+// object for the ObjectLayer TransitionObject operation.
+type TransitionOptions struct {
+	Tier         string // name of the remote tier.
+	RemoteObject string // name of the object on the remote tier.
+	ETag         string // etag of the object as copied to the remote tier.
 }
 
 // LockType represents required locking for ObjectLayer operations
@@ -69,6 +84,7 @@ type ObjectLayer interface {
 	DeleteBucket(ctx context.Context, bucket string) error
 	ListObjects(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (result ListObjectsInfo, err error)
 	ListObjectsV2(ctx context.Context, bucket, prefix, continuationToken, delimiter string, maxKeys int, fetchOwner bool, startAfter string) (result ListObjectsV2Info, err error)
+	ListObjectVersions(ctx context.Context, bucket, prefix, marker, versionMarker, delimiter string, maxKeys int) (result ListObjectVersionsInfo, err error)
 	Walk(ctx context.Context, bucket, prefix string, results chan<- ObjectInfo) error
 
 	// Object operations.
@@ -86,6 +102,9 @@ type ObjectLayer interface {
 	CopyObject(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error)
 	DeleteObject(ctx context.Context, bucket, object string) error
 	DeleteObjects(ctx context.Context, bucket string, objects []string) ([]error, error)
+	DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error)
+	TransitionObject(ctx context.Context, bucket, object string, opts ObjectOptions) error
+	PutObjectMetadata(ctx context.Context, bucket, object string, opts ObjectOptions) error
 
 	// Multipart operations.
 	ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result ListMultipartsInfo, err error)
@@ -130,6 +149,35 @@ type ObjectLayer interface {
 	GetBucketSSEConfig(context.Context, string) (*bucketsse.BucketSSEConfig, error)
 	DeleteBucketSSEConfig(context.Context, string) error
 
+	// Bucket Versioning operations
+	SetBucketVersioning(context.Context, string, *versioning.Versioning) error
+	GetBucketVersioning(context.Context, string) (*versioning.Versioning, error)
+
+	// Bucket Replication operations
+	SetBucketReplicationConfig(context.Context, string, *replication.Config) error
+	GetBucketReplicationConfig(context.Context, string) (*replication.Config, error)
+	DeleteBucketReplicationConfig(context.Context, string) error
+
+	// Bucket Tagging operations
+	SetBucketTagging(context.Context, string, *tagging.Tagging) error
+	GetBucketTagging(context.Context, string) (*tagging.Tagging, error)
+	DeleteBucketTagging(context.Context, string) error
+
+	// Bucket CORS operations
+	SetBucketCorsConfig(context.Context, string, *cors.Config) error
+	GetBucketCorsConfig(context.Context, string) (*cors.Config, error)
+	DeleteBucketCorsConfig(context.Context, string) error
+
+	// Bucket website operations
+	SetBucketWebsiteConfig(context.Context, string, *website.Config) error
+	GetBucketWebsiteConfig(context.Context, string) (*website.Config, error)
+	DeleteBucketWebsiteConfig(context.Context, string) error
+
+	// Bucket logging operations
+	SetBucketLoggingConfig(context.Context, string, *logging.Config) error
+	GetBucketLoggingConfig(context.Context, string) (*logging.Config, error)
+	DeleteBucketLoggingConfig(context.Context, string) error
+
 	// Backend related metrics
 	GetMetrics(ctx context.Context) (*Metrics, error)
 
@@ -45,6 +45,7 @@ import (
 	"github.com/minio/minio/pkg/hash"
 	"github.com/minio/minio/pkg/ioutil"
 	"github.com/minio/minio/pkg/wildcard"
+	"github.com/minio/sio"
 	"github.com/skyrings/skyring-common/tools/uuid"
 )
 
@@ -366,9 +367,6 @@ func (o ObjectInfo) IsCompressedOK() (bool, error) {
 	if !ok {
 		return false, nil
 	}
-	if crypto.IsEncrypted(o.UserDefined) {
-		return true, fmt.Errorf("compression %q and encryption enabled on same object", scheme)
-	}
 	switch scheme {
 	case compressionAlgorithmV1, compressionAlgorithmV2:
 		return true, nil
@@ -389,11 +387,15 @@ func (o ObjectInfo) GetActualSize() int64 {
 	return -1
 }
 
-// Disabling compression for encrypted enabled requests.
-// Using compression and encryption together enables room for side channel attacks.
+// Disabling compression for encrypted enabled requests, unless explicitly
+// allowed. Using compression and encryption together enables room for side
+// channel attacks.
 // Eliminate non-compressible objects by extensions/content-types.
 func isCompressible(header http.Header, object string) bool {
-	if crypto.IsRequested(header) || excludeForCompression(header, object, globalCompressConfig) {
+	if crypto.IsRequested(header) && !globalCompressConfig.AllowEncrypted {
+		return false
+	}
+	if excludeForCompression(header, object, globalCompressConfig) {
 		return false
 	}
 	return true
@@ -458,15 +460,25 @@ func getPartFile(entries []string, partNumber int, etag string) string {
 }
 
 // Returns the compressed offset which should be skipped.
+// The compressed parts of encrypted objects are encrypted as well,
+// the offset is an offset of the decrypted compressed stream.
 func getCompressedOffsets(objectInfo ObjectInfo, offset int64) (int64, int64) {
 	var compressedOffset int64
 	var skipLength int64
 	var cumulativeActualSize int64
+	isEncrypted := crypto.IsEncrypted(objectInfo.UserDefined)
 	if len(objectInfo.Parts) > 0 {
 		for _, part := range objectInfo.Parts {
 			cumulativeActualSize += part.ActualSize
 			if cumulativeActualSize <= offset {
-				compressedOffset += part.Size
+				partSize := part.Size
+				if isEncrypted {
+					decPartSize, err := sio.DecryptedSize(uint64(part.Size))
+					if err == nil {
+						partSize = int64(decPartSize)
+					}
+				}
+				compressedOffset += partSize
 			} else {
 				skipLength = cumulativeActualSize - part.ActualSize
 				break
@@ -523,8 +535,9 @@ type ObjReaderFn func(inputReader io.Reader, h http.Header, pcfn CheckCopyPrecon
 // NewGetObjectReader creates a new GetObjectReader. The cleanUpFns
 // are called on Close() in reverse order as passed here. NOTE: It is
 // assumed that clean up functions do not panic (otherwise, they may
-// not all run!).
-func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, pcfn CheckCopyPreconditionFn, cleanUpFns ...func()) (
+// not all run!). The header h provides the encryption parameters
+// needed to read the compression index of encrypted objects.
+func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, h http.Header, pcfn CheckCopyPreconditionFn, cleanUpFns ...func()) (
 	fn ObjReaderFn, off, length int64, err error) {
 
 	// Call the clean-up functions immediately in case of exit
@@ -546,7 +559,7 @@ func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, pcfn CheckCopyPrecondi
 	// Calculate range to read (different for
 	// e.g. encrypted/compressed objects)
 	switch {
-	case isEncrypted:
+	case isEncrypted && !isCompressed:
 		var seqNumber uint32
 		var partStart int
 		off, length, skipLen, seqNumber, partStart, err = oi.GetDecryptedRange(rs)
@@ -615,32 +628,75 @@ func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, pcfn CheckCopyPrecondi
 		if actualSize < 0 {
 			return nil, 0, 0, errInvalidDecompressedSize
 		}
-		off, length = int64(0), oi.Size
+		// The compressed stream of encrypted objects is
+		// encrypted as well, its size is the decrypted size.
+		compSize := oi.Size
+		if isEncrypted {
+			compSize, err = oi.DecryptedSize()
+			if err != nil {
+				return nil, 0, 0, err
+			}
+		}
+		compOff, compLength := int64(0), compSize
 		decOff, decLength := int64(0), actualSize
+		var fromIndex bool
 		if rs != nil {
-			off, length, err = rs.GetOffsetLength(actualSize)
+			var rangeOff int64
+			rangeOff, decLength, err = rs.GetOffsetLength(actualSize)
 			if err != nil {
 				return nil, 0, 0, err
 			}
-			// In case of range based queries on multiparts, the offset and length are reduced.
-			off, decOff = getCompressedOffsets(oi, off)
-			decLength = length
-			length = oi.Size - off
-
-			// For negative length we read everything.
-			if decLength < 0 {
-				decLength = actualSize - decOff
+			if index := getCompressionIndex(oi, h); len(index) > 0 {
+				// Decompress from the closest flushed offset of the
+				// compressed stream.
+				compOff, compLength, decOff = index.compressedRange(rangeOff, decLength, compSize)
+				fromIndex = true
+			} else {
+				// In case of range based queries on multiparts, the offset and length are reduced.
+				compOff, decOff = getCompressedOffsets(oi, rangeOff)
+				compLength = compSize - compOff
 			}
+		}
+		off, length = compOff, compLength
 
-			// Reply back invalid range if the input offset and length fall out of range.
-			if decOff > actualSize || decOff+decLength > actualSize {
-				return nil, 0, 0, errInvalidRange
+		var seqNumber uint32
+		var partStart int
+		if isEncrypted {
+			// Read the DARE packages containing the compressed range.
+			var compRange *HTTPRangeSpec
+			if rs != nil {
+				compRange = &HTTPRangeSpec{Start: compOff, End: compOff + compLength - 1}
+			}
+			off, length, skipLen, seqNumber, partStart, err = oi.GetDecryptedRange(compRange)
+			if err != nil {
+				return nil, 0, 0, err
 			}
 		}
-		fn = func(inputReader io.Reader, _ http.Header, pcfn CheckCopyPreconditionFn, cFns ...func()) (r *GetObjectReader, err error) {
+		fn = func(inputReader io.Reader, h http.Header, pcfn CheckCopyPreconditionFn, cFns ...func()) (r *GetObjectReader, err error) {
 			cFns = append(cleanUpFns, cFns...)
+			var encETag string
+			if isEncrypted {
+				copySource := h.Get(crypto.SSECopyAlgorithm) != ""
+
+				// Attach decrypter on inputReader
+				inputReader, err = DecryptBlocksRequestR(inputReader, h,
+					off, length, seqNumber, partStart, oi, copySource)
+				if err != nil {
+					// Call the cleanup funcs
+					for i := len(cFns) - 1; i >= 0; i-- {
+						cFns[i]()
+					}
+					return nil, err
+				}
+				encETag = oi.ETag
+				oi.ETag = getDecryptedETag(h, oi, copySource) // Decrypt the ETag before top layer consumes this value.
+
+				// Apply the skipLen and limit on the
+				// decrypted compressed stream.
+				inputReader = io.LimitReader(ioutil.NewSkipReader(inputReader, skipLen), compLength)
+			}
 			if pcfn != nil {
-				if ok := pcfn(oi, ""); ok {
+				if ok := pcfn(oi, encETag); ok {
 					// Call the cleanup funcs
 					for i := len(cFns) - 1; i >= 0; i-- {
 						cFns[i]()
@@ -648,6 +704,11 @@ func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, pcfn CheckCopyPrecondi
 					return nil, PreConditionFailed{}
 				}
 			}
+			if fromIndex && compOff > 0 {
+				// The stream identifier is only present at the
+				// start of the compressed stream.
+				inputReader = io.MultiReader(strings.NewReader(s2StreamIdentifier), inputReader)
+			}
 			// Decompression reader.
 			s2Reader := s2.NewReader(inputReader)
 			// Apply the skipLen and limit on the decompressed stream.
@@ -739,6 +800,7 @@ type PutObjReader struct {
 	*hash.Reader              // actual data stream
 	rawReader    *hash.Reader // original data stream
 	sealMD5Fn    SealMD5CurrFn
+	indexFn      func() []byte // index of the compressed data stream
 }
 
 // Size returns the absolute number of bytes the Reader
@@ -775,6 +837,36 @@ func (p *PutObjReader) MD5CurrentHexString() string {
 	return hex.EncodeToString(md5sumCurr)
 }
 
+// CompressionIndex returns the index of the compressed data stream,
+// if the data is compressed. It is only complete once all the data
+// has been read.
+func (p *PutObjReader) CompressionIndex() []byte {
+	if p.indexFn == nil {
+		return nil
+	}
+	return p.indexFn()
+}
+
+// setCompressionIndex sets the compressed data stream the index of
+// which is stored along with the object. The index of encrypted
+// objects is sealed with the object encryption key.
+func (p *PutObjReader) setCompressionIndex(s2c *s2CompressReader, encKey []byte) {
+	p.indexFn = func() []byte {
+		index := s2c.Index()
+		if len(index) == 0 || len(encKey) == 0 {
+			return index
+		}
+		var objectKey crypto.ObjectKey
+		copy(objectKey[:], encKey)
+		sealedIndex, err := sealCompressionIndex(objectKey, index)
+		if err != nil {
+			// The object is readable without its index.
+			return nil
+		}
+		return sealedIndex
+	}
+}
+
 // NewPutObjReader returns a new PutObjReader and holds
 // reference to underlying data stream from client and the encrypted
 // data reader
@@ -820,21 +912,59 @@ func CleanMinioInternalMetadataKeys(metadata map[string]string) map[string]strin
 	return newMeta
 }
 
+// s2CompressReader is the compressed data stream along with the
+// index of the offsets at which the compressed stream was flushed.
+type s2CompressReader struct {
+	*io.PipeReader
+
+	mu    sync.Mutex
+	index compressionIndex
+}
+
+// Index returns the encoded compression index, it is only complete
+// once all the compressed data has been read.
+func (s *s2CompressReader) Index() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.index.marshal()
+}
+
 // newS2CompressReader will read data from r, compress it and return the compressed data as a Reader.
-// Use Close to ensure resources are released on incomplete streams.
-func newS2CompressReader(r io.Reader) io.ReadCloser {
+// The compressed stream is flushed at every compressionIndexInterval of data, to allow decompressing
+// ranges from the closest flushed offset. Use Close to ensure resources are released on incomplete streams.
+func newS2CompressReader(r io.Reader) *s2CompressReader {
 	pr, pw := io.Pipe()
-	comp := s2.NewWriter(pw)
+	cw := &compressedSizeWriter{Writer: pw}
+	comp := s2.NewWriter(cw)
+	s2c := &s2CompressReader{PipeReader: pr}
 	// Copy input to compressor
 	go func() {
-		_, err := io.Copy(comp, r)
-		if err != nil {
-			comp.Close()
-			pw.CloseWithError(err)
-			return
+		var uncompressed int64
+		for {
+			n, err := io.CopyN(comp, r, compressionIndexInterval)
+			uncompressed += n
+			if err == io.EOF {
+				break
+			}
+			if err == nil {
+				// Flush to start a new block of the compressed
+				// stream at the indexed offset.
+				err = comp.Flush()
+			}
+			if err != nil {
+				comp.Close()
+				pw.CloseWithError(err)
+				return
+			}
+			s2c.mu.Lock()
+			s2c.index = append(s2c.index, compressionIndexEntry{
+				uncompressed: uncompressed,
+				compressed:   cw.n,
+			})
+			s2c.mu.Unlock()
 		}
 		// Close the stream.
-		err = comp.Close()
+		err := comp.Close()
 		if err != nil {
 			pw.CloseWithError(err)
 			return
@@ -842,7 +972,7 @@ func newS2CompressReader(r io.Reader) io.ReadCloser {
 		// Everything ok, do regular close.
 		pw.Close()
 	}()
-	return pr
+	return s2c
 }
 
 // Returns error if the cancelCh has been closed (indicating that S3 client has disconnected)
@@ -349,7 +349,6 @@ func TestIsCompressed(t *testing.T) {
 				},
 			},
 			result: true,
-			err:    true,
 		},
 		{
 			objInfo: ObjectInfo{
@@ -252,10 +252,14 @@ func deleteObject(ctx context.Context, obj ObjectLayer, cache CacheObjectLayer,
 	if cache != nil {
 		deleteObject = cache.DeleteObject
 	}
+	size := globalBucketQuotaSys.removedObjectSize(ctx, obj, bucket, object, ObjectOptions{})
 	// Proceed to delete the object.
 	if err = deleteObject(ctx, bucket, object); err != nil {
 		return err
 	}
+	globalBucketQuotaSys.AccountUsage(bucket, -size)
+
+	scheduleReplicationDelete(ctx, bucket, object)
 
 	// Notify object deleted event.
 	sendEvent(eventArgs{
@@ -271,3 +275,35 @@ func deleteObject(ctx context.Context, obj ObjectLayer, cache CacheObjectLayer,
 
 	return nil
 }
+
+// deleteObjectVersion is a convenient wrapper to delete a version of an
+// object in a bucket with versioning configured and send an event.
+func deleteObjectVersion(ctx context.Context, obj ObjectLayer, bucket, object string, opts ObjectOptions, r *http.Request) (objInfo ObjectInfo, err error) {
+	size := globalBucketQuotaSys.removedObjectSize(ctx, obj, bucket, object, opts)
+	// Proceed to delete the object version.
+	if objInfo, err = obj.DeleteObjectVersion(ctx, bucket, object, opts); err != nil {
+		return objInfo, err
+	}
+	globalBucketQuotaSys.AccountUsage(bucket, -size)
+
+	// Only delete markers are replicated, removing a
+	// specific version is not replayed on the replica.
+	if opts.VersionID == "" {
+		scheduleReplicationDelete(ctx, bucket, object)
+	}
+
+	// Notify object deleted event.
+	sendEvent(eventArgs{
+		EventName:  event.ObjectRemovedDelete,
+		BucketName: bucket,
+		Object: ObjectInfo{
+			Name:      object,
+			VersionID: objInfo.VersionID,
+		},
+		ReqParams: extractReqParams(r),
+		UserAgent: r.UserAgent(),
+		Host:      handlers.GetSourceIP(r),
+	})
+
+	return objInfo, nil
+}
@@ -43,6 +43,7 @@ import (
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
 	"github.com/minio/minio/pkg/bucket/object/tagging"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/replication"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/handlers"
 	"github.com/minio/minio/pkg/hash"
@@ -282,7 +283,8 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	if vid := r.URL.Query().Get("versionId"); vid != "" && vid != "null" {
+	// Buckets without versioning do not support any versions other than "null".
+	if vid := r.URL.Query().Get("versionId"); vid != "" && vid != nullVersionID && !globalBucketVersioningSys.Configured(bucket) {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
 		return
 	}
@@ -333,7 +335,8 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	getObjectNInfo := objectAPI.GetObjectNInfo
-	if api.CacheAPI() != nil {
+	// Only the latest version of an object is cached.
+	if api.CacheAPI() != nil && opts.VersionID == "" {
 		getObjectNInfo = api.CacheAPI().GetObjectNInfo
 	}
 
@@ -464,22 +467,24 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	if vid := r.URL.Query().Get("versionId"); vid != "" && vid != "null" {
+	// Buckets without versioning do not support any versions other than "null".
+	if vid := r.URL.Query().Get("versionId"); vid != "" && vid != nullVersionID && !globalBucketVersioningSys.Configured(bucket) {
 		writeErrorResponseHeadersOnly(w, errorCodes.ToAPIErr(ErrNoSuchVersion))
 		return
 	}
 
-	getObjectInfo := objectAPI.GetObjectInfo
-	if api.CacheAPI() != nil {
-		getObjectInfo = api.CacheAPI().GetObjectInfo
-	}
-
 	opts, err := getOpts(ctx, r, bucket, object)
 	if err != nil {
 		writeErrorResponseHeadersOnly(w, toAPIError(ctx, err))
 		return
 	}
 
+	getObjectInfo := objectAPI.GetObjectInfo
+	// Only the latest version of an object is cached.
+	if api.CacheAPI() != nil && opts.VersionID == "" {
+		getObjectInfo = api.CacheAPI().GetObjectInfo
+	}
+
 	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
 		if getRequestAuthType(r) == authTypeAnonymous {
 			// As per "Permission" section in
@@ -530,6 +535,10 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 
 	objInfo, err := getObjectInfo(ctx, bucket, object, opts)
 	if err != nil {
+		if objInfo.DeleteMarker {
+			w.Header().Set(xhttp.AmzDeleteMarker, "true")
+			w.Header().Set(xhttp.AmzVersionID, objInfo.VersionID)
+		}
 		writeErrorResponseHeadersOnly(w, toAPIError(ctx, err))
 		return
 	}
@@ -736,25 +745,14 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	// has a version ID. If you have not enabled versioning, Amazon S3 sets the value
 	// of the version ID to null. If you have enabled versioning, Amazon S3 assigns a
 	// unique version ID value for the object.
+	var srcVersionID string
 	if u, err := url.Parse(cpSrcPath); err == nil {
-		// Check if versionId query param was added, if yes then check if
-		// its non "null" value, we should error out since we do not support
-		// any versions other than "null".
-		if vid := u.Query().Get("versionId"); vid != "" && vid != "null" {
-			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
-			return
-		}
+		srcVersionID = u.Query().Get("versionId")
 		// Note that url.Parse does the unescaping
 		cpSrcPath = u.Path
 	}
 	if vid := r.Header.Get(xhttp.AmzCopySourceVersionID); vid != "" {
-		// Check if versionId header was added, if yes then check if
-		// its non "null" value, we should error out since we do not support
-		// any versions other than "null".
-		if vid != "null" {
-			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
-			return
-		}
+		srcVersionID = vid
 	}
 
 	srcBucket, srcObject := path2BucketObject(cpSrcPath)
@@ -764,6 +762,14 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// Check if versionId was added, if yes then check if its non "null"
+	// value, we should error out for buckets without versioning since they
+	// do not support any versions other than "null".
+	if srcVersionID != "" && srcVersionID != nullVersionID && !globalBucketVersioningSys.Configured(srcBucket) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, srcBucket, srcObject); s3Error != ErrNone {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
 		return
@@ -801,6 +807,10 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	if getSSE != srcOpts.ServerSideEncryption {
 		getOpts.ServerSideEncryption = getSSE
 	}
+	if globalBucketVersioningSys.Configured(srcBucket) {
+		getOpts.VersionID = srcVersionID
+		srcOpts.VersionID = srcVersionID
+	}
 	dstOpts, err = copyDstOpts(ctx, r, dstBucket, dstObject, nil)
 	if err != nil {
 		logger.LogIf(ctx, err)
@@ -839,6 +849,14 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// Metadata only copies do not add to the usage of the bucket.
+	if !cpSrcDstSame {
+		if err = enforceBucketQuota(ctx, dstBucket, srcInfo.Size); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+
 	// We have to copy metadata only if source and destination are same.
 	// this changes for encryption which can be observed below.
 	if cpSrcDstSame {
@@ -848,9 +866,10 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	var reader io.Reader
 	var length = srcInfo.Size
 
-	// Set the actual size to the decrypted size if encrypted.
+	// Set the actual size to the decrypted size if encrypted, the
+	// source reader of compressed objects reports the decompressed size.
 	actualSize := srcInfo.Size
-	if crypto.IsEncrypted(srcInfo.UserDefined) {
+	if crypto.IsEncrypted(srcInfo.UserDefined) && !srcInfo.IsCompressed() {
 		actualSize, err = srcInfo.DecryptedSize()
 		if err != nil {
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
@@ -860,6 +879,7 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	}
 
 	var compressMetadata map[string]string
+	var s2c *s2CompressReader
 	// No need to compress for remote etcd calls
 	// Pass the decompressed stream to such calls.
 	isCompressed := objectAPI.IsCompressionSupported() && isCompressible(r.Header, srcObject) && !isRemoteCopyRequired(ctx, srcBucket, dstBucket, objectAPI)
@@ -868,18 +888,12 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		// Preserving the compression metadata.
 		compressMetadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
 		compressMetadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(actualSize, 10)
-		// Remove all source encrypted related metadata to
-		// avoid copying them in target object.
-		crypto.RemoveInternalEntries(srcInfo.UserDefined)
 
-		s2c := newS2CompressReader(gr)
+		s2c = newS2CompressReader(gr)
 		defer s2c.Close()
 		reader = s2c
 		length = -1
 	} else {
-		// Remove the metadata for remote calls.
-		delete(srcInfo.UserDefined, ReservedMetadataPrefix+"compression")
-		delete(srcInfo.UserDefined, ReservedMetadataPrefix+"actual-size")
 		reader = gr
 	}
 
@@ -893,7 +907,8 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	pReader := NewPutObjReader(srcInfo.Reader, nil, nil)
 
 	var encMetadata = make(map[string]string)
-	if objectAPI.IsEncryptionSupported() && !isCompressed {
+	var keyRotation bool
+	if objectAPI.IsEncryptionSupported() {
 		// Encryption parameters not applicable for this object.
 		if !crypto.IsEncrypted(srcInfo.UserDefined) && crypto.SSECopy.IsRequested(r.Header) {
 			writeErrorResponse(ctx, w, toAPIError(ctx, errInvalidEncryptionParameters), r.URL, guessIsBrowserReq(r))
@@ -926,7 +941,6 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		// - the object is encrypted using SSE-C and two different SSE-C keys are present
 		// - the object is encrypted using SSE-S3 and the SSE-S3 header is present
 		// than execute a key rotation.
-		var keyRotation bool
 		if cpSrcDstSame && (sseCopyC && sseC) {
 			oldKey, err = ParseSSECopyCustomerRequest(r.Header, srcInfo.UserDefined)
 			if err != nil {
@@ -971,7 +985,14 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 			case !isSourceEncrypted && isTargetEncrypted:
 				targetSize = srcInfo.EncryptedSize()
 			case isSourceEncrypted && !isTargetEncrypted:
-				targetSize, _ = srcInfo.DecryptedSize()
+				targetSize = actualSize
+			}
+
+			// The size of compressed data is unknown, its actual
+			// size is the size of the decompressed data.
+			targetActualSize := targetSize
+			if isCompressed {
+				targetSize, targetActualSize = -1, actualSize
 			}
 
 			if isTargetEncrypted {
@@ -989,14 +1010,19 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 			}
 
 			// do not try to verify encrypted content
-			srcInfo.Reader, err = hash.NewReader(reader, targetSize, "", "", targetSize, globalCLIContext.StrictS3Compat)
+			srcInfo.Reader, err = hash.NewReader(reader, targetSize, "", "", targetActualSize, globalCLIContext.StrictS3Compat)
 			if err != nil {
 				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 				return
 			}
 
 			pReader = NewPutObjReader(rawReader, srcInfo.Reader, objEncKey)
+			if s2c != nil {
+				pReader.setCompressionIndex(s2c, objEncKey)
+			}
 		}
+	} else if s2c != nil {
+		pReader.setCompressionIndex(s2c, nil)
 	}
 
 	srcInfo.PutObjReader = pReader
@@ -1038,9 +1064,16 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
 		return
 	}
-	// Store the preserved compression metadata.
-	for k, v := range compressMetadata {
-		srcInfo.UserDefined[k] = v
+	// Store the preserved compression metadata, key rotations
+	// don't rewrite the object which keeps its compression.
+	if !keyRotation {
+		// Remove the compression metadata of the source object.
+		delete(srcInfo.UserDefined, ReservedMetadataPrefix+"compression")
+		delete(srcInfo.UserDefined, ReservedMetadataPrefix+"actual-size")
+		delete(srcInfo.UserDefined, compressionIndexKey)
+		for k, v := range compressMetadata {
+			srcInfo.UserDefined[k] = v
+		}
 	}
 
 	// We need to preserve the encryption headers set in EncryptRequest,
@@ -1049,6 +1082,13 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		srcInfo.UserDefined[k] = v
 	}
 
+	// The copy is replicated on its own, regardless
+	// of the replication status of its source.
+	delete(srcInfo.UserDefined, xhttp.AmzBucketReplicationStatus)
+	if mustReplicate(dstBucket, dstObject, srcInfo.UserDefined[xhttp.AmzObjectTagging], false) {
+		srcInfo.UserDefined[xhttp.AmzBucketReplicationStatus] = replication.Pending.String()
+	}
+
 	// Ensure that metadata does not contain sensitive information
 	crypto.RemoveSensitiveEntries(srcInfo.UserDefined)
 	// Check if x-amz-metadata-directive or x-amz-tagging-directive was not set to REPLACE and source,
@@ -1100,14 +1140,26 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 			return
 		}
+		if !cpSrcDstSame {
+			globalBucketQuotaSys.AccountUsage(dstBucket, objInfo.Size)
+		}
 	}
 
 	response := generateCopyObjectResponse(getDecryptedETag(r.Header, objInfo, false), objInfo.ModTime)
 	encodedSuccessResponse := encodeResponse(response)
 
+	if srcInfo.VersionID != "" {
+		w.Header().Set(xhttp.AmzCopySourceVersionID, srcInfo.VersionID)
+	}
+	if objInfo.VersionID != "" {
+		w.Header().Set(xhttp.AmzVersionID, objInfo.VersionID)
+	}
+
 	// Write success response.
 	writeSuccessResponseXML(w, encodedSuccessResponse)
 
+	scheduleReplication(ctx, objInfo, objectAPI)
+
 	if objInfo.IsCompressed() {
 		objInfo.Size = actualSize
 	}
@@ -1208,6 +1260,11 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if err = enforceBucketQuota(ctx, bucket, size); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	metadata, err := extractMetadata(ctx, r)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
@@ -1222,6 +1279,10 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 	}
 
+	if mustReplicate(bucket, object, metadata[xhttp.AmzObjectTagging], false) {
+		metadata[xhttp.AmzBucketReplicationStatus] = replication.Pending.String()
+	}
+
 	if rAuthType == authTypeStreamingSigned {
 		if contentEncoding, ok := metadata["content-encoding"]; ok {
 			contentEncoding = trimAwsChunkedContentEncoding(contentEncoding)
@@ -1291,6 +1352,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 
 	actualSize := size
 
+	var s2c *s2CompressReader
 	if objectAPI.IsCompressionSupported() && isCompressible(r.Header, object) && size > 0 {
 		// Storing the compression metadata.
 		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
@@ -1303,7 +1365,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 
 		// Set compression metrics.
-		s2c := newS2CompressReader(actualReader)
+		s2c = newS2CompressReader(actualReader)
 		defer s2c.Close()
 		reader = s2c
 		size = -1   // Since compressed size is un-predictable.
@@ -1362,7 +1424,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 			}
 			info := ObjectInfo{Size: size}
 			// do not try to verify encrypted content
-			hashReader, err = hash.NewReader(reader, info.EncryptedSize(), "", "", size, globalCLIContext.StrictS3Compat)
+			hashReader, err = hash.NewReader(reader, info.EncryptedSize(), "", "", actualSize, globalCLIContext.StrictS3Compat)
 			if err != nil {
 				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 				return
@@ -1370,6 +1432,9 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 			pReader = NewPutObjReader(rawReader, hashReader, objectEncryptionKey)
 		}
 	}
+	if s2c != nil {
+		pReader.setCompressionIndex(s2c, objectEncryptionKey)
+	}
 
 	// Ensure that metadata does not contain sensitive information
 	crypto.RemoveSensitiveEntries(metadata)
@@ -1381,6 +1446,8 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	globalBucketQuotaSys.AccountUsage(bucket, objInfo.Size)
+
 	etag := objInfo.ETag
 	if objInfo.IsCompressed() {
 		if !strings.HasSuffix(objInfo.ETag, "-1") {
@@ -1391,6 +1458,10 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 	}
 	w.Header()[xhttp.ETag] = []string{"\"" + etag + "\""}
 
+	if objInfo.VersionID != "" {
+		w.Header().Set(xhttp.AmzVersionID, objInfo.VersionID)
+	}
+
 	if objectAPI.IsEncryptionSupported() {
 		if crypto.IsEncrypted(objInfo.UserDefined) {
 			switch {
@@ -1405,6 +1476,8 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 
 	writeSuccessResponseHeadersOnly(w)
 
+	scheduleReplication(ctx, objInfo, objectAPI)
+
 	// Notify object created event.
 	sendEvent(eventArgs{
 		EventName:    event.ObjectCreatedPut,
@@ -1528,6 +1601,10 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
 	}
 
+	if mustReplicate(bucket, object, metadata[xhttp.AmzObjectTagging], false) {
+		metadata[xhttp.AmzBucketReplicationStatus] = replication.Pending.String()
+	}
+
 	opts, err = putOpts(ctx, r, bucket, object, metadata)
 	if err != nil {
 		writeErrorResponseHeadersOnly(w, toAPIError(ctx, err))
@@ -1589,25 +1666,14 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 	// has a version ID. If you have not enabled versioning, Amazon S3 sets the value
 	// of the version ID to null. If you have enabled versioning, Amazon S3 assigns a
 	// unique version ID value for the object.
+	var srcVersionID string
 	if u, err := url.Parse(cpSrcPath); err == nil {
-		// Check if versionId query param was added, if yes then check if
-		// its non "null" value, we should error out since we do not support
-		// any versions other than "null".
-		if vid := u.Query().Get("versionId"); vid != "" && vid != "null" {
-			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
-			return
-		}
+		srcVersionID = u.Query().Get("versionId")
 		// Note that url.Parse does the unescaping
 		cpSrcPath = u.Path
 	}
 	if vid := r.Header.Get(xhttp.AmzCopySourceVersionID); vid != "" {
-		// Check if X-Amz-Copy-Source-Version-Id header was added, if yes then check if
-		// its non "null" value, we should error out since we do not support
-		// any versions other than "null".
-		if vid != "null" {
-			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
-			return
-		}
+		srcVersionID = vid
 	}
 
 	srcBucket, srcObject := path2BucketObject(cpSrcPath)
@@ -1617,6 +1683,14 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 		return
 	}
 
+	// Check if versionId was added, if yes then check if its non "null"
+	// value, we should error out for buckets without versioning since they
+	// do not support any versions other than "null".
+	if srcVersionID != "" && srcVersionID != nullVersionID && !globalBucketVersioningSys.Configured(srcBucket) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, srcBucket, srcObject); s3Error != ErrNone {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
 		return
@@ -1648,6 +1722,10 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 	if srcOpts.ServerSideEncryption != nil {
 		getOpts.ServerSideEncryption = encrypt.SSE(srcOpts.ServerSideEncryption)
 	}
+	if globalBucketVersioningSys.Configured(srcBucket) {
+		getOpts.VersionID = srcVersionID
+		srcOpts.VersionID = srcVersionID
+	}
 	dstOpts, err = copyDstOpts(ctx, r, dstBucket, dstObject, nil)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
@@ -1663,7 +1741,7 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 	}
 
 	getObjectNInfo := objectAPI.GetObjectNInfo
-	if api.CacheAPI() != nil {
+	if api.CacheAPI() != nil && getOpts.VersionID == "" {
 		getObjectNInfo = api.CacheAPI().GetObjectNInfo
 	}
 
@@ -1700,7 +1778,11 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 	srcInfo := gr.ObjInfo
 
 	actualPartSize := srcInfo.Size
-	if crypto.IsEncrypted(srcInfo.UserDefined) {
+	if srcInfo.IsCompressed() {
+		// The source reader of compressed objects reports
+		// the decompressed size of the requested range.
+		actualPartSize = srcInfo.GetActualSize()
+	} else if crypto.IsEncrypted(srcInfo.UserDefined) {
 		actualPartSize, err = srcInfo.DecryptedSize()
 		if err != nil {
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
@@ -1791,7 +1873,7 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 
 	isEncrypted := false
 	var objectEncryptionKey []byte
-	if objectAPI.IsEncryptionSupported() && !isCompressed {
+	if objectAPI.IsEncryptionSupported() {
 		li, lerr := objectAPI.ListObjectParts(ctx, dstBucket, dstObject, uploadID, 0, 1, dstOpts)
 		if lerr != nil {
 			writeErrorResponse(ctx, w, toAPIError(ctx, lerr), r.URL, guessIsBrowserReq(r))
@@ -1840,7 +1922,7 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 			}
 
 			info := ObjectInfo{Size: length}
-			srcInfo.Reader, err = hash.NewReader(reader, info.EncryptedSize(), "", "", length, globalCLIContext.StrictS3Compat)
+			srcInfo.Reader, err = hash.NewReader(reader, info.EncryptedSize(), "", "", actualPartSize, globalCLIContext.StrictS3Compat)
 			if err != nil {
 				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 				return
@@ -1941,6 +2023,11 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		return
 	}
 
+	if err = enforceBucketQuota(ctx, bucket, size); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	uploadID := r.URL.Query().Get("uploadId")
 	partIDString := r.URL.Query().Get("partNumber")
 
@@ -2012,7 +2099,6 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 	// Read compression metadata preserved in the init multipart for the decision.
 	_, compressPart := li.UserDefined[ReservedMetadataPrefix+"compression"]
 
-	isCompressed := false
 	if objectAPI.IsCompressionSupported() && compressPart {
 		actualReader, err := hash.NewReader(reader, size, md5hex, sha256hex, actualSize, globalCLIContext.StrictS3Compat)
 		if err != nil {
@@ -2027,7 +2113,6 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		size = -1   // Since compressed size is un-predictable.
 		md5hex = "" // Do not try to verify the content.
 		sha256hex = ""
-		isCompressed = true
 	}
 
 	hashReader, err := hash.NewReader(reader, size, md5hex, sha256hex, actualSize, globalCLIContext.StrictS3Compat)
@@ -2048,7 +2133,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 
 	isEncrypted := false
 	var objectEncryptionKey []byte
-	if objectAPI.IsEncryptionSupported() && !isCompressed {
+	if objectAPI.IsEncryptionSupported() {
 		var li ListPartsInfo
 		li, err = objectAPI.ListObjectParts(ctx, bucket, object, uploadID, 0, 1, ObjectOptions{})
 		if err != nil {
@@ -2104,7 +2189,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 			}
 			info := ObjectInfo{Size: size}
 			// do not try to verify encrypted content
-			hashReader, err = hash.NewReader(reader, info.EncryptedSize(), "", "", size, globalCLIContext.StrictS3Compat)
+			hashReader, err = hash.NewReader(reader, info.EncryptedSize(), "", "", actualSize, globalCLIContext.StrictS3Compat)
 			if err != nil {
 				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 				return
@@ -2439,6 +2524,11 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		completeParts = append(completeParts, part)
 	}
 
+	if err = enforceBucketQuotaMultipart(ctx, objectAPI, bucket, object, uploadID, completeParts); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	completeMultiPartUpload := objectAPI.CompleteMultipartUpload
 
 	// This code is specifically to handle the requirements for slow
@@ -2476,6 +2566,8 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		return
 	}
 
+	globalBucketQuotaSys.AccountUsage(bucket, objInfo.Size)
+
 	// Get object location.
 	location := getObjectLocation(r, globalDomainNames, bucket, object)
 	// Generate complete multipart response.
@@ -2494,9 +2586,15 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 	// Set etag.
 	w.Header()[xhttp.ETag] = []string{"\"" + objInfo.ETag + "\""}
 
+	if objInfo.VersionID != "" {
+		w.Header().Set(xhttp.AmzVersionID, objInfo.VersionID)
+	}
+
 	// Write success response.
 	writeSuccessResponseXML(w, encodedSuccessResponse)
 
+	scheduleReplication(ctx, objInfo, objectAPI)
+
 	// Notify object created event.
 	sendEvent(eventArgs{
 		EventName:    event.ObjectCreatedCompleteMultipartUpload,
@@ -2536,7 +2634,9 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	if vid := r.URL.Query().Get("versionId"); vid != "" && vid != "null" {
+	versioned := globalBucketVersioningSys.Configured(bucket)
+	versionID := r.URL.Query().Get("versionId")
+	if versionID != "" && versionID != nullVersionID && !versioned {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
 		return
 	}
@@ -2546,10 +2646,14 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 		getObjectInfo = api.CacheAPI().GetObjectInfo
 	}
 
-	govBypassPerms := checkRequestAuthType(ctx, r, policy.BypassGovernanceRetentionAction, bucket, object)
-	if _, err := enforceRetentionBypassForDelete(ctx, r, bucket, object, getObjectInfo, govBypassPerms); err != ErrNone {
-		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(err), r.URL, guessIsBrowserReq(r))
-		return
+	// Adding a delete marker leaves the data of a versioned object
+	// untouched, retention only protects the versions themselves.
+	if !versioned || versionID != "" {
+		govBypassPerms := checkRequestAuthType(ctx, r, policy.BypassGovernanceRetentionAction, bucket, object)
+		if _, err := enforceRetentionBypassForDelete(ctx, r, bucket, object, getObjectInfo, govBypassPerms); err != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(err), r.URL, guessIsBrowserReq(r))
+			return
+		}
 	}
 
 	if globalDNSConfig != nil {
@@ -2561,6 +2665,29 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 	}
 
 	// http://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectDELETE.html
+	if versioned {
+		objInfo, err := deleteObjectVersion(ctx, objectAPI, bucket, object, ObjectOptions{VersionID: versionID}, r)
+		if err != nil {
+			switch err.(type) {
+			case BucketNotFound:
+				// When bucket doesn't exist specially handle it.
+				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+				return
+			}
+			// Ignore delete object errors while replying to client, since we are suppposed to reply only 204.
+			writeSuccessNoContent(w)
+			return
+		}
+		if objInfo.VersionID != "" {
+			w.Header().Set(xhttp.AmzVersionID, objInfo.VersionID)
+		}
+		if objInfo.DeleteMarker {
+			w.Header().Set(xhttp.AmzDeleteMarker, "true")
+		}
+		writeSuccessNoContent(w)
+		return
+	}
+
 	if err := deleteObject(ctx, objectAPI, api.CacheAPI(), bucket, object, r); err != nil {
 		switch err.(type) {
 		case BucketNotFound:
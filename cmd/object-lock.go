@@ -44,6 +44,11 @@ func enforceRetentionBypassForDelete(ctx context.Context, r *http.Request, bucke
 	}
 	oi, err = getObjectInfoFn(ctx, bucket, object, opts)
 	if err != nil {
+		// delete markers carry no retention
+		if oi.DeleteMarker {
+			oi.UserDefined = map[string]string{}
+			return oi, ErrNone
+		}
 		// ignore case where object no longer exists
 		if toAPIError(ctx, err).Code == "NoSuchKey" {
 			oi.UserDefined = map[string]string{}
@@ -31,10 +31,15 @@ import (
 	"github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/cmd/rest"
+	"github.com/minio/minio/pkg/bucket/cors"
 	bucketsse "github.com/minio/minio/pkg/bucket/encryption"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/bucket/logging"
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/bucket/versioning"
+	"github.com/minio/minio/pkg/bucket/website"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/madmin"
 	xnet "github.com/minio/minio/pkg/net"
@@ -412,6 +417,180 @@ func (client *peerRESTClient) SetBucketLifecycle(bucket string, bucketLifecycle
 	return nil
 }
 
+// SetBucketVersioning - Set bucket versioning configuration on the peer node
+func (client *peerRESTClient) SetBucketVersioning(bucket string, config *versioning.Versioning) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+
+	var reader bytes.Buffer
+	err := gob.NewEncoder(&reader).Encode(config)
+	if err != nil {
+		return err
+	}
+
+	respBody, err := client.call(peerRESTMethodBucketVersioningSet, values, &reader, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// SetBucketReplicationConfig - Set bucket replication configuration on the peer node
+func (client *peerRESTClient) SetBucketReplicationConfig(bucket string, config *replication.Config) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+
+	var reader bytes.Buffer
+	err := gob.NewEncoder(&reader).Encode(config)
+	if err != nil {
+		return err
+	}
+
+	respBody, err := client.call(peerRESTMethodBucketReplicationSet, values, &reader, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// RemoveBucketReplicationConfig - Remove bucket replication configuration on the peer node
+func (client *peerRESTClient) RemoveBucketReplicationConfig(bucket string) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+	respBody, err := client.call(peerRESTMethodBucketReplicationRemove, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// SetBucketQuotaConfig - Set bucket quota configuration on the peer node
+func (client *peerRESTClient) SetBucketQuotaConfig(bucket string, quota madmin.BucketQuota) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+
+	var reader bytes.Buffer
+	err := gob.NewEncoder(&reader).Encode(quota)
+	if err != nil {
+		return err
+	}
+
+	respBody, err := client.call(peerRESTMethodBucketQuotaSet, values, &reader, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// RemoveBucketQuotaConfig - Remove bucket quota configuration on the peer node
+func (client *peerRESTClient) RemoveBucketQuotaConfig(bucket string) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+	respBody, err := client.call(peerRESTMethodBucketQuotaRemove, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// SetBucketCorsConfig - Set bucket CORS configuration on the peer node
+func (client *peerRESTClient) SetBucketCorsConfig(bucket string, config *cors.Config) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+
+	var reader bytes.Buffer
+	err := gob.NewEncoder(&reader).Encode(config)
+	if err != nil {
+		return err
+	}
+
+	respBody, err := client.call(peerRESTMethodBucketCorsSet, values, &reader, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// RemoveBucketCorsConfig - Remove bucket CORS configuration on the peer node
+func (client *peerRESTClient) RemoveBucketCorsConfig(bucket string) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+	respBody, err := client.call(peerRESTMethodBucketCorsRemove, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// SetBucketWebsiteConfig - Set bucket website configuration on the peer node
+func (client *peerRESTClient) SetBucketWebsiteConfig(bucket string, config *website.Config) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+
+	var reader bytes.Buffer
+	err := gob.NewEncoder(&reader).Encode(config)
+	if err != nil {
+		return err
+	}
+
+	respBody, err := client.call(peerRESTMethodBucketWebsiteSet, values, &reader, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// RemoveBucketWebsiteConfig - Remove bucket website configuration on the peer node
+func (client *peerRESTClient) RemoveBucketWebsiteConfig(bucket string) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+	respBody, err := client.call(peerRESTMethodBucketWebsiteRemove, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// SetBucketLoggingConfig - Set bucket logging configuration on the peer node
+func (client *peerRESTClient) SetBucketLoggingConfig(bucket string, config *logging.Config) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+
+	var reader bytes.Buffer
+	err := gob.NewEncoder(&reader).Encode(config)
+	if err != nil {
+		return err
+	}
+
+	respBody, err := client.call(peerRESTMethodBucketLoggingSet, values, &reader, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// RemoveBucketLoggingConfig - Remove bucket logging configuration on the peer node
+func (client *peerRESTClient) RemoveBucketLoggingConfig(bucket string) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+	respBody, err := client.call(peerRESTMethodBucketLoggingRemove, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
 // RemoveBucketSSEConfig - Remove bucket encryption configuration on the peer node
 func (client *peerRESTClient) RemoveBucketSSEConfig(bucket string) error {
 	values := make(url.Values)
@@ -550,6 +729,45 @@ func (client *peerRESTClient) LoadUser(accessKey string, temp bool) (err error)
 	return nil
 }
 
+// DeleteServiceAccount - delete a specific service account.
+func (client *peerRESTClient) DeleteServiceAccount(accessKey string) (err error) {
+	values := make(url.Values)
+	values.Set(peerRESTUser, accessKey)
+
+	respBody, err := client.call(peerRESTMethodDeleteServiceAccount, values, nil, -1)
+	if err != nil {
+		return
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// RevokeSTSSession - revoke specific temporary credentials.
+func (client *peerRESTClient) RevokeSTSSession(accessKey string) (err error) {
+	values := make(url.Values)
+	values.Set(peerRESTUser, accessKey)
+
+	respBody, err := client.call(peerRESTMethodRevokeSTSSession, values, nil, -1)
+	if err != nil {
+		return
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// LoadServiceAccount - reload a specific service account.
+func (client *peerRESTClient) LoadServiceAccount(accessKey string) (err error) {
+	values := make(url.Values)
+	values.Set(peerRESTUser, accessKey)
+
+	respBody, err := client.call(peerRESTMethodLoadServiceAccount, values, nil, -1)
+	if err != nil {
+		return
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
 // LoadUsers - send load users command to peer nodes.
 func (client *peerRESTClient) LoadUsers() (err error) {
 	respBody, err := client.call(peerRESTMethodLoadUsers, nil, nil, -1)
@@ -560,6 +778,36 @@ func (client *peerRESTClient) LoadUsers() (err error) {
 	return nil
 }
 
+// LoadTierConfig - send load remote tiers config command to peer nodes.
+func (client *peerRESTClient) LoadTierConfig() (err error) {
+	respBody, err := client.call(peerRESTMethodLoadTierConfig, nil, nil, -1)
+	if err != nil {
+		return
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// LoadBucketTargets - send load remote bucket targets command to peer nodes.
+func (client *peerRESTClient) LoadBucketTargets() (err error) {
+	respBody, err := client.call(peerRESTMethodLoadBucketTargets, nil, nil, -1)
+	if err != nil {
+		return
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// LoadZonesMeta - send load zones decommissioning status command to peer nodes.
+func (client *peerRESTClient) LoadZonesMeta() (err error) {
+	respBody, err := client.call(peerRESTMethodLoadZonesMeta, nil, nil, -1)
+	if err != nil {
+		return
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
 // LoadGroup - send load group command to peers.
 func (client *peerRESTClient) LoadGroup(group string) error {
 	values := make(url.Values)
@@ -617,7 +865,11 @@ func (client *peerRESTClient) BackgroundHealStatus() (madmin.BgHealState, error)
 // BgLifecycleOpsStatus describes the status
 // of the background lifecycle operations
 type BgLifecycleOpsStatus struct {
-	LastActivity time.Time
+	LastActivity         time.Time
+	ExpiredObjects       uint64
+	ExpiredVersions      uint64
+	ExpiredDeleteMarkers uint64
+	TransitionedObjects  uint64
 }
 
 // BgOpsStatus describes the status of all operations performed
@@ -17,7 +17,7 @@
 package cmd
 
 const (
-	peerRESTVersion       = "v6"
+	peerRESTVersion       = "v17"
 	peerRESTVersionPrefix = SlashSeparator + peerRESTVersion
 	peerRESTPrefix        = minioReservedBucketPath + "/peer"
 	peerRESTPath          = peerRESTPrefix + peerRESTVersionPrefix
@@ -39,11 +39,17 @@ const (
 	peerRESTMethodBucketPolicyRemove           = "/removebucketpolicy"
 	peerRESTMethodLoadUser                     = "/loaduser"
 	peerRESTMethodDeleteUser                   = "/deleteuser"
+	peerRESTMethodLoadServiceAccount           = "/loadserviceaccount"
+	peerRESTMethodDeleteServiceAccount         = "/deleteserviceaccount"
+	peerRESTMethodRevokeSTSSession             = "/revokestssession"
 	peerRESTMethodLoadPolicy                   = "/loadpolicy"
 	peerRESTMethodLoadPolicyMapping            = "/loadpolicymapping"
 	peerRESTMethodDeletePolicy                 = "/deletepolicy"
 	peerRESTMethodLoadUsers                    = "/loadusers"
 	peerRESTMethodLoadGroup                    = "/loadgroup"
+	peerRESTMethodLoadTierConfig               = "/loadtierconfig"
+	peerRESTMethodLoadBucketTargets            = "/loadbuckettargets"
+	peerRESTMethodLoadZonesMeta                = "/loadzonesmeta"
 	peerRESTMethodStartProfiling               = "/startprofiling"
 	peerRESTMethodDownloadProfilingData        = "/downloadprofilingdata"
 	peerRESTMethodBucketPolicySet              = "/setbucketpolicy"
@@ -58,6 +64,17 @@ const (
 	peerRESTMethodBucketLifecycleRemove        = "/removebucketlifecycle"
 	peerRESTMethodBucketEncryptionSet          = "/setbucketencryption"
 	peerRESTMethodBucketEncryptionRemove       = "/removebucketencryption"
+	peerRESTMethodBucketVersioningSet          = "/setbucketversioning"
+	peerRESTMethodBucketReplicationSet         = "/setbucketreplication"
+	peerRESTMethodBucketReplicationRemove      = "/removebucketreplication"
+	peerRESTMethodBucketQuotaSet               = "/setbucketquota"
+	peerRESTMethodBucketQuotaRemove            = "/removebucketquota"
+	peerRESTMethodBucketCorsSet                = "/setbucketcors"
+	peerRESTMethodBucketCorsRemove             = "/removebucketcors"
+	peerRESTMethodBucketWebsiteSet             = "/setbucketwebsite"
+	peerRESTMethodBucketWebsiteRemove          = "/removebucketwebsite"
+	peerRESTMethodBucketLoggingSet             = "/setbucketlogging"
+	peerRESTMethodBucketLoggingRemove          = "/removebucketlogging"
 	peerRESTMethodLog                          = "/log"
 	peerRESTMethodHardwareCPUInfo              = "/cpuhardwareinfo"
 	peerRESTMethodHardwareNetworkInfo          = "/networkhardwareinfo"
@@ -23,14 +23,18 @@ import (
 	"fmt"
 	"net/http"
 	"path"
+	"strconv"
+	"strings"
 	"sync"
 
 	jsoniter "github.com/json-iterator/go"
 	miniogopolicy "github.com/minio/minio-go/v6/pkg/policy"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/policy/condition"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/handlers"
+	"github.com/minio/minio/pkg/madmin"
 )
 
 // PolicySys - policy subsystem.
@@ -91,6 +95,73 @@ func (sys *PolicySys) IsAllowed(args policy.Args) bool {
 	return args.IsOwner
 }
 
+// SimulatePolicy - evaluates given policy args like IsAllowed and also
+// returns the statements of the bucket policy which apply to them.
+func (sys *PolicySys) SimulatePolicy(args policy.Args) madmin.PolicySimulationResult {
+	result := madmin.PolicySimulationResult{Allowed: sys.IsAllowed(args)}
+
+	var p policy.Policy
+	if globalIsGateway {
+		objAPI := newObjectLayerFn()
+		if objAPI == nil {
+			return result
+		}
+		config, err := objAPI.GetBucketPolicy(context.Background(), args.BucketName)
+		if err != nil {
+			return result
+		}
+		p = *config
+	} else {
+		sys.RLock()
+		config, found := sys.bucketPolicyMap[args.BucketName]
+		sys.RUnlock()
+		if !found {
+			return result
+		}
+		p = config
+	}
+
+	for _, i := range p.MatchedStatements(args) {
+		data, err := json.Marshal(p.Statements[i])
+		if err != nil {
+			logger.LogIf(context.Background(), err)
+			continue
+		}
+		result.Statements = append(result.Statements, madmin.PolicySimulationStatement{
+			Source:    madmin.PolicySourceBucket,
+			Index:     i,
+			Effect:    string(p.Statements[i].Effect),
+			Statement: data,
+		})
+	}
+	return result
+}
+
+// hasConditionKey - returns whether the policy of given bucket has
+// conditions on given condition key.
+func (sys *PolicySys) hasConditionKey(bucketName string, key condition.Key) bool {
+	if globalIsGateway {
+		// No cached policies are available in gateway mode.
+		return false
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+
+	p, found := sys.bucketPolicyMap[bucketName]
+	if !found {
+		return false
+	}
+
+	for _, statement := range p.Statements {
+		if statement.Conditions.Keys().Match(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Loads policies for all buckets into PolicySys.
 func (sys *PolicySys) load(buckets []BucketInfo, objAPI ObjectLayer) error {
 	for _, bucket := range buckets {
@@ -152,7 +223,7 @@ func getConditionValues(request *http.Request, locationConstraint string, userna
 		return "Anonymous"
 	}()
 	args := map[string][]string{
-		"CurrenTime":      {currTime.Format(event.AMZTimeFormat)},
+		"CurrentTime":     {currTime.Format(event.AMZTimeFormat)},
 		"EpochTime":       {fmt.Sprintf("%d", currTime.Unix())},
 		"principaltype":   {principalType},
 		"SecureTransport": {fmt.Sprintf("%t", request.TLS != nil)},
@@ -164,6 +235,9 @@ func getConditionValues(request *http.Request, locationConstraint string, userna
 	}
 
 	for key, values := range request.Header {
+		if isClaimConditionKey(key) {
+			continue
+		}
 		if existingValues, found := args[key]; found {
 			args[key] = append(existingValues, values...)
 		} else {
@@ -172,6 +246,9 @@ func getConditionValues(request *http.Request, locationConstraint string, userna
 	}
 
 	for key, values := range request.URL.Query() {
+		if isClaimConditionKey(key) {
+			continue
+		}
 		if existingValues, found := args[key]; found {
 			args[key] = append(existingValues, values...)
 		} else {
@@ -183,13 +260,55 @@ func getConditionValues(request *http.Request, locationConstraint string, userna
 		args["LocationConstraint"] = []string{locationConstraint}
 	}
 
-	// JWT specific values
+	// JWT and LDAP specific values
+	for k, v := range getClaimConditionValues(claims) {
+		args[k] = v
+	}
+	return args
+}
+
+// isClaimConditionKey - returns whether the condition value name is of
+// a JWT or LDAP claim, which are only taken from the credentials.
+func isClaimConditionKey(name string) bool {
+	return strings.HasPrefix(name, string(condition.JWTClaim)) ||
+		strings.HasPrefix(name, "ldap:")
+}
+
+// getClaimConditionValues - returns the condition values of the claims of
+// temporary credentials, such as "jwt:preferred_username" for the policy
+// variable "${jwt:preferred_username}". String, number and boolean claims
+// and lists of strings are supported.
+func getClaimConditionValues(claims map[string]interface{}) map[string][]string {
+	args := make(map[string][]string)
 	for k, v := range claims {
-		vStr, ok := v.(string)
-		if ok {
-			args[k] = []string{vStr}
+		var values []string
+		switch v := v.(type) {
+		case string:
+			values = []string{v}
+		case bool:
+			values = []string{strconv.FormatBool(v)}
+		case float64:
+			values = []string{strconv.FormatFloat(v, 'f', -1, 64)}
+		case json.Number:
+			values = []string{v.String()}
+		case []interface{}:
+			for _, e := range v {
+				if s, ok := e.(string); ok {
+					values = append(values, s)
+				}
+			}
+		}
+		if len(values) > 0 {
+			args[string(condition.JWTClaim)+k] = values
 		}
 	}
+
+	if user, ok := claims[ldapUser].(string); ok {
+		args[condition.LDAPUsername.Name()] = []string{user}
+	}
+	if userDN, ok := claims[ldapUserDN].(string); ok {
+		args[condition.LDAPUserDN.Name()] = []string{userDN}
+	}
 	return args
 }
 
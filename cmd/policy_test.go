@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"net/http/httptest"
 	"reflect"
 	"testing"
 
@@ -281,6 +282,41 @@ func getReadOnlyStatement(bucketName, prefix string) []miniogopolicy.Statement {
 	}
 }
 
+func TestGetConditionValuesClaims(t *testing.T) {
+	// Claims can't be set by the query parameters or headers of a request.
+	r := httptest.NewRequest("GET", "/bucket/object?jwt:department=admin&ldap:username=admin&preferred_username=admin", nil)
+	claims := map[string]interface{}{
+		"sub":                "2c1b3a",
+		"preferred_username": "alice",
+		"groups":             []interface{}{"finance", "audit"},
+		"email_verified":     true,
+		"uid":                float64(1001),
+		ldapUser:             "bob",
+		ldapUserDN:           "uid=bob,dc=min,dc=io",
+	}
+	values := getConditionValues(r, "", "accesskey", claims)
+
+	expectedValues := map[string][]string{
+		"username":               {"accesskey"},
+		"preferred_username":     {"admin"},
+		"jwt:sub":                {"2c1b3a"},
+		"jwt:preferred_username": {"alice"},
+		"jwt:groups":             {"finance", "audit"},
+		"jwt:email_verified":     {"true"},
+		"jwt:uid":                {"1001"},
+		"ldap:username":          {"bob"},
+		"ldap:dn":                {"uid=bob,dc=min,dc=io"},
+	}
+	for k, v := range expectedValues {
+		if !reflect.DeepEqual(values[k], v) {
+			t.Errorf("%s: expected %v, got %v", k, v, values[k])
+		}
+	}
+	if v, ok := values["jwt:department"]; ok {
+		t.Errorf("expected no jwt:department value, got %v", v)
+	}
+}
+
 func TestPolicyToBucketAccessPolicy(t *testing.T) {
 	case1Policy := &policy.Policy{
 		Version: policy.DefaultVersion,
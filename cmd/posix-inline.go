@@ -0,0 +1,62 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	slashpath "path"
+)
+
+// Name of the only part of objects stored inline in `xl.json`.
+const inlinePartName = "part.1"
+
+// readInlineVersion - returns the version of an object holding the
+// data of the given part inline in `xl.json`, errFileNotFound if the
+// part is not stored inline. Such a part is addressed like a part
+// file, either alongside `xl.json` or in the data directory of the
+// version, so that callers don't need to know where data is stored.
+func (s *posix) readInlineVersion(volumeDir, path string) (xlMetaV1, error) {
+	if slashpath.Base(path) != inlinePartName {
+		return xlMetaV1{}, errFileNotFound
+	}
+
+	dir := slashpath.Dir(path)
+	candidates := []struct {
+		object, dataDir string
+	}{
+		{dir, ""},
+		{slashpath.Dir(dir), slashpath.Base(dir)},
+	}
+	for _, c := range candidates {
+		buf, err := ioutil.ReadFile(pathJoin(volumeDir, c.object, xlMetaJSONFile))
+		if err != nil {
+			continue
+		}
+		xlMeta, err := xlMetaV1UnmarshalJSON(context.Background(), buf)
+		if err != nil {
+			return xlMetaV1{}, errFileCorrupt
+		}
+		for _, version := range xlMeta.allVersions() {
+			if version.Inline && version.DataDir == c.dataDir {
+				return version, nil
+			}
+		}
+		return xlMetaV1{}, errFileNotFound
+	}
+	return xlMetaV1{}, errFileNotFound
+}
@@ -836,8 +836,6 @@ func (s *posix) ReadFile(volume, path string, offset int64, buffer []byte, verif
 		return 0, err
 	}
 
-	var n int
-
 	// Stat a volume entry.
 	_, err = os.Stat((volumeDir))
 	if err != nil {
@@ -860,7 +858,11 @@ func (s *posix) ReadFile(volume, path string, offset int64, buffer []byte, verif
 	if err != nil {
 		switch {
 		case os.IsNotExist(err):
-			return 0, errFileNotFound
+			version, ierr := s.readInlineVersion(volumeDir, path)
+			if ierr != nil {
+				return 0, errFileNotFound
+			}
+			return s.readFileAt(bytes.NewReader(version.Data), offset, buffer, verifier)
 		case os.IsPermission(err):
 			return 0, errFileAccessDenied
 		case isSysErrNotDir(err):
@@ -888,8 +890,19 @@ func (s *posix) ReadFile(volume, path string, offset int64, buffer []byte, verif
 		return 0, errIsNotRegular
 	}
 
+	return s.readFileAt(file, offset, buffer, verifier)
+}
+
+// fileReader - reads a part file or the data of a part stored inline.
+type fileReader interface {
+	io.Reader
+	io.ReaderAt
+}
+
+// readFileAt - implements ReadFile on an opened part.
+func (s *posix) readFileAt(file fileReader, offset int64, buffer []byte, verifier *BitrotVerifier) (int64, error) {
 	if verifier == nil {
-		n, err = file.ReadAt(buffer, offset)
+		n, err := file.ReadAt(buffer, offset)
 		return int64(n), err
 	}
 
@@ -897,19 +910,19 @@ func (s *posix) ReadFile(volume, path string, offset int64, buffer []byte, verif
 	defer s.pool.Put(bufp)
 
 	h := verifier.algorithm.New()
-	if _, err = io.CopyBuffer(h, io.LimitReader(file, offset), *bufp); err != nil {
+	if _, err := io.CopyBuffer(h, io.LimitReader(file, offset), *bufp); err != nil {
 		return 0, err
 	}
 
-	if n, err = io.ReadFull(file, buffer); err != nil {
+	if n, err := io.ReadFull(file, buffer); err != nil {
 		return int64(n), err
 	}
 
-	if _, err = h.Write(buffer); err != nil {
+	if _, err := h.Write(buffer); err != nil {
 		return 0, err
 	}
 
-	if _, err = io.CopyBuffer(h, file, *bufp); err != nil {
+	if _, err := io.CopyBuffer(h, file, *bufp); err != nil {
 		return 0, err
 	}
 
@@ -1007,7 +1020,14 @@ func (s *posix) ReadFileStream(volume, path string, offset, length int64) (io.Re
 	if err != nil {
 		switch {
 		case os.IsNotExist(err):
-			return nil, errFileNotFound
+			version, ierr := s.readInlineVersion(volumeDir, path)
+			if ierr != nil {
+				return nil, errFileNotFound
+			}
+			if offset > int64(len(version.Data)) {
+				offset = int64(len(version.Data))
+			}
+			return ioutil.NopCloser(io.LimitReader(bytes.NewReader(version.Data[offset:]), length)), nil
 		case os.IsPermission(err):
 			return nil, errFileAccessDenied
 		case isSysErrNotDir(err):
@@ -1240,6 +1260,14 @@ func (s *posix) StatFile(volume, path string) (file FileInfo, err error) {
 	if err != nil {
 		switch {
 		case os.IsNotExist(err):
+			if version, ierr := s.readInlineVersion(volumeDir, path); ierr == nil {
+				return FileInfo{
+					Volume:  volume,
+					Name:    path,
+					ModTime: version.Stat.ModTime,
+					Size:    int64(len(version.Data)),
+				}, nil
+			}
 			// File is really not found.
 			return FileInfo{}, errFileNotFound
 		case isSysErrIO(err):
@@ -1557,12 +1585,30 @@ func (s *posix) VerifyFile(volume, path string, fileSize int64, algo BitrotAlgor
 	// Open the file for reading.
 	file, err := os.Open(filePath)
 	if err != nil {
+		if os.IsNotExist(err) {
+			if version, ierr := s.readInlineVersion(volumeDir, path); ierr == nil {
+				return s.verifyBitrot(bytes.NewReader(version.Data), int64(len(version.Data)),
+					fileSize, algo, sum, shardSize)
+			}
+		}
 		return osErrToFSFileErr(err)
 	}
 
 	// Close the file descriptor.
 	defer file.Close()
 
+	fi, err := file.Stat()
+	if err != nil {
+		// Unable to stat on the file, return an expected error
+		// for healing code to fix this file.
+		return err
+	}
+
+	return s.verifyBitrot(file, fi.Size(), fileSize, algo, sum, shardSize)
+}
+
+// verifyBitrot - implements VerifyFile on an opened part of the given size.
+func (s *posix) verifyBitrot(file io.Reader, size, fileSize int64, algo BitrotAlgorithm, sum []byte, shardSize int64) (err error) {
 	if algo != HighwayHash256S {
 		bufp := s.pool.Get().(*[]byte)
 		defer s.pool.Put(bufp)
@@ -1581,14 +1627,6 @@ func (s *posix) VerifyFile(volume, path string, fileSize int64, algo BitrotAlgor
 	buf := make([]byte, shardSize)
 	h := algo.New()
 	hashBuf := make([]byte, h.Size())
-	fi, err := file.Stat()
-	if err != nil {
-		// Unable to stat on the file, return an expected error
-		// for healing code to fix this file.
-		return err
-	}
-
-	size := fi.Size()
 
 	// Calculate the size of the bitrot file and compare
 	// it with the actual file size.
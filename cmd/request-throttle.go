@@ -0,0 +1,274 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio/cmd/config/throttle"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/handlers"
+	"github.com/minio/minio/pkg/ratelimit"
+)
+
+// API classes which request rates are limited for.
+const (
+	readAPIClass = iota
+	writeAPIClass
+	listAPIClass
+	numAPIClasses
+)
+
+// Query parameters of the bucket list APIs, GET requests on buckets with
+// other query parameters read bucket sub-resources.
+var listAPIQueries = map[string]bool{
+	"list-type":          true,
+	"prefix":             true,
+	"delimiter":          true,
+	"marker":             true,
+	"max-keys":           true,
+	"encoding-type":      true,
+	"continuation-token": true,
+	"fetch-owner":        true,
+	"start-after":        true,
+	"uploads":            true,
+	"max-uploads":        true,
+	"key-marker":         true,
+	"upload-id-marker":   true,
+	"versions":           true,
+	"version-id-marker":  true,
+	"events":             true, // ListenBucketNotification
+}
+
+// getRequestAPIClass - returns the API class of the request.
+func getRequestAPIClass(r *http.Request, bucket, object string) int {
+	query := r.URL.Query()
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		if bucket == "" {
+			return listAPIClass
+		}
+		if object != "" {
+			if _, ok := query["uploadId"]; ok {
+				return listAPIClass
+			}
+			return readAPIClass
+		}
+		for k := range query {
+			if !listAPIQueries[k] {
+				return readAPIClass
+			}
+		}
+		return listAPIClass
+	case http.MethodPost:
+		if _, ok := query["select"]; ok {
+			return readAPIClass
+		}
+	}
+	return writeAPIClass
+}
+
+// requestThrottler - token buckets limiting the request rates and the
+// bandwidth of users and buckets, limits which aren't set are nil.
+type requestThrottler struct {
+	userRequests   [numAPIClasses]*ratelimit.Group
+	bucketRequests [numAPIClasses]*ratelimit.Group
+
+	userGet, userPut     *ratelimit.Group
+	bucketGet, bucketPut *ratelimit.Group
+}
+
+// newRateLimitGroup - returns token buckets allowing a burst of a second
+// worth of tokens, nil if the rate is unlimited.
+func newRateLimitGroup(rate uint64) *ratelimit.Group {
+	if rate == 0 {
+		return nil
+	}
+	return ratelimit.NewGroup(float64(rate), int64(rate))
+}
+
+// newRequestThrottler - returns the request throttler of the config, nil
+// when no limits are set.
+func newRequestThrottler(cfg throttle.Config) *requestThrottler {
+	if !cfg.Enabled() {
+		return nil
+	}
+	t := &requestThrottler{
+		userGet:   newRateLimitGroup(cfg.UserBandwidth.Get),
+		userPut:   newRateLimitGroup(cfg.UserBandwidth.Put),
+		bucketGet: newRateLimitGroup(cfg.BucketBandwidth.Get),
+		bucketPut: newRateLimitGroup(cfg.BucketBandwidth.Put),
+	}
+	t.userRequests[readAPIClass] = newRateLimitGroup(cfg.UserRequests.Read)
+	t.userRequests[writeAPIClass] = newRateLimitGroup(cfg.UserRequests.Write)
+	t.userRequests[listAPIClass] = newRateLimitGroup(cfg.UserRequests.List)
+	t.bucketRequests[readAPIClass] = newRateLimitGroup(cfg.BucketRequests.Read)
+	t.bucketRequests[writeAPIClass] = newRateLimitGroup(cfg.BucketRequests.Write)
+	t.bucketRequests[listAPIClass] = newRateLimitGroup(cfg.BucketRequests.List)
+	return t
+}
+
+// allow - takes a request token of the user and of the bucket, requests
+// which aren't on a bucket are only limited by the user limits.
+func (t *requestThrottler) allow(user, bucket string, class int) bool {
+	if g := t.userRequests[class]; g != nil && !g.Get(user).Allow() {
+		return false
+	}
+	if bucket == "" {
+		return true
+	}
+	if g := t.bucketRequests[class]; g != nil && !g.Get(bucket).Allow() {
+		return false
+	}
+	return true
+}
+
+// bandwidthBuckets - returns the token buckets of the user and of the
+// bucket which limit the bandwidth.
+func bandwidthBuckets(userGroup, bucketGroup *ratelimit.Group, user, bucket string) (buckets []*ratelimit.Bucket) {
+	if userGroup != nil {
+		buckets = append(buckets, userGroup.Get(user))
+	}
+	if bucketGroup != nil {
+		buckets = append(buckets, bucketGroup.Get(bucket))
+	}
+	return buckets
+}
+
+// throttledResponseWriter - limits the bandwidth of the response body.
+type throttledResponseWriter struct {
+	http.ResponseWriter
+	w io.Writer
+}
+
+func (t *throttledResponseWriter) Write(p []byte) (int, error) {
+	return t.w.Write(p)
+}
+
+// Calls the underlying Flush.
+func (t *throttledResponseWriter) Flush() {
+	t.ResponseWriter.(http.Flusher).Flush()
+}
+
+// throttledRequestBody - limits the bandwidth of the request body.
+type throttledRequestBody struct {
+	io.Reader
+	io.Closer
+}
+
+var (
+	// Request rate and bandwidth limits, nil when not throttled.
+	globalRequestThrottler   *requestThrottler
+	globalRequestThrottlerMu sync.RWMutex
+)
+
+// getRequestThrottler - returns the current request throttler.
+func getRequestThrottler() *requestThrottler {
+	globalRequestThrottlerMu.RLock()
+	defer globalRequestThrottlerMu.RUnlock()
+	return globalRequestThrottler
+}
+
+// setRequestThrottler - replaces the request throttler, called when the
+// throttle configuration is (re)loaded.
+func setRequestThrottler(t *requestThrottler) {
+	globalRequestThrottlerMu.Lock()
+	defer globalRequestThrottlerMu.Unlock()
+	globalRequestThrottler = t
+}
+
+// getThrottleUser - returns the access key which the request is limited
+// by, service accounts share the limits of their parent user. Requests
+// are charged to a user only once their signature is verified, anonymous
+// requests and requests with invalid signatures are limited by the client
+// address instead so that they cannot exhaust the limits of a user, ok is
+// false for them.
+func getThrottleUser(r *http.Request) (user string, ok bool) {
+	var cred auth.Credentials
+	switch getRequestAuthType(r) {
+	case authTypeSigned, authTypePresigned, authTypeStreamingSigned:
+		// The region is validated by the API handlers.
+		if reqSignatureV4Verify(r, "", serviceS3) == ErrNone {
+			cred, _, _ = getReqAccessKeyV4(r, "", serviceS3)
+		}
+	case authTypeSignedV2, authTypePresignedV2:
+		if isReqAuthenticatedV2(r) == ErrNone {
+			cred, _, _ = getReqAccessKeyV2(r)
+		}
+	}
+	if cred.AccessKey == "" {
+		// Access keys cannot contain ':', so addresses never collide
+		// with users.
+		return "ip:" + handlers.GetSourceIP(r), false
+	}
+	if cred.IsServiceAccount() {
+		return cred.ParentUser, true
+	}
+	return cred.AccessKey, true
+}
+
+type requestThrottleHandler struct {
+	handler http.Handler
+}
+
+// setRequestThrottleHandler - limits the request rates and the bandwidth
+// of S3 requests of users and buckets as configured by the throttle
+// sub-system, requests exceeding the rate limits fail with SlowDown.
+func setRequestThrottleHandler(h http.Handler) http.Handler {
+	return requestThrottleHandler{h}
+}
+
+func (h requestThrottleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	t := getRequestThrottler()
+	if t == nil || strings.HasPrefix(r.URL.Path, minioReservedBucketPath) {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	bucket, object := request2BucketObjectName(r)
+	user, ok := getThrottleUser(r)
+	class := getRequestAPIClass(r, bucket, object)
+
+	// Unauthenticated requests share separate limits of the bucket,
+	// so that they cannot exhaust the limits of the users of the
+	// bucket. Bucket names cannot contain ':'.
+	throttleBucket := bucket
+	if !ok && bucket != "" {
+		throttleBucket = "anonymous:" + bucket
+	}
+	if !t.allow(user, throttleBucket, class) {
+		writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrSlowDown), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if object != "" {
+		switch {
+		case r.Method == http.MethodPut:
+			if buckets := bandwidthBuckets(t.userPut, t.bucketPut, user, throttleBucket); len(buckets) > 0 {
+				r.Body = throttledRequestBody{ratelimit.NewReader(r.Context(), r.Body, buckets...), r.Body}
+			}
+		case r.Method == http.MethodGet && class == readAPIClass:
+			if buckets := bandwidthBuckets(t.userGet, t.bucketGet, user, throttleBucket); len(buckets) > 0 {
+				w = &throttledResponseWriter{w, ratelimit.NewWriter(r.Context(), w, buckets...)}
+			}
+		}
+	}
+	h.handler.ServeHTTP(w, r)
+}
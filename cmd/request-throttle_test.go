@@ -0,0 +1,196 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/cmd/config/throttle"
+	"github.com/minio/minio/pkg/auth"
+)
+
+func TestGetRequestAPIClass(t *testing.T) {
+	testCases := []struct {
+		method        string
+		url           string
+		expectedClass int
+	}{
+		{http.MethodGet, "/", listAPIClass},
+		{http.MethodGet, "/bucket", listAPIClass},
+		{http.MethodGet, "/bucket?list-type=2&prefix=a&delimiter=%2F", listAPIClass},
+		{http.MethodGet, "/bucket?uploads", listAPIClass},
+		{http.MethodGet, "/bucket?versions", listAPIClass},
+		{http.MethodGet, "/bucket?policy", readAPIClass},
+		{http.MethodGet, "/bucket?location", readAPIClass},
+		{http.MethodHead, "/bucket", listAPIClass},
+		{http.MethodGet, "/bucket/object", readAPIClass},
+		{http.MethodHead, "/bucket/object", readAPIClass},
+		{http.MethodGet, "/bucket/object?uploadId=id", listAPIClass},
+		{http.MethodPost, "/bucket/object?select&select-type=2", readAPIClass},
+		{http.MethodPut, "/bucket/object", writeAPIClass},
+		{http.MethodPost, "/bucket?delete", writeAPIClass},
+		{http.MethodDelete, "/bucket/object", writeAPIClass},
+		{http.MethodPut, "/bucket", writeAPIClass},
+	}
+
+	for i, testCase := range testCases {
+		r := httptest.NewRequest(testCase.method, testCase.url, nil)
+		bucket, object := path2BucketObject(r.URL.Path)
+		if class := getRequestAPIClass(r, bucket, object); class != testCase.expectedClass {
+			t.Errorf("Test %d: %s %s: expected API class %d, got %d", i+1,
+				testCase.method, testCase.url, testCase.expectedClass, class)
+		}
+	}
+}
+
+func TestRequestThrottler(t *testing.T) {
+	if newRequestThrottler(throttle.Config{}) != nil {
+		t.Fatal("expected no throttler without limits")
+	}
+
+	th := newRequestThrottler(throttle.Config{
+		UserRequests:   throttle.Requests{Write: 2},
+		BucketRequests: throttle.Requests{Read: 1},
+	})
+
+	// Write requests of each user are limited.
+	if !th.allow("alice", "bucket", writeAPIClass) || !th.allow("alice", "other", writeAPIClass) {
+		t.Fatal("expected write requests within the burst to be allowed")
+	}
+	if th.allow("alice", "bucket", writeAPIClass) {
+		t.Error("expected write request exceeding the user limit to be throttled")
+	}
+	if !th.allow("bob", "bucket", writeAPIClass) {
+		t.Error("expected write request of another user to be allowed")
+	}
+
+	// Read requests on each bucket are limited.
+	if !th.allow("alice", "bucket", readAPIClass) {
+		t.Fatal("expected read request within the burst to be allowed")
+	}
+	if th.allow("bob", "bucket", readAPIClass) {
+		t.Error("expected read request exceeding the bucket limit to be throttled")
+	}
+	if !th.allow("bob", "other", readAPIClass) || !th.allow("bob", "", readAPIClass) {
+		t.Error("expected read requests on other buckets to be allowed")
+	}
+
+	// List requests are unlimited.
+	for i := 0; i < 10; i++ {
+		if !th.allow("alice", "bucket", listAPIClass) {
+			t.Fatal("expected unlimited list requests to be allowed")
+		}
+	}
+}
+
+func TestGetThrottleUser(t *testing.T) {
+	cred, err := auth.GetNewCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(cred auth.Credentials) { globalActiveCred = cred }(globalActiveCred)
+	globalActiveCred = cred
+
+	newRequest := func(accessKey, secretKey string, v2 bool) *http.Request {
+		var req *http.Request
+		switch {
+		case accessKey == "":
+			req, err = newTestRequest(http.MethodGet, "http://127.0.0.1:9000/bucket/object", 0, nil)
+		case v2:
+			req, err = newTestSignedRequestV2(http.MethodGet, "http://127.0.0.1:9000/bucket/object", 0, nil, accessKey, secretKey, nil)
+		default:
+			req, err = newTestSignedRequestV4(http.MethodGet, "http://127.0.0.1:9000/bucket/object", 0, nil, accessKey, secretKey, nil)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.RemoteAddr = "10.0.0.1:1234"
+		return req
+	}
+
+	testCases := []struct {
+		req          *http.Request
+		expectedUser string
+		expectedOk   bool
+	}{
+		// Anonymous requests are limited by the client address.
+		{newRequest("", "", false), "ip:10.0.0.1", false},
+		// Verified requests are limited by the user.
+		{newRequest(cred.AccessKey, cred.SecretKey, false), cred.AccessKey, true},
+		{newRequest(cred.AccessKey, cred.SecretKey, true), cred.AccessKey, true},
+		// Requests with invalid signatures must not be charged to the user.
+		{newRequest(cred.AccessKey, "invalid-secret-key", false), "ip:10.0.0.1", false},
+		{newRequest(cred.AccessKey, "invalid-secret-key", true), "ip:10.0.0.1", false},
+	}
+
+	for i, testCase := range testCases {
+		user, ok := getThrottleUser(testCase.req)
+		if user != testCase.expectedUser || ok != testCase.expectedOk {
+			t.Errorf("Test %d: expected user %q (%v), got %q (%v)", i+1,
+				testCase.expectedUser, testCase.expectedOk, user, ok)
+		}
+	}
+}
+
+func TestRequestThrottleHandlerAnonymous(t *testing.T) {
+	cred, err := auth.GetNewCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(cred auth.Credentials) { globalActiveCred = cred }(globalActiveCred)
+	globalActiveCred = cred
+
+	defer setRequestThrottler(getRequestThrottler())
+	setRequestThrottler(newRequestThrottler(throttle.Config{
+		BucketRequests: throttle.Requests{Read: 1},
+	}))
+
+	handler := setRequestThrottleHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(signed bool) int {
+		var req *http.Request
+		if signed {
+			req, err = newTestSignedRequestV4(http.MethodGet, "http://127.0.0.1:9000/bucket/object", 0, nil, cred.AccessKey, cred.SecretKey, nil)
+		} else {
+			req, err = newTestRequest(http.MethodGet, "http://127.0.0.1:9000/bucket/object", 0, nil)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Anonymous requests exhausting their limit of the bucket
+	// don't throttle the requests of users.
+	if code := serve(false); code != http.StatusOK {
+		t.Fatalf("expected anonymous request to be allowed, got %d", code)
+	}
+	if code := serve(false); code != http.StatusServiceUnavailable {
+		t.Fatalf("expected anonymous request to be throttled, got %d", code)
+	}
+	if code := serve(true); code != http.StatusOK {
+		t.Fatalf("expected signed request to be allowed, got %d", code)
+	}
+	if code := serve(true); code != http.StatusServiceUnavailable {
+		t.Fatalf("expected signed request to be throttled, got %d", code)
+	}
+}
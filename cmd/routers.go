@@ -63,6 +63,8 @@ var globalHandlers = []HandlerFunc{
 	setBrowserCacheControlHandler,
 	// Validates all incoming requests to have a valid date header.
 	setTimeValidityHandler,
+	// Serves static websites of buckets with a website configuration.
+	setBucketWebsiteHandler,
 	// CORS setting for all browser API requests.
 	setCorsHandler,
 	// Validates all incoming URL resources, for invalid/unsupported
@@ -72,6 +74,8 @@ var globalHandlers = []HandlerFunc{
 	// routes them accordingly. Client receives a HTTP error for
 	// invalid/unsupported signatures.
 	setAuthHandler,
+	// Limits request rates and bandwidth of users and buckets.
+	setRequestThrottleHandler,
 	// Enforce rules specific for TLS requests
 	setSSETLSHandler,
 	// filters HTTP headers which are treated as metadata and are reserved
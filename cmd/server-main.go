@@ -150,6 +150,30 @@ func newAllSubsystems() {
 
 	// Create new bucket encryption subsystem
 	globalBucketSSEConfigSys = NewBucketSSEConfigSys()
+
+	// Create new bucket versioning subsystem
+	globalBucketVersioningSys = NewBucketVersioningSys()
+
+	// Create new bucket replication subsystem
+	globalBucketReplicationSys = NewBucketReplicationSys()
+
+	// Create new bucket quota subsystem
+	globalBucketQuotaSys = NewBucketQuotaSys()
+
+	// Create new bucket CORS subsystem
+	globalBucketCorsSys = NewBucketCorsSys()
+
+	// Create new bucket website subsystem
+	globalBucketWebsiteSys = NewBucketWebsiteSys()
+
+	// Create new bucket logging subsystem
+	globalBucketLoggingSys = NewBucketLoggingSys()
+
+	// Create new remote bucket targets subsystem
+	globalBucketTargetSys = NewBucketTargetSys()
+
+	// Create new remote tiers subsystem
+	globalTierSys = NewTierSys()
 }
 
 func initSafeMode(buckets []BucketInfo) (err error) {
@@ -281,6 +305,53 @@ func initAllSubsystems(buckets []BucketInfo, newObject ObjectLayer) (err error)
 		return fmt.Errorf("Unable to initialize bucket encryption subsystem: %w", err)
 	}
 
+	// Initialize bucket versioning subsystem.
+	if err = globalBucketVersioningSys.Init(buckets, newObject); err != nil {
+		return fmt.Errorf("Unable to initialize bucket versioning subsystem: %w", err)
+	}
+
+	// Initialize bucket replication subsystem.
+	if err = globalBucketReplicationSys.Init(buckets, newObject); err != nil {
+		return fmt.Errorf("Unable to initialize bucket replication subsystem: %w", err)
+	}
+
+	// Initialize bucket quota subsystem.
+	if err = globalBucketQuotaSys.Init(buckets, newObject); err != nil {
+		return fmt.Errorf("Unable to initialize bucket quota subsystem: %w", err)
+	}
+
+	// Initialize bucket CORS subsystem.
+	if err = globalBucketCorsSys.Init(buckets, newObject); err != nil {
+		return fmt.Errorf("Unable to initialize bucket CORS subsystem: %w", err)
+	}
+
+	// Initialize bucket website subsystem.
+	if err = globalBucketWebsiteSys.Init(buckets, newObject); err != nil {
+		return fmt.Errorf("Unable to initialize bucket website subsystem: %w", err)
+	}
+
+	// Initialize bucket logging subsystem.
+	if err = globalBucketLoggingSys.Init(buckets, newObject); err != nil {
+		return fmt.Errorf("Unable to initialize bucket logging subsystem: %w", err)
+	}
+
+	// Initialize remote bucket targets subsystem.
+	if err = globalBucketTargetSys.Init(newObject); err != nil {
+		return fmt.Errorf("Unable to initialize remote bucket targets subsystem: %w", err)
+	}
+
+	// Initialize remote tiers subsystem.
+	if err = globalTierSys.Init(newObject); err != nil {
+		return fmt.Errorf("Unable to initialize remote tiers subsystem: %w", err)
+	}
+
+	// Initialize the decommissioning status of the zones.
+	if z, ok := newObject.(*xlZones); ok {
+		if err = z.initZonesMeta(context.Background()); err != nil {
+			return fmt.Errorf("Unable to initialize zones: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -427,6 +498,16 @@ func serverMain(ctx *cli.Context) {
 
 	initDataUsageStats()
 	initDailyLifecycle()
+	initBackgroundReplication()
+	initBucketQuotaEnforcement()
+	initBucketAccessLogging()
+	initLDAPSync()
+
+	if globalIsXL {
+		initZonesDecommission()
+		initZonesRebalance()
+		initBitrotScan()
+	}
 
 	// Disable safe mode operation, after all initialization is over.
 	globalObjLayerMutex.Lock()
@@ -63,6 +63,12 @@ type FileInfo struct {
 	Parts []ObjectPartInfo
 
 	Quorum int
+
+	// Version ID of the current version of the object.
+	VersionID string
+
+	// Indicates the current version of the object is a delete marker.
+	Deleted bool
 }
 
 // ToObjectInfo converts FileInfo into objectInfo.
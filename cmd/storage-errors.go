@@ -43,6 +43,9 @@ var errDiskAccessDenied = StorageErr("disk access denied")
 // errFileNotFound - cannot find the file.
 var errFileNotFound = StorageErr("file not found")
 
+// errFileVersionNotFound - cannot find the requested version of the file.
+var errFileVersionNotFound = StorageErr("file version not found")
+
 // errTooManyOpenFiles - too many open files.
 var errTooManyOpenFiles = StorageErr("too many open files")
 
@@ -24,14 +24,12 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
-	xldap "github.com/minio/minio/cmd/config/identity/ldap"
 	"github.com/minio/minio/cmd/config/identity/openid"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
 	iampolicy "github.com/minio/minio/pkg/iam/policy"
 	"github.com/minio/minio/pkg/wildcard"
-	ldap "gopkg.in/ldap.v3"
 )
 
 const (
@@ -58,8 +56,18 @@ const (
 	expClaim = "exp"
 	subClaim = "sub"
 
+	// Service account claim keys
+	parentClaim = "parent"
+
+	// Temporary credentials claim keys, the issue time is not
+	// the "iat" claim which is rejected if issued in the future
+	// of another server.
+	stsActionClaim = "stsAction"
+	issuedClaim    = "issued"
+
 	// LDAP claim keys
 	ldapUser   = "ldapUser"
+	ldapUserDN = "ldapUserDN"
 	ldapGroups = "ldapGroups"
 )
 
@@ -221,6 +229,9 @@ func (sts *stsAPIHandlers) AssumeRole(w http.ResponseWriter, r *http.Request) {
 	// requesting for temporary credentials. The temporary
 	// credentials will inherit the same policy requirements.
 	m[iamPolicyClaimName()] = policyName
+	m[parentClaim] = user.AccessKey
+	m[stsActionClaim] = action
+	m[issuedClaim] = UTCNow().Unix()
 
 	if len(sessionPolicyStr) > 0 {
 		m[iampolicy.SessionPolicyName] = base64.StdEncoding.EncodeToString([]byte(sessionPolicyStr))
@@ -344,41 +355,17 @@ func (sts *stsAPIHandlers) AssumeRoleWithJWT(w http.ResponseWriter, r *http.Requ
 		m[iampolicy.SessionPolicyName] = base64.StdEncoding.EncodeToString([]byte(sessionPolicyStr))
 	}
 
-	secret := globalActiveCred.SecretKey
-	cred, err := auth.GetNewCredentialsWithMetadata(m, secret)
+	cred, err := newJWTTempCredentials(ctx, action, m)
 	if err != nil {
 		writeSTSErrorResponse(ctx, w, ErrSTSInternalError, err)
 		return
 	}
 
-	// JWT has requested a custom claim with policy value set.
-	// This is a MinIO STS API specific value, this value should
-	// be set and configured on your identity provider as part of
-	// JWT custom claims.
-	var policyName string
-	if v, ok := m[iamPolicyClaimName()]; ok {
-		policyName, _ = v.(string)
-	}
-
 	var subFromToken string
 	if v, ok := m[subClaim]; ok {
 		subFromToken, _ = v.(string)
 	}
 
-	// Set the newly generated credentials.
-	if err = globalIAMSys.SetTempUser(cred.AccessKey, cred, policyName); err != nil {
-		writeSTSErrorResponse(ctx, w, ErrSTSInternalError, err)
-		return
-	}
-
-	// Notify all other MinIO peers to reload temp users
-	for _, nerr := range globalNotificationSys.LoadUser(cred.AccessKey, true) {
-		if nerr.Err != nil {
-			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
-			logger.LogIf(ctx, nerr.Err)
-		}
-	}
-
 	var encodedSuccessResponse []byte
 	switch action {
 	case clientGrants:
@@ -404,6 +391,43 @@ func (sts *stsAPIHandlers) AssumeRoleWithJWT(w http.ResponseWriter, r *http.Requ
 	writeSuccessResponseXML(w, encodedSuccessResponse)
 }
 
+// newJWTTempCredentials - generates temporary credentials for the validated
+// claims of a JWT, and sets them on all the servers.
+func newJWTTempCredentials(ctx context.Context, action string, m map[string]interface{}) (auth.Credentials, error) {
+	m[stsActionClaim] = action
+	m[issuedClaim] = UTCNow().Unix()
+
+	secret := globalActiveCred.SecretKey
+	cred, err := auth.GetNewCredentialsWithMetadata(m, secret)
+	if err != nil {
+		return cred, err
+	}
+
+	// JWT has requested a custom claim with policy value set.
+	// This is a MinIO STS API specific value, this value should
+	// be set and configured on your identity provider as part of
+	// JWT custom claims.
+	var policyName string
+	if v, ok := m[iamPolicyClaimName()]; ok {
+		policyName, _ = v.(string)
+	}
+
+	// Set the newly generated credentials.
+	if err = globalIAMSys.SetTempUser(cred.AccessKey, cred, policyName); err != nil {
+		return cred, err
+	}
+
+	// Notify all other MinIO peers to reload temp users
+	for _, nerr := range globalNotificationSys.LoadUser(cred.AccessKey, true) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+
+	return cred, nil
+}
+
 // AssumeRoleWithWebIdentity - implementation of AWS STS API supporting OAuth2.0
 // users from web identity provider such as Facebook, Google, or any OpenID
 // Connect-compatible identity provider.
@@ -493,53 +517,29 @@ func (sts *stsAPIHandlers) AssumeRoleWithLDAPIdentity(w http.ResponseWriter, r *
 	// Close ldap connection to avoid leaks.
 	defer ldapConn.Close()
 
-	usernameSubs, _ := xldap.NewSubstituter("username", ldapUsername)
-	// We ignore error below as we already validated the username
-	// format string at startup.
-	usernameDN, _ := usernameSubs.Substitute(globalLDAPConfig.UsernameFormat)
 	// Bind with user credentials to validate the password
-	if err = ldapConn.Bind(usernameDN, ldapPassword); err != nil {
-		err = fmt.Errorf("LDAP authentication failure: %w", err)
+	usernameDN, err := globalLDAPConfig.Bind(ldapConn, ldapUsername, ldapPassword)
+	if err != nil {
 		writeSTSErrorResponse(ctx, w, ErrSTSInvalidParameterValue, err)
 		return
 	}
 
-	groups := []string{}
-	if globalLDAPConfig.GroupSearchFilter != "" {
-		// Verified user credentials. Now we find the groups they are
-		// a member of.
-		searchSubs, _ := xldap.NewSubstituter(
-			"username", ldapUsername,
-			"usernamedn", usernameDN,
-		)
-		// We ignore error below as we already validated the search string
-		// at startup.
-		groupSearchFilter, _ := searchSubs.Substitute(globalLDAPConfig.GroupSearchFilter)
-		baseDN, _ := searchSubs.Substitute(globalLDAPConfig.GroupSearchBaseDN)
-		searchRequest := ldap.NewSearchRequest(
-			baseDN,
-			ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
-			groupSearchFilter,
-			[]string{globalLDAPConfig.GroupNameAttribute},
-			nil,
-		)
-
-		sr, err := ldapConn.Search(searchRequest)
-		if err != nil {
-			writeSTSErrorResponse(ctx, w, ErrSTSInvalidParameterValue, fmt.Errorf("LDAP search failure: %w", err))
-			return
-		}
-		for _, entry := range sr.Entries {
-			// We only queried one attribute, so we only look up
-			// the first one.
-			groups = append(groups, entry.Attributes[0].Values...)
-		}
+	// Verified user credentials. Now we find the groups they are
+	// a member of.
+	groups, err := globalLDAPConfig.GetGroups(ldapConn, ldapUsername, usernameDN)
+	if err != nil {
+		writeSTSErrorResponse(ctx, w, ErrSTSInvalidParameterValue, err)
+		return
 	}
+
 	expiryDur := globalLDAPConfig.GetExpiryDuration()
 	m := map[string]interface{}{
-		expClaim:   UTCNow().Add(expiryDur).Unix(),
-		ldapUser:   ldapUsername,
-		ldapGroups: groups,
+		expClaim:       UTCNow().Add(expiryDur).Unix(),
+		ldapUser:       ldapUsername,
+		ldapUserDN:     usernameDN,
+		ldapGroups:     groups,
+		stsActionClaim: action,
+		issuedClaim:    UTCNow().Unix(),
 	}
 
 	if len(sessionPolicyStr) > 0 {
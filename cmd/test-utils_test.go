@@ -377,6 +377,9 @@ func UnstartedTestServer(t TestErrHandler, instanceType string) TestServer {
 	globalBucketSSEConfigSys = NewBucketSSEConfigSys()
 	globalBucketSSEConfigSys.Init(buckets, objLayer)
 
+	globalBucketVersioningSys = NewBucketVersioningSys()
+	globalBucketVersioningSys.Init(buckets, objLayer)
+
 	return testServer
 }
 
@@ -1430,6 +1433,13 @@ func getPutPolicyURL(endPoint, bucketName string) string {
 	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
 }
 
+// return URL for setting or fetching bucket tags.
+func getBucketTaggingURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("tagging", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
 // return URL for fetching bucket policy.
 func getGetPolicyURL(endPoint, bucketName string) string {
 	queryValue := url.Values{}
@@ -1983,6 +1993,9 @@ func ExecObjectLayerTest(t TestErrHandler, objTest objTestType) {
 	globalBucketSSEConfigSys = NewBucketSSEConfigSys()
 	globalBucketSSEConfigSys.Init(buckets, objLayer)
 
+	globalBucketVersioningSys = NewBucketVersioningSys()
+	globalBucketVersioningSys.Init(buckets, objLayer)
+
 	// Executing the object layer tests for single node setup.
 	objTest(objLayer, FSTestStr, t)
 
@@ -2124,6 +2137,12 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 		case "ListenBucketNotification":
 			// Register ListenBucketNotification Handler.
 			bucket.Methods("GET").HandlerFunc(api.ListenBucketNotificationHandler).Queries("events", "{events:.*}")
+		case "PutBucketTagging":
+			// Register PutBucketTagging Handler.
+			bucket.Methods("PUT").HandlerFunc(api.PutBucketTaggingHandler).Queries("tagging", "")
+		case "GetBucketTagging":
+			// Register GetBucketTagging Handler.
+			bucket.Methods("GET").HandlerFunc(api.GetBucketTaggingHandler).Queries("tagging", "")
 		}
 	}
 }
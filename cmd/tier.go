@@ -0,0 +1,450 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	miniogo "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/credentials"
+	"github.com/minio/minio-go/v6/pkg/s3utils"
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Remote tiers configuration file name.
+	tierConfigFile = "tier-config.json"
+
+	// Remote tiers configuration format version.
+	tierConfigVersion = "1"
+)
+
+// tierConfigV1 - on disk format of the remote tiers configuration,
+// stored along with the server config so that the credentials of the
+// tiers are encrypted the same way as the rest of the config.
+type tierConfigV1 struct {
+	Version string                       `json:"version"`
+	Tiers   map[string]madmin.TierConfig `json:"tiers"`
+}
+
+// warmBackend - remote tier holding the data of transitioned objects.
+type warmBackend interface {
+	objectName(bucket, object string) string
+	Put(ctx context.Context, object string, r io.Reader, length int64) error
+	Get(ctx context.Context, object string, offset, length int64) (io.ReadCloser, error)
+	Remove(ctx context.Context, object string) error
+	InUse(ctx context.Context) (bool, error)
+}
+
+// warmBackendS3 - remote tier backed by an S3 compatible object storage.
+type warmBackendS3 struct {
+	client *miniogo.Core
+	Bucket string
+	Prefix string
+}
+
+// newRemoteS3Client - initializes a client of a remote S3 compatible
+// server, the same way the S3 gateway initializes its backend client.
+func newRemoteS3Client(endpointURL, accessKey, secretKey, region string) (*miniogo.Core, error) {
+	u, err := url.Parse(endpointURL)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, secure, err := ParseGatewayEndpoint(endpointURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if region == "" {
+		region = s3utils.GetRegionFromURL(*u)
+	}
+
+	options := miniogo.Options{
+		Creds:        credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure:       secure,
+		Region:       region,
+		BucketLookup: miniogo.BucketLookupAuto,
+	}
+
+	clnt, err := miniogo.NewWithOptions(endpoint, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set custom transport
+	clnt.SetCustomTransport(NewCustomHTTPTransport())
+
+	return &miniogo.Core{Client: clnt}, nil
+}
+
+// newWarmBackendS3 - initializes a client of the S3 compatible remote tier.
+func newWarmBackendS3(cfg madmin.TierConfig) (*warmBackendS3, error) {
+	client, err := newRemoteS3Client(cfg.Endpoint, cfg.AccessKey, cfg.SecretKey, cfg.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	return &warmBackendS3{
+		client: client,
+		Bucket: cfg.Bucket,
+		Prefix: cfg.Prefix,
+	}, nil
+}
+
+// objectName - returns a unique name on the remote tier for the data
+// of the given object.
+func (w *warmBackendS3) objectName(bucket, object string) string {
+	return path.Join(w.Prefix, bucket, object, mustGetUUID())
+}
+
+// validate - verifies the bucket of the remote tier is accessible.
+func (w *warmBackendS3) validate() error {
+	ok, err := w.client.BucketExists(w.Bucket)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return BucketNotFound{Bucket: w.Bucket}
+	}
+	return nil
+}
+
+// Put - uploads the data of an object to the remote tier.
+func (w *warmBackendS3) Put(ctx context.Context, object string, r io.Reader, length int64) error {
+	_, err := w.client.PutObjectWithContext(ctx, w.Bucket, object, r, length, miniogo.PutObjectOptions{})
+	return err
+}
+
+// Get - returns a reader of length bytes of the data of an object
+// held by the remote tier from offset.
+func (w *warmBackendS3) Get(ctx context.Context, object string, offset, length int64) (io.ReadCloser, error) {
+	opts := miniogo.GetObjectOptions{}
+	if err := opts.SetRange(offset, offset+length-1); err != nil {
+		return nil, err
+	}
+	r, _, _, err := w.client.GetObject(w.Bucket, object, opts)
+	return r, err
+}
+
+// Remove - removes the data of an object from the remote tier.
+func (w *warmBackendS3) Remove(ctx context.Context, object string) error {
+	return w.client.RemoveObject(w.Bucket, object)
+}
+
+// InUse - returns true if the remote tier still holds the data of
+// any transitioned object.
+func (w *warmBackendS3) InUse(ctx context.Context) (bool, error) {
+	prefix := w.Prefix
+	if prefix != "" && !strings.HasSuffix(prefix, SlashSeparator) {
+		prefix += SlashSeparator
+	}
+	result, err := w.client.ListObjectsV2(w.Bucket, prefix, "", false, "", 1, "")
+	if err != nil {
+		return false, err
+	}
+	return len(result.Contents) > 0, nil
+}
+
+// TierSys - remote tiers subsystem, lifecycle transition rules move
+// the data of objects to the tier named by their storage class.
+type TierSys struct {
+	sync.RWMutex
+	tiers    map[string]madmin.TierConfig
+	backends map[string]warmBackend
+}
+
+// NewTierSys - creates new remote tiers subsystem.
+func NewTierSys() *TierSys {
+	return &TierSys{
+		tiers:    make(map[string]madmin.TierConfig),
+		backends: make(map[string]warmBackend),
+	}
+}
+
+// Init - initializes the remote tiers subsystem from the stored config.
+func (sys *TierSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	// Objects are not transitioned in gateway mode, nothing to do.
+	if globalIsGateway {
+		return nil
+	}
+
+	return sys.Load(objAPI)
+}
+
+// Load - reloads the remote tiers from the stored config.
+func (sys *TierSys) Load(objAPI ObjectLayer) error {
+	cfg, err := readTierConfig(context.Background(), objAPI)
+	if err != nil {
+		return err
+	}
+
+	backends := make(map[string]warmBackend, len(cfg.Tiers))
+	for name, tier := range cfg.Tiers {
+		backend, err := newWarmBackendS3(tier)
+		if err != nil {
+			// Do not fail the server startup on a bad tier,
+			// objects on other tiers are still accessible.
+			logger.LogIf(context.Background(), err)
+			continue
+		}
+		backends[name] = backend
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+	sys.tiers = cfg.Tiers
+	sys.backends = backends
+	return nil
+}
+
+// Get - returns the client of the given remote tier.
+func (sys *TierSys) Get(name string) (backend warmBackend, ok bool) {
+	if sys == nil {
+		return nil, false
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+	backend, ok = sys.backends[name]
+	return backend, ok
+}
+
+// List - returns all the remote tiers sorted by name, their secret
+// keys are left out.
+func (sys *TierSys) List() []madmin.TierConfig {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	tiers := make([]madmin.TierConfig, 0, len(sys.tiers))
+	for _, tier := range sys.tiers {
+		tier.SecretKey = ""
+		tiers = append(tiers, tier)
+	}
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].Name < tiers[j].Name
+	})
+	return tiers
+}
+
+// Add - validates and stores a new remote tier.
+func (sys *TierSys) Add(ctx context.Context, objAPI ObjectLayer, tier madmin.TierConfig) error {
+	if tier.Name == "" || tier.Bucket == "" || tier.Type != madmin.S3TierType {
+		return errInvalidArgument
+	}
+
+	backend, err := newWarmBackendS3(tier)
+	if err == nil {
+		err = backend.validate()
+	}
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return errTierBackendInvalid
+	}
+
+	return sys.update(ctx, objAPI, func(cfg *tierConfigV1) error {
+		if _, ok := cfg.Tiers[tier.Name]; ok {
+			return errTierAlreadyExists
+		}
+		cfg.Tiers[tier.Name] = tier
+		return nil
+	})
+}
+
+// Remove - removes a remote tier which is not referred to by any
+// lifecycle transition rule. Unless forced, a tier still holding the
+// data of transitioned objects is not removed either, since these
+// objects cannot be read anymore once their tier is gone.
+func (sys *TierSys) Remove(ctx context.Context, objAPI ObjectLayer, name string, force bool) error {
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+	for _, bucket := range buckets {
+		lc, ok := globalLifecycleSys.Get(bucket.Name)
+		if !ok {
+			continue
+		}
+		for _, rule := range lc.Rules {
+			if rule.Transition.StorageClass == name {
+				return errTierInUse
+			}
+		}
+	}
+
+	if !force {
+		if err = sys.checkEmpty(ctx, name); err != nil {
+			return err
+		}
+	}
+
+	return sys.update(ctx, objAPI, func(cfg *tierConfigV1) error {
+		if _, ok := cfg.Tiers[name]; !ok {
+			return errNoSuchTier
+		}
+		delete(cfg.Tiers, name)
+		return nil
+	})
+}
+
+// checkEmpty - returns an error if the given remote tier still holds
+// the data of transitioned objects, or cannot be checked for it.
+func (sys *TierSys) checkEmpty(ctx context.Context, name string) error {
+	sys.RLock()
+	_, ok := sys.tiers[name]
+	backend, loaded := sys.backends[name]
+	sys.RUnlock()
+	if !ok {
+		return errNoSuchTier
+	}
+	if !loaded {
+		return errTierBackendInvalid
+	}
+
+	inUse, err := backend.InUse(ctx)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return errTierBackendInvalid
+	}
+	if inUse {
+		return errTierNotEmpty
+	}
+	return nil
+}
+
+// update - applies fn to the stored config and reloads the remote
+// tiers from it, the config is locked for the whole cluster meanwhile.
+func (sys *TierSys) update(ctx context.Context, objAPI ObjectLayer, fn func(cfg *tierConfigV1) error) error {
+	// The config file itself is locked while it is read and saved,
+	// hence a separate lock is held for the whole update.
+	configLock := objAPI.NewNSLock(ctx, minioMetaBucket, path.Join(minioConfigPrefix, tierConfigFile+".lock"))
+	if err := configLock.GetLock(globalOperationTimeout); err != nil {
+		return err
+	}
+	defer configLock.Unlock()
+
+	cfg, err := readTierConfig(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	if err = fn(&cfg); err != nil {
+		return err
+	}
+	if err = saveTierConfig(ctx, objAPI, cfg); err != nil {
+		return err
+	}
+	return sys.Load(objAPI)
+}
+
+// readTierConfig - reads the remote tiers config, an empty config is
+// returned when none was stored yet.
+func readTierConfig(ctx context.Context, objAPI ObjectLayer) (tierConfigV1, error) {
+	cfg := tierConfigV1{
+		Version: tierConfigVersion,
+		Tiers:   make(map[string]madmin.TierConfig),
+	}
+
+	configFile := path.Join(minioConfigPrefix, tierConfigFile)
+	data, err := readConfig(ctx, objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+
+	if globalConfigEncrypted {
+		data, err = madmin.DecryptData(globalActiveCred.String(), bytes.NewReader(data))
+		if err != nil {
+			if err == madmin.ErrMaliciousData {
+				return cfg, config.ErrInvalidCredentialsBackendEncrypted(nil)
+			}
+			return cfg, err
+		}
+	}
+
+	if err = json.Unmarshal(data, &cfg); err != nil {
+		return cfg, err
+	}
+	if cfg.Tiers == nil {
+		cfg.Tiers = make(map[string]madmin.TierConfig)
+	}
+	return cfg, nil
+}
+
+// saveTierConfig - stores the remote tiers config.
+func saveTierConfig(ctx context.Context, objAPI ObjectLayer, cfg tierConfigV1) error {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	if globalConfigEncrypted {
+		data, err = madmin.EncryptData(globalActiveCred.String(), data)
+		if err != nil {
+			return err
+		}
+	}
+
+	configFile := path.Join(minioConfigPrefix, tierConfigFile)
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// getTransitionedObject - writes length bytes of the data of a
+// transitioned version from offset, as read from its remote tier.
+func getTransitionedObject(ctx context.Context, version xlMetaV1, offset, length int64, writer io.Writer) error {
+	if length == 0 {
+		return nil
+	}
+	backend, ok := globalTierSys.Get(version.TransitionTier)
+	if !ok {
+		return errNoSuchTier
+	}
+	r, err := backend.Get(ctx, version.TransitionObject, offset, length)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	_, err = io.CopyN(writer, r, length)
+	return err
+}
+
+// deleteTransitionedObject - removes the data of a transitioned
+// version from its remote tier, failures are only logged since the
+// version itself is gone already.
+func deleteTransitionedObject(ctx context.Context, version xlMetaV1) {
+	backend, ok := globalTierSys.Get(version.TransitionTier)
+	if !ok {
+		logger.LogIf(ctx, errNoSuchTier)
+		return
+	}
+	logger.LogIf(ctx, backend.Remove(ctx, version.TransitionObject))
+}
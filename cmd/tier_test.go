@@ -0,0 +1,236 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"path"
+	"sync"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// mockWarmBackend - remote tier keeping the data of objects in memory.
+type mockWarmBackend struct {
+	sync.Mutex
+	objects map[string][]byte
+}
+
+func newMockWarmBackend() *mockWarmBackend {
+	return &mockWarmBackend{objects: make(map[string][]byte)}
+}
+
+func (m *mockWarmBackend) objectName(bucket, object string) string {
+	return path.Join(bucket, object, mustGetUUID())
+}
+
+func (m *mockWarmBackend) Put(ctx context.Context, object string, r io.Reader, length int64) error {
+	data, err := ioutil.ReadAll(io.LimitReader(r, length))
+	if err != nil {
+		return err
+	}
+	m.Lock()
+	defer m.Unlock()
+	m.objects[object] = data
+	return nil
+}
+
+func (m *mockWarmBackend) Get(ctx context.Context, object string, offset, length int64) (io.ReadCloser, error) {
+	m.Lock()
+	defer m.Unlock()
+	data, ok := m.objects[object]
+	if !ok {
+		return nil, errFileNotFound
+	}
+	return ioutil.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+}
+
+func (m *mockWarmBackend) Remove(ctx context.Context, object string) error {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.objects, object)
+	return nil
+}
+
+func (m *mockWarmBackend) InUse(ctx context.Context) (bool, error) {
+	return m.count() > 0, nil
+}
+
+func (m *mockWarmBackend) count() int {
+	m.Lock()
+	defer m.Unlock()
+	return len(m.objects)
+}
+
+// transitionTestObject - writes an object and transitions its data
+// to the remote tier.
+func transitionTestObject(t *testing.T, obj ObjectLayer, backend warmBackend, bucket, object string) {
+	ctx := context.Background()
+	data := bytes.Repeat([]byte("a"), 1024)
+	objInfo, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remoteObject := backend.objectName(bucket, object)
+	if err = backend.Put(ctx, remoteObject, bytes.NewReader(data), int64(len(data))); err != nil {
+		t.Fatal(err)
+	}
+	opts := ObjectOptions{Transition: TransitionOptions{Tier: "WARM", RemoteObject: remoteObject, ETag: objInfo.ETag}}
+	if err = obj.TransitionObject(ctx, bucket, object, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	// Transitioned objects are read from the remote tier.
+	var buf bytes.Buffer
+	if err = obj.GetObject(ctx, bucket, object, 0, int64(len(data)), &buf, "", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatal("unexpected data read from the remote tier")
+	}
+}
+
+func TestPurgeTransitionedObjects(t *testing.T) {
+	obj, fsDirs, err := prepareXL(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	backend := newMockWarmBackend()
+	oldTierSys := globalTierSys
+	globalTierSys = NewTierSys()
+	globalTierSys.backends["WARM"] = backend
+	defer func() { globalTierSys = oldTierSys }()
+
+	ctx := context.Background()
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("overwritten")
+	testCases := []struct {
+		name string
+		fn   func(object string) error
+	}{
+		{"DeleteObject", func(object string) error {
+			return obj.DeleteObject(ctx, bucket, object)
+		}},
+		{"DeleteObjects", func(object string) error {
+			errs, err := obj.DeleteObjects(ctx, bucket, []string{object})
+			if err != nil {
+				return err
+			}
+			return errs[0]
+		}},
+		{"PutObject", func(object string) error {
+			_, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+			return err
+		}},
+		{"CompleteMultipartUpload", func(object string) error {
+			uploadID, err := obj.NewMultipartUpload(ctx, bucket, object, ObjectOptions{})
+			if err != nil {
+				return err
+			}
+			part, err := obj.PutObjectPart(ctx, bucket, object, uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+			if err != nil {
+				return err
+			}
+			_, err = obj.CompleteMultipartUpload(ctx, bucket, object, uploadID, []CompletePart{{PartNumber: 1, ETag: part.ETag}}, ObjectOptions{})
+			return err
+		}},
+	}
+
+	for _, testCase := range testCases {
+		object := "object-" + testCase.name
+		transitionTestObject(t, obj, backend, bucket, object)
+		if backend.count() != 1 {
+			t.Fatalf("%s: expected the data on the remote tier, got %d objects", testCase.name, backend.count())
+		}
+		if err = testCase.fn(object); err != nil {
+			t.Fatalf("%s: %v", testCase.name, err)
+		}
+		if backend.count() != 0 {
+			t.Errorf("%s: expected the data to be removed from the remote tier", testCase.name)
+		}
+		// Clean up overwritten objects for the next test case.
+		obj.DeleteObject(ctx, bucket, object)
+	}
+}
+
+func TestTierSysRemove(t *testing.T) {
+	obj, fsDirs, err := prepareXL(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	oldTierSys, oldLifecycleSys := globalTierSys, globalLifecycleSys
+	globalTierSys, globalLifecycleSys = NewTierSys(), NewLifecycleSys()
+	defer func() { globalTierSys, globalLifecycleSys = oldTierSys, oldLifecycleSys }()
+
+	ctx := context.Background()
+	cfg := tierConfigV1{
+		Version: tierConfigVersion,
+		Tiers: map[string]madmin.TierConfig{
+			"WARM": {
+				Name:     "WARM",
+				Type:     madmin.S3TierType,
+				Endpoint: "http://127.0.0.1:9000",
+				Bucket:   "warm",
+			},
+		},
+	}
+	if err = saveTierConfig(ctx, obj, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalTierSys.Load(obj); err != nil {
+		t.Fatal(err)
+	}
+	backend := newMockWarmBackend()
+	globalTierSys.backends["WARM"] = backend
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	transitionTestObject(t, obj, backend, bucket, "object")
+
+	// The transitioned object would not be readable anymore.
+	if err = globalTierSys.Remove(ctx, obj, "WARM", false); err != errTierNotEmpty {
+		t.Fatalf("expected %v, got %v", errTierNotEmpty, err)
+	}
+	if _, ok := globalTierSys.Get("WARM"); !ok {
+		t.Fatal("expected the remote tier to be kept")
+	}
+
+	if err = globalTierSys.Remove(ctx, obj, "WARM", true); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := globalTierSys.Get("WARM"); ok {
+		t.Fatal("expected the remote tier to be removed")
+	}
+	if err = globalTierSys.Remove(ctx, obj, "WARM", true); err != errNoSuchTier {
+		t.Fatalf("expected %v, got %v", errNoSuchTier, err)
+	}
+}
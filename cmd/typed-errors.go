@@ -77,6 +77,9 @@ var errInvalidDecompressedSize = errors.New("Invalid Decompressed Size")
 // error returned in IAM subsystem when user doesn't exist.
 var errNoSuchUser = errors.New("Specified user does not exist")
 
+// error returned in IAM subsystem when service account doesn't exist.
+var errNoSuchServiceAccount = errors.New("Specified service account does not exist")
+
 // error returned in IAM subsystem when groups doesn't exist.
 var errNoSuchGroup = errors.New("Specified group does not exist")
 
@@ -87,6 +90,55 @@ var errGroupNotEmpty = errors.New("Specified group is not empty - cannot remove
 // error returned in IAM subsystem when policy doesn't exist.
 var errNoSuchPolicy = errors.New("Specified canned policy does not exist")
 
+// error returned when the remote tier doesn't exist.
+var errNoSuchTier = errors.New("Specified remote tier does not exist")
+
+// error returned when a remote tier with the same name already exists.
+var errTierAlreadyExists = errors.New("Specified remote tier already exists")
+
+// error returned when the bucket of a remote tier cannot be accessed.
+var errTierBackendInvalid = errors.New("Unable to access the bucket of the remote tier")
+
+// error returned when a remote tier referred to by lifecycle rules
+// needs to be removed.
+var errTierInUse = errors.New("Specified remote tier is in use by lifecycle rules - cannot remove it")
+
+// error returned when a remote tier still holding the data of
+// transitioned objects needs to be removed.
+var errTierNotEmpty = errors.New("Specified remote tier still holds the data of transitioned objects - cannot remove it")
+
+// error returned when the remote bucket target doesn't exist.
+var errNoSuchBucketTarget = errors.New("Specified remote bucket target does not exist")
+
+// error returned when a remote bucket target with the same name already exists.
+var errBucketTargetAlreadyExists = errors.New("Specified remote bucket target already exists")
+
+// error returned when a remote bucket target cannot be accessed.
+var errBucketTargetBackendInvalid = errors.New("Unable to access the remote bucket target")
+
+// error returned when a remote bucket target referred to by bucket
+// replication configurations needs to be removed.
+var errBucketTargetInUse = errors.New("Specified remote bucket target is in use by bucket replication - cannot remove it")
+
+// error returned when the zone doesn't exist.
+var errNoSuchZone = errors.New("Specified zone does not exist")
+
+// error returned when the zone is already being decommissioned.
+var errZoneDecommissionInProgress = errors.New("Specified zone is already being decommissioned")
+
+// error returned when the zone is not being decommissioned.
+var errZoneNotDecommissioning = errors.New("Specified zone is not being decommissioned")
+
+// error returned when all the other zones are being decommissioned,
+// the objects of the zone have no zone to be migrated to.
+var errZoneLastActive = errors.New("Specified zone is the last zone not being decommissioned")
+
+// error returned when the zones are already being rebalanced.
+var errRebalanceInProgress = errors.New("Zones are already being rebalanced")
+
+// error returned when the zones are not being rebalanced.
+var errRebalanceNotInProgress = errors.New("Zones are not being rebalanced")
+
 // error returned in IAM subsystem when an external users systems is configured.
 var errIAMActionNotAllowed = errors.New("Specified IAM action is not allowed under the current configuration")
 
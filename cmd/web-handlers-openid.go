@@ -0,0 +1,241 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	jwtgo "github.com/dgrijalva/jwt-go"
+	"github.com/minio/minio/cmd/config/identity/openid"
+	xjwt "github.com/minio/minio/cmd/jwt"
+	"github.com/minio/minio/cmd/logger"
+)
+
+const (
+	// Cookie holding the state of an OpenID login in progress.
+	openIDLoginCookie = "minio-openid-login"
+
+	// Path of the OpenID login endpoints under the browser path.
+	openIDLoginPath = "/openid"
+
+	// An OpenID login has to be completed within ten minutes.
+	openIDLoginExpiry = 10 * time.Minute
+)
+
+var errInvalidOpenIDLoginState = errors.New("Invalid or expired OpenID login state")
+
+// openIDRedirectURI - returns the callback URL of the OpenID login,
+// which must be registered with the provider.
+func openIDRedirectURI(r *http.Request) string {
+	if globalOpenIDConfig.RedirectURI != "" {
+		return globalOpenIDConfig.RedirectURI
+	}
+	scheme := "http"
+	if globalIsSSL {
+		scheme = "https"
+	}
+	u := url.URL{
+		Scheme: scheme,
+		Host:   r.Host,
+		Path:   minioReservedBucketPath + openIDLoginPath + "/callback",
+	}
+	return u.String()
+}
+
+// openIDLoginState - the state of an OpenID login in progress, kept
+// by the browser in a cookie signed by the server.
+type openIDLoginState struct {
+	state        string
+	nonce        string
+	codeVerifier string
+}
+
+func (s openIDLoginState) cookie() (*http.Cookie, error) {
+	claims := xjwt.NewMapClaims()
+	claims.SetExpiry(UTCNow().Add(openIDLoginExpiry))
+	claims.MapClaims["sub"] = s.state
+	claims.MapClaims["nonce"] = s.nonce
+	claims.MapClaims["verifier"] = s.codeVerifier
+
+	token, err := jwtgo.NewWithClaims(jwtgo.SigningMethodHS512, claims).SignedString([]byte(globalActiveCred.SecretKey))
+	if err != nil {
+		return nil, err
+	}
+	return &http.Cookie{
+		Name:     openIDLoginCookie,
+		Value:    token,
+		Path:     minioReservedBucketPath + openIDLoginPath,
+		MaxAge:   int(openIDLoginExpiry / time.Second),
+		Secure:   globalIsSSL,
+		HttpOnly: true,
+		// The callback is a top-level navigation from the
+		// provider, which lax cookies are sent with.
+		SameSite: http.SameSiteLaxMode,
+	}, nil
+}
+
+// parseOpenIDLoginState - returns the state of the OpenID login of the
+// browser from its cookie.
+func parseOpenIDLoginState(r *http.Request) (s openIDLoginState, err error) {
+	cookie, err := r.Cookie(openIDLoginCookie)
+	if err != nil {
+		return s, errInvalidOpenIDLoginState
+	}
+	claims := xjwt.NewMapClaims()
+	if err = xjwt.ParseWithClaims(cookie.Value, claims, func(*xjwt.MapClaims) ([]byte, error) {
+		return []byte(globalActiveCred.SecretKey), nil
+	}); err != nil {
+		return s, errInvalidOpenIDLoginState
+	}
+	s.state = claims.AccessKey
+	s.nonce, _ = claims.Lookup("nonce")
+	s.codeVerifier, _ = claims.Lookup("verifier")
+	if s.state == "" || s.nonce == "" || s.codeVerifier == "" {
+		return s, errInvalidOpenIDLoginState
+	}
+	return s, nil
+}
+
+// redirectOpenIDLogin - redirects the browser to the OpenID login page of
+// the UI, which reads the login result from the URL fragment.
+func redirectOpenIDLogin(w http.ResponseWriter, r *http.Request, result url.Values) {
+	// Expire the login state, it can't be used again.
+	http.SetCookie(w, &http.Cookie{
+		Name:     openIDLoginCookie,
+		Path:     minioReservedBucketPath + openIDLoginPath,
+		MaxAge:   -1,
+		Secure:   globalIsSSL,
+		HttpOnly: true,
+	})
+	http.Redirect(w, r, minioReservedBucketPath+"/login/openid#"+result.Encode(), http.StatusFound)
+}
+
+func redirectOpenIDLoginError(w http.ResponseWriter, r *http.Request, code, description string) {
+	redirectOpenIDLogin(w, r, url.Values{
+		"error":             []string{code},
+		"error_description": []string{description},
+	})
+}
+
+// LoginOpenID - redirects the browser to the authorization endpoint of
+// the OpenID provider, to log in with the authorization code flow with
+// PKCE https://tools.ietf.org/html/rfc7636
+func (web *webAPIHandlers) LoginOpenID(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "WebLoginOpenID")
+
+	if !globalOpenIDConfig.CodeFlowEnabled() {
+		writeWebErrorResponse(w, errServerNotInitialized)
+		return
+	}
+
+	var s openIDLoginState
+	var err error
+	for _, v := range []*string{&s.state, &s.nonce, &s.codeVerifier} {
+		if *v, err = openid.NewRandomValue(); err != nil {
+			logger.LogIf(ctx, err)
+			writeWebErrorResponse(w, err)
+			return
+		}
+	}
+
+	cookie, err := s.cookie()
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeWebErrorResponse(w, err)
+		return
+	}
+	http.SetCookie(w, cookie)
+
+	authURL := globalOpenIDConfig.AuthCodeURL(openIDRedirectURI(r), s.state, s.nonce, s.codeVerifier)
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// LoginOpenIDCallback - exchanges the authorization code returned by the
+// OpenID provider for an ID token, and logs the browser in with the
+// temporary credentials issued for the claims of the ID token.
+func (web *webAPIHandlers) LoginOpenIDCallback(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "WebLoginOpenIDCallback")
+
+	defer logger.AuditLog(w, r, "WebLoginOpenIDCallback", nil)
+
+	query := r.URL.Query()
+	if code := query.Get("error"); code != "" {
+		redirectOpenIDLoginError(w, r, code, query.Get("error_description"))
+		return
+	}
+
+	if !globalOpenIDConfig.CodeFlowEnabled() || globalOpenIDValidators == nil {
+		redirectOpenIDLoginError(w, r, "server_error", errServerNotInitialized.Error())
+		return
+	}
+
+	s, err := parseOpenIDLoginState(r)
+	if err == nil && subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(s.state)) != 1 {
+		err = errInvalidOpenIDLoginState
+	}
+	if err != nil {
+		redirectOpenIDLoginError(w, r, "invalid_request", err.Error())
+		return
+	}
+
+	idToken, err := globalOpenIDConfig.ExchangeCode(query.Get("code"), s.codeVerifier, openIDRedirectURI(r))
+	if err != nil {
+		logger.LogIf(ctx, err)
+		redirectOpenIDLoginError(w, r, "access_denied", err.Error())
+		return
+	}
+
+	v, err := globalOpenIDValidators.Get("jwt")
+	if err != nil {
+		redirectOpenIDLoginError(w, r, "server_error", err.Error())
+		return
+	}
+
+	m, err := v.Validate(idToken, "")
+	if err == nil {
+		err = globalOpenIDConfig.ValidateIDTokenClaims(m, s.nonce)
+	}
+	if err != nil {
+		redirectOpenIDLoginError(w, r, "access_denied", err.Error())
+		return
+	}
+
+	// Without OPA the temporary credentials are only usable
+	// with a policy claim, see getClaimsFromSessionToken.
+	if globalPolicyOPA == nil {
+		if policyName, _ := m[iamPolicyClaimName()].(string); policyName == "" {
+			redirectOpenIDLoginError(w, r, "access_denied", "No policy claim in the ID token: "+iamPolicyClaimName())
+			return
+		}
+	}
+
+	cred, err := newJWTTempCredentials(ctx, webIdentity, m)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		redirectOpenIDLoginError(w, r, "server_error", err.Error())
+		return
+	}
+
+	// The session token is the authentication token of the browser.
+	redirectOpenIDLogin(w, r, url.Values{
+		"token": []string{cred.SessionToken},
+	})
+}
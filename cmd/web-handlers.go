@@ -46,6 +46,7 @@ import (
 	"github.com/minio/minio/pkg/auth"
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/replication"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/handlers"
 	"github.com/minio/minio/pkg/hash"
@@ -529,17 +530,17 @@ func (web *webAPIHandlers) ListObjects(r *http.Request, args *ListObjectsArgs, r
 			return &json2.Error{Message: err.Error()}
 		}
 		for i := range lo.Objects {
-			if crypto.IsEncrypted(lo.Objects[i].UserDefined) {
-				lo.Objects[i].Size, err = lo.Objects[i].DecryptedSize()
-				if err != nil {
-					return toJSONError(ctx, err)
-				}
-			} else if lo.Objects[i].IsCompressed() {
+			if lo.Objects[i].IsCompressed() {
 				actualSize := lo.Objects[i].GetActualSize()
 				if actualSize < 0 {
 					return toJSONError(ctx, errInvalidDecompressedSize)
 				}
 				lo.Objects[i].Size = actualSize
+			} else if crypto.IsEncrypted(lo.Objects[i].UserDefined) {
+				lo.Objects[i].Size, err = lo.Objects[i].DecryptedSize()
+				if err != nil {
+					return toJSONError(ctx, err)
+				}
 			}
 		}
 
@@ -1028,6 +1029,11 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err = enforceBucketQuota(ctx, bucket, size); err != nil {
+		writeWebErrorResponse(w, err)
+		return
+	}
+
 	// Extract incoming metadata if any.
 	metadata, err := extractMetadata(ctx, r)
 	if err != nil {
@@ -1044,6 +1050,7 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		writeWebErrorResponse(w, err)
 		return
 	}
+	var s2c *s2CompressReader
 	if objectAPI.IsCompressionSupported() && isCompressible(r.Header, object) && size > 0 {
 		// Storing the compression metadata.
 		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
@@ -1057,7 +1064,7 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 
 		// Set compression metrics.
 		size = -1 // Since compressed size is un-predictable.
-		s2c := newS2CompressReader(actualReader)
+		s2c = newS2CompressReader(actualReader)
 		defer s2c.Close()
 		reader = s2c
 		hashReader, err = hash.NewReader(reader, size, "", "", actualSize, globalCLIContext.StrictS3Compat)
@@ -1074,10 +1081,10 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		writeErrorResponseHeadersOnly(w, toAPIError(ctx, err))
 		return
 	}
+	var objectEncryptionKey []byte
 	if objectAPI.IsEncryptionSupported() {
 		if crypto.IsRequested(r.Header) && !HasSuffix(object, SlashSeparator) { // handle SSE requests
 			rawReader := hashReader
-			var objectEncryptionKey []byte
 			reader, objectEncryptionKey, err = EncryptRequest(hashReader, r, bucket, object, metadata)
 			if err != nil {
 				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
@@ -1085,7 +1092,7 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 			}
 			info := ObjectInfo{Size: size}
 			// do not try to verify encrypted content
-			hashReader, err = hash.NewReader(reader, info.EncryptedSize(), "", "", size, globalCLIContext.StrictS3Compat)
+			hashReader, err = hash.NewReader(reader, info.EncryptedSize(), "", "", actualSize, globalCLIContext.StrictS3Compat)
 			if err != nil {
 				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 				return
@@ -1093,6 +1100,9 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 			pReader = NewPutObjReader(rawReader, hashReader, objectEncryptionKey)
 		}
 	}
+	if s2c != nil {
+		pReader.setCompressionIndex(s2c, objectEncryptionKey)
+	}
 
 	// Ensure that metadata does not contain sensitive information
 	crypto.RemoveSensitiveEntries(metadata)
@@ -1115,6 +1125,10 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if mustReplicate(bucket, object, "", false) {
+		opts.UserDefined[xhttp.AmzBucketReplicationStatus] = replication.Pending.String()
+	}
+
 	putObject := objectAPI.PutObject
 	if web.CacheAPI() != nil {
 		putObject = web.CacheAPI().PutObject
@@ -1125,6 +1139,10 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		writeWebErrorResponse(w, err)
 		return
 	}
+
+	globalBucketQuotaSys.AccountUsage(bucket, objInfo.Size)
+
+	scheduleReplication(ctx, objInfo, objectAPI)
 	if objectAPI.IsEncryptionSupported() {
 		if crypto.IsEncrypted(objInfo.UserDefined) {
 			switch {
@@ -2000,6 +2018,9 @@ type DiscoveryDocResp struct {
 	DiscoveryDoc openid.DiscoveryDoc
 	UIVersion    string `json:"uiVersion"`
 	ClientID     string `json:"clientId"`
+	// Set when the server can log in the browser with
+	// the authorization code flow.
+	CodeFlow bool `json:"codeFlow"`
 }
 
 // GetDiscoveryDoc - returns parsed value of OpenID discovery document
@@ -2007,6 +2028,7 @@ func (web *webAPIHandlers) GetDiscoveryDoc(r *http.Request, args *WebGenericArgs
 	if globalOpenIDConfig.DiscoveryDoc.AuthEndpoint != "" {
 		reply.DiscoveryDoc = globalOpenIDConfig.DiscoveryDoc
 		reply.ClientID = globalOpenIDConfig.ClientID
+		reply.CodeFlow = globalOpenIDConfig.CodeFlowEnabled()
 	}
 	reply.UIVersion = browser.UIVersion
 	return nil
@@ -2196,6 +2218,8 @@ func toWebAPIError(ctx context.Context, err error) APIError {
 		return getAPIError(ErrWriteQuorum)
 	case InsufficientReadQuorum:
 		return getAPIError(ErrReadQuorum)
+	case BucketQuotaExceeded:
+		return getAPIError(ErrBucketQuotaExceeded)
 	case NotImplemented:
 		return APIError{
 			Code:           "NotImplemented",
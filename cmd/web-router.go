@@ -91,6 +91,10 @@ func registerWebRouter(router *mux.Router) error {
 	webBrowserRouter.Methods("GET").Path("/download/{bucket}/{object:.+}").Queries("token", "{token:.*}").HandlerFunc(httpTraceHdrs(web.Download))
 	webBrowserRouter.Methods("POST").Path("/zip").Queries("token", "{token:.*}").HandlerFunc(httpTraceHdrs(web.DownloadZip))
 
+	// OpenID login with the authorization code flow.
+	webBrowserRouter.Methods("GET").Path(openIDLoginPath + "/authorize").HandlerFunc(httpTraceHdrs(web.LoginOpenID))
+	webBrowserRouter.Methods("GET").Path(openIDLoginPath + "/callback").HandlerFunc(httpTraceHdrs(web.LoginOpenIDCallback))
+
 	// Create compressed assets handler
 	compressAssets := handlers.CompressHandler(http.StripPrefix(minioReservedBucketPath, http.FileServer(assetFS())))
 
@@ -30,10 +30,15 @@ import (
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/bpool"
+	"github.com/minio/minio/pkg/bucket/cors"
 	bucketsse "github.com/minio/minio/pkg/bucket/encryption"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/bucket/logging"
 	"github.com/minio/minio/pkg/bucket/object/tagging"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/bucket/versioning"
+	"github.com/minio/minio/pkg/bucket/website"
 	"github.com/minio/minio/pkg/dsync"
 	"github.com/minio/minio/pkg/madmin"
 	"github.com/minio/minio/pkg/sync/errgroup"
@@ -622,6 +627,91 @@ func (s *xlSets) DeleteBucketSSEConfig(ctx context.Context, bucket string) error
 	return removeBucketSSEConfig(ctx, s, bucket)
 }
 
+// SetBucketVersioning sets bucket versioning config on given bucket
+func (s *xlSets) SetBucketVersioning(ctx context.Context, bucket string, config *versioning.Versioning) error {
+	return saveBucketVersioningConfig(ctx, s, bucket, config)
+}
+
+// GetBucketVersioning returns bucket versioning config on given bucket
+func (s *xlSets) GetBucketVersioning(ctx context.Context, bucket string) (*versioning.Versioning, error) {
+	return getBucketVersioningConfig(s, bucket)
+}
+
+// SetBucketReplicationConfig sets bucket replication config on given bucket
+func (s *xlSets) SetBucketReplicationConfig(ctx context.Context, bucket string, config *replication.Config) error {
+	return saveBucketReplicationConfig(ctx, s, bucket, config)
+}
+
+// GetBucketReplicationConfig returns bucket replication config on given bucket
+func (s *xlSets) GetBucketReplicationConfig(ctx context.Context, bucket string) (*replication.Config, error) {
+	return getBucketReplicationConfig(s, bucket)
+}
+
+// DeleteBucketReplicationConfig deletes bucket replication config on given bucket
+func (s *xlSets) DeleteBucketReplicationConfig(ctx context.Context, bucket string) error {
+	return removeBucketReplicationConfig(ctx, s, bucket)
+}
+
+// SetBucketTagging sets bucket tagging on given bucket
+func (s *xlSets) SetBucketTagging(ctx context.Context, bucket string, tags *tagging.Tagging) error {
+	return saveBucketTaggingConfig(ctx, s, bucket, tags)
+}
+
+// GetBucketTagging returns bucket tagging on given bucket
+func (s *xlSets) GetBucketTagging(ctx context.Context, bucket string) (*tagging.Tagging, error) {
+	return getBucketTaggingConfig(s, bucket)
+}
+
+// DeleteBucketTagging deletes bucket tagging on given bucket
+func (s *xlSets) DeleteBucketTagging(ctx context.Context, bucket string) error {
+	return removeBucketTaggingConfig(ctx, s, bucket)
+}
+
+// SetBucketCorsConfig sets bucket CORS config on given bucket
+func (s *xlSets) SetBucketCorsConfig(ctx context.Context, bucket string, config *cors.Config) error {
+	return saveBucketCorsConfig(ctx, s, bucket, config)
+}
+
+// GetBucketCorsConfig returns bucket CORS config on given bucket
+func (s *xlSets) GetBucketCorsConfig(ctx context.Context, bucket string) (*cors.Config, error) {
+	return getBucketCorsConfig(s, bucket)
+}
+
+// DeleteBucketCorsConfig deletes bucket CORS config on given bucket
+func (s *xlSets) DeleteBucketCorsConfig(ctx context.Context, bucket string) error {
+	return removeBucketCorsConfig(ctx, s, bucket)
+}
+
+// SetBucketWebsiteConfig sets bucket website config on given bucket
+func (s *xlSets) SetBucketWebsiteConfig(ctx context.Context, bucket string, config *website.Config) error {
+	return saveBucketWebsiteConfig(ctx, s, bucket, config)
+}
+
+// GetBucketWebsiteConfig returns bucket website config on given bucket
+func (s *xlSets) GetBucketWebsiteConfig(ctx context.Context, bucket string) (*website.Config, error) {
+	return getBucketWebsiteConfig(s, bucket)
+}
+
+// DeleteBucketWebsiteConfig deletes bucket website config on given bucket
+func (s *xlSets) DeleteBucketWebsiteConfig(ctx context.Context, bucket string) error {
+	return removeBucketWebsiteConfig(ctx, s, bucket)
+}
+
+// SetBucketLoggingConfig sets bucket logging config on given bucket
+func (s *xlSets) SetBucketLoggingConfig(ctx context.Context, bucket string, config *logging.Config) error {
+	return saveBucketLoggingConfig(ctx, s, bucket, config)
+}
+
+// GetBucketLoggingConfig returns bucket logging config on given bucket
+func (s *xlSets) GetBucketLoggingConfig(ctx context.Context, bucket string) (*logging.Config, error) {
+	return getBucketLoggingConfig(s, bucket)
+}
+
+// DeleteBucketLoggingConfig deletes bucket logging config on given bucket
+func (s *xlSets) DeleteBucketLoggingConfig(ctx context.Context, bucket string) error {
+	return removeBucketLoggingConfig(ctx, s, bucket)
+}
+
 // IsNotificationSupported returns whether bucket notification is applicable for this layer.
 func (s *xlSets) IsNotificationSupported() bool {
 	return s.getHashedSet("").IsNotificationSupported()
@@ -779,6 +869,26 @@ func (s *xlSets) DeleteObjects(ctx context.Context, bucket string, objects []str
 	return delErrs, nil
 }
 
+// DeleteObjectVersion - deletes a version of an object from the hashedSet based on the object name.
+func (s *xlSets) DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	return s.getHashedSet(object).DeleteObjectVersion(ctx, bucket, object, opts)
+}
+
+// TransitionObject - marks an object of the hashedSet based on the object name as transitioned.
+func (s *xlSets) TransitionObject(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	return s.getHashedSet(object).TransitionObject(ctx, bucket, object, opts)
+}
+
+// PutObjectMetadata - updates the metadata of an object of the hashedSet based on the object name.
+func (s *xlSets) PutObjectMetadata(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	return s.getHashedSet(object).PutObjectMetadata(ctx, bucket, object, opts)
+}
+
+// getObjectVersions - returns all the versions of an object from the hashedSet based on the object name.
+func (s *xlSets) getObjectVersions(ctx context.Context, bucket, object string) ([]ObjectInfo, error) {
+	return s.getHashedSet(object).getObjectVersions(ctx, bucket, object)
+}
+
 // CopyObject - copies objects from one hashedSet to another hashedSet, on server side.
 func (s *xlSets) CopyObject(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error) {
 	srcSet := s.getHashedSet(srcObject)
@@ -786,7 +896,7 @@ func (s *xlSets) CopyObject(ctx context.Context, srcBucket, srcObject, destBucke
 
 	// Check if this request is only metadata update.
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(destBucket, destObject))
-	if cpSrcDstSame && srcInfo.metadataOnly {
+	if cpSrcDstSame && srcInfo.metadataOnly && !globalBucketVersioningSys.Configured(destBucket) {
 		return srcSet.CopyObject(ctx, srcBucket, srcObject, destBucket, destObject, srcInfo, srcOpts, dstOpts)
 	}
 
@@ -914,6 +1024,11 @@ func mergeEntriesCh(entryChs []FileInfoCh, maxKeys int, totalDrives int, partial
 				// Skip entries which do not have quorum.
 				continue
 			}
+			// Objects whose current version is a delete
+			// marker are not listed.
+			if fi.Deleted {
+				continue
+			}
 		}
 		entries.Files = append(entries.Files, fi)
 		i++
@@ -999,6 +1114,12 @@ func (s *xlSets) listObjectsNonSlash(ctx context.Context, bucket, prefix, marker
 			continue
 		}
 
+		// Objects whose current version is a delete
+		// marker are not listed.
+		if result.Deleted {
+			continue
+		}
+
 		var objInfo ObjectInfo
 
 		index := strings.Index(strings.TrimPrefix(result.Name, prefix), delimiter)
@@ -1148,6 +1269,11 @@ func (s *xlSets) listObjects(ctx context.Context, bucket, prefix, marker, delimi
 	return loi, nil
 }
 
+// ListObjectVersions - not implemented, listing is done by xlZones.
+func (s *xlSets) ListObjectVersions(ctx context.Context, bucket, prefix, marker, versionMarker, delimiter string, maxKeys int) (ListObjectVersionsInfo, error) {
+	return ListObjectVersionsInfo{}, NotImplemented{}
+}
+
 // ListObjects - implements listing of objects across disks, each disk is indepenently
 // walked and merged at this layer. Resulting value through the merge process sends
 // the data in lexically sorted order.
@@ -1646,6 +1772,10 @@ func (s *xlSets) Walk(ctx context.Context, bucket, prefix string, results chan<-
 				return
 			}
 
+			if entry.Deleted {
+				continue
+			}
+
 			results <- entry.ToObjectInfo()
 		}
 	}()
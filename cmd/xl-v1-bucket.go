@@ -22,9 +22,15 @@ import (
 
 	"github.com/minio/minio-go/v6/pkg/s3utils"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/cors"
 	bucketsse "github.com/minio/minio/pkg/bucket/encryption"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/bucket/logging"
+	"github.com/minio/minio/pkg/bucket/object/tagging"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/bucket/versioning"
+	"github.com/minio/minio/pkg/bucket/website"
 
 	"github.com/minio/minio/pkg/sync/errgroup"
 )
@@ -310,6 +316,91 @@ func (xl xlObjects) DeleteBucketSSEConfig(ctx context.Context, bucket string) er
 	return removeBucketSSEConfig(ctx, xl, bucket)
 }
 
+// SetBucketVersioning sets bucket versioning config on given bucket
+func (xl xlObjects) SetBucketVersioning(ctx context.Context, bucket string, config *versioning.Versioning) error {
+	return saveBucketVersioningConfig(ctx, xl, bucket, config)
+}
+
+// GetBucketVersioning returns bucket versioning config on given bucket
+func (xl xlObjects) GetBucketVersioning(ctx context.Context, bucket string) (*versioning.Versioning, error) {
+	return getBucketVersioningConfig(xl, bucket)
+}
+
+// SetBucketReplicationConfig sets bucket replication config on given bucket
+func (xl xlObjects) SetBucketReplicationConfig(ctx context.Context, bucket string, config *replication.Config) error {
+	return saveBucketReplicationConfig(ctx, xl, bucket, config)
+}
+
+// GetBucketReplicationConfig returns bucket replication config on given bucket
+func (xl xlObjects) GetBucketReplicationConfig(ctx context.Context, bucket string) (*replication.Config, error) {
+	return getBucketReplicationConfig(xl, bucket)
+}
+
+// DeleteBucketReplicationConfig deletes bucket replication config on given bucket
+func (xl xlObjects) DeleteBucketReplicationConfig(ctx context.Context, bucket string) error {
+	return removeBucketReplicationConfig(ctx, xl, bucket)
+}
+
+// SetBucketTagging sets bucket tagging on given bucket
+func (xl xlObjects) SetBucketTagging(ctx context.Context, bucket string, tags *tagging.Tagging) error {
+	return saveBucketTaggingConfig(ctx, xl, bucket, tags)
+}
+
+// GetBucketTagging returns bucket tagging on given bucket
+func (xl xlObjects) GetBucketTagging(ctx context.Context, bucket string) (*tagging.Tagging, error) {
+	return getBucketTaggingConfig(xl, bucket)
+}
+
+// DeleteBucketTagging deletes bucket tagging on given bucket
+func (xl xlObjects) DeleteBucketTagging(ctx context.Context, bucket string) error {
+	return removeBucketTaggingConfig(ctx, xl, bucket)
+}
+
+// SetBucketCorsConfig sets bucket CORS config on given bucket
+func (xl xlObjects) SetBucketCorsConfig(ctx context.Context, bucket string, config *cors.Config) error {
+	return saveBucketCorsConfig(ctx, xl, bucket, config)
+}
+
+// GetBucketCorsConfig returns bucket CORS config on given bucket
+func (xl xlObjects) GetBucketCorsConfig(ctx context.Context, bucket string) (*cors.Config, error) {
+	return getBucketCorsConfig(xl, bucket)
+}
+
+// DeleteBucketCorsConfig deletes bucket CORS config on given bucket
+func (xl xlObjects) DeleteBucketCorsConfig(ctx context.Context, bucket string) error {
+	return removeBucketCorsConfig(ctx, xl, bucket)
+}
+
+// SetBucketWebsiteConfig sets bucket website config on given bucket
+func (xl xlObjects) SetBucketWebsiteConfig(ctx context.Context, bucket string, config *website.Config) error {
+	return saveBucketWebsiteConfig(ctx, xl, bucket, config)
+}
+
+// GetBucketWebsiteConfig returns bucket website config on given bucket
+func (xl xlObjects) GetBucketWebsiteConfig(ctx context.Context, bucket string) (*website.Config, error) {
+	return getBucketWebsiteConfig(xl, bucket)
+}
+
+// DeleteBucketWebsiteConfig deletes bucket website config on given bucket
+func (xl xlObjects) DeleteBucketWebsiteConfig(ctx context.Context, bucket string) error {
+	return removeBucketWebsiteConfig(ctx, xl, bucket)
+}
+
+// SetBucketLoggingConfig sets bucket logging config on given bucket
+func (xl xlObjects) SetBucketLoggingConfig(ctx context.Context, bucket string, config *logging.Config) error {
+	return saveBucketLoggingConfig(ctx, xl, bucket, config)
+}
+
+// GetBucketLoggingConfig returns bucket logging config on given bucket
+func (xl xlObjects) GetBucketLoggingConfig(ctx context.Context, bucket string) (*logging.Config, error) {
+	return getBucketLoggingConfig(xl, bucket)
+}
+
+// DeleteBucketLoggingConfig deletes bucket logging config on given bucket
+func (xl xlObjects) DeleteBucketLoggingConfig(ctx context.Context, bucket string) error {
+	return removeBucketLoggingConfig(ctx, xl, bucket)
+}
+
 // IsNotificationSupported returns whether bucket notification is applicable for this layer.
 func (xl xlObjects) IsNotificationSupported() bool {
 	return true
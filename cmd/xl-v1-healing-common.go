@@ -171,39 +171,59 @@ func disksWithAllParts(ctx context.Context, onlineDisks []StorageAPI, partsMetad
 
 		switch scanMode {
 		case madmin.HealDeepScan:
-			erasureInfo := partsMetadata[i].Erasure
-			erasure, err := NewErasure(ctx, erasureInfo.DataBlocks, erasureInfo.ParityBlocks, erasureInfo.BlockSize)
-			if err != nil {
-				dataErrs[i] = err
-				continue
-			}
-
 			// disk has a valid xl.json but may not have all the
-			// parts. This is considered an outdated disk, since
-			// it needs healing too.
-			for _, part := range partsMetadata[i].Parts {
-				checksumInfo := erasureInfo.GetChecksumInfo(part.Number)
-				partPath := pathJoin(object, fmt.Sprintf("part.%d", part.Number))
-				err = onlineDisk.VerifyFile(bucket, partPath, erasure.ShardFileSize(part.Size), checksumInfo.Algorithm, checksumInfo.Hash, erasure.ShardSize())
+			// parts of all the versions. This is considered an
+			// outdated disk, since it needs healing too.
+			for _, version := range partsMetadata[i].allVersions() {
+				if len(version.Parts) == 0 || version.isTransitioned() {
+					continue
+				}
+				erasureInfo := version.Erasure
+				erasure, err := NewErasure(ctx, erasureInfo.DataBlocks, erasureInfo.ParityBlocks, erasureInfo.BlockSize)
 				if err != nil {
-					if !IsErr(err, []error{
-						errFileNotFound,
-						errVolumeNotFound,
-						errFileCorrupt,
-					}...) {
-						logger.GetReqInfo(ctx).AppendTags("disk", onlineDisk.String())
-						logger.LogIf(ctx, err)
-					}
 					dataErrs[i] = err
 					break
 				}
+				for _, part := range version.Parts {
+					checksumInfo := erasureInfo.GetChecksumInfo(part.Number)
+					partPath := pathJoin(object, version.DataDir, fmt.Sprintf("part.%d", part.Number))
+					err = onlineDisk.VerifyFile(bucket, partPath, erasure.ShardFileSize(part.Size), checksumInfo.Algorithm, checksumInfo.Hash, erasure.ShardSize())
+					if err != nil {
+						if !IsErr(err, []error{
+							errFileNotFound,
+							errVolumeNotFound,
+							errFileCorrupt,
+						}...) {
+							logger.GetReqInfo(ctx).AppendTags("disk", onlineDisk.String())
+							logger.LogIf(ctx, err)
+							// Shards which can't be read, e.g. on I/O
+							// errors, are healed as corrupt ones.
+							if !IsErr(err, errDiskNotFound, errFaultyDisk) {
+								err = errFileCorrupt
+							}
+						}
+						dataErrs[i] = err
+						break
+					}
+				}
+				if dataErrs[i] != nil {
+					break
+				}
 			}
 		case madmin.HealNormalScan:
-			for _, part := range partsMetadata[i].Parts {
-				partPath := pathJoin(object, fmt.Sprintf("part.%d", part.Number))
-				_, err := onlineDisk.StatFile(bucket, partPath)
-				if err != nil {
-					dataErrs[i] = err
+			for _, version := range partsMetadata[i].allVersions() {
+				if version.isTransitioned() {
+					continue
+				}
+				for _, part := range version.Parts {
+					partPath := pathJoin(object, version.DataDir, fmt.Sprintf("part.%d", part.Number))
+					_, err := onlineDisk.StatFile(bucket, partPath)
+					if err != nil {
+						dataErrs[i] = err
+						break
+					}
+				}
+				if dataErrs[i] != nil {
 					break
 				}
 			}
@@ -353,12 +353,12 @@ func (xl xlObjects) healObject(ctx context.Context, bucket string, object string
 			continue
 		}
 
-		// List and delete the object directory,
+		// List and delete the object directory, including
+		// the data directories of all the versions.
 		files, derr := disk.ListDir(bucket, object, -1, "")
 		if derr == nil {
 			for _, entry := range files {
-				_ = disk.DeleteFile(bucket,
-					pathJoin(object, entry))
+				_ = cleanupDir(ctx, disk, bucket, pathJoin(object, entry))
 			}
 		}
 	}
@@ -367,80 +367,121 @@ func (xl xlObjects) healObject(ctx context.Context, bucket string, object string
 	latestDisks = shuffleDisks(availableDisks, latestMeta.Erasure.Distribution)
 	outDatedDisks = shuffleDisks(outDatedDisks, latestMeta.Erasure.Distribution)
 	partsMetadata = shufflePartsMetadata(partsMetadata, latestMeta.Erasure.Distribution)
+
+	// We write at temporary location and then rename to final location.
+	tmpID := mustGetUUID()
+
+	// Heal each part of every version of the object. erasure.Heal()
+	// will write the healed part to .minio/tmp/uuid/ which needs to
+	// be renamed later to the final location.
+	latestVersions := latestMeta.allVersions()
+	healedVersions := make([][]xlMetaV1, len(outDatedDisks))
 	for i := range outDatedDisks {
 		if outDatedDisks[i] == nil {
 			continue
 		}
-		partsMetadata[i] = newXLMetaFromXLMeta(latestMeta)
+		healedVersions[i] = make([]xlMetaV1, len(latestVersions))
+		for vIndex, version := range latestVersions {
+			healedVersions[i][vIndex] = newXLMetaFromXLMeta(version)
+		}
 	}
 
-	// We write at temporary location and then rename to final location.
-	tmpID := mustGetUUID()
-
-	// Heal each part. erasureHealFile() will write the healed
-	// part to .minio/tmp/uuid/ which needs to be renamed later to
-	// the final location.
-	erasure, err := NewErasure(ctx, latestMeta.Erasure.DataBlocks,
-		latestMeta.Erasure.ParityBlocks, latestMeta.Erasure.BlockSize)
-	if err != nil {
-		return result, toObjectErr(err, bucket, object)
-	}
-
-	erasureInfo := latestMeta.Erasure
-	for partIndex := 0; partIndex < len(latestMeta.Parts); partIndex++ {
-		partSize := latestMeta.Parts[partIndex].Size
-		partActualSize := latestMeta.Parts[partIndex].ActualSize
-		partNumber := latestMeta.Parts[partIndex].Number
-		tillOffset := erasure.ShardFileTillOffset(0, partSize, partSize)
-		readers := make([]io.ReaderAt, len(latestDisks))
-		checksumAlgo := erasureInfo.GetChecksumInfo(partNumber).Algorithm
-		for i, disk := range latestDisks {
-			if disk == OfflineDisk {
-				continue
+	for vIndex, version := range latestVersions {
+		if version.isTransitioned() {
+			// Data of transitioned versions is held by the
+			// remote tier, only their metadata is healed.
+			for i := range outDatedDisks {
+				if outDatedDisks[i] != nil {
+					healedVersions[i][vIndex].Parts = version.Parts
+				}
 			}
-			checksumInfo := partsMetadata[i].Erasure.GetChecksumInfo(partNumber)
-			partPath := pathJoin(object, fmt.Sprintf("part.%d", partNumber))
-			readers[i] = newBitrotReader(disk, bucket, partPath, tillOffset, checksumAlgo, checksumInfo.Hash, erasure.ShardSize())
+			continue
 		}
-		writers := make([]io.Writer, len(outDatedDisks))
-		for i, disk := range outDatedDisks {
-			if disk == OfflineDisk {
-				continue
+		if len(version.Parts) == 0 {
+			// Delete markers carry no data.
+			continue
+		}
+
+		erasure, err := NewErasure(ctx, version.Erasure.DataBlocks,
+			version.Erasure.ParityBlocks, version.Erasure.BlockSize)
+		if err != nil {
+			return result, toObjectErr(err, bucket, object)
+		}
+
+		erasureInfo := version.Erasure
+		for partIndex := 0; partIndex < len(version.Parts); partIndex++ {
+			partSize := version.Parts[partIndex].Size
+			partActualSize := version.Parts[partIndex].ActualSize
+			partNumber := version.Parts[partIndex].Number
+			tillOffset := erasure.ShardFileTillOffset(0, partSize, partSize)
+			readers := make([]io.ReaderAt, len(latestDisks))
+			checksumAlgo := erasureInfo.GetChecksumInfo(partNumber).Algorithm
+			for i, disk := range latestDisks {
+				if disk == OfflineDisk {
+					continue
+				}
+				diskVersion, vErr := partsMetadata[i].pickVersion(version.versionID())
+				if vErr != nil {
+					continue
+				}
+				checksumInfo := diskVersion.Erasure.GetChecksumInfo(partNumber)
+				partPath := pathJoin(object, version.DataDir, fmt.Sprintf("part.%d", partNumber))
+				readers[i] = newBitrotReader(disk, bucket, partPath, tillOffset, checksumAlgo, checksumInfo.Hash, erasure.ShardSize())
 			}
-			partPath := pathJoin(tmpID, fmt.Sprintf("part.%d", partNumber))
-			writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, partPath, tillOffset, checksumAlgo, erasure.ShardSize())
-		}
-		hErr := erasure.Heal(ctx, readers, writers, partSize)
-		closeBitrotReaders(readers)
-		closeBitrotWriters(writers)
-		if hErr != nil {
-			return result, toObjectErr(hErr, bucket, object)
-		}
-		// outDatedDisks that had write errors should not be
-		// written to for remaining parts, so we nil it out.
-		for i, disk := range outDatedDisks {
-			if disk == nil {
-				continue
+			writers := make([]io.Writer, len(outDatedDisks))
+			for i, disk := range outDatedDisks {
+				if disk == OfflineDisk {
+					continue
+				}
+				if version.Inline {
+					writers[i] = newInlineBitrotWriter(checksumAlgo)
+					continue
+				}
+				partPath := pathJoin(tmpID, version.DataDir, fmt.Sprintf("part.%d", partNumber))
+				writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, partPath, tillOffset, checksumAlgo, erasure.ShardSize())
 			}
-			// A non-nil stale disk which did not receive
-			// a healed part checksum had a write error.
-			if writers[i] == nil {
-				outDatedDisks[i] = nil
-				disksToHealCount--
-				continue
+			hErr := erasure.Heal(ctx, readers, writers, partSize)
+			closeBitrotReaders(readers)
+			closeBitrotWriters(writers)
+			if hErr != nil {
+				return result, toObjectErr(hErr, bucket, object)
+			}
+			// outDatedDisks that had write errors should not be
+			// written to for remaining parts, so we nil it out.
+			for i, disk := range outDatedDisks {
+				if disk == nil {
+					continue
+				}
+				// A non-nil stale disk which did not receive
+				// a healed part checksum had a write error.
+				if writers[i] == nil {
+					outDatedDisks[i] = nil
+					disksToHealCount--
+					continue
+				}
+				healedVersions[i][vIndex].AddObjectPart(partNumber, "", partSize, partActualSize)
+				healedVersions[i][vIndex].Erasure.AddChecksumInfo(ChecksumInfo{
+					PartNumber: partNumber,
+					Algorithm:  checksumAlgo,
+					Hash:       bitrotWriterSum(writers[i]),
+				})
+				if version.Inline {
+					healedVersions[i][vIndex].Data = bitrotWriterData(writers[i])
+				}
+			}
+
+			// If all disks are having errors, we give up.
+			if disksToHealCount == 0 {
+				return result, fmt.Errorf("all disks without up-to-date data had write errors")
 			}
-			partsMetadata[i].AddObjectPart(partNumber, "", partSize, partActualSize)
-			partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{
-				PartNumber: partNumber,
-				Algorithm:  checksumAlgo,
-				Hash:       bitrotWriterSum(writers[i]),
-			})
 		}
+	}
 
-		// If all disks are having errors, we give up.
-		if disksToHealCount == 0 {
-			return result, fmt.Errorf("all disks without up-to-date data had write errors")
+	for i := range outDatedDisks {
+		if outDatedDisks[i] == nil {
+			continue
 		}
+		partsMetadata[i] = newXLMetaFromVersions(healedVersions[i])
 	}
 
 	// Cleanup in case of xl.json writing failure
@@ -19,9 +19,11 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/minio/minio/cmd/config/storageclass"
 	"github.com/minio/minio/pkg/madmin"
 )
 
@@ -369,3 +371,84 @@ func TestHealEmptyDirectoryXL(t *testing.T) {
 		}
 	}
 }
+
+// Tests that objects stored inline in `xl.json` are read and healed
+// without part files.
+func TestHealObjectInlineXL(t *testing.T) {
+	nDisks := 16
+	fsDirs, err := getRandomDisks(nDisks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer removeRoots(fsDirs)
+
+	obj, _, err := initObjectLayer(mustGetZoneEndpoints(fsDirs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	globalStorageClass = storageclass.Config{InlineThreshold: 128 * 1024}
+	defer func() { globalStorageClass = storageclass.Config{} }()
+
+	bucket := "bucket"
+	object := "object"
+	data := bytes.Repeat([]byte("a"), 10*1024)
+
+	err = obj.MakeBucketWithLocation(context.Background(), bucket, "")
+	if err != nil {
+		t.Fatalf("Failed to make a bucket - %v", err)
+	}
+
+	_, err = obj.PutObject(context.Background(), bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatalf("Failed to putObject %v", err)
+	}
+
+	for _, dir := range fsDirs {
+		if _, err = os.Stat(filepath.Join(dir, bucket, object, inlinePartName)); !os.IsNotExist(err) {
+			t.Fatalf("Expected no part file for an inline object, got %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err = obj.GetObject(context.Background(), bucket, object, 0, int64(len(data)), &buf, "", ObjectOptions{}); err != nil {
+		t.Fatalf("Failed to getObject %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatal("Unexpected data of inline object")
+	}
+
+	// Remove `xl.json` holding the object from the first disk.
+	z := obj.(*xlZones)
+	xl := z.zones[0].sets[0]
+	firstDisk := xl.getDisks()[0]
+	err = firstDisk.DeleteFile(bucket, filepath.Join(object, xlMetaJSONFile))
+	if err != nil {
+		t.Fatalf("Failed to delete a file - %v", err)
+	}
+
+	_, err = obj.HealObject(context.Background(), bucket, object, false, false, madmin.HealNormalScan)
+	if err != nil {
+		t.Fatalf("Failed to heal object - %v", err)
+	}
+
+	xlMeta, err := readXLMeta(context.Background(), firstDisk, bucket, object)
+	if err != nil {
+		t.Fatalf("Expected xl.json file to be present but read failed - %v", err)
+	}
+	if !xlMeta.Inline || len(xlMeta.Data) == 0 {
+		t.Fatal("Expected the healed object to be stored inline")
+	}
+
+	// Verify the healed data with a deep scan.
+	res, err := obj.HealObject(context.Background(), bucket, object, true, false, madmin.HealDeepScan)
+	if err != nil {
+		t.Fatalf("Failed to heal object - %v", err)
+	}
+	for _, drive := range res.Before.Drives {
+		if drive.State != madmin.DriveStateOk {
+			t.Errorf("Expected drive %s to be ok, got %s", drive.Endpoint, drive.State)
+		}
+	}
+}
@@ -160,12 +160,34 @@ type xlMetaV1 struct {
 	Meta map[string]string `json:"meta,omitempty"`
 	// Captures all the individual object `xl.json`.
 	Parts []ObjectPartInfo `json:"parts,omitempty"`
+	// Unique version identifier of this object version, empty
+	// for the "null" version.
+	VersionID string `json:"versionId,omitempty"`
+	// Directory relative to the object holding the parts of this
+	// version, empty when parts are stored alongside `xl.json`.
+	DataDir string `json:"dataDir,omitempty"`
+	// Indicates this version is a delete marker.
+	DeleteMarker bool `json:"deleteMarker,omitempty"`
+	// Remote tier holding the data of this version and the name
+	// of the object on it, set once the version was transitioned.
+	TransitionTier   string `json:"transitionTier,omitempty"`
+	TransitionObject string `json:"transitionObject,omitempty"`
+	// Indicates the data of this version is stored inline, Data
+	// holds the bitrot protected erasure shard of this disk.
+	Inline bool   `json:"inline,omitempty"`
+	Data   []byte `json:"data,omitempty"`
+	// All the noncurrent versions of the object, latest first,
+	// only set on the current version.
+	Versions []xlMetaV1 `json:"versions,omitempty"`
 }
 
 // XL metadata constants.
 const (
 	// XL meta version.
-	xlMetaVersion = "1.0.1"
+	xlMetaVersion = "1.0.2"
+
+	// XL meta version.
+	xlMetaVersion101 = "1.0.1"
 
 	// XL meta version.
 	xlMetaVersion100 = "1.0.0"
@@ -198,6 +220,7 @@ func newXLMetaFromXLMeta(meta xlMetaV1) xlMetaV1 {
 	xlMeta := meta
 	xlMeta.Erasure.Checksums = nil
 	xlMeta.Parts = nil
+	xlMeta.Data = nil
 	return xlMeta
 }
 
@@ -211,8 +234,8 @@ func (m xlMetaV1) IsValid() bool {
 // Verifies if the backend format metadata is sane by validating
 // the version string and format style.
 func isXLMetaFormatValid(version, format string) bool {
-	return ((version == xlMetaVersion || version == xlMetaVersion100) &&
-		format == xlMetaFormat)
+	return ((version == xlMetaVersion || version == xlMetaVersion101 ||
+		version == xlMetaVersion100) && format == xlMetaFormat)
 }
 
 // Verifies if the backend format metadata is sane by validating
@@ -259,6 +282,9 @@ func (m xlMetaV1) ToObjectInfo(bucket, object string) ObjectInfo {
 	// All the parts per object.
 	objInfo.Parts = m.Parts
 
+	objInfo.VersionID = m.VersionID
+	objInfo.DeleteMarker = m.DeleteMarker
+
 	// Update storage class
 	if sc, ok := m.Meta[xhttp.AmzStorageClass]; ok {
 		objInfo.StorageClass = sc
@@ -266,10 +292,145 @@ func (m xlMetaV1) ToObjectInfo(bucket, object string) ObjectInfo {
 		objInfo.StorageClass = globalMinioDefaultStorageClass
 	}
 
+	// Transitioned objects are presented with the
+	// name of the remote tier as storage class.
+	if m.isTransitioned() {
+		objInfo.TransitionTier = m.TransitionTier
+		objInfo.StorageClass = m.TransitionTier
+	}
+
 	// Success.
 	return objInfo
 }
 
+// isTransitioned - returns true if the data of this version
+// was moved to a remote tier.
+func (m xlMetaV1) isTransitioned() bool {
+	return m.TransitionTier != ""
+}
+
+// versionID - returns the version id of this version as
+// presented to clients, "null" for the null version.
+func (m xlMetaV1) versionID() string {
+	if m.VersionID == "" {
+		return nullVersionID
+	}
+	return m.VersionID
+}
+
+// allVersions - returns all the versions of the object, the
+// current version first followed by the noncurrent versions.
+func (m xlMetaV1) allVersions() []xlMetaV1 {
+	versions := make([]xlMetaV1, 0, len(m.Versions)+1)
+	current := m
+	current.Versions = nil
+	versions = append(versions, current)
+	return append(versions, m.Versions...)
+}
+
+// newXLMetaFromVersions - returns the `xl.json` content carrying
+// all the given versions, latest first.
+func newXLMetaFromVersions(versions []xlMetaV1) xlMetaV1 {
+	m := versions[0]
+	m.Versions = nil
+	if len(versions) > 1 {
+		m.Versions = append([]xlMetaV1(nil), versions[1:]...)
+	}
+	return m
+}
+
+// pickVersion - returns the version matching versionID as a
+// standalone xlMetaV1, an empty versionID refers to the current
+// version of the object.
+func (m xlMetaV1) pickVersion(versionID string) (xlMetaV1, error) {
+	for _, version := range m.allVersions() {
+		if versionID == "" || version.versionID() == versionID {
+			return version, nil
+		}
+	}
+	return xlMetaV1{}, errFileVersionNotFound
+}
+
+// addVersion - returns the `xl.json` content with the given
+// version as the current version. When replaceNull is set, any
+// existing null version is dropped and returned to the caller
+// so that its data can be purged.
+func (m xlMetaV1) addVersion(version xlMetaV1, replaceNull bool) (xlMetaV1, []xlMetaV1) {
+	var purged []xlMetaV1
+	versions := []xlMetaV1{version}
+	for _, v := range m.allVersions() {
+		if replaceNull && v.VersionID == "" {
+			purged = append(purged, v)
+			continue
+		}
+		versions = append(versions, v)
+	}
+	return newXLMetaFromVersions(versions), purged
+}
+
+// removeVersion - returns the `xl.json` content without the
+// version matching versionID, remaining is false when no other
+// version of the object is left.
+func (m xlMetaV1) removeVersion(versionID string) (xlMeta xlMetaV1, remaining bool) {
+	var versions []xlMetaV1
+	for _, v := range m.allVersions() {
+		if v.versionID() == versionID {
+			continue
+		}
+		versions = append(versions, v)
+	}
+	if len(versions) == 0 {
+		return xlMetaV1{}, false
+	}
+	return newXLMetaFromVersions(versions), true
+}
+
+// transitionVersion - returns the `xl.json` content with the version
+// matching versionID marked as transitioned to the given remote tier,
+// ok is false when no such version exists. The parts are kept since
+// they describe the layout of the data held by the remote tier.
+func (m xlMetaV1) transitionVersion(versionID, tier, remoteObject string) (xlMeta xlMetaV1, ok bool) {
+	versions := m.allVersions()
+	for i, v := range versions {
+		if v.versionID() != versionID {
+			continue
+		}
+		v.TransitionTier = tier
+		v.TransitionObject = remoteObject
+		v.DataDir = ""
+		v.Inline = false
+		v.Data = nil
+		v.Erasure.Checksums = nil
+		versions[i] = v
+		return newXLMetaFromVersions(versions), true
+	}
+	return m, false
+}
+
+// updateVersionMeta - returns the `xl.json` content with the given
+// metadata merged into the metadata of the version matching versionID,
+// ok is false when no such version exists.
+func (m xlMetaV1) updateVersionMeta(versionID string, meta map[string]string) (xlMeta xlMetaV1, ok bool) {
+	versions := m.allVersions()
+	for i, v := range versions {
+		if v.versionID() != versionID {
+			continue
+		}
+		// Versions may share the same metadata map, update a copy.
+		newMeta := make(map[string]string, len(v.Meta)+len(meta))
+		for k, val := range v.Meta {
+			newMeta[k] = val
+		}
+		for k, val := range meta {
+			newMeta[k] = val
+		}
+		v.Meta = newMeta
+		versions[i] = v
+		return newXLMetaFromVersions(versions), true
+	}
+	return m, false
+}
+
 // objectPartIndex - returns the index of matching object part number.
 func objectPartIndex(parts []ObjectPartInfo, partNumber int) int {
 	for i, part := range parts {
@@ -470,6 +631,9 @@ func writeUniqueXLMetadata(ctx context.Context, disks []StorageAPI, bucket, pref
 			}
 			// Pick one xlMeta for a disk at index.
 			xlMetas[index].Erasure.Index = index + 1
+			for v := range xlMetas[index].Versions {
+				xlMetas[index].Versions[v].Erasure.Index = index + 1
+			}
 			return writeXLMetadata(ctx, disks[index], bucket, prefix, xlMetas[index])
 		}, index)
 	}
@@ -383,6 +383,7 @@ func TestIsXLMetaFormatValid(t *testing.T) {
 		{4, xlMetaVersion100, "hello", false},
 		{5, xlMetaVersion, xlMetaFormat, true},
 		{6, xlMetaVersion100, xlMetaFormat, true},
+		{7, xlMetaVersion101, xlMetaFormat, true},
 	}
 	for _, tt := range tests {
 		if got := isXLMetaFormatValid(tt.version, tt.format); got != tt.want {
@@ -391,6 +392,53 @@ func TestIsXLMetaFormatValid(t *testing.T) {
 	}
 }
 
+// Tests adding, picking and removing versions of an object.
+func TestXLMetaVersions(t *testing.T) {
+	nullVersion := newXLMetaV1("object", 8, 8)
+	nullVersion.Stat.ModTime = UTCNow()
+
+	v1 := newXLMetaV1("object", 8, 8)
+	v1.VersionID = mustGetUUID()
+	v1.DataDir = mustGetUUID()
+
+	xlMeta, purged := nullVersion.addVersion(v1, false)
+	if len(purged) != 0 {
+		t.Fatalf("Expected no purged versions, got %d", len(purged))
+	}
+	if xlMeta.VersionID != v1.VersionID || len(xlMeta.Versions) != 1 {
+		t.Fatalf("Expected %s to be the current version of 2 versions", v1.VersionID)
+	}
+
+	if _, err := xlMeta.pickVersion(nullVersionID); err != nil {
+		t.Fatalf("Expected null version to be found, got %v", err)
+	}
+	if _, err := xlMeta.pickVersion(mustGetUUID()); err != errFileVersionNotFound {
+		t.Fatalf("Expected %v, got %v", errFileVersionNotFound, err)
+	}
+	latest, err := xlMeta.pickVersion("")
+	if err != nil || latest.VersionID != v1.VersionID || len(latest.Versions) != 0 {
+		t.Fatalf("Expected %s as latest version, got %s (%v)", v1.VersionID, latest.VersionID, err)
+	}
+
+	marker := newXLMetaV1("object", 8, 8)
+	marker.DeleteMarker = true
+	xlMeta, purged = xlMeta.addVersion(marker, true)
+	if len(purged) != 1 || purged[0].VersionID != "" {
+		t.Fatalf("Expected null version to be purged")
+	}
+	if !xlMeta.DeleteMarker || len(xlMeta.allVersions()) != 2 {
+		t.Fatalf("Expected null delete marker to be the current version of 2 versions")
+	}
+
+	xlMeta, remaining := xlMeta.removeVersion(nullVersionID)
+	if !remaining || xlMeta.VersionID != v1.VersionID || len(xlMeta.Versions) != 0 {
+		t.Fatalf("Expected only %s to remain", v1.VersionID)
+	}
+	if _, remaining = xlMeta.removeVersion(v1.VersionID); remaining {
+		t.Fatalf("Expected no versions to remain")
+	}
+}
+
 func TestIsXLMetaErasureInfoValid(t *testing.T) {
 	tests := []struct {
 		name   int
@@ -0,0 +1,238 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	xhttp "github.com/minio/minio/cmd/http"
+)
+
+// readXLMetaQuorum - reads `xl.json` of an object from all the disks,
+// returns the metadata agreed upon by read quorum, the metadata of each
+// disk and the disks holding the latest metadata.
+func (xl xlObjects) readXLMetaQuorum(ctx context.Context, bucket, object string) (xlMetaV1, []xlMetaV1, []StorageAPI, error) {
+	disks := xl.getDisks()
+	metaArr, errs := readAllXLMetadata(ctx, disks, bucket, object)
+
+	readQuorum, _, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
+	if err != nil {
+		return xlMetaV1{}, nil, nil, toObjectErr(err, bucket, object)
+	}
+
+	if reducedErr := reduceReadQuorumErrs(ctx, errs, objectOpIgnoredErrs, readQuorum); reducedErr != nil {
+		return xlMetaV1{}, nil, nil, toObjectErr(reducedErr, bucket, object)
+	}
+
+	onlineDisks, modTime := listOnlineDisks(disks, metaArr, errs)
+	xlMeta, err := pickValidXLMeta(ctx, metaArr, modTime, readQuorum)
+	if err != nil {
+		return xlMetaV1{}, nil, nil, toObjectErr(err, bucket, object)
+	}
+	return xlMeta, metaArr, onlineDisks, nil
+}
+
+// getObjectModTime - returns the modification time of the current
+// version of an object, delete markers included.
+func (xl xlObjects) getObjectModTime(ctx context.Context, bucket, object string) (time.Time, error) {
+	xlMeta, _, _, err := xl.readXLMetaQuorum(ctx, bucket, object)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return xlMeta.Stat.ModTime, nil
+}
+
+// decodeXLPart - writes the data of a part of a version of an object
+// to w. disks and metaArr hold the disks and the version picked from
+// their `xl.json`, in erasure distribution order.
+func decodeXLPart(ctx context.Context, bucket, object string, version xlMetaV1, disks []StorageAPI, metaArr []xlMetaV1, partIndex int, w io.Writer) error {
+	erasure, err := NewErasure(ctx, version.Erasure.DataBlocks, version.Erasure.ParityBlocks, version.Erasure.BlockSize)
+	if err != nil {
+		return err
+	}
+
+	part := version.Parts[partIndex]
+	tillOffset := erasure.ShardFileTillOffset(0, part.Size, part.Size)
+	readers := make([]io.ReaderAt, len(disks))
+	for index, disk := range disks {
+		if disk == OfflineDisk {
+			continue
+		}
+		checksumInfo := metaArr[index].Erasure.GetChecksumInfo(part.Number)
+		partPath := pathJoin(object, version.DataDir, fmt.Sprintf("part.%d", part.Number))
+		readers[index] = newBitrotReader(disk, bucket, partPath, tillOffset,
+			checksumInfo.Algorithm, checksumInfo.Hash, erasure.ShardSize())
+	}
+	err = erasure.Decode(ctx, w, readers, 0, part.Size, part.Size)
+	closeBitrotReaders(readers)
+	return err
+}
+
+// copyXLObject - copies all the versions of an object, or a multipart
+// upload, from the source to the destination erasure set. The versions
+// keep their version IDs, data directories and modification times, their
+// data is erasure coded again with the parity of the destination set.
+// The object is expected not to exist on the destination set. Returns
+// the number of bytes copied.
+func copyXLObject(ctx context.Context, src, dst *xlObjects, bucket, object string) (int64, error) {
+	latest, metaArr, srcOnline, err := src.readXLMetaQuorum(ctx, bucket, object)
+	if err != nil {
+		return 0, err
+	}
+
+	dstDisks := dst.getDisks()
+	writeQuorum := len(dstDisks)/2 + 1
+
+	tempObj := mustGetUUID()
+	// Delete temporary object in the event of failure.
+	defer dst.deleteObject(ctx, minioMetaTmpBucket, tempObj, writeQuorum, false)
+
+	// The erasure distribution only depends on the object name,
+	// hence it is the same for all the versions.
+	onlineDisks := shuffleDisks(dstDisks, hashOrder(object, len(dstDisks)))
+
+	buffer := dst.bp.Get()
+	defer dst.bp.Put(buffer)
+
+	var copied int64
+	versions := make([][]xlMetaV1, len(onlineDisks))
+	for _, version := range latest.allVersions() {
+		// Get parity and data drive count based on storage class metadata
+		parityDrives := globalStorageClass.GetParityForSC(version.Meta[xhttp.AmzStorageClass])
+		if parityDrives == 0 {
+			parityDrives = len(dstDisks) / 2
+		}
+		dataDrives := len(dstDisks) - parityDrives
+		if dataDrives+1 > writeQuorum {
+			writeQuorum = dataDrives + 1
+		}
+
+		xlMeta := newXLMetaV1(object, dataDrives, parityDrives)
+		xlMeta.Stat = version.Stat
+		xlMeta.Meta = version.Meta
+		xlMeta.VersionID = version.VersionID
+		xlMeta.DataDir = version.DataDir
+		xlMeta.DeleteMarker = version.DeleteMarker
+		xlMeta.TransitionTier = version.TransitionTier
+		xlMeta.TransitionObject = version.TransitionObject
+		xlMeta.Inline = version.Inline
+
+		partsMetadata := make([]xlMetaV1, len(onlineDisks))
+		for index := range partsMetadata {
+			partsMetadata[index] = xlMeta
+		}
+
+		switch {
+		case version.DeleteMarker:
+		case version.isTransitioned():
+			// The data of transitioned versions is on the remote tier.
+			for index := range partsMetadata {
+				partsMetadata[index].Parts = version.Parts
+			}
+		default:
+			// Pick the version from the metadata of each source disk.
+			srcDisks := make([]StorageAPI, len(srcOnline))
+			srcMetas := make([]xlMetaV1, len(srcOnline))
+			for index, disk := range srcOnline {
+				if disk == OfflineDisk {
+					continue
+				}
+				if srcMetas[index], err = metaArr[index].pickVersion(version.versionID()); err == nil {
+					srcDisks[index] = disk
+				}
+			}
+			srcDisks = shuffleDisks(srcDisks, version.Erasure.Distribution)
+			srcMetas = shufflePartsMetadata(srcMetas, version.Erasure.Distribution)
+
+			erasure, err := NewErasure(ctx, xlMeta.Erasure.DataBlocks, xlMeta.Erasure.ParityBlocks, xlMeta.Erasure.BlockSize)
+			if err != nil {
+				return 0, toObjectErr(err, bucket, object)
+			}
+			if len(buffer) > int(xlMeta.Erasure.BlockSize) {
+				buffer = buffer[:xlMeta.Erasure.BlockSize]
+			}
+
+			for partIndex, part := range version.Parts {
+				partPath := pathJoin(tempObj, version.DataDir, fmt.Sprintf("part.%d", part.Number))
+				writers := make([]io.Writer, len(onlineDisks))
+				for index, disk := range onlineDisks {
+					if disk == nil {
+						continue
+					}
+					if version.Inline {
+						writers[index] = newInlineBitrotWriter(DefaultBitrotAlgorithm)
+						continue
+					}
+					writers[index] = newBitrotWriter(disk, minioMetaTmpBucket, partPath,
+						erasure.ShardFileSize(part.Size), DefaultBitrotAlgorithm, erasure.ShardSize())
+				}
+
+				pr, pw := io.Pipe()
+				go func(partIndex int) {
+					pw.CloseWithError(decodeXLPart(ctx, bucket, object, version, srcDisks, srcMetas, partIndex, pw))
+				}(partIndex)
+				n, err := erasure.Encode(ctx, pr, writers, buffer, dataDrives+1)
+				pr.Close()
+				closeBitrotWriters(writers)
+				if err != nil {
+					return 0, toObjectErr(err, bucket, object)
+				}
+				if n != part.Size {
+					return 0, toObjectErr(errLessData, bucket, object)
+				}
+
+				for index, w := range writers {
+					if w == nil {
+						onlineDisks[index] = nil
+						continue
+					}
+					partsMetadata[index].AddObjectPart(part.Number, part.ETag, n, part.ActualSize)
+					partsMetadata[index].Erasure.AddChecksumInfo(ChecksumInfo{
+						PartNumber: part.Number,
+						Algorithm:  DefaultBitrotAlgorithm,
+						Hash:       bitrotWriterSum(w),
+					})
+					partsMetadata[index].Data = bitrotWriterData(w)
+				}
+				copied += n
+			}
+		}
+
+		for index := range versions {
+			versions[index] = append(versions[index], partsMetadata[index])
+		}
+	}
+
+	xlMetas := make([]xlMetaV1, len(onlineDisks))
+	for index := range xlMetas {
+		xlMetas[index] = newXLMetaFromVersions(versions[index])
+	}
+
+	// Write unique `xl.json` for each disk.
+	if onlineDisks, err = writeUniqueXLMetadata(ctx, onlineDisks, minioMetaTmpBucket, tempObj, xlMetas, writeQuorum); err != nil {
+		return 0, toObjectErr(err, bucket, object)
+	}
+
+	// Rename the successfully written temporary object to final location.
+	if _, err = rename(ctx, onlineDisks, minioMetaTmpBucket, tempObj, bucket, object, true, writeQuorum, nil); err != nil {
+		return 0, toObjectErr(err, bucket, object)
+	}
+	return copied, nil
+}
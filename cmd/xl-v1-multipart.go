@@ -50,7 +50,7 @@ func (xl xlObjects) getMultipartSHADir(bucket, object string) string {
 
 // checkUploadIDExists - verify if a given uploadID exists and is valid.
 func (xl xlObjects) checkUploadIDExists(ctx context.Context, bucket, object, uploadID string) error {
-	_, err := xl.getObjectInfo(ctx, minioMetaMultipartBucket, xl.getUploadIDDir(bucket, object, uploadID))
+	_, err := xl.getObjectInfo(ctx, minioMetaMultipartBucket, xl.getUploadIDDir(bucket, object, uploadID), ObjectOptions{})
 	return err
 }
 
@@ -648,12 +648,28 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 	// Save the consolidated actual size.
 	xlMeta.Meta[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
 
+	// Each version of an object in a bucket with versioning
+	// configured keeps its parts in a unique data directory.
+	versioned := globalBucketVersioningSys.Configured(bucket)
+	if versioned {
+		xlMeta.DataDir = mustGetUUID()
+		if globalBucketVersioningSys.Enabled(bucket) {
+			xlMeta.VersionID = mustGetUUID()
+		}
+	}
+
 	// Update all xl metadata, make sure to not modify fields like
 	// checksum which are different on each disks.
 	for index := range partsMetadata {
 		partsMetadata[index].Stat = xlMeta.Stat
 		partsMetadata[index].Meta = xlMeta.Meta
 		partsMetadata[index].Parts = xlMeta.Parts
+		partsMetadata[index].DataDir = xlMeta.DataDir
+		partsMetadata[index].VersionID = xlMeta.VersionID
+	}
+
+	if versioned {
+		return xl.completeMultipartVersion(ctx, bucket, object, uploadID, onlineDisks, partsMetadata, currentXLMeta, xlMeta, writeQuorum)
 	}
 
 	tempXLMetaPath := mustGetUUID()
@@ -672,14 +688,19 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		return oi, toObjectErr(rErr, minioMetaMultipartBucket, uploadIDPath)
 	}
 
+	// Data of an overwritten transitioned object is removed from the remote tier.
+	var transitioned []xlMetaV1
+
 	if xl.isObject(bucket, object) {
 		// Deny if WORM is enabled
 		if isWORMEnabled(bucket) {
-			if _, err := xl.getObjectInfo(ctx, bucket, object); err == nil {
+			if _, err := xl.getObjectInfo(ctx, bucket, object, ObjectOptions{}); err == nil {
 				return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
 			}
 		}
 
+		transitioned = xl.getTransitionedVersions(ctx, bucket, object)
+
 		// Rename if an object already exists to temporary location.
 		newUniqueID := mustGetUUID()
 
@@ -713,6 +734,8 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		return oi, toObjectErr(err, bucket, object)
 	}
 
+	xl.purgeVersions(ctx, bucket, object, transitioned, writeQuorum)
+
 	// Check if there is any offline disk and add it to the MRF list
 	for i := 0; i < len(onlineDisks); i++ {
 		if onlineDisks[i] == nil || storageDisks[i] == nil {
@@ -724,6 +747,58 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 	return xlMeta.ToObjectInfo(bucket, object), nil
 }
 
+// completeMultipartVersion - commits the uploaded parts of a multipart
+// upload as a new version of an object in a bucket with versioning
+// configured, onlineDisks and partsMetadata are expected in erasure
+// distribution order.
+func (xl xlObjects) completeMultipartVersion(ctx context.Context, bucket, object, uploadID string,
+	onlineDisks []StorageAPI, partsMetadata []xlMetaV1, currentXLMeta, xlMeta xlMetaV1, writeQuorum int) (oi ObjectInfo, err error) {
+	uploadIDPath := xl.getUploadIDDir(bucket, object, uploadID)
+
+	// Remove parts that weren't present in CompleteMultipartUpload request.
+	for _, curpart := range currentXLMeta.Parts {
+		if objectPartIndex(xlMeta.Parts, curpart.Number) == -1 {
+			xl.removeObjectPart(bucket, object, uploadID, curpart.Number)
+		}
+	}
+
+	tempObj := mustGetUUID()
+
+	// Cleanup in case of failure
+	defer xl.deleteObject(ctx, minioMetaTmpBucket, tempObj, writeQuorum, false)
+
+	// Move the uploaded parts to the data directory of the new version.
+	dataDir := pathJoin(tempObj, xlMeta.DataDir)
+	if onlineDisks, err = rename(ctx, onlineDisks, minioMetaMultipartBucket, uploadIDPath, minioMetaTmpBucket, dataDir, true, writeQuorum, nil); err != nil {
+		return oi, toObjectErr(err, bucket, object)
+	}
+
+	// `xl.json` of the upload is superseded by the version being committed.
+	for _, disk := range onlineDisks {
+		if disk == nil {
+			continue
+		}
+		_ = disk.DeleteFile(minioMetaTmpBucket, pathJoin(dataDir, xlMetaJSONFile))
+	}
+
+	// Add the new version to the existing versions of the object.
+	if onlineDisks, err = xl.commitXLVersion(ctx, bucket, object, tempObj, onlineDisks, partsMetadata, writeQuorum); err != nil {
+		return oi, toObjectErr(err, bucket, object)
+	}
+
+	// Check if there is any offline disk and add it to the MRF list
+	for i := 0; i < len(onlineDisks); i++ {
+		if onlineDisks[i] == nil {
+			xl.addPartialUpload(bucket, object)
+			break
+		}
+	}
+
+	oi = xlMeta.ToObjectInfo(bucket, object)
+	oi.VersionID = xlMeta.versionID()
+	return oi, nil
+}
+
 // AbortMultipartUpload - aborts an ongoing multipart operation
 // signified by the input uploadID. This is an atomic operation
 // doesn't require clients to initiate multiple such requests.
@@ -19,6 +19,7 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"net/http"
@@ -63,12 +64,13 @@ func (xl xlObjects) putObjectDir(ctx context.Context, bucket, object string, wri
 
 // CopyObject - copy object source object to destination object.
 // if source object and destination object are same we only
-// update metadata.
+// update metadata, unless the bucket is versioned in which
+// case a new version is always created.
 func (xl xlObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (oi ObjectInfo, e error) {
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(dstBucket, dstObject))
 
 	// Check if this request is only metadata update.
-	if cpSrcDstSame {
+	if cpSrcDstSame && !globalBucketVersioningSys.Configured(dstBucket) {
 		// Read metadata associated with the object from all disks.
 		storageDisks := xl.getDisks()
 
@@ -141,12 +143,12 @@ func (xl xlObjects) GetObjectNInfo(ctx context.Context, bucket, object string, r
 	}
 
 	var objInfo ObjectInfo
-	objInfo, err = xl.getObjectInfo(ctx, bucket, object)
+	objInfo, err = xl.getObjectInfo(ctx, bucket, object, opts)
 	if err != nil {
 		return nil, toObjectErr(err, bucket, object)
 	}
 
-	fn, off, length, nErr := NewGetObjectReader(rs, objInfo, opts.CheckCopyPrecondFn)
+	fn, off, length, nErr := NewGetObjectReader(rs, objInfo, h, opts.CheckCopyPrecondFn)
 	if nErr != nil {
 		return nil, nErr
 	}
@@ -199,8 +201,8 @@ func (xl xlObjects) getObject(ctx context.Context, bucket, object string, startO
 		return toObjectErr(err, bucket, object)
 	}
 
-	// Read metadata associated with the object from all disks.
-	metaArr, errs := readAllXLMetadata(ctx, xl.getDisks(), bucket, object)
+	// Read metadata of the requested version associated with the object from all disks.
+	metaArr, errs := readAllXLMetadataVersion(ctx, xl.getDisks(), bucket, object, opts.VersionID)
 
 	// get Quorum for this object
 	readQuorum, _, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
@@ -221,6 +223,14 @@ func (xl xlObjects) getObject(ctx context.Context, bucket, object string, startO
 		return err
 	}
 
+	// Delete markers have no content to be read.
+	if xlMeta.DeleteMarker {
+		if opts.VersionID == "" {
+			return toObjectErr(errFileNotFound, bucket, object)
+		}
+		return toObjectErr(errMethodNotAllowed, bucket, object, opts.VersionID)
+	}
+
 	// Reorder online disks based on erasure distribution order.
 	onlineDisks = shuffleDisks(onlineDisks, xlMeta.Erasure.Distribution)
 
@@ -238,6 +248,14 @@ func (xl xlObjects) getObject(ctx context.Context, bucket, object string, startO
 		return InvalidRange{startOffset, length, xlMeta.Stat.Size}
 	}
 
+	// Data of transitioned objects is read from the remote tier.
+	if xlMeta.isTransitioned() {
+		if err = getTransitionedObject(ctx, xlMeta, startOffset, length, writer); err != nil {
+			return toObjectErr(err, bucket, object)
+		}
+		return nil
+	}
+
 	// Get start part index and offset.
 	partIndex, partOffset, err := xlMeta.ObjectToPartOffset(ctx, startOffset)
 	if err != nil {
@@ -286,7 +304,7 @@ func (xl xlObjects) getObject(ctx context.Context, bucket, object string, startO
 				continue
 			}
 			checksumInfo := metaArr[index].Erasure.GetChecksumInfo(partNumber)
-			partPath := pathJoin(object, fmt.Sprintf("part.%d", partNumber))
+			partPath := pathJoin(object, xlMeta.DataDir, fmt.Sprintf("part.%d", partNumber))
 			readers[index] = newBitrotReader(disk, bucket, partPath, tillOffset,
 				checksumInfo.Algorithm, checksumInfo.Hash, erasure.ShardSize())
 		}
@@ -359,20 +377,23 @@ func (xl xlObjects) GetObjectInfo(ctx context.Context, bucket, object string, op
 		return info, nil
 	}
 
-	info, err := xl.getObjectInfo(ctx, bucket, object)
+	info, err := xl.getObjectInfo(ctx, bucket, object, opts)
 	if err != nil {
-		return oi, toObjectErr(err, bucket, object)
+		return info, toObjectErr(err, bucket, object)
 	}
 
 	return info, nil
 }
 
 // getObjectInfo - wrapper for reading object metadata and constructs ObjectInfo.
-func (xl xlObjects) getObjectInfo(ctx context.Context, bucket, object string) (objInfo ObjectInfo, err error) {
+// When the requested version is a delete marker, errFileNotFound is returned
+// for the current version and errMethodNotAllowed for an explicit version,
+// along with an ObjectInfo carrying the delete marker details.
+func (xl xlObjects) getObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
 	disks := xl.getDisks()
 
-	// Read metadata associated with the object from all disks.
-	metaArr, errs := readAllXLMetadata(ctx, disks, bucket, object)
+	// Read metadata of the requested version associated with the object from all disks.
+	metaArr, errs := readAllXLMetadataVersion(ctx, disks, bucket, object, opts.VersionID)
 
 	readQuorum, _, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
 	if err != nil {
@@ -391,7 +412,25 @@ func (xl xlObjects) getObjectInfo(ctx context.Context, bucket, object string) (o
 		return objInfo, err
 	}
 
-	return xlMeta.ToObjectInfo(bucket, object), nil
+	if xlMeta.DeleteMarker {
+		objInfo = ObjectInfo{
+			Bucket:       bucket,
+			Name:         object,
+			ModTime:      xlMeta.Stat.ModTime,
+			VersionID:    xlMeta.versionID(),
+			DeleteMarker: true,
+		}
+		if opts.VersionID == "" {
+			return objInfo, errFileNotFound
+		}
+		return objInfo, errMethodNotAllowed
+	}
+
+	objInfo = xlMeta.ToObjectInfo(bucket, object)
+	if globalBucketVersioningSys.Configured(bucket) {
+		objInfo.VersionID = xlMeta.versionID()
+	}
+	return objInfo, nil
 }
 
 func undoRename(disks []StorageAPI, srcBucket, srcEntry, dstBucket, dstEntry string, isDir bool, errs []error) {
@@ -543,6 +582,22 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 
 	xlMeta := newXLMetaV1(object, dataDrives, parityDrives)
 
+	// Each version of an object in a bucket with versioning
+	// configured keeps its parts in a unique data directory.
+	versioned := globalBucketVersioningSys.Configured(bucket)
+	if versioned {
+		xlMeta.DataDir = mustGetUUID()
+		if globalBucketVersioningSys.Enabled(bucket) {
+			xlMeta.VersionID = mustGetUUID()
+		}
+	}
+
+	// Small objects are stored inline in `xl.json` of each
+	// disk, saving the creation of a part file per disk. The
+	// size of compressed data is unknown, the actual size of
+	// the object is used instead.
+	xlMeta.Inline = globalStorageClass.Inline(data.ActualSize())
+
 	// Initialize xl meta.
 	for index := range partsMetadata {
 		partsMetadata[index] = xlMeta
@@ -574,13 +629,17 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 	}
 
 	partName := "part.1"
-	tempErasureObj := pathJoin(uniqueID, partName)
+	tempErasureObj := pathJoin(uniqueID, xlMeta.DataDir, partName)
 
 	writers := make([]io.Writer, len(onlineDisks))
 	for i, disk := range onlineDisks {
 		if disk == nil {
 			continue
 		}
+		if xlMeta.Inline {
+			writers[i] = newInlineBitrotWriter(DefaultBitrotAlgorithm)
+			continue
+		}
 		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tempErasureObj, erasure.ShardFileSize(data.Size()), DefaultBitrotAlgorithm, erasure.ShardSize())
 	}
 
@@ -608,26 +667,35 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 			Algorithm:  DefaultBitrotAlgorithm,
 			Hash:       bitrotWriterSum(w),
 		})
+		partsMetadata[i].Data = bitrotWriterData(w)
 	}
 
 	// Save additional erasureMetadata.
 	modTime := UTCNow()
 
 	opts.UserDefined["etag"] = r.MD5CurrentHexString()
+	if index := r.CompressionIndex(); len(index) > 0 {
+		opts.UserDefined[compressionIndexKey] = base64.StdEncoding.EncodeToString(index)
+	}
 
 	// Guess content-type from the extension if possible.
 	if opts.UserDefined["content-type"] == "" {
 		opts.UserDefined["content-type"] = mimedb.TypeByExtension(path.Ext(object))
 	}
 
-	if xl.isObject(bucket, object) {
+	// Data of an overwritten transitioned object is removed from the remote tier.
+	var transitioned []xlMetaV1
+
+	if !versioned && xl.isObject(bucket, object) {
 		// Deny if WORM is enabled
 		if isWORMEnabled(bucket) {
-			if _, err := xl.getObjectInfo(ctx, bucket, object); err == nil {
+			if _, err := xl.getObjectInfo(ctx, bucket, object, ObjectOptions{}); err == nil {
 				return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
 			}
 		}
 
+		transitioned = xl.getTransitionedVersions(ctx, bucket, object)
+
 		// Rename if an object already exists to temporary location.
 		newUniqueID := mustGetUUID()
 
@@ -651,14 +719,23 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 		partsMetadata[index].Stat.ModTime = modTime
 	}
 
-	// Write unique `xl.json` for each disk.
-	if onlineDisks, err = writeUniqueXLMetadata(ctx, onlineDisks, minioMetaTmpBucket, tempObj, partsMetadata, writeQuorum); err != nil {
-		return ObjectInfo{}, toObjectErr(err, bucket, object)
-	}
+	if versioned {
+		// Add the new version to the existing versions of the object.
+		if onlineDisks, err = xl.commitXLVersion(ctx, bucket, object, tempObj, onlineDisks, partsMetadata, writeQuorum); err != nil {
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
+	} else {
+		// Write unique `xl.json` for each disk.
+		if onlineDisks, err = writeUniqueXLMetadata(ctx, onlineDisks, minioMetaTmpBucket, tempObj, partsMetadata, writeQuorum); err != nil {
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
 
-	// Rename the successfully written temporary object to final location.
-	if onlineDisks, err = rename(ctx, onlineDisks, minioMetaTmpBucket, tempObj, bucket, object, true, writeQuorum, nil); err != nil {
-		return ObjectInfo{}, toObjectErr(err, bucket, object)
+		// Rename the successfully written temporary object to final location.
+		if onlineDisks, err = rename(ctx, onlineDisks, minioMetaTmpBucket, tempObj, bucket, object, true, writeQuorum, nil); err != nil {
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
+
+		xl.purgeVersions(ctx, bucket, object, transitioned, writeQuorum)
 	}
 
 	// Whether a disk was initially or becomes offline
@@ -685,6 +762,9 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 		ContentEncoding: xlMeta.Meta["content-encoding"],
 		UserDefined:     xlMeta.Meta,
 	}
+	if versioned {
+		objInfo.VersionID = xlMeta.versionID()
+	}
 
 	return objInfo, nil
 }
@@ -834,6 +914,15 @@ func (xl xlObjects) deleteObjects(ctx context.Context, bucket string, objects []
 	writeQuorums := make([]int, len(objects))
 	isObjectDirs := make([]bool, len(objects))
 
+	// Objects in a versioned bucket are deleted by
+	// adding a delete marker to each of them.
+	if globalBucketVersioningSys.Configured(bucket) {
+		for i, object := range objects {
+			errs[i] = xl.DeleteObject(ctx, bucket, object)
+		}
+		return errs, nil
+	}
+
 	for i, object := range objects {
 		errs[i] = checkDelObjArgs(ctx, bucket, object)
 	}
@@ -871,7 +960,25 @@ func (xl xlObjects) deleteObjects(ctx context.Context, bucket string, objects []
 		writeQuorums[i] = len(xl.getDisks())/2 + 1
 	}
 
-	return xl.doDeleteObjects(ctx, bucket, objects, errs, writeQuorums, isObjectDirs)
+	// Data of transitioned objects is removed from the remote tier as well.
+	transitioned := make([][]xlMetaV1, len(objects))
+	for i, object := range objects {
+		if errs[i] == nil && !isObjectDirs[i] {
+			transitioned[i] = xl.getTransitionedVersions(ctx, bucket, object)
+		}
+	}
+
+	errs, err := xl.doDeleteObjects(ctx, bucket, objects, errs, writeQuorums, isObjectDirs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, object := range objects {
+		if errs[i] == nil {
+			xl.purgeVersions(ctx, bucket, object, transitioned[i], writeQuorums[i])
+		}
+	}
+	return errs, nil
 }
 
 // DeleteObjects deletes objects in bulk, this function will still automatically split objects list
@@ -925,7 +1032,8 @@ func (xl xlObjects) DeleteObjects(ctx context.Context, bucket string, objects []
 
 // DeleteObject - deletes an object, this call doesn't necessary reply
 // any error as it is not necessary for the handler to reply back a
-// response to the client request.
+// response to the client request. Objects in a versioned bucket are
+// not removed, a delete marker is added instead.
 func (xl xlObjects) DeleteObject(ctx context.Context, bucket, object string) (err error) {
 	if err = checkDelObjArgs(ctx, bucket, object); err != nil {
 		return err
@@ -934,6 +1042,11 @@ func (xl xlObjects) DeleteObject(ctx context.Context, bucket, object string) (er
 	var writeQuorum int
 	var isObjectDir = HasSuffix(object, SlashSeparator)
 
+	if !isObjectDir && globalBucketVersioningSys.Configured(bucket) {
+		_, err = xl.addDeleteMarker(ctx, bucket, object)
+		return err
+	}
+
 	if isObjectDir {
 		_, err = xl.getObjectInfoDir(ctx, bucket, object)
 		if err == errXLReadQuorum {
@@ -947,6 +1060,9 @@ func (xl xlObjects) DeleteObject(ctx context.Context, bucket, object string) (er
 		}
 	}
 
+	// Data of transitioned objects is removed from the remote tier as well.
+	var transitioned []xlMetaV1
+
 	if isObjectDir {
 		writeQuorum = len(xl.getDisks())/2 + 1
 	} else {
@@ -957,6 +1073,7 @@ func (xl xlObjects) DeleteObject(ctx context.Context, bucket, object string) (er
 		if err != nil {
 			return toObjectErr(err, bucket, object)
 		}
+		transitioned = transitionedVersions(partsMetadata, errs)
 	}
 
 	// Delete the object on all disks.
@@ -964,6 +1081,8 @@ func (xl xlObjects) DeleteObject(ctx context.Context, bucket, object string) (er
 		return toObjectErr(err, bucket, object)
 	}
 
+	xl.purgeVersions(ctx, bucket, object, transitioned, writeQuorum)
+
 	// Success.
 	return nil
 }
@@ -1041,6 +1160,80 @@ func (xl xlObjects) DeleteObjectTag(ctx context.Context, bucket, object string)
 	return xl.PutObjectTag(ctx, bucket, object, "")
 }
 
+// PutObjectMetadata - merges opts.UserDefined into the metadata of a
+// version of an object, leaving its data untouched. PreConditionFailed
+// is returned when opts.CheckCopyPrecondFn rejects the version.
+func (xl xlObjects) PutObjectMetadata(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	storageDisks := xl.getDisks()
+
+	// Read metadata associated with the object from all disks.
+	metaArr, errs := readAllXLMetadata(ctx, storageDisks, bucket, object)
+
+	// Pick the requested version from each of them.
+	versionArr := make([]xlMetaV1, len(metaArr))
+	versionErrs := make([]error, len(errs))
+	for index := range metaArr {
+		if versionErrs[index] = errs[index]; errs[index] != nil {
+			continue
+		}
+		versionArr[index], versionErrs[index] = metaArr[index].pickVersion(opts.VersionID)
+	}
+
+	readQuorum, writeQuorum, err := objectQuorumFromMeta(ctx, xl, versionArr, versionErrs)
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	if reducedErr := reduceReadQuorumErrs(ctx, versionErrs, objectOpIgnoredErrs, readQuorum); reducedErr != nil {
+		return toObjectErr(reducedErr, bucket, object)
+	}
+
+	_, modTime := listOnlineDisks(storageDisks, versionArr, versionErrs)
+
+	version, err := pickValidXLMeta(ctx, versionArr, modTime, readQuorum)
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	if version.DeleteMarker {
+		return toObjectErr(errFileNotFound, bucket, object)
+	}
+
+	if opts.CheckCopyPrecondFn != nil && opts.CheckCopyPrecondFn(version.ToObjectInfo(bucket, object), "") {
+		return PreConditionFailed{}
+	}
+
+	// Update the version in `xl.json` of each disk.
+	disks := make([]StorageAPI, len(storageDisks))
+	for index := range metaArr {
+		if errs[index] != nil {
+			continue
+		}
+		var ok bool
+		metaArr[index], ok = metaArr[index].updateVersionMeta(version.versionID(), opts.UserDefined)
+		if ok {
+			disks[index] = storageDisks[index]
+		}
+	}
+
+	tempObj := mustGetUUID()
+
+	// Cleanup in case of xl.json writing failure
+	defer xl.deleteObject(ctx, minioMetaTmpBucket, tempObj, writeQuorum, false)
+
+	// Write unique `xl.json` for each disk.
+	if disks, err = writeUniqueXLMetadata(ctx, disks, minioMetaTmpBucket, tempObj, metaArr, writeQuorum); err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	// Atomically rename `xl.json` from tmp location to destination for each disk.
+	if _, err = renameXLMetadata(ctx, disks, minioMetaTmpBucket, tempObj, bucket, object, writeQuorum); err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	return nil
+}
+
 // GetObjectTag - get object tags from an existing object
 func (xl xlObjects) GetObjectTag(ctx context.Context, bucket, object string) (tagging.Tagging, error) {
 	// GetObjectInfo will return tag value as well
@@ -23,12 +23,16 @@ import (
 	"math/rand"
 	"os"
 	"path"
+	"path/filepath"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
+	"github.com/klauspost/compress/s2"
 	"github.com/minio/minio/cmd/config/storageclass"
+	"github.com/minio/minio/pkg/hash"
 	"github.com/minio/minio/pkg/madmin"
 )
 
@@ -633,3 +637,71 @@ func testObjectQuorumFromMeta(obj ObjectLayer, instanceType string, dirs []strin
 		}
 	}
 }
+
+// Tests compressed objects, whose size is unknown while they are
+// written, are stored inline based on their actual size.
+func TestPutObjectCompressedInline(t *testing.T) {
+	obj, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	globalStorageClass = storageclass.Config{InlineThreshold: 128 * 1024}
+	defer func() { globalStorageClass = storageclass.Config{} }()
+
+	ctx := context.Background()
+	bucket := "bucket"
+	object := "object.json"
+	data := bytes.Repeat([]byte(`{"a":"b"}`), 1024)
+
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Compress the data the same way the PutObject handler does.
+	actualReader, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", "", int64(len(data)), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2c := newS2CompressReader(actualReader)
+	defer s2c.Close()
+	hashReader, err := hash.NewReader(s2c, -1, "", "", int64(len(data)), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := ObjectOptions{UserDefined: map[string]string{
+		ReservedMetadataPrefix + "compression": compressionAlgorithmV2,
+		ReservedMetadataPrefix + "actual-size": strconv.Itoa(len(data)),
+	}}
+	objInfo, err := obj.PutObject(ctx, bucket, object, NewPutObjReader(hashReader, nil, nil), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dir := range fsDirs {
+		if _, err = os.Stat(filepath.Join(dir, bucket, object, inlinePartName)); !os.IsNotExist(err) {
+			t.Fatalf("Expected no part file for an inline object, got %v", err)
+		}
+	}
+	z := obj.(*xlZones)
+	xlMeta, err := readXLMeta(ctx, z.zones[0].sets[0].getDisks()[0], bucket, object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !xlMeta.Inline || len(xlMeta.Data) == 0 {
+		t.Fatal("Expected the compressed object to be stored inline")
+	}
+
+	var buf bytes.Buffer
+	if err = obj.GetObject(ctx, bucket, object, 0, objInfo.Size, &buf, "", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	decompressed, err := ioutil.ReadAll(s2.NewReader(&buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decompressed, data) {
+		t.Fatal("Unexpected data of the compressed inline object")
+	}
+}
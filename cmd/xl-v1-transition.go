@@ -0,0 +1,107 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+)
+
+// TransitionObject - marks a version of an object as transitioned to
+// the remote tier holding a copy of its data, as described by
+// opts.Transition, and purges the data from the local disks. Only
+// `xl.json` is left behind, reads are served from the remote tier.
+// PreConditionFailed is returned when the version was modified since
+// its data was copied to the remote tier.
+func (xl xlObjects) TransitionObject(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	if err := checkDelObjArgs(ctx, bucket, object); err != nil {
+		return err
+	}
+
+	storageDisks := xl.getDisks()
+
+	// Read metadata associated with the object from all disks.
+	metaArr, errs := readAllXLMetadata(ctx, storageDisks, bucket, object)
+
+	// Pick the requested version from each of them.
+	versionArr := make([]xlMetaV1, len(metaArr))
+	versionErrs := make([]error, len(errs))
+	for index := range metaArr {
+		if versionErrs[index] = errs[index]; errs[index] != nil {
+			continue
+		}
+		versionArr[index], versionErrs[index] = metaArr[index].pickVersion(opts.VersionID)
+	}
+
+	readQuorum, writeQuorum, err := objectQuorumFromMeta(ctx, xl, versionArr, versionErrs)
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	if reducedErr := reduceReadQuorumErrs(ctx, versionErrs, objectOpIgnoredErrs, readQuorum); reducedErr != nil {
+		return toObjectErr(reducedErr, bucket, object)
+	}
+
+	_, modTime := listOnlineDisks(storageDisks, versionArr, versionErrs)
+
+	version, err := pickValidXLMeta(ctx, versionArr, modTime, readQuorum)
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	if version.DeleteMarker {
+		return toObjectErr(errFileNotFound, bucket, object)
+	}
+
+	// The version was transitioned or overwritten meanwhile.
+	if version.isTransitioned() || extractETag(version.Meta) != opts.Transition.ETag {
+		return PreConditionFailed{}
+	}
+
+	// Mark the version as transitioned in `xl.json` of each disk.
+	disks := make([]StorageAPI, len(storageDisks))
+	for index := range metaArr {
+		if errs[index] != nil {
+			continue
+		}
+		var ok bool
+		metaArr[index], ok = metaArr[index].transitionVersion(version.versionID(),
+			opts.Transition.Tier, opts.Transition.RemoteObject)
+		if ok {
+			disks[index] = storageDisks[index]
+		}
+	}
+
+	tempObj := mustGetUUID()
+
+	// Cleanup in case of xl.json writing failure
+	defer xl.deleteObject(ctx, minioMetaTmpBucket, tempObj, writeQuorum, false)
+
+	// Write unique `xl.json` for each disk.
+	if disks, err = writeUniqueXLMetadata(ctx, disks, minioMetaTmpBucket, tempObj, metaArr, writeQuorum); err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	// Atomically rename `xl.json` from tmp location to destination for each disk.
+	if _, err = renameXLMetadata(ctx, disks, minioMetaTmpBucket, tempObj, bucket, object, writeQuorum); err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	// The local data of the version is no longer needed.
+	xl.purgeVersions(ctx, bucket, object, []xlMetaV1{version}, writeQuorum)
+
+	return nil
+}
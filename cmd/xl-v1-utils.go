@@ -199,6 +199,20 @@ func readAllXLMetadata(ctx context.Context, disks []StorageAPI, bucket, object s
 	return metadataArray, g.Wait()
 }
 
+// Reads all `xl.json` metadata and picks the requested version of
+// the object from each of them, an empty versionID picks the current
+// version. Returns error slice indicating the failed metadata reads.
+func readAllXLMetadataVersion(ctx context.Context, disks []StorageAPI, bucket, object, versionID string) ([]xlMetaV1, []error) {
+	metadataArray, errs := readAllXLMetadata(ctx, disks, bucket, object)
+	for index := range metadataArray {
+		if errs[index] != nil {
+			continue
+		}
+		metadataArray[index], errs[index] = metadataArray[index].pickVersion(versionID)
+	}
+	return metadataArray, errs
+}
+
 // Return shuffled partsMetadata depending on distribution.
 func shufflePartsMetadata(partsMetadata []xlMetaV1, distribution []int) (shuffledPartsMetadata []xlMetaV1) {
 	if distribution == nil {
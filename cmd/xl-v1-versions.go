@@ -0,0 +1,325 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/minio/minio/cmd/logger"
+)
+
+// commitXLVersion - commits a new version of an object staged at
+// minioMetaTmpBucket/tempObj, whose parts are stored under its data
+// directory. The version is added to the versions already present
+// in `xl.json` of each disk, any replaced null version is purged.
+// disks and partsMetadata are expected in erasure distribution order.
+func (xl xlObjects) commitXLVersion(ctx context.Context, bucket, object, tempObj string,
+	disks []StorageAPI, partsMetadata []xlMetaV1, writeQuorum int) ([]StorageAPI, error) {
+	dataDir := partsMetadata[0].DataDir
+	inline := partsMetadata[0].Inline
+
+	// A null version replaces any existing null version.
+	replaceNull := partsMetadata[0].VersionID == ""
+
+	existing, errs := readAllXLMetadata(ctx, disks, bucket, object)
+
+	// Disks whose existing versions can't be read are left out, the
+	// new `xl.json` would drop all the versions they hold otherwise.
+	disks = append([]StorageAPI(nil), disks...)
+
+	var purged []xlMetaV1
+	for index := range partsMetadata {
+		switch errs[index] {
+		case nil:
+		case errFileNotFound:
+			// No existing versions on this disk.
+			continue
+		default:
+			disks[index] = nil
+			continue
+		}
+		var replaced []xlMetaV1
+		partsMetadata[index], replaced = existing[index].addVersion(partsMetadata[index], replaceNull)
+		if len(replaced) > len(purged) {
+			purged = replaced
+		}
+	}
+
+	var err error
+
+	// Write unique `xl.json` for each disk.
+	if disks, err = writeUniqueXLMetadata(ctx, disks, minioMetaTmpBucket, tempObj, partsMetadata, writeQuorum); err != nil {
+		return nil, err
+	}
+
+	// Delete markers have no data directory, neither
+	// have versions stored inline in `xl.json`.
+	if dataDir != "" && !inline {
+		// Rename the data directory of the version to its final location.
+		if disks, err = rename(ctx, disks, minioMetaTmpBucket, pathJoin(tempObj, dataDir),
+			bucket, pathJoin(object, dataDir), true, writeQuorum, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	// Atomically rename `xl.json` from tmp location to destination for each disk.
+	if disks, err = renameXLMetadata(ctx, disks, minioMetaTmpBucket, tempObj, bucket, object, writeQuorum); err != nil {
+		return nil, err
+	}
+
+	xl.purgeVersions(ctx, bucket, object, purged, writeQuorum)
+
+	return disks, nil
+}
+
+// purgeVersions - removes the data of the given versions of an object,
+// the versions are expected to be removed from `xl.json` already. The
+// data of transitioned versions is removed from their remote tier.
+func (xl xlObjects) purgeVersions(ctx context.Context, bucket, object string, versions []xlMetaV1, writeQuorum int) {
+	for _, version := range versions {
+		if version.isTransitioned() {
+			deleteTransitionedObject(ctx, version)
+			continue
+		}
+		if version.Inline {
+			// Removed along with `xl.json`.
+			continue
+		}
+		if version.DataDir != "" {
+			logger.LogIf(ctx, xl.deleteObject(ctx, bucket, pathJoin(object, version.DataDir), writeQuorum, false))
+			continue
+		}
+
+		// Parts of objects written before versioning was
+		// configured are stored alongside `xl.json`.
+		for _, part := range version.Parts {
+			partPath := pathJoin(object, fmt.Sprintf("part.%d", part.Number))
+			for _, disk := range xl.getDisks() {
+				if disk == nil {
+					continue
+				}
+				_ = disk.DeleteFile(bucket, partPath)
+			}
+		}
+	}
+}
+
+// transitionedVersions - returns the object read from any disk if its
+// data was transitioned to a remote tier. Only meant for buckets without
+// versioning, whose objects are a single version.
+func transitionedVersions(metaArr []xlMetaV1, errs []error) []xlMetaV1 {
+	for index := range metaArr {
+		if errs[index] == nil && metaArr[index].isTransitioned() {
+			return []xlMetaV1{metaArr[index]}
+		}
+	}
+	return nil
+}
+
+// getTransitionedVersions - reads the object from all disks and returns
+// it if its data was transitioned to a remote tier, such data is purged
+// once the object is overwritten or deleted.
+func (xl xlObjects) getTransitionedVersions(ctx context.Context, bucket, object string) []xlMetaV1 {
+	return transitionedVersions(readAllXLMetadata(ctx, xl.getDisks(), bucket, object))
+}
+
+// addDeleteMarker - adds a delete marker as the current version of
+// an object, the marker is a null version when versioning is suspended.
+func (xl xlObjects) addDeleteMarker(ctx context.Context, bucket, object string) (ObjectInfo, error) {
+	storageDisks := xl.getDisks()
+
+	// Delete markers carry no data, they are written with the default
+	// parity and the (N/2 + 1) write quorum assumed for all deletes.
+	parityDrives := len(storageDisks) / 2
+	dataDrives := len(storageDisks) - parityDrives
+	writeQuorum := len(storageDisks)/2 + 1
+
+	marker := newXLMetaV1(object, dataDrives, parityDrives)
+	marker.DeleteMarker = true
+	marker.Stat.ModTime = UTCNow()
+	if globalBucketVersioningSys.Enabled(bucket) {
+		marker.VersionID = mustGetUUID()
+	}
+
+	partsMetadata := make([]xlMetaV1, len(storageDisks))
+	for index := range partsMetadata {
+		partsMetadata[index] = marker
+	}
+
+	tempObj := mustGetUUID()
+
+	// Cleanup in case of xl.json writing failure
+	defer xl.deleteObject(ctx, minioMetaTmpBucket, tempObj, writeQuorum, false)
+
+	disks := shuffleDisks(storageDisks, marker.Erasure.Distribution)
+	if _, err := xl.commitXLVersion(ctx, bucket, object, tempObj, disks, partsMetadata, writeQuorum); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	return ObjectInfo{
+		Bucket:       bucket,
+		Name:         object,
+		ModTime:      marker.Stat.ModTime,
+		VersionID:    marker.versionID(),
+		DeleteMarker: true,
+	}, nil
+}
+
+// deleteVersion - permanently removes a version of an object, the
+// object itself is removed along with its last remaining version.
+func (xl xlObjects) deleteVersion(ctx context.Context, bucket, object, versionID string) (objInfo ObjectInfo, err error) {
+	storageDisks := xl.getDisks()
+
+	// Read metadata associated with the object from all disks.
+	metaArr, errs := readAllXLMetadata(ctx, storageDisks, bucket, object)
+
+	// Pick the requested version from each of them.
+	versionArr := make([]xlMetaV1, len(metaArr))
+	versionErrs := make([]error, len(errs))
+	for index := range metaArr {
+		if versionErrs[index] = errs[index]; errs[index] != nil {
+			continue
+		}
+		versionArr[index], versionErrs[index] = metaArr[index].pickVersion(versionID)
+	}
+
+	readQuorum, writeQuorum, err := objectQuorumFromMeta(ctx, xl, versionArr, versionErrs)
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	if reducedErr := reduceReadQuorumErrs(ctx, versionErrs, objectOpIgnoredErrs, readQuorum); reducedErr != nil {
+		return objInfo, toObjectErr(reducedErr, bucket, object)
+	}
+
+	_, modTime := listOnlineDisks(storageDisks, versionArr, versionErrs)
+
+	version, err := pickValidXLMeta(ctx, versionArr, modTime, readQuorum)
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	objInfo = ObjectInfo{
+		Bucket:       bucket,
+		Name:         object,
+		ModTime:      version.Stat.ModTime,
+		VersionID:    versionID,
+		DeleteMarker: version.DeleteMarker,
+	}
+
+	// Remove the version from `xl.json` of each disk, disks
+	// left without any version are not updated.
+	disks := make([]StorageAPI, len(storageDisks))
+	var remaining bool
+	for index := range metaArr {
+		if errs[index] != nil {
+			continue
+		}
+		var ok bool
+		if metaArr[index], ok = metaArr[index].removeVersion(versionID); ok {
+			disks[index] = storageDisks[index]
+			remaining = true
+		}
+	}
+
+	if !remaining {
+		// Last version of the object, delete the object on all disks.
+		if err = xl.deleteObject(ctx, bucket, object, writeQuorum, false); err != nil {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+		return objInfo, nil
+	}
+
+	tempObj := mustGetUUID()
+
+	// Cleanup in case of xl.json writing failure
+	defer xl.deleteObject(ctx, minioMetaTmpBucket, tempObj, writeQuorum, false)
+
+	// Write unique `xl.json` for each disk.
+	if disks, err = writeUniqueXLMetadata(ctx, disks, minioMetaTmpBucket, tempObj, metaArr, writeQuorum); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	// Atomically rename `xl.json` from tmp location to destination for each disk.
+	if _, err = renameXLMetadata(ctx, disks, minioMetaTmpBucket, tempObj, bucket, object, writeQuorum); err != nil {
+		return objInfo, toObjectErr(err, bucket, object)
+	}
+
+	xl.purgeVersions(ctx, bucket, object, []xlMetaV1{version}, writeQuorum)
+
+	return objInfo, nil
+}
+
+// DeleteObjectVersion - deletes a version of an object. When no version
+// is specified a delete marker is added as the current version instead,
+// otherwise the specified version is permanently removed.
+func (xl xlObjects) DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	if err := checkDelObjArgs(ctx, bucket, object); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	// Directory objects are not versioned.
+	if HasSuffix(object, SlashSeparator) {
+		return ObjectInfo{}, xl.DeleteObject(ctx, bucket, object)
+	}
+
+	if opts.VersionID == "" {
+		return xl.addDeleteMarker(ctx, bucket, object)
+	}
+	return xl.deleteVersion(ctx, bucket, object, opts.VersionID)
+}
+
+// getObjectVersions - returns all the versions of an object latest
+// first, including the delete markers.
+func (xl xlObjects) getObjectVersions(ctx context.Context, bucket, object string) ([]ObjectInfo, error) {
+	disks := xl.getDisks()
+
+	// Read metadata associated with the object from all disks.
+	metaArr, errs := readAllXLMetadata(ctx, disks, bucket, object)
+
+	readQuorum, _, err := objectQuorumFromMeta(ctx, xl, metaArr, errs)
+	if err != nil {
+		return nil, err
+	}
+
+	if reducedErr := reduceReadQuorumErrs(ctx, errs, objectOpIgnoredErrs, readQuorum); reducedErr != nil {
+		return nil, reducedErr
+	}
+
+	_, modTime := listOnlineDisks(disks, metaArr, errs)
+
+	// Pick latest valid metadata.
+	xlMeta, err := pickValidXLMeta(ctx, metaArr, modTime, readQuorum)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := xlMeta.allVersions()
+	objInfos := make([]ObjectInfo, len(versions))
+	for i, version := range versions {
+		objInfos[i] = version.ToObjectInfo(bucket, object)
+		objInfos[i].VersionID = version.versionID()
+		objInfos[i].IsLatest = i == 0
+	}
+	return objInfos, nil
+}
+
+// ListObjectVersions - not implemented, listing is done by xlZones.
+func (xl xlObjects) ListObjectVersions(ctx context.Context, bucket, prefix, marker, versionMarker, delimiter string, maxKeys int) (ListObjectVersionsInfo, error) {
+	return ListObjectVersionsInfo{}, NotImplemented{}
+}
@@ -0,0 +1,160 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/minio/minio/pkg/bucket/versioning"
+)
+
+// putObjectVersions - writes a null version of the object, then
+// versions more versions with versioning enabled on the bucket.
+func putObjectVersions(t *testing.T, obj ObjectLayer, bucket, object string, versions int) {
+	data := []byte("hello")
+	globalBucketVersioningSys.Remove(bucket)
+	if _, err := obj.PutObject(context.Background(), bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	globalBucketVersioningSys.Set(bucket, versioning.Versioning{Status: versioning.Enabled})
+	for i := 0; i < versions; i++ {
+		if _, err := obj.PutObject(context.Background(), bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestListObjectVersionsMarker(t *testing.T) {
+	obj, fsDirs, err := prepareXL(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	oldVersioningSys := globalBucketVersioningSys
+	globalBucketVersioningSys = NewBucketVersioningSys()
+	defer func() { globalBucketVersioningSys = oldVersioningSys }()
+
+	ctx := context.Background()
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	putObjectVersions(t, obj, bucket, "object-1", 2)
+	putObjectVersions(t, obj, bucket, "object-2", 0)
+
+	all, err := obj.ListObjectVersions(ctx, bucket, "", "", "", "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all.Objects) != 4 {
+		t.Fatalf("expected 4 versions, got %d", len(all.Objects))
+	}
+
+	testCases := []struct {
+		versionMarker string
+		expected      int
+	}{
+		// Resumes after the version marker of the marker object.
+		{objectVersionID(all.Objects[0]), 3},
+		// The null version is the oldest version of object-1.
+		{nullVersionID, 1},
+		// Unknown version markers skip past the marker object.
+		{"00000000-0000-0000-0000-000000000000", 1},
+	}
+	for i, testCase := range testCases {
+		loi, err := obj.ListObjectVersions(ctx, bucket, "", "object-1", testCase.versionMarker, "", 1000)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if len(loi.Objects) != testCase.expected {
+			t.Errorf("Test %d: expected %d versions, got %d", i+1, testCase.expected, len(loi.Objects))
+		}
+	}
+
+	// Paginating one version at a time lists all the versions, the
+	// null version included.
+	var marker, versionMarker string
+	var listed int
+	for {
+		loi, err := obj.ListObjectVersions(ctx, bucket, "", marker, versionMarker, "", 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		listed += len(loi.Objects)
+		if !loi.IsTruncated {
+			break
+		}
+		marker, versionMarker = loi.NextMarker, loi.NextVersionIDMarker
+	}
+	if listed != len(all.Objects) {
+		t.Errorf("expected %d versions listed page by page, got %d", len(all.Objects), listed)
+	}
+}
+
+func TestCommitXLVersionUnreadableMeta(t *testing.T) {
+	obj, fsDirs, err := prepareXL(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	oldVersioningSys := globalBucketVersioningSys
+	globalBucketVersioningSys = NewBucketVersioningSys()
+	defer func() { globalBucketVersioningSys = oldVersioningSys }()
+
+	ctx := context.Background()
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	putObjectVersions(t, obj, bucket, object, 1)
+
+	// `xl.json` of the first disk can't be read.
+	metaPath := filepath.Join(fsDirs[0], bucket, object, xlMetaJSONFile)
+	garbage := []byte("not xl.json")
+	if err = ioutil.WriteFile(metaPath, garbage, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("world")
+	if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The disk isn't overwritten with an `xl.json` holding only the
+	// new version, it is left to the healing.
+	content, err := ioutil.ReadFile(metaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content, garbage) {
+		t.Error("expected xl.json with unreadable versions to be left as is")
+	}
+
+	loi, err := obj.ListObjectVersions(ctx, bucket, "", "", "", "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loi.Objects) != 3 {
+		t.Errorf("expected 3 versions, got %d", len(loi.Objects))
+	}
+}
@@ -0,0 +1,786 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	zonesMetaFile    = "zones.json"
+	zonesMetaVersion = 1
+
+	// Interval at which the progress of a decommissioning is saved.
+	decommissionSaveInterval = 30 * time.Second
+
+	// Interval at which the leadership of decommissioning is tried to
+	// be acquired.
+	decommissionCheckInterval = time.Minute
+)
+
+// Interval at which every server reloads the zones metadata, in case
+// it missed the notification of a change. Multipart uploads left on a
+// decommissioned zone are waited for at the same interval.
+var zonesMetaReloadInterval = time.Minute
+
+// zonesMeta - the zones of the cluster and the progress of their
+// decommissioning and rebalancing, saved along with the config of
+// the cluster.
+type zonesMeta struct {
+	Version   int                   `json:"version"`
+	Zones     []zoneMeta            `json:"zones"`
+	Rebalance *madmin.RebalanceInfo `json:"rebalance,omitempty"`
+}
+
+// zoneMeta - a zone is identified by the UUID of its first disk, which
+// doesn't change when zones are removed from the command line.
+type zoneMeta struct {
+	ID           string                   `json:"id"`
+	Decommission *madmin.DecommissionInfo `json:"decommission,omitempty"`
+}
+
+func (m *zonesMeta) zone(id string) *zoneMeta {
+	for i := range m.Zones {
+		if m.Zones[i].ID == id {
+			return &m.Zones[i]
+		}
+	}
+	return nil
+}
+
+// readZonesMeta - reads the zones metadata, an empty metadata is
+// returned when none was saved yet.
+func readZonesMeta(ctx context.Context, objAPI ObjectLayer) (zonesMeta, error) {
+	meta := zonesMeta{Version: zonesMetaVersion}
+
+	data, err := readConfig(ctx, objAPI, path.Join(minioConfigPrefix, zonesMetaFile))
+	if err != nil {
+		if err == errConfigNotFound {
+			return meta, nil
+		}
+		return meta, err
+	}
+
+	if err = json.Unmarshal(data, &meta); err != nil {
+		return meta, err
+	}
+	if meta.Version != zonesMetaVersion {
+		return meta, fmt.Errorf("Unknown zones metadata version %d", meta.Version)
+	}
+	return meta, nil
+}
+
+func saveZonesMeta(ctx context.Context, objAPI ObjectLayer, meta zonesMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, path.Join(minioConfigPrefix, zonesMetaFile), data)
+}
+
+// zoneID - returns the ID of the zone at index idx.
+func (z *xlZones) zoneID(idx int) string {
+	return z.zones[idx].format.XL.Sets[0][0]
+}
+
+// isDecommissioning - returns true if the zone at index idx is being,
+// or was, decommissioned. No new objects are written to such a zone.
+func (z *xlZones) isDecommissioning(idx int) bool {
+	z.metaMu.RLock()
+	defer z.metaMu.RUnlock()
+	zm := z.meta.zone(z.zoneID(idx))
+	return zm != nil && zm.Decommission != nil
+}
+
+// anyDecommissioning - returns true if any zone is being decommissioned.
+func (z *xlZones) anyDecommissioning() bool {
+	for idx := range z.zones {
+		if z.isDecommissioning(idx) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadZonesMeta - loads the zones metadata saved by any server.
+func (z *xlZones) loadZonesMeta(ctx context.Context) error {
+	meta, err := readZonesMeta(ctx, z)
+	if err != nil {
+		return err
+	}
+	z.setZonesMeta(meta)
+	return nil
+}
+
+func (z *xlZones) setZonesMeta(meta zonesMeta) {
+	z.metaMu.Lock()
+	z.meta = meta
+	z.metaMu.Unlock()
+
+	// Wake up the decommissioning and rebalancing routines, a zone
+	// may have been added or removed from decommissioning, or the
+	// rebalancing started or canceled.
+	select {
+	case z.decommissionCh <- struct{}{}:
+	default:
+	}
+	select {
+	case z.rebalanceCh <- struct{}{}:
+	default:
+	}
+}
+
+// updateZonesMeta - applies fn to the saved zones metadata and loads
+// it, the metadata is locked for the whole cluster meanwhile.
+func (z *xlZones) updateZonesMeta(ctx context.Context, fn func(meta *zonesMeta) error) error {
+	// The metadata file itself is locked while it is read and
+	// saved, hence a separate lock is held for the whole update.
+	metaLock := z.NewNSLock(ctx, minioMetaBucket, path.Join(minioConfigPrefix, zonesMetaFile+".lock"))
+	if err := metaLock.GetLock(globalOperationTimeout); err != nil {
+		return err
+	}
+	defer metaLock.Unlock()
+
+	meta, err := readZonesMeta(ctx, z)
+	if err != nil {
+		return err
+	}
+	before, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	if err = fn(&meta); err != nil {
+		return err
+	}
+	after, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(before, after) {
+		if err = saveZonesMeta(ctx, z, meta); err != nil {
+			return err
+		}
+	}
+	z.setZonesMeta(meta)
+	return nil
+}
+
+// initZonesMeta - records the zones added to the command line and
+// forgets decommissioned zones removed from it. Zones removed from the
+// command line before they were decommissioned are refused, the
+// objects on them would be lost.
+func (z *xlZones) initZonesMeta(ctx context.Context) error {
+	return z.updateZonesMeta(ctx, func(meta *zonesMeta) error {
+		ids := make(map[string]bool, len(z.zones))
+		zones := make([]zoneMeta, 0, len(z.zones))
+		for idx := range z.zones {
+			id := z.zoneID(idx)
+			ids[id] = true
+			if zm := meta.zone(id); zm != nil {
+				zones = append(zones, *zm)
+			} else {
+				zones = append(zones, zoneMeta{ID: id})
+			}
+		}
+		for _, zm := range meta.Zones {
+			if !ids[zm.ID] && (zm.Decommission == nil || !zm.Decommission.Complete) {
+				return fmt.Errorf("Zone %s was removed from the command line before it was decommissioned", zm.ID)
+			}
+		}
+		meta.Zones = zones
+		return nil
+	})
+}
+
+// StartDecommission - starts decommissioning the zone at index idx,
+// a failed decommissioning is started over with the remaining objects.
+func (z *xlZones) StartDecommission(ctx context.Context, idx int) error {
+	if idx < 0 || idx >= len(z.zones) {
+		return errNoSuchZone
+	}
+	return z.updateZonesMeta(ctx, func(meta *zonesMeta) error {
+		active := 0
+		for i := range z.zones {
+			if zm := meta.zone(z.zoneID(i)); i != idx && (zm == nil || zm.Decommission == nil) {
+				active++
+			}
+		}
+		if active == 0 {
+			return errZoneLastActive
+		}
+
+		zm := meta.zone(z.zoneID(idx))
+		if zm == nil {
+			meta.Zones = append(meta.Zones, zoneMeta{ID: z.zoneID(idx)})
+			zm = &meta.Zones[len(meta.Zones)-1]
+		}
+		switch {
+		case zm.Decommission == nil:
+			zm.Decommission = &madmin.DecommissionInfo{StartTime: UTCNow()}
+		case zm.Decommission.Failed:
+			zm.Decommission.Failed = false
+			zm.Decommission.EndTime = time.Time{}
+			zm.Decommission.Bucket = ""
+			zm.Decommission.Object = ""
+			zm.Decommission.ObjectsFailed = 0
+		default:
+			return errZoneDecommissionInProgress
+		}
+		return nil
+	})
+}
+
+// CancelDecommission - stops decommissioning the zone at index idx,
+// new objects are written to the zone again.
+func (z *xlZones) CancelDecommission(ctx context.Context, idx int) error {
+	if idx < 0 || idx >= len(z.zones) {
+		return errNoSuchZone
+	}
+	return z.updateZonesMeta(ctx, func(meta *zonesMeta) error {
+		zm := meta.zone(z.zoneID(idx))
+		if zm == nil || zm.Decommission == nil {
+			return errZoneNotDecommissioning
+		}
+		zm.Decommission = nil
+		return nil
+	})
+}
+
+// ZonesStatus - returns the status of all the zones, the progress of
+// decommissioning is read from the saved zones metadata.
+func (z *xlZones) ZonesStatus(ctx context.Context) ([]madmin.ZoneStatus, error) {
+	meta, err := readZonesMeta(ctx, z)
+	if err != nil {
+		return nil, err
+	}
+
+	zones := make([]madmin.ZoneStatus, len(z.zones))
+	for idx, zone := range z.zones {
+		zones[idx] = madmin.ZoneStatus{
+			Index: idx,
+			ID:    z.zoneID(idx),
+		}
+		for _, endpoint := range zone.endpoints {
+			zones[idx].Endpoints = append(zones[idx].Endpoints, endpoint.String())
+		}
+		storageInfo := zone.StorageInfo(ctx, false)
+		for i := range storageInfo.Total {
+			zones[idx].TotalSpace += storageInfo.Total[i]
+			zones[idx].AvailableSpace += storageInfo.Available[i]
+		}
+		if zm := meta.zone(zones[idx].ID); zm != nil {
+			zones[idx].Decommission = zm.Decommission
+		}
+	}
+	return zones, nil
+}
+
+// getObjectZoneIdx - returns the index of the zone holding an object,
+// -1 if no zone holds it.
+func (z *xlZones) getObjectZoneIdx(ctx context.Context, bucket, object string) (int, error) {
+	for idx, zone := range z.zones {
+		_, err := zone.getHashedSet(object).getObjectModTime(ctx, bucket, object)
+		if err == nil {
+			return idx, nil
+		}
+		if !isErrObjectNotFound(err) {
+			return -1, err
+		}
+	}
+	return -1, nil
+}
+
+// moveObject - moves all the versions of an object from the zone at
+// index idx to the zone at index dstIdx, or to the least used zone if
+// dstIdx is negative. The object found on another zone as well was left
+// over by an interrupted move or overwrite, the zone with the latest
+// version of the object wins. Returns the index of the zone holding the
+// object and the number of bytes moved. Callers are expected to hold the
+// write lock of the object.
+func (z *xlZones) moveObject(ctx context.Context, idx, dstIdx int, bucket, object string) (int, int64, error) {
+	src := z.zones[idx].getHashedSet(object)
+	writeQuorum := len(src.getDisks())/2 + 1
+
+	// Directory objects have no data, they are created again.
+	if HasSuffix(object, SlashSeparator) {
+		if dstIdx < 0 {
+			dstIdx = z.getAvailableZoneIdx(ctx)
+		}
+		hashReader, err := hash.NewReader(bytes.NewReader(nil), 0, "", "", 0, globalCLIContext.StrictS3Compat)
+		if err != nil {
+			return -1, 0, err
+		}
+		if _, err = z.zones[dstIdx].PutObject(ctx, bucket, object, NewPutObjReader(hashReader, nil, nil), ObjectOptions{}); err != nil {
+			return -1, 0, err
+		}
+		return dstIdx, 0, src.deleteObject(ctx, bucket, object, writeQuorum, true)
+	}
+
+	modTime, err := src.getObjectModTime(ctx, bucket, object)
+	if err != nil {
+		return -1, 0, err
+	}
+
+	for i, zone := range z.zones {
+		if i == idx {
+			continue
+		}
+		set := zone.getHashedSet(object)
+		otherModTime, err := set.getObjectModTime(ctx, bucket, object)
+		if isErrObjectNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return -1, 0, err
+		}
+		if otherModTime.After(modTime) {
+			// The object was overwritten on the other zone.
+			return i, 0, src.deleteObject(ctx, bucket, object, writeQuorum, false)
+		}
+		if err = set.deleteObject(ctx, bucket, object, len(set.getDisks())/2+1, false); err != nil {
+			return -1, 0, err
+		}
+		if !z.isDecommissioning(i) {
+			dstIdx = i
+		}
+		break
+	}
+
+	if dstIdx < 0 {
+		dstIdx = z.getAvailableZoneIdx(ctx)
+	}
+	n, err := copyXLObject(ctx, src, z.zones[dstIdx].getHashedSet(object), bucket, object)
+	if err != nil {
+		return -1, 0, err
+	}
+	return dstIdx, n, src.deleteObject(ctx, bucket, object, writeQuorum, false)
+}
+
+// moveUpload - moves a multipart upload from the zone at index idx to
+// the zone at index dstIdx. Callers are expected to hold the upload lock.
+func (z *xlZones) moveUpload(ctx context.Context, idx, dstIdx int, bucket, object, uploadID string) error {
+	src := z.zones[idx].getHashedSet(object)
+	uploadIDPath := src.getUploadIDDir(bucket, object, uploadID)
+	if _, err := copyXLObject(ctx, src, z.zones[dstIdx].getHashedSet(object), minioMetaMultipartBucket, uploadIDPath); err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+	return src.deleteObject(ctx, minioMetaMultipartBucket, uploadIDPath, len(src.getDisks())/2+1, false)
+}
+
+// putDecommissioningObject - writes an object found on the zone at
+// index idx being decommissioned to another zone with put. The previous
+// versions of the object are moved along in versioned buckets, otherwise
+// the overwritten object is removed from the decommissioned zone. Callers
+// are expected to hold the write lock of the object.
+func (z *xlZones) putDecommissioningObject(ctx context.Context, idx int, bucket, object string,
+	put func(zone *xlSets) (ObjectInfo, error)) (ObjectInfo, error) {
+	if globalBucketVersioningSys.Configured(bucket) {
+		dstIdx, _, err := z.moveObject(ctx, idx, -1, bucket, object)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		return put(z.zones[dstIdx])
+	}
+
+	objInfo, err := put(z.zones[z.getAvailableZoneIdx(ctx)])
+	if err != nil {
+		return objInfo, err
+	}
+	if err = z.zones[idx].DeleteObject(ctx, bucket, object); err != nil && !isErrObjectNotFound(err) {
+		logger.LogIf(ctx, err)
+	}
+	return objInfo, nil
+}
+
+// getCompleteUploadZoneIdx - returns the index of the zone to complete
+// a multipart upload found on the zone at index idx on. An upload on a
+// zone being decommissioned is moved to another zone first. In versioned
+// buckets the upload is moved to the zone holding the previous versions
+// of the object, which may have been moved off the zone by rebalancing
+// since the upload was started, and the previous versions are moved off
+// a zone being decommissioned along. Callers are expected to hold the
+// write lock of the object and the upload lock.
+func (z *xlZones) getCompleteUploadZoneIdx(ctx context.Context, idx int, bucket, object, uploadID string) (int, error) {
+	dstIdx := -1
+	if globalBucketVersioningSys.Configured(bucket) {
+		// All the versions of an object live in the same zone.
+		objIdx, err := z.getObjectZoneIdx(ctx, bucket, object)
+		if err != nil {
+			return -1, err
+		}
+		if objIdx >= 0 && z.isDecommissioning(objIdx) {
+			target := -1
+			if !z.isDecommissioning(idx) {
+				target = idx
+			}
+			if objIdx, _, err = z.moveObject(ctx, objIdx, target, bucket, object); err != nil {
+				return -1, err
+			}
+		}
+		dstIdx = objIdx
+	}
+
+	if dstIdx < 0 {
+		if !z.isDecommissioning(idx) {
+			return idx, nil
+		}
+		dstIdx = z.getAvailableZoneIdx(ctx)
+	}
+	if dstIdx == idx {
+		return idx, nil
+	}
+	return dstIdx, z.moveUpload(ctx, idx, dstIdx, bucket, object, uploadID)
+}
+
+// countMultipartUploads - returns the number of multipart uploads in
+// progress on the zone at index idx.
+func (z *xlZones) countMultipartUploads(idx int) (count int) {
+	for _, set := range z.zones[idx].sets {
+		for _, disk := range set.getLoadBalancedDisks() {
+			if disk == nil {
+				continue
+			}
+			shaDirs, err := disk.ListDir(minioMetaMultipartBucket, "", -1, "")
+			if err != nil {
+				continue
+			}
+			for _, shaDir := range shaDirs {
+				uploadIDDirs, err := disk.ListDir(minioMetaMultipartBucket, shaDir, -1, "")
+				if err == nil {
+					count += len(uploadIDDirs)
+				}
+			}
+			break
+		}
+	}
+	return count
+}
+
+// decommissionRoot - a bucket, or a prefix of a bucket of the metadata
+// of the cluster, which objects are migrated when decommissioning.
+type decommissionRoot struct {
+	bucket, prefix string
+}
+
+func (r decommissionRoot) String() string {
+	return pathJoin(r.bucket, r.prefix)
+}
+
+// getDecommissionRoots - returns the buckets of the zone at index idx
+// and the metadata of the cluster, sorted to be migrated in order.
+func (z *xlZones) getDecommissionRoots(ctx context.Context, idx int) ([]decommissionRoot, error) {
+	buckets, err := z.zones[idx].ListBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := []decommissionRoot{
+		{minioMetaBucket, bucketMetaPrefix + SlashSeparator},
+		{minioMetaBucket, minioConfigPrefix + SlashSeparator},
+		{minioMetaBackgroundOpsBucket, ""},
+	}
+	for _, bucket := range buckets {
+		roots = append(roots, decommissionRoot{bucket.Name, ""})
+	}
+	sort.Slice(roots, func(i, j int) bool {
+		return roots[i].String() < roots[j].String()
+	})
+	return roots, nil
+}
+
+// walkZone - walks the objects of the zone at index idx in bucket under
+// prefix in lexical order starting after marker, until fn returns false.
+// Dangling objects, with too few disks holding them to be read, are skipped.
+func (z *xlZones) walkZone(ctx context.Context, idx int, bucket, prefix, marker string, fn func(object string) bool) {
+	zone := z.zones[idx]
+
+	endWalkCh := make(chan struct{})
+	defer close(endWalkCh)
+
+	entryChs := zone.startMergeWalks(ctx, bucket, prefix, marker, true, endWalkCh)
+	entries := make([]FileInfo, len(entryChs))
+	entriesValid := make([]bool, len(entryChs))
+	for {
+		entry, quorumCount, ok := leastEntry(entryChs, entries, entriesValid)
+		if !ok {
+			return
+		}
+		if quorumCount < zone.drivesPerSet/2 {
+			continue
+		}
+		if !fn(entry.Name) {
+			return
+		}
+	}
+}
+
+// decommissionPass - migrates all the objects on the zone at index idx
+// to the remaining zones, resuming from the bucket and object last
+// migrated. Returns the number of objects found on the zone.
+func (z *xlZones) decommissionPass(ctx context.Context, idx int, info *madmin.DecommissionInfo) (int64, error) {
+	zone := z.zones[idx]
+	id := z.zoneID(idx)
+
+	roots, err := z.getDecommissionRoots(ctx, idx)
+	if err != nil {
+		return 0, err
+	}
+
+	var found int64
+	lastSave := UTCNow()
+	for _, root := range roots {
+		if root.String() < info.Bucket {
+			continue
+		}
+		var marker string
+		if root.String() == info.Bucket {
+			marker = info.Object
+		}
+		info.Bucket = root.String()
+
+		z.walkZone(ctx, idx, root.bucket, root.prefix, marker, func(object string) bool {
+			// Wait and proceed if there are active requests
+			waitForLowHTTPReq(int32(zone.drivesPerSet))
+
+			n, merr := z.migrateObject(ctx, idx, -1, root.bucket, object)
+			switch {
+			case merr == nil:
+				found++
+				info.ObjectsMigrated++
+				info.BytesMigrated += n
+			case isErrObjectNotFound(merr):
+				// Removed since it was listed.
+			default:
+				found++
+				info.ObjectsFailed++
+				logger.LogIf(ctx, fmt.Errorf("Unable to migrate %s off zone %s: %w",
+					pathJoin(root.bucket, object), id, merr))
+			}
+			info.Object = object
+
+			if ctx.Err() != nil || !z.isDecommissioning(idx) {
+				err = errZoneNotDecommissioning
+				return false
+			}
+			if UTCNow().Sub(lastSave) > decommissionSaveInterval {
+				if err = z.saveDecommission(ctx, id, *info); err != nil {
+					return false
+				}
+				lastSave = UTCNow()
+			}
+			return true
+		})
+		if err != nil {
+			return found, err
+		}
+	}
+
+	info.Bucket = ""
+	info.Object = ""
+	return found, z.saveDecommission(ctx, id, *info)
+}
+
+// migrateObject - moves an object off the zone at index idx to the zone
+// at index dstIdx, or to the least used zone if dstIdx is negative.
+func (z *xlZones) migrateObject(ctx context.Context, idx, dstIdx int, bucket, object string) (int64, error) {
+	objectLock := z.NewNSLock(ctx, bucket, object)
+	if err := objectLock.GetLock(globalOperationTimeout); err != nil {
+		return 0, err
+	}
+	defer objectLock.Unlock()
+
+	_, n, err := z.moveObject(ctx, idx, dstIdx, bucket, object)
+	return n, err
+}
+
+// saveDecommission - saves the progress of decommissioning a zone,
+// unless the decommissioning was canceled meanwhile.
+func (z *xlZones) saveDecommission(ctx context.Context, id string, info madmin.DecommissionInfo) error {
+	return z.updateZonesMeta(ctx, func(meta *zonesMeta) error {
+		zm := meta.zone(id)
+		if zm == nil || zm.Decommission == nil {
+			return errZoneNotDecommissioning
+		}
+		*zm.Decommission = info
+		return nil
+	})
+}
+
+// decommissionZone - migrates the objects of the zone at index idx to
+// the remaining zones until none is left. Objects failing to migrate
+// fail the decommissioning, multipart uploads in progress on the zone
+// are waited for to be completed or aborted. Servers which didn't reload
+// the zones metadata yet may still write objects to the zone, hence the
+// zone is looked for objects again after every server reloaded it before
+// the decommissioning is complete.
+func (z *xlZones) decommissionZone(ctx context.Context, idx int) error {
+	z.metaMu.RLock()
+	zm := z.meta.zone(z.zoneID(idx))
+	if zm == nil || zm.Decommission == nil {
+		z.metaMu.RUnlock()
+		return errZoneNotDecommissioning
+	}
+	info := *zm.Decommission
+	z.metaMu.RUnlock()
+
+	ticker := time.NewTicker(zonesMetaReloadInterval)
+	defer ticker.Stop()
+
+	var recheck bool
+	for {
+		found, err := z.decommissionPass(ctx, idx, &info)
+		if err != nil {
+			return err
+		}
+		switch {
+		case info.ObjectsFailed > 0:
+			info.Failed = true
+		case found == 0 && recheck:
+			info.Complete = true
+		case found == 0:
+			// Once no upload is left, wait for every server to
+			// reload the zones metadata and look again.
+			recheck = z.countMultipartUploads(idx) == 0
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+			continue
+		default:
+			// Look for objects written meanwhile.
+			recheck = false
+			continue
+		}
+		info.EndTime = UTCNow()
+		return z.saveDecommission(ctx, z.zoneID(idx), info)
+	}
+}
+
+// nextDecommissioningZone - returns the index of a zone to decommission,
+// -1 if there is none.
+func (z *xlZones) nextDecommissioningZone() int {
+	z.metaMu.RLock()
+	defer z.metaMu.RUnlock()
+	for idx := range z.zones {
+		zm := z.meta.zone(z.zoneID(idx))
+		if zm != nil && zm.Decommission != nil && !zm.Decommission.Complete && !zm.Decommission.Failed {
+			return idx
+		}
+	}
+	return -1
+}
+
+func initZonesDecommission() {
+	go startZonesDecommission()
+}
+
+// reloadZonesMeta - reloads the zones metadata periodically, on every
+// server whether it decommissions and rebalances zones or not.
+func (z *xlZones) reloadZonesMeta(ctx context.Context) {
+	ticker := time.NewTicker(zonesMetaReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			logger.LogIf(ctx, z.loadZonesMeta(ctx))
+		}
+	}
+}
+
+// startZonesDecommission - decommissions zones in the background, only
+// one server of the cluster decommissions zones at a time.
+func startZonesDecommission() {
+	// Wait until the object layer is ready
+	var objAPI ObjectLayer
+	for {
+		objAPI = newObjectLayerWithoutSafeModeFn()
+		if objAPI == nil {
+			time.Sleep(time.Second)
+			continue
+		}
+		break
+	}
+
+	z, ok := objAPI.(*xlZones)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-GlobalServiceDoneCh
+		cancel()
+	}()
+
+	go z.reloadZonesMeta(ctx)
+
+	locker := z.NewNSLock(ctx, minioMetaBucket, "leader-zones-decommission")
+	for {
+		err := locker.GetLock(leaderLockTimeout)
+		if err == nil {
+			// Break without unlocking, this node will acquire
+			// zones decommissioning role for its lifetime.
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(decommissionCheckInterval):
+		}
+	}
+	defer locker.Unlock()
+
+	for {
+		if idx := z.nextDecommissioningZone(); idx >= 0 && ctx.Err() == nil {
+			switch err := z.decommissionZone(ctx, idx); {
+			case err == nil:
+				continue
+			case err == errZoneNotDecommissioning:
+				// Decommissioning was canceled.
+				if err = z.loadZonesMeta(ctx); err == nil {
+					continue
+				}
+				logger.LogIf(ctx, err)
+			case ctx.Err() == nil:
+				logger.LogIf(ctx, fmt.Errorf("Unable to decommission zone %s: %w", z.zoneID(idx), err))
+			}
+		}
+
+		// Woken up whenever the zones metadata is loaded,
+		// which every server does periodically.
+		select {
+		case <-ctx.Done():
+			return
+		case <-z.decommissionCh:
+		}
+	}
+}
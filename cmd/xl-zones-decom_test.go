@@ -0,0 +1,301 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/bucket/versioning"
+)
+
+func prepareXLZones(nZones, nDisks int) (*xlZones, []string, error) {
+	var fsDirs []string
+	var endpointZones EndpointZones
+	for i := 0; i < nZones; i++ {
+		dirs, err := getRandomDisks(nDisks)
+		if err != nil {
+			removeRoots(fsDirs)
+			return nil, nil, err
+		}
+		fsDirs = append(fsDirs, dirs...)
+		endpointZones = append(endpointZones, ZoneEndpoints{
+			SetCount:     1,
+			DrivesPerSet: nDisks,
+			Endpoints:    mustGetNewEndpoints(dirs...),
+		})
+	}
+	obj, _, err := initObjectLayer(endpointZones)
+	if err != nil {
+		removeRoots(fsDirs)
+		return nil, nil, err
+	}
+	return obj.(*xlZones), fsDirs, nil
+}
+
+func TestDecommissionZone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	defer func(d time.Duration) { zonesMetaReloadInterval = d }(zonesMetaReloadInterval)
+	zonesMetaReloadInterval = time.Millisecond
+
+	z, fsDirs, err := prepareXLZones(2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	if err = z.initZonesMeta(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := "bucket"
+	if err = z.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Write all the objects to the first zone.
+	objects := map[string][]byte{}
+	for i := 0; i < 10; i++ {
+		object := fmt.Sprintf("prefix/object-%d", i)
+		data := bytes.Repeat([]byte{byte('a' + i)}, (i+1)*1024)
+		objects[object] = data
+		if _, err = z.zones[0].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = z.StartDecommission(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err = z.StartDecommission(ctx, 0); err != errZoneLastActive {
+		t.Fatalf("expected %v, got %v", errZoneLastActive, err)
+	}
+	if err = z.CancelDecommission(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err = z.CancelDecommission(ctx, 1); err != errZoneNotDecommissioning {
+		t.Fatalf("expected %v, got %v", errZoneNotDecommissioning, err)
+	}
+
+	if err = z.StartDecommission(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err = z.StartDecommission(ctx, 0); err != errZoneDecommissionInProgress {
+		t.Fatalf("expected %v, got %v", errZoneDecommissionInProgress, err)
+	}
+	if idx := z.getAvailableZoneIdx(ctx); idx != 1 {
+		t.Fatalf("expected new objects to be written to zone 1, got zone %d", idx)
+	}
+
+	if err = z.decommissionZone(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	zones, err := z.ZonesStatus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := zones[0].Decommission
+	if info == nil || !info.Complete || info.Failed {
+		t.Fatalf("expected decommissioning to be complete, got %#v", info)
+	}
+	if info.ObjectsMigrated < int64(len(objects)) {
+		t.Errorf("expected at least %d objects migrated, got %d", len(objects), info.ObjectsMigrated)
+	}
+
+	for object, data := range objects {
+		if _, err = z.zones[0].GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Errorf("%s: expected object to be removed from zone 0, got %v", object, err)
+		}
+		var buf bytes.Buffer
+		if err = z.GetObject(ctx, bucket, object, 0, -1, &buf, "", ObjectOptions{}); err != nil {
+			t.Fatalf("%s: %v", object, err)
+		}
+		if !bytes.Equal(buf.Bytes(), data) {
+			t.Errorf("%s: object content mismatch", object)
+		}
+	}
+
+	// Zone 0 can now be removed from the command line.
+	z.zones = z.zones[1:]
+	if err = z.initZonesMeta(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInitZonesMetaRemovedZone(t *testing.T) {
+	ctx := context.Background()
+
+	z, fsDirs, err := prepareXLZones(2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	if err = z.initZonesMeta(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	z.zones = z.zones[:1]
+	if err = z.initZonesMeta(ctx); err == nil {
+		t.Fatal("expected removing a zone which wasn't decommissioned to fail")
+	}
+}
+
+func TestDecommissionZoneVersioned(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	defer func(d time.Duration) { zonesMetaReloadInterval = d }(zonesMetaReloadInterval)
+	zonesMetaReloadInterval = time.Millisecond
+
+	z, fsDirs, err := prepareXLZones(2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	if err = z.initZonesMeta(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(sys *BucketVersioningSys) { globalBucketVersioningSys = sys }(globalBucketVersioningSys)
+	globalBucketVersioningSys = NewBucketVersioningSys()
+
+	bucket, object := "bucket", "object"
+	if err = z.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	globalBucketVersioningSys.Set(bucket, versioning.Versioning{Status: versioning.Enabled})
+
+	// Write all the versions of the object to the first zone.
+	versions := map[string][]byte{}
+	for i := 0; i < 3; i++ {
+		data := bytes.Repeat([]byte{byte('a' + i)}, (i+1)*1024)
+		objInfo, err := z.zones[0].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		versions[objInfo.VersionID] = data
+	}
+
+	if err = z.StartDecommission(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err = z.decommissionZone(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = z.zones[0].GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("expected object to be removed from zone 0, got %v", err)
+	}
+	for versionID, data := range versions {
+		var buf bytes.Buffer
+		if err = z.zones[1].GetObject(ctx, bucket, object, 0, -1, &buf, "", ObjectOptions{VersionID: versionID}); err != nil {
+			t.Fatalf("version %s: %v", versionID, err)
+		}
+		if !bytes.Equal(buf.Bytes(), data) {
+			t.Errorf("version %s: object content mismatch", versionID)
+		}
+	}
+}
+
+func TestDecommissionZoneMultipart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	defer func(d time.Duration) { zonesMetaReloadInterval = d }(zonesMetaReloadInterval)
+	zonesMetaReloadInterval = time.Millisecond
+
+	z, fsDirs, err := prepareXLZones(2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	if err = z.initZonesMeta(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(sys *BucketVersioningSys) { globalBucketVersioningSys = sys }(globalBucketVersioningSys)
+	globalBucketVersioningSys = NewBucketVersioningSys()
+
+	bucket, object := "bucket", "object"
+	if err = z.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	globalBucketVersioningSys.Set(bucket, versioning.Versioning{Status: versioning.Enabled})
+
+	// A previous version of the object and an upload in progress
+	// are on the first zone.
+	prevData := bytes.Repeat([]byte("a"), 1024)
+	prevInfo, err := z.zones[0].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(prevData), int64(len(prevData)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	uploadID, err := z.zones[0].NewMultipartUpload(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("b"), 2048)
+	partInfo, err := z.zones[0].PutObjectPart(ctx, bucket, object, uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = z.StartDecommission(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if count := z.countMultipartUploads(0); count != 1 {
+		t.Fatalf("expected 1 upload in progress on zone 0, got %d", count)
+	}
+
+	// The upload is completed on the remaining zone, along with
+	// the previous version of the object.
+	objInfo, err := z.CompleteMultipartUpload(ctx, bucket, object, uploadID, []CompletePart{{PartNumber: 1, ETag: partInfo.ETag}}, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count := z.countMultipartUploads(0); count != 0 {
+		t.Fatalf("expected the upload to be moved off zone 0, got %d uploads", count)
+	}
+	for versionID, expected := range map[string][]byte{prevInfo.VersionID: prevData, objInfo.VersionID: data} {
+		var buf bytes.Buffer
+		if err = z.zones[1].GetObject(ctx, bucket, object, 0, -1, &buf, "", ObjectOptions{VersionID: versionID}); err != nil {
+			t.Fatalf("version %s: %v", versionID, err)
+		}
+		if !bytes.Equal(buf.Bytes(), expected) {
+			t.Errorf("version %s: object content mismatch", versionID)
+		}
+	}
+
+	if err = z.decommissionZone(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	zones, err := z.ZonesStatus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info := zones[0].Decommission; info == nil || !info.Complete {
+		t.Fatalf("expected decommissioning to be complete, got %#v", info)
+	}
+}
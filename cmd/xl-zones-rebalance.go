@@ -0,0 +1,454 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/sync/errgroup"
+)
+
+const (
+	// Default allowed difference in percentage points between the
+	// used space of a zone and the average used space of the zones.
+	defaultRebalanceThreshold = 5.0
+
+	// Interval at which the leadership of rebalancing is tried to
+	// be acquired.
+	rebalanceCheckInterval = time.Minute
+)
+
+var (
+	// Interval at which the used space of the zones is refreshed
+	// while rebalancing, to know when to stop moving objects.
+	rebalanceUsageInterval = 10 * time.Second
+
+	// Returns the used space of the zones, replaced by tests as the
+	// disks of all the zones share the same filesystem there.
+	getZonesUsageFn = (*xlZones).getZonesUsage
+)
+
+// zoneUsage - the used and total space of a zone.
+type zoneUsage struct {
+	Used  uint64
+	Total uint64
+}
+
+// percent - returns the used space of the zone in percent.
+func (u zoneUsage) percent() float64 {
+	if u.Total == 0 {
+		return 0
+	}
+	return 100 * float64(u.Used) / float64(u.Total)
+}
+
+// getZonesUsage - returns the used and total space of each zone.
+func (z *xlZones) getZonesUsage(ctx context.Context) []zoneUsage {
+	usage := make([]zoneUsage, len(z.zones))
+
+	g := errgroup.WithNErrs(len(z.zones))
+	for index := range z.zones {
+		index := index
+		g.Go(func() error {
+			storageInfo := z.zones[index].StorageInfo(ctx, false)
+			for i := range storageInfo.Total {
+				usage[index].Used += storageInfo.Used[i]
+				usage[index].Total += storageInfo.Total[i]
+			}
+			return nil
+		}, index)
+	}
+
+	// Wait for the go routines.
+	g.Wait()
+
+	return usage
+}
+
+// getActiveZones - returns which zones objects can be moved to, the
+// zones which are not being decommissioned.
+func (z *xlZones) getActiveZones() []bool {
+	active := make([]bool, len(z.zones))
+	for idx := range z.zones {
+		active[idx] = !z.isDecommissioning(idx)
+	}
+	return active
+}
+
+// averageUsage - returns the average used space of the active zones
+// in percent, weighted by their total space.
+func averageUsage(usage []zoneUsage, active []bool) float64 {
+	var total zoneUsage
+	for idx, u := range usage {
+		if active[idx] {
+			total.Used += u.Used
+			total.Total += u.Total
+		}
+	}
+	return total.percent()
+}
+
+// getRebalanceSource - returns the active zone using the most space
+// above the average plus threshold percentage points, -1 if the used
+// space of all the active zones is within threshold of the average.
+func getRebalanceSource(usage []zoneUsage, active []bool, threshold float64) int {
+	avg := averageUsage(usage, active)
+	src := -1
+	for idx, u := range usage {
+		if !active[idx] || u.percent() <= avg+threshold {
+			continue
+		}
+		if src < 0 || u.percent() > usage[src].percent() {
+			src = idx
+		}
+	}
+	return src
+}
+
+// getRebalanceTarget - returns the active zone using the least space
+// below the average to move objects of the zone at index src to, -1 once
+// the zone at index src uses no more space than the average.
+func getRebalanceTarget(usage []zoneUsage, active []bool, src int) int {
+	avg := averageUsage(usage, active)
+	if usage[src].percent() <= avg {
+		return -1
+	}
+	dst := -1
+	for idx, u := range usage {
+		if idx == src || !active[idx] || u.percent() >= avg {
+			continue
+		}
+		if dst < 0 || u.percent() < usage[dst].percent() {
+			dst = idx
+		}
+	}
+	return dst
+}
+
+// isRebalanceRunning - returns true if the rebalancing was started,
+// and neither completed, failed nor was canceled since.
+func isRebalanceRunning(info *madmin.RebalanceInfo) bool {
+	return info != nil && !info.Complete && !info.Failed && !info.Canceled
+}
+
+// isRebalancing - returns true while the zones are being rebalanced.
+func (z *xlZones) isRebalancing() bool {
+	z.metaMu.RLock()
+	defer z.metaMu.RUnlock()
+	return isRebalanceRunning(z.meta.Rebalance)
+}
+
+// getRebalanceZoneInfo - returns the rebalancing progress of the zone
+// with the given ID, added to info if missing.
+func getRebalanceZoneInfo(info *madmin.RebalanceInfo, id string) *madmin.RebalanceZoneInfo {
+	for i := range info.Zones {
+		if info.Zones[i].ID == id {
+			return &info.Zones[i]
+		}
+	}
+	info.Zones = append(info.Zones, madmin.RebalanceZoneInfo{ID: id})
+	return &info.Zones[len(info.Zones)-1]
+}
+
+// StartRebalance - starts rebalancing the objects of the zones until
+// their used space is within threshold percentage points of the average.
+func (z *xlZones) StartRebalance(ctx context.Context, threshold float64) error {
+	return z.updateZonesMeta(ctx, func(meta *zonesMeta) error {
+		if isRebalanceRunning(meta.Rebalance) {
+			return errRebalanceInProgress
+		}
+		meta.Rebalance = &madmin.RebalanceInfo{
+			StartTime: UTCNow(),
+			Threshold: threshold,
+		}
+		for idx := range z.zones {
+			meta.Rebalance.Zones = append(meta.Rebalance.Zones, madmin.RebalanceZoneInfo{
+				Index: idx,
+				ID:    z.zoneID(idx),
+			})
+		}
+		return nil
+	})
+}
+
+// CancelRebalance - stops rebalancing the zones, objects already moved
+// stay on the zones they were moved to.
+func (z *xlZones) CancelRebalance(ctx context.Context) error {
+	return z.updateZonesMeta(ctx, func(meta *zonesMeta) error {
+		if !isRebalanceRunning(meta.Rebalance) {
+			return errRebalanceNotInProgress
+		}
+		meta.Rebalance.Canceled = true
+		meta.Rebalance.EndTime = UTCNow()
+		return nil
+	})
+}
+
+// RebalanceStatus - returns the progress of the last rebalancing of the
+// zones read from the saved zones metadata, along with the current used
+// space of the zones.
+func (z *xlZones) RebalanceStatus(ctx context.Context) (madmin.RebalanceInfo, error) {
+	meta, err := readZonesMeta(ctx, z)
+	if err != nil {
+		return madmin.RebalanceInfo{}, err
+	}
+
+	var info madmin.RebalanceInfo
+	if meta.Rebalance != nil {
+		info = *meta.Rebalance
+	}
+
+	usage := getZonesUsageFn(z, ctx)
+	zones := make([]madmin.RebalanceZoneInfo, len(z.zones))
+	for idx := range z.zones {
+		zones[idx] = *getRebalanceZoneInfo(&info, z.zoneID(idx))
+		zones[idx].Index = idx
+		zones[idx].UsedPercent = usage[idx].percent()
+	}
+	info.Zones = zones
+	return info, nil
+}
+
+// rebalancePass - moves the objects of the zone at index src to the zones
+// using less space than the average, until the zone uses no more space
+// than the average. Resumes from the bucket and object last moved off the
+// zone, returns the number of objects moved.
+func (z *xlZones) rebalancePass(ctx context.Context, src int, info *madmin.RebalanceInfo) (int64, error) {
+	zone := z.zones[src]
+	id := z.zoneID(src)
+
+	buckets, err := zone.ListBuckets(ctx)
+	if err != nil {
+		return 0, err
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Name < buckets[j].Name
+	})
+
+	zi := getRebalanceZoneInfo(info, id)
+
+	var moved int64
+	var balanced bool
+	var lastUsage time.Time
+	dst := -1
+	lastSave := UTCNow()
+	for _, bucket := range buckets {
+		if bucket.Name < zi.Bucket {
+			continue
+		}
+		var marker string
+		if bucket.Name == zi.Bucket {
+			marker = zi.Object
+		}
+		zi.Bucket = bucket.Name
+
+		z.walkZone(ctx, src, bucket.Name, "", marker, func(object string) bool {
+			if ctx.Err() != nil || !z.isRebalancing() || z.isDecommissioning(src) {
+				err = errRebalanceNotInProgress
+				return false
+			}
+
+			// Objects are moved to the least used zone until the
+			// zone doesn't use more space than the average anymore.
+			if dst < 0 || z.isDecommissioning(dst) || UTCNow().Sub(lastUsage) > rebalanceUsageInterval {
+				dst = getRebalanceTarget(getZonesUsageFn(z, ctx), z.getActiveZones(), src)
+				lastUsage = UTCNow()
+				if dst < 0 {
+					balanced = true
+					return false
+				}
+			}
+
+			// Wait and proceed if there are active requests
+			waitForLowHTTPReq(int32(zone.drivesPerSet))
+
+			n, merr := z.migrateObject(ctx, src, dst, bucket.Name, object)
+			switch {
+			case merr == nil:
+				moved++
+				zi.ObjectsMoved++
+				zi.BytesMoved += n
+			case isErrObjectNotFound(merr):
+				// Removed since it was listed.
+			default:
+				zi.ObjectsFailed++
+				logger.LogIf(ctx, fmt.Errorf("Unable to move %s off zone %s: %w",
+					pathJoin(bucket.Name, object), id, merr))
+			}
+			zi.Object = object
+
+			if UTCNow().Sub(lastSave) > decommissionSaveInterval {
+				if err = z.saveRebalance(ctx, *info); err != nil {
+					return false
+				}
+				lastSave = UTCNow()
+			}
+			return true
+		})
+		if err != nil {
+			return moved, err
+		}
+		if balanced {
+			break
+		}
+	}
+
+	if !balanced {
+		// All the objects of the zone were walked, the next
+		// pass starts over with the objects written meanwhile.
+		zi.Bucket = ""
+		zi.Object = ""
+	}
+	return moved, z.saveRebalance(ctx, *info)
+}
+
+// saveRebalance - saves the progress of rebalancing the zones, unless the
+// rebalancing was canceled meanwhile.
+func (z *xlZones) saveRebalance(ctx context.Context, info madmin.RebalanceInfo) error {
+	info.Zones = append([]madmin.RebalanceZoneInfo(nil), info.Zones...)
+	return z.updateZonesMeta(ctx, func(meta *zonesMeta) error {
+		if !isRebalanceRunning(meta.Rebalance) || !meta.Rebalance.StartTime.Equal(info.StartTime) {
+			return errRebalanceNotInProgress
+		}
+		*meta.Rebalance = info
+		return nil
+	})
+}
+
+// rebalanceZones - moves objects off the zones using more space than the
+// average until the used space of all the zones is within the threshold
+// of the average. Zones being decommissioned are left out.
+func (z *xlZones) rebalanceZones(ctx context.Context) error {
+	z.metaMu.RLock()
+	if !isRebalanceRunning(z.meta.Rebalance) {
+		z.metaMu.RUnlock()
+		return errRebalanceNotInProgress
+	}
+	info := *z.meta.Rebalance
+	info.Zones = append([]madmin.RebalanceZoneInfo(nil), info.Zones...)
+	z.metaMu.RUnlock()
+
+	var err error
+	for {
+		src := getRebalanceSource(getZonesUsageFn(z, ctx), z.getActiveZones(), info.Threshold)
+		if src < 0 {
+			info.Complete = true
+			break
+		}
+
+		var moved int64
+		moved, err = z.rebalancePass(ctx, src, &info)
+		if err != nil {
+			if err == errRebalanceNotInProgress || ctx.Err() != nil {
+				return err
+			}
+			info.Failed = true
+			break
+		}
+		if moved == 0 {
+			// Nothing is left to be moved off the zone, the
+			// space it uses isn't used by objects.
+			info.Complete = true
+			break
+		}
+	}
+
+	info.EndTime = UTCNow()
+	if serr := z.saveRebalance(ctx, info); serr != nil {
+		return serr
+	}
+	return err
+}
+
+func initZonesRebalance() {
+	go startZonesRebalance()
+}
+
+// startZonesRebalance - rebalances the zones in the background, only one
+// server of the cluster rebalances the zones at a time.
+func startZonesRebalance() {
+	// Wait until the object layer is ready
+	var objAPI ObjectLayer
+	for {
+		objAPI = newObjectLayerWithoutSafeModeFn()
+		if objAPI == nil {
+			time.Sleep(time.Second)
+			continue
+		}
+		break
+	}
+
+	z, ok := objAPI.(*xlZones)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-GlobalServiceDoneCh
+		cancel()
+	}()
+
+	locker := z.NewNSLock(ctx, minioMetaBucket, "leader-zones-rebalance")
+	for {
+		err := locker.GetLock(leaderLockTimeout)
+		if err == nil {
+			// Break without unlocking, this node will acquire
+			// zones rebalancing role for its lifetime.
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(rebalanceCheckInterval):
+		}
+	}
+	defer locker.Unlock()
+
+	for {
+		if z.isRebalancing() {
+			switch err := z.rebalanceZones(ctx); {
+			case err == nil:
+			case ctx.Err() != nil:
+				return
+			case err == errRebalanceNotInProgress:
+				// Rebalancing was canceled, or the zone
+				// objects were moved off is decommissioned.
+				if err = z.loadZonesMeta(ctx); err == nil {
+					continue
+				}
+				logger.LogIf(ctx, err)
+			default:
+				logger.LogIf(ctx, fmt.Errorf("Unable to rebalance zones: %w", err))
+			}
+		}
+
+		// Woken up whenever the zones metadata is loaded,
+		// which every server does periodically.
+		select {
+		case <-ctx.Done():
+			return
+		case <-z.rebalanceCh:
+		}
+	}
+}
@@ -0,0 +1,275 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/bucket/versioning"
+)
+
+func TestGetRebalanceZones(t *testing.T) {
+	testCases := []struct {
+		usage       []zoneUsage
+		active      []bool
+		threshold   float64
+		expectedSrc int
+		expectedDst int
+	}{
+		// Zones within the threshold of the average.
+		{[]zoneUsage{{50, 100}, {46, 100}}, []bool{true, true}, 5, -1, -1},
+		// The full old zone is rebalanced to the new empty zone.
+		{[]zoneUsage{{90, 100}, {0, 100}}, []bool{true, true}, 5, 0, 1},
+		// The average is weighted by the total space of the zones.
+		{[]zoneUsage{{50, 100}, {100, 400}, {0, 100}}, []bool{true, true, true}, 5, 0, 2},
+		// Zones being decommissioned are left out.
+		{[]zoneUsage{{90, 100}, {0, 100}, {50, 100}}, []bool{true, false, true}, 5, 0, 2},
+		{[]zoneUsage{{90, 100}, {0, 100}}, []bool{false, true}, 5, -1, -1},
+	}
+
+	for i, testCase := range testCases {
+		src := getRebalanceSource(testCase.usage, testCase.active, testCase.threshold)
+		if src != testCase.expectedSrc {
+			t.Fatalf("Test %d: expected source zone %d, got %d", i+1, testCase.expectedSrc, src)
+		}
+		if src < 0 {
+			continue
+		}
+		dst := getRebalanceTarget(testCase.usage, testCase.active, src)
+		if dst != testCase.expectedDst {
+			t.Errorf("Test %d: expected target zone %d, got %d", i+1, testCase.expectedDst, dst)
+		}
+	}
+
+	// Objects are moved until the zone uses no more space than the average.
+	if dst := getRebalanceTarget([]zoneUsage{{52, 100}, {48, 100}}, []bool{true, true}, 0); dst != 1 {
+		t.Errorf("expected target zone 1, got %d", dst)
+	}
+	if dst := getRebalanceTarget([]zoneUsage{{50, 100}, {50, 100}}, []bool{true, true}, 0); dst != -1 {
+		t.Errorf("expected no target zone, got %d", dst)
+	}
+}
+
+func TestRebalanceZones(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z, fsDirs, err := prepareXLZones(2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	if err = z.initZonesMeta(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = z.CancelRebalance(ctx); err != errRebalanceNotInProgress {
+		t.Fatalf("expected %v, got %v", errRebalanceNotInProgress, err)
+	}
+	if err = z.StartRebalance(ctx, defaultRebalanceThreshold); err != nil {
+		t.Fatal(err)
+	}
+	if err = z.StartRebalance(ctx, defaultRebalanceThreshold); err != errRebalanceInProgress {
+		t.Fatalf("expected %v, got %v", errRebalanceInProgress, err)
+	}
+	if err = z.CancelRebalance(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err = z.rebalanceZones(ctx); err != errRebalanceNotInProgress {
+		t.Fatalf("expected %v, got %v", errRebalanceNotInProgress, err)
+	}
+
+	// The disks of both zones share the same filesystem, the
+	// zones are balanced already.
+	if err = z.StartRebalance(ctx, defaultRebalanceThreshold); err != nil {
+		t.Fatal(err)
+	}
+	if err = z.rebalanceZones(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := z.RebalanceStatus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.Complete || info.Canceled || info.Failed {
+		t.Fatalf("expected rebalancing to be complete, got %#v", info)
+	}
+	if len(info.Zones) != 2 {
+		t.Fatalf("expected the status of 2 zones, got %d", len(info.Zones))
+	}
+	for idx, zi := range info.Zones {
+		if zi.Index != idx || zi.ID != z.zoneID(idx) {
+			t.Errorf("unexpected zone %d status %#v", idx, zi)
+		}
+	}
+}
+
+func TestRebalanceZonesMovesObjects(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z, fsDirs, err := prepareXLZones(2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	if err = z.initZonesMeta(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := "bucket"
+	if err = z.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Write all the objects to the first zone.
+	objects := map[string][]byte{}
+	for i := 0; i < 10; i++ {
+		object := fmt.Sprintf("object-%d", i)
+		data := bytes.Repeat([]byte{byte('a' + i)}, (i+1)*1024)
+		objects[object] = data
+		if _, err = z.zones[0].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	countObjects := func(idx int) int {
+		result, err := z.zones[idx].ListObjects(ctx, bucket, "", "", "", maxObjectList)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(result.Objects)
+	}
+
+	// The used space of a zone is the number of objects it holds,
+	// refreshed after every object moved.
+	defer func(d time.Duration) { rebalanceUsageInterval = d }(rebalanceUsageInterval)
+	rebalanceUsageInterval = 0
+	defer func(fn func(*xlZones, context.Context) []zoneUsage) { getZonesUsageFn = fn }(getZonesUsageFn)
+	getZonesUsageFn = func(z *xlZones, ctx context.Context) []zoneUsage {
+		usage := make([]zoneUsage, len(z.zones))
+		for idx := range z.zones {
+			usage[idx] = zoneUsage{Used: uint64(countObjects(idx)), Total: uint64(len(objects))}
+		}
+		return usage
+	}
+
+	if err = z.StartRebalance(ctx, defaultRebalanceThreshold); err != nil {
+		t.Fatal(err)
+	}
+	if err = z.rebalanceZones(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := z.RebalanceStatus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.Complete || info.Canceled || info.Failed {
+		t.Fatalf("expected rebalancing to be complete, got %#v", info)
+	}
+	if moved := info.Zones[0].ObjectsMoved; moved < int64(len(objects)/2) {
+		t.Errorf("expected at least %d objects moved off zone 0, got %d", len(objects)/2, moved)
+	}
+	for idx := range z.zones {
+		if count := countObjects(idx); count != len(objects)/2 {
+			t.Errorf("expected zone %d to hold %d objects, got %d", idx, len(objects)/2, count)
+		}
+	}
+
+	for object, data := range objects {
+		var buf bytes.Buffer
+		if err = z.GetObject(ctx, bucket, object, 0, -1, &buf, "", ObjectOptions{}); err != nil {
+			t.Fatalf("%s: %v", object, err)
+		}
+		if !bytes.Equal(buf.Bytes(), data) {
+			t.Errorf("%s: object content mismatch", object)
+		}
+	}
+}
+
+func TestCompleteMultipartUploadRebalancedObject(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z, fsDirs, err := prepareXLZones(2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	if err = z.initZonesMeta(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(sys *BucketVersioningSys) { globalBucketVersioningSys = sys }(globalBucketVersioningSys)
+	globalBucketVersioningSys = NewBucketVersioningSys()
+
+	bucket, object := "bucket", "object"
+	if err = z.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	globalBucketVersioningSys.Set(bucket, versioning.Versioning{Status: versioning.Enabled})
+
+	// An upload is started on the zone holding the previous version.
+	prevData := bytes.Repeat([]byte("a"), 1024)
+	prevInfo, err := z.zones[0].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(prevData), int64(len(prevData)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	uploadID, err := z.NewMultipartUpload(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("b"), 2048)
+	partInfo, err := z.PutObjectPart(ctx, bucket, object, uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Rebalancing moves the object meanwhile.
+	if _, err = z.migrateObject(ctx, 0, 1, bucket, object); err != nil {
+		t.Fatal(err)
+	}
+
+	objInfo, err := z.CompleteMultipartUpload(ctx, bucket, object, uploadID, []CompletePart{{PartNumber: 1, ETag: partInfo.ETag}}, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count := z.countMultipartUploads(0); count != 0 {
+		t.Fatalf("expected the upload to be moved off zone 0, got %d uploads", count)
+	}
+	if _, err = z.zones[0].GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("expected no version on zone 0, got %v", err)
+	}
+	for versionID, expected := range map[string][]byte{prevInfo.VersionID: prevData, objInfo.VersionID: data} {
+		var buf bytes.Buffer
+		if err = z.zones[1].GetObject(ctx, bucket, object, 0, -1, &buf, "", ObjectOptions{VersionID: versionID}); err != nil {
+			t.Fatalf("version %s: %v", versionID, err)
+		}
+		if !bytes.Equal(buf.Bytes(), expected) {
+			t.Errorf("version %s: object content mismatch", versionID)
+		}
+	}
+}
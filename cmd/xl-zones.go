@@ -27,16 +27,29 @@ import (
 
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/cors"
 	bucketsse "github.com/minio/minio/pkg/bucket/encryption"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/bucket/logging"
 	"github.com/minio/minio/pkg/bucket/object/tagging"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/bucket/versioning"
+	"github.com/minio/minio/pkg/bucket/website"
 	"github.com/minio/minio/pkg/madmin"
 	"github.com/minio/minio/pkg/sync/errgroup"
 )
 
 type xlZones struct {
 	zones []*xlSets
+
+	// Decommissioning and rebalancing status of the zones.
+	metaMu sync.RWMutex
+	meta   zonesMeta
+
+	// Signal changes of the decommissioning and rebalancing status.
+	decommissionCh chan struct{}
+	rebalanceCh    chan struct{}
 }
 
 func (z *xlZones) SingleZone() bool {
@@ -60,7 +73,11 @@ func newXLZones(endpointZones EndpointZones) (ObjectLayer, error) {
 		err          error
 
 		formats = make([]*formatXLV3, len(endpointZones))
-		z       = &xlZones{zones: make([]*xlSets, len(endpointZones))}
+		z       = &xlZones{
+			zones:          make([]*xlSets, len(endpointZones)),
+			decommissionCh: make(chan struct{}, 1),
+			rebalanceCh:    make(chan struct{}, 1),
+		}
 	)
 	local := endpointZones.FirstLocal()
 	for i, ep := range endpointZones {
@@ -103,11 +120,19 @@ func (p zonesAvailableSpace) TotalAvailable() uint64 {
 	return total
 }
 
+// getAvailableZoneIdx - returns the index of a zone to write a new
+// object to, picked randomly in proportion of their available space.
+// Zones being decommissioned are never picked.
 func (z *xlZones) getAvailableZoneIdx(ctx context.Context) int {
 	zones := z.getZonesAvailableSpace(ctx)
 	total := zones.TotalAvailable()
 	if total == 0 {
 		// Houston, we have a problem, maybe panic??
+		for _, zone := range zones {
+			if !z.isDecommissioning(zone.Index) {
+				return zone.Index
+			}
+		}
 		return zones[0].Index
 	}
 	// choose when we reach this many
@@ -123,6 +148,28 @@ func (z *xlZones) getAvailableZoneIdx(ctx context.Context) int {
 	panic(fmt.Errorf("reached end of zones (total: %v, atTotal: %v, choose: %v)", total, atTotal, choose))
 }
 
+// getZoneIdx returns the index of the zone holding the given object,
+// objects whose current version is a delete marker are considered
+// present since all the versions of an object live in the same zone.
+// The least used zone is returned when the object is not found.
+func (z *xlZones) getZoneIdx(ctx context.Context, bucket, object string) (int, error) {
+	if z.SingleZone() {
+		return 0, nil
+	}
+	for i, zone := range z.zones {
+		objInfo, err := zone.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		switch {
+		case err == nil, objInfo.DeleteMarker:
+			return i, nil
+		case isErrObjectNotFound(err):
+			continue
+		default:
+			return -1, err
+		}
+	}
+	return z.getAvailableZoneIdx(ctx), nil
+}
+
 func (z *xlZones) getZonesAvailableSpace(ctx context.Context) zonesAvailableSpace {
 	var zones = make(zonesAvailableSpace, len(z.zones))
 
@@ -144,6 +191,11 @@ func (z *xlZones) getZonesAvailableSpace(ctx context.Context) zonesAvailableSpac
 		for _, davailable := range zinfo.Available {
 			available += davailable
 		}
+		// No space is available for new objects on
+		// zones being decommissioned.
+		if z.isDecommissioning(i) {
+			available = 0
+		}
 		zones[i] = zoneAvailableSpace{
 			Index:     i,
 			Available: available,
@@ -331,7 +383,7 @@ func (z *xlZones) GetObjectNInfo(ctx context.Context, bucket, object string, rs
 	for _, zone := range z.zones {
 		gr, err := zone.GetObjectNInfo(ctx, bucket, object, rs, h, lockType, opts)
 		if err != nil {
-			if isErrObjectNotFound(err) {
+			if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
 				continue
 			}
 			nsUnlocker()
@@ -341,6 +393,9 @@ func (z *xlZones) GetObjectNInfo(ctx context.Context, bucket, object string, rs
 		return gr, nil
 	}
 	nsUnlocker()
+	if opts.VersionID != "" {
+		return nil, VersionNotFound{Bucket: bucket, Object: object, VersionID: opts.VersionID}
+	}
 	return nil, ObjectNotFound{Bucket: bucket, Object: object}
 }
 
@@ -357,13 +412,16 @@ func (z *xlZones) GetObject(ctx context.Context, bucket, object string, startOff
 	}
 	for _, zone := range z.zones {
 		if err := zone.GetObject(ctx, bucket, object, startOffset, length, writer, etag, opts); err != nil {
-			if isErrObjectNotFound(err) {
+			if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
 				continue
 			}
 			return err
 		}
 		return nil
 	}
+	if opts.VersionID != "" {
+		return VersionNotFound{Bucket: bucket, Object: object, VersionID: opts.VersionID}
+	}
 	return ObjectNotFound{Bucket: bucket, Object: object}
 }
 
@@ -381,13 +439,21 @@ func (z *xlZones) GetObjectInfo(ctx context.Context, bucket, object string, opts
 	for _, zone := range z.zones {
 		objInfo, err := zone.GetObjectInfo(ctx, bucket, object, opts)
 		if err != nil {
-			if isErrObjectNotFound(err) {
+			// Current version of the object is a delete
+			// marker, the object lives in this zone.
+			if objInfo.DeleteMarker {
+				return objInfo, err
+			}
+			if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
 				continue
 			}
 			return objInfo, err
 		}
 		return objInfo, nil
 	}
+	if opts.VersionID != "" {
+		return ObjectInfo{}, VersionNotFound{Bucket: bucket, Object: object, VersionID: opts.VersionID}
+	}
 	return ObjectInfo{}, ObjectNotFound{Bucket: bucket, Object: object}
 }
 
@@ -404,19 +470,18 @@ func (z *xlZones) PutObject(ctx context.Context, bucket string, object string, d
 		return z.zones[0].PutObject(ctx, bucket, object, data, opts)
 	}
 
-	for _, zone := range z.zones {
-		objInfo, err := zone.GetObjectInfo(ctx, bucket, object, opts)
-		if err != nil {
-			if isErrObjectNotFound(err) {
-				continue
-			}
-			return objInfo, err
-		}
-		// Overwrite request upload to right zone.
-		return zone.PutObject(ctx, bucket, object, data, opts)
+	// Overwrite request upload to right zone, if object
+	// not found pick the least used and upload to this zone.
+	idx, err := z.getZoneIdx(ctx, bucket, object)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	if z.isDecommissioning(idx) {
+		return z.putDecommissioningObject(ctx, idx, bucket, object, func(zone *xlSets) (ObjectInfo, error) {
+			return zone.PutObject(ctx, bucket, object, data, opts)
+		})
 	}
-	// Object not found pick the least used and upload to this zone.
-	return z.zones[z.getAvailableZoneIdx(ctx)].PutObject(ctx, bucket, object, data, opts)
+	return z.zones[idx].PutObject(ctx, bucket, object, data, opts)
 }
 
 func (z *xlZones) DeleteObject(ctx context.Context, bucket string, object string) error {
@@ -430,6 +495,16 @@ func (z *xlZones) DeleteObject(ctx context.Context, bucket string, object string
 	if z.SingleZone() {
 		return z.zones[0].DeleteObject(ctx, bucket, object)
 	}
+
+	// Delete marker is added to the zone holding the object.
+	if globalBucketVersioningSys.Configured(bucket) {
+		idx, err := z.getZoneIdx(ctx, bucket, object)
+		if err != nil {
+			return err
+		}
+		return z.zones[idx].DeleteObject(ctx, bucket, object)
+	}
+
 	for _, zone := range z.zones {
 		err := zone.DeleteObject(ctx, bucket, object)
 		if err != nil && !isErrObjectNotFound(err) {
@@ -452,6 +527,22 @@ func (z *xlZones) DeleteObjects(ctx context.Context, bucket string, objects []st
 	}
 	defer multiDeleteLock.Unlock()
 
+	// Delete markers are added to the zone holding each object.
+	if globalBucketVersioningSys.Configured(bucket) {
+		for i, object := range objects {
+			if derrs[i] != nil {
+				continue
+			}
+			idx, err := z.getZoneIdx(ctx, bucket, object)
+			if err != nil {
+				derrs[i] = err
+				continue
+			}
+			derrs[i] = z.zones[idx].DeleteObject(ctx, bucket, object)
+		}
+		return derrs, nil
+	}
+
 	for _, zone := range z.zones {
 		errs, err := zone.DeleteObjects(ctx, bucket, objects)
 		if err != nil {
@@ -468,10 +559,103 @@ func (z *xlZones) DeleteObjects(ctx context.Context, bucket string, objects []st
 	return derrs, nil
 }
 
+// DeleteObjectVersion - deletes a version of an object, adds a delete
+// marker to the zone holding the object when no version is specified.
+func (z *xlZones) DeleteObjectVersion(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	// Acquire a write lock before deleting the object version.
+	objectLock := z.NewNSLock(ctx, bucket, object)
+	if err := objectLock.GetLock(globalOperationTimeout); err != nil {
+		return ObjectInfo{}, err
+	}
+	defer objectLock.Unlock()
+
+	if z.SingleZone() {
+		return z.zones[0].DeleteObjectVersion(ctx, bucket, object, opts)
+	}
+
+	if opts.VersionID == "" {
+		idx, err := z.getZoneIdx(ctx, bucket, object)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		return z.zones[idx].DeleteObjectVersion(ctx, bucket, object, opts)
+	}
+
+	for _, zone := range z.zones {
+		objInfo, err := zone.DeleteObjectVersion(ctx, bucket, object, opts)
+		if err != nil {
+			if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+				continue
+			}
+			return objInfo, err
+		}
+		return objInfo, nil
+	}
+	return ObjectInfo{}, VersionNotFound{Bucket: bucket, Object: object, VersionID: opts.VersionID}
+}
+
+// TransitionObject - marks a version of an object as transitioned to a
+// remote tier, in the zone holding the version.
+func (z *xlZones) TransitionObject(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	// Acquire a write lock before transitioning the object version.
+	objectLock := z.NewNSLock(ctx, bucket, object)
+	if err := objectLock.GetLock(globalOperationTimeout); err != nil {
+		return err
+	}
+	defer objectLock.Unlock()
+
+	if z.SingleZone() {
+		return z.zones[0].TransitionObject(ctx, bucket, object, opts)
+	}
+
+	for _, zone := range z.zones {
+		err := zone.TransitionObject(ctx, bucket, object, opts)
+		if err != nil {
+			if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+				continue
+			}
+			return err
+		}
+		return nil
+	}
+	return ObjectNotFound{Bucket: bucket, Object: object}
+}
+
+// PutObjectMetadata - updates the metadata of a version of an object,
+// in the zone holding the version.
+func (z *xlZones) PutObjectMetadata(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	// Acquire a write lock before updating the object version.
+	objectLock := z.NewNSLock(ctx, bucket, object)
+	if err := objectLock.GetLock(globalOperationTimeout); err != nil {
+		return err
+	}
+	defer objectLock.Unlock()
+
+	if z.SingleZone() {
+		return z.zones[0].PutObjectMetadata(ctx, bucket, object, opts)
+	}
+
+	for _, zone := range z.zones {
+		err := zone.PutObjectMetadata(ctx, bucket, object, opts)
+		if err != nil {
+			if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+				continue
+			}
+			return err
+		}
+		return nil
+	}
+	return ObjectNotFound{Bucket: bucket, Object: object}
+}
+
 func (z *xlZones) CopyObject(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error) {
 	// Check if this request is only metadata update.
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(destBucket, destObject))
-	if !cpSrcDstSame {
+
+	// Objects in a versioned bucket are never updated in place,
+	// a new version is created which needs the object lock.
+	versioned := globalBucketVersioningSys.Configured(destBucket)
+	if !cpSrcDstSame || versioned {
 		objectLock := z.NewNSLock(ctx, destBucket, destObject)
 		if err := objectLock.GetLock(globalObjectTimeout); err != nil {
 			return objInfo, err
@@ -482,7 +666,7 @@ func (z *xlZones) CopyObject(ctx context.Context, srcBucket, srcObject, destBuck
 	if z.SingleZone() {
 		return z.zones[0].CopyObject(ctx, srcBucket, srcObject, destBucket, destObject, srcInfo, srcOpts, dstOpts)
 	}
-	if cpSrcDstSame && srcInfo.metadataOnly {
+	if cpSrcDstSame && srcInfo.metadataOnly && !versioned {
 		for _, zone := range z.zones {
 			objInfo, err = zone.CopyObject(ctx, srcBucket, srcObject, destBucket,
 				destObject, srcInfo, srcOpts, dstOpts)
@@ -496,7 +680,19 @@ func (z *xlZones) CopyObject(ctx context.Context, srcBucket, srcObject, destBuck
 		}
 		return objInfo, ObjectNotFound{Bucket: srcBucket, Object: srcObject}
 	}
-	return z.zones[z.getAvailableZoneIdx(ctx)].CopyObject(ctx, srcBucket, srcObject,
+
+	idx, err := z.getZoneIdx(ctx, destBucket, destObject)
+	if err != nil {
+		return objInfo, err
+	}
+	// Objects copied onto themselves are read from the zone, they
+	// are left to be migrated when the zone is decommissioned.
+	if z.isDecommissioning(idx) && !cpSrcDstSame {
+		return z.putDecommissioningObject(ctx, idx, destBucket, destObject, func(zone *xlSets) (ObjectInfo, error) {
+			return zone.CopyObject(ctx, srcBucket, srcObject, destBucket, destObject, srcInfo, srcOpts, dstOpts)
+		})
+	}
+	return z.zones[idx].CopyObject(ctx, srcBucket, srcObject,
 		destBucket, destObject, srcInfo, srcOpts, dstOpts)
 }
 
@@ -571,6 +767,12 @@ func (z *xlZones) listObjectsNonSlash(ctx context.Context, bucket, prefix, marke
 			continue
 		}
 
+		// Objects whose current version is a delete
+		// marker are not listed.
+		if result.Deleted {
+			continue
+		}
+
 		var objInfo ObjectInfo
 
 		index := strings.Index(strings.TrimPrefix(result.Name, prefix), delimiter)
@@ -846,6 +1048,11 @@ func mergeZonesEntriesCh(zonesEntryChs [][]FileInfoCh, maxKeys int, zoneDrives [
 				// Skip entries which do not have quorum.
 				continue
 			}
+			// Objects whose current version is a delete
+			// marker are not listed.
+			if fi.Deleted {
+				continue
+			}
 		}
 		entries.Files = append(entries.Files, fi)
 		i++
@@ -895,6 +1102,168 @@ func (z *xlZones) ListObjects(ctx context.Context, bucket, prefix, marker, delim
 	return z.listObjects(ctx, bucket, prefix, marker, delimiter, maxKeys, false)
 }
 
+// objectVersionID - returns the version ID of the object version as
+// presented to clients, "null" for the null version.
+func objectVersionID(objInfo ObjectInfo) string {
+	if objInfo.VersionID == "" {
+		return nullVersionID
+	}
+	return objInfo.VersionID
+}
+
+// ListObjectVersions - lists all the versions of the objects in a bucket,
+// delete markers included. Versions of an object are listed latest first,
+// listing resumes after versionMarker of the marker object when set.
+func (z *xlZones) ListObjectVersions(ctx context.Context, bucket, prefix, marker, versionMarker, delimiter string, maxKeys int) (ListObjectVersionsInfo, error) {
+	loi := ListObjectVersionsInfo{}
+
+	if err := checkListObjsArgs(ctx, bucket, prefix, marker, z); err != nil {
+		return loi, err
+	}
+
+	// Marker is set validate pre-condition.
+	if marker != "" {
+		// Marker not common with prefix is not implemented. Send an empty response
+		if !HasPrefix(marker, prefix) {
+			return loi, nil
+		}
+	}
+
+	// With max keys of zero we have reached eof, return right here.
+	if maxKeys == 0 {
+		return loi, nil
+	}
+
+	// For delimiter and prefix as '/' we do not list anything at all
+	// since according to s3 spec we stop at the 'delimiter'
+	// along // with the prefix. On a flat namespace with 'prefix'
+	// as '/' we don't have any entries, since all the keys are
+	// of form 'keyName/...'
+	if delimiter == SlashSeparator && prefix == SlashSeparator {
+		return loi, nil
+	}
+
+	// Over flowing count - reset to maxObjectList.
+	if maxKeys < 0 || maxKeys > maxObjectList {
+		maxKeys = maxObjectList
+	}
+
+	var zonesEntryChs [][]FileInfoCh
+
+	endWalkCh := make(chan struct{})
+	defer close(endWalkCh)
+
+	for _, zone := range z.zones {
+		zonesEntryChs = append(zonesEntryChs,
+			zone.startMergeWalks(ctx, bucket, prefix, "", true, endWalkCh))
+	}
+
+	var zoneDrivesPerSet []int
+	for _, zone := range z.zones {
+		zoneDrivesPerSet = append(zoneDrivesPerSet, zone.drivesPerSet)
+	}
+
+	var zonesEntriesInfos [][]FileInfo
+	var zonesEntriesValid [][]bool
+	for _, entryChs := range zonesEntryChs {
+		zonesEntriesInfos = append(zonesEntriesInfos, make([]FileInfo, len(entryChs)))
+		zonesEntriesValid = append(zonesEntriesValid, make([]bool, len(entryChs)))
+	}
+
+	var count int
+	var prevPrefix string
+	for {
+		if count == maxKeys {
+			loi.IsTruncated = true
+			break
+		}
+		result, quorumCount, zoneIndex, ok := leastEntryZone(zonesEntryChs, zonesEntriesInfos, zonesEntriesValid)
+		if !ok {
+			break
+		}
+		rquorum := result.Quorum
+		// Quorum is zero for all directories.
+		if rquorum == 0 {
+			// Choose N/2 quorum for directory entries.
+			rquorum = zoneDrivesPerSet[zoneIndex] / 2
+		}
+		if quorumCount < rquorum {
+			continue
+		}
+
+		if delimiter != "" {
+			index := strings.Index(strings.TrimPrefix(result.Name, prefix), delimiter)
+			if index != -1 {
+				index = len(prefix) + index + len(delimiter)
+				currPrefix := result.Name[:index]
+				if currPrefix == prevPrefix || currPrefix <= marker {
+					continue
+				}
+				prevPrefix = currPrefix
+
+				loi.Prefixes = append(loi.Prefixes, currPrefix)
+				loi.NextMarker, loi.NextVersionIDMarker = currPrefix, ""
+				count++
+				continue
+			}
+		}
+
+		// Versions of the marker object listed before the
+		// version marker were sent in the previous listing.
+		if result.Name < marker || (result.Name == marker && versionMarker == "") {
+			continue
+		}
+
+		var versions []ObjectInfo
+		if HasSuffix(result.Name, SlashSeparator) {
+			// Directory objects are not versioned.
+			objInfo := result.ToObjectInfo()
+			objInfo.Bucket = bucket
+			objInfo.IsLatest = true
+			versions = []ObjectInfo{objInfo}
+		} else {
+			var err error
+			versions, err = z.zones[zoneIndex].getObjectVersions(ctx, bucket, result.Name)
+			if err != nil {
+				// Object was removed or is not readable
+				// in quorum, skip it from the listing.
+				continue
+			}
+		}
+
+		if result.Name == marker {
+			found := false
+			for i, version := range versions {
+				if objectVersionID(version) == versionMarker {
+					versions = versions[i+1:]
+					found = true
+					break
+				}
+			}
+			if !found {
+				// The version marker is unknown, or was removed since
+				// the previous listing, skip past the marker object.
+				continue
+			}
+		}
+
+		for _, version := range versions {
+			if count == maxKeys {
+				break
+			}
+			loi.Objects = append(loi.Objects, version)
+			loi.NextMarker, loi.NextVersionIDMarker = version.Name, objectVersionID(version)
+			count++
+		}
+	}
+
+	if !loi.IsTruncated {
+		loi.NextMarker, loi.NextVersionIDMarker = "", ""
+	}
+
+	return loi, nil
+}
+
 func (z *xlZones) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (ListMultipartsInfo, error) {
 	if z.SingleZone() {
 		return z.zones[0].ListMultipartUploads(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads)
@@ -920,6 +1289,20 @@ func (z *xlZones) NewMultipartUpload(ctx context.Context, bucket, object string,
 	if z.SingleZone() {
 		return z.zones[0].NewMultipartUpload(ctx, bucket, object, opts)
 	}
+
+	// All the versions of an object live in the same zone, previous
+	// versions on a zone being decommissioned are moved along when
+	// the upload is completed.
+	if globalBucketVersioningSys.Configured(bucket) {
+		idx, err := z.getZoneIdx(ctx, bucket, object)
+		if err != nil {
+			return "", err
+		}
+		if z.isDecommissioning(idx) {
+			idx = z.getAvailableZoneIdx(ctx)
+		}
+		return z.zones[idx].NewMultipartUpload(ctx, bucket, object, opts)
+	}
 	return z.zones[z.getAvailableZoneIdx(ctx)].NewMultipartUpload(ctx, bucket, object, opts)
 }
 
@@ -1033,18 +1416,28 @@ func (z *xlZones) CompleteMultipartUpload(ctx context.Context, bucket, object, u
 		return z.zones[0].CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
 	}
 
-	// Purge any existing object.
-	for _, zone := range z.zones {
-		zone.DeleteObject(ctx, bucket, object)
+	// Purge any existing object, previous versions of
+	// objects in a versioned bucket are retained.
+	if !globalBucketVersioningSys.Configured(bucket) {
+		for _, zone := range z.zones {
+			zone.DeleteObject(ctx, bucket, object)
+		}
 	}
 
-	for _, zone := range z.zones {
+	for idx, zone := range z.zones {
 		result, err := zone.ListMultipartUploads(ctx, bucket, object, "", "", "", maxObjectList)
 		if err != nil {
 			return objInfo, err
 		}
 		if result.Lookup(uploadID) {
-			return zone.CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
+			// Uploads are not completed on zones being decommissioned,
+			// and all the versions of an object live in the same zone.
+			if z.anyDecommissioning() || globalBucketVersioningSys.Configured(bucket) {
+				if idx, err = z.getCompleteUploadZoneIdx(ctx, idx, bucket, object, uploadID); err != nil {
+					return objInfo, err
+				}
+			}
+			return z.zones[idx].CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
 		}
 	}
 	return objInfo, InvalidUploadID{
@@ -1119,6 +1512,91 @@ func (z *xlZones) DeleteBucketSSEConfig(ctx context.Context, bucket string) erro
 	return removeBucketSSEConfig(ctx, z, bucket)
 }
 
+// SetBucketVersioning sets bucket versioning config on given bucket
+func (z *xlZones) SetBucketVersioning(ctx context.Context, bucket string, config *versioning.Versioning) error {
+	return saveBucketVersioningConfig(ctx, z, bucket, config)
+}
+
+// GetBucketVersioning returns bucket versioning config on given bucket
+func (z *xlZones) GetBucketVersioning(ctx context.Context, bucket string) (*versioning.Versioning, error) {
+	return getBucketVersioningConfig(z, bucket)
+}
+
+// SetBucketReplicationConfig sets bucket replication config on given bucket
+func (z *xlZones) SetBucketReplicationConfig(ctx context.Context, bucket string, config *replication.Config) error {
+	return saveBucketReplicationConfig(ctx, z, bucket, config)
+}
+
+// GetBucketReplicationConfig returns bucket replication config on given bucket
+func (z *xlZones) GetBucketReplicationConfig(ctx context.Context, bucket string) (*replication.Config, error) {
+	return getBucketReplicationConfig(z, bucket)
+}
+
+// DeleteBucketReplicationConfig deletes bucket replication config on given bucket
+func (z *xlZones) DeleteBucketReplicationConfig(ctx context.Context, bucket string) error {
+	return removeBucketReplicationConfig(ctx, z, bucket)
+}
+
+// SetBucketTagging sets bucket tagging on given bucket
+func (z *xlZones) SetBucketTagging(ctx context.Context, bucket string, tags *tagging.Tagging) error {
+	return saveBucketTaggingConfig(ctx, z, bucket, tags)
+}
+
+// GetBucketTagging returns bucket tagging on given bucket
+func (z *xlZones) GetBucketTagging(ctx context.Context, bucket string) (*tagging.Tagging, error) {
+	return getBucketTaggingConfig(z, bucket)
+}
+
+// DeleteBucketTagging deletes bucket tagging on given bucket
+func (z *xlZones) DeleteBucketTagging(ctx context.Context, bucket string) error {
+	return removeBucketTaggingConfig(ctx, z, bucket)
+}
+
+// SetBucketCorsConfig sets bucket CORS config on given bucket
+func (z *xlZones) SetBucketCorsConfig(ctx context.Context, bucket string, config *cors.Config) error {
+	return saveBucketCorsConfig(ctx, z, bucket, config)
+}
+
+// GetBucketCorsConfig returns bucket CORS config on given bucket
+func (z *xlZones) GetBucketCorsConfig(ctx context.Context, bucket string) (*cors.Config, error) {
+	return getBucketCorsConfig(z, bucket)
+}
+
+// DeleteBucketCorsConfig deletes bucket CORS config on given bucket
+func (z *xlZones) DeleteBucketCorsConfig(ctx context.Context, bucket string) error {
+	return removeBucketCorsConfig(ctx, z, bucket)
+}
+
+// SetBucketWebsiteConfig sets bucket website config on given bucket
+func (z *xlZones) SetBucketWebsiteConfig(ctx context.Context, bucket string, config *website.Config) error {
+	return saveBucketWebsiteConfig(ctx, z, bucket, config)
+}
+
+// GetBucketWebsiteConfig returns bucket website config on given bucket
+func (z *xlZones) GetBucketWebsiteConfig(ctx context.Context, bucket string) (*website.Config, error) {
+	return getBucketWebsiteConfig(z, bucket)
+}
+
+// DeleteBucketWebsiteConfig deletes bucket website config on given bucket
+func (z *xlZones) DeleteBucketWebsiteConfig(ctx context.Context, bucket string) error {
+	return removeBucketWebsiteConfig(ctx, z, bucket)
+}
+
+// SetBucketLoggingConfig sets bucket logging config on given bucket
+func (z *xlZones) SetBucketLoggingConfig(ctx context.Context, bucket string, config *logging.Config) error {
+	return saveBucketLoggingConfig(ctx, z, bucket, config)
+}
+
+// GetBucketLoggingConfig returns bucket logging config on given bucket
+func (z *xlZones) GetBucketLoggingConfig(ctx context.Context, bucket string) (*logging.Config, error) {
+	return getBucketLoggingConfig(z, bucket)
+}
+
+// DeleteBucketLoggingConfig deletes bucket logging config on given bucket
+func (z *xlZones) DeleteBucketLoggingConfig(ctx context.Context, bucket string) error {
+	return removeBucketLoggingConfig(ctx, z, bucket)
+}
+
 // IsNotificationSupported returns whether bucket notification is applicable for this layer.
 func (z *xlZones) IsNotificationSupported() bool {
 	return true
@@ -1329,6 +1807,10 @@ func (z *xlZones) Walk(ctx context.Context, bucket, prefix string, results chan<
 				continue
 			}
 
+			if entry.Deleted {
+				continue
+			}
+
 			results <- entry.ToObjectInfo()
 		}
 	}()
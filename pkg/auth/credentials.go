@@ -90,6 +90,7 @@ type Credentials struct {
 	Expiration   time.Time `xml:"Expiration" json:"expiration,omitempty"`
 	SessionToken string    `xml:"SessionToken" json:"sessionToken,omitempty"`
 	Status       string    `xml:"-" json:"status,omitempty"`
+	ParentUser   string    `xml:"-" json:"parentUser,omitempty"`
 }
 
 func (cred Credentials) String() string {
@@ -119,7 +120,12 @@ func (cred Credentials) IsExpired() bool {
 
 // IsTemp - returns whether credential is temporary or not.
 func (cred Credentials) IsTemp() bool {
-	return cred.SessionToken != ""
+	return cred.SessionToken != "" && !cred.Expiration.IsZero() && cred.Expiration != timeSentinel
+}
+
+// IsServiceAccount - returns whether credential is a service account or not.
+func (cred Credentials) IsServiceAccount() bool {
+	return cred.ParentUser != "" && (cred.Expiration.IsZero() || cred.Expiration == timeSentinel)
 }
 
 // IsValid - returns whether credential is valid or not.
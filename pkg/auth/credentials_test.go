@@ -179,3 +179,30 @@ func TestCredentialsEqual(t *testing.T) {
 		}
 	}
 }
+
+func TestCredentialsType(t *testing.T) {
+	expiry := time.Now().UTC().Add(time.Hour)
+	testCases := []struct {
+		cred             Credentials
+		isTemp           bool
+		isServiceAccount bool
+	}{
+		// Long term credentials.
+		{Credentials{AccessKey: "myuser", Expiration: timeSentinel}, false, false},
+		// Temporary credentials.
+		{Credentials{AccessKey: "myuser", SessionToken: "token", Expiration: expiry}, true, false},
+		// Service account credentials.
+		{Credentials{AccessKey: "myuser", SessionToken: "token", Expiration: timeSentinel, ParentUser: "parent"}, false, true},
+		// Service account credentials without expiry.
+		{Credentials{AccessKey: "myuser", SessionToken: "token", ParentUser: "parent"}, false, true},
+	}
+
+	for i, testCase := range testCases {
+		if isTemp := testCase.cred.IsTemp(); isTemp != testCase.isTemp {
+			t.Fatalf("test %v: IsTemp: expected: %v, got: %v", i+1, testCase.isTemp, isTemp)
+		}
+		if isServiceAccount := testCase.cred.IsServiceAccount(); isServiceAccount != testCase.isServiceAccount {
+			t.Fatalf("test %v: IsServiceAccount: expected: %v, got: %v", i+1, testCase.isServiceAccount, isServiceAccount)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+var (
+	errCORSTooManyRules = Errorf("CORS configuration allows a maximum of 100 rules")
+	errCORSNoRule       = Errorf("CORS configuration should have at least one CORSRule")
+)
+
+// Config - CORS configuration of a bucket.
+type Config struct {
+	XMLName xml.Name `xml:"CORSConfiguration"`
+	Rules   []Rule   `xml:"CORSRule"`
+}
+
+// ParseConfig - parses data in given reader to Config.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate - validates the CORS configuration
+func (c Config) Validate() error {
+	if len(c.Rules) > 100 {
+		return errCORSTooManyRules
+	}
+	if len(c.Rules) == 0 {
+		return errCORSNoRule
+	}
+	for _, r := range c.Rules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Match - returns the first rule allowing a request from the given
+// origin with the given method and request headers, as rules are
+// evaluated in the order they appear in the configuration.
+func (c Config) Match(origin, method string, headers []string) (rule Rule, ok bool) {
+	for _, r := range c.Rules {
+		if _, ok = r.MatchOrigin(origin); !ok {
+			continue
+		}
+		if r.MatchMethod(method) && r.MatchHeaders(headers) {
+			return r, true
+		}
+	}
+	return rule, false
+}
@@ -0,0 +1,141 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expectedErr error
+	}{
+		{ // Valid configuration with a single rule
+			input:       `<CORSConfiguration><CORSRule><AllowedOrigin>http://*.example.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+			expectedErr: nil,
+		},
+		{ // No rules
+			input:       `<CORSConfiguration></CORSConfiguration>`,
+			expectedErr: errCORSNoRule,
+		},
+		{ // Missing allowed method
+			input:       `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin></CORSRule></CORSConfiguration>`,
+			expectedErr: errNoAllowedMethod,
+		},
+		{ // Missing allowed origin
+			input:       `<CORSConfiguration><CORSRule><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+			expectedErr: errNoAllowedOrigin,
+		},
+		{ // Origin with more than one wildcard
+			input:       `<CORSConfiguration><CORSRule><AllowedOrigin>http://*.*.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+			expectedErr: errTooManyOriginWildcards,
+		},
+		{ // Header with more than one wildcard
+			input:       `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod><AllowedHeader>x-*-*</AllowedHeader></CORSRule></CORSConfiguration>`,
+			expectedErr: errTooManyHeaderWildcards,
+		},
+		{ // Negative max age
+			input:       `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod><MaxAgeSeconds>-1</MaxAgeSeconds></CORSRule></CORSConfiguration>`,
+			expectedErr: errInvalidMaxAgeSeconds,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			_, err := ParseConfig(bytes.NewReader([]byte(tc.input)))
+			if err != tc.expectedErr {
+				t.Fatalf("expected err %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+
+	// Unsupported methods are rejected.
+	input := `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>PATCH</AllowedMethod></CORSRule></CORSConfiguration>`
+	if _, err := ParseConfig(bytes.NewReader([]byte(input))); err == nil {
+		t.Fatal("expected an error for an unsupported method")
+	}
+}
+
+func TestMarshalConfig(t *testing.T) {
+	input := `<CORSConfiguration><CORSRule><AllowedHeader>*</AllowedHeader><AllowedMethod>GET</AllowedMethod><AllowedMethod>PUT</AllowedMethod><AllowedOrigin>https://example.com</AllowedOrigin><ExposeHeader>ETag</ExposeHeader><MaxAgeSeconds>3000</MaxAgeSeconds></CORSRule></CORSConfiguration>`
+	config, err := ParseConfig(bytes.NewReader([]byte(input)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := xml.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != input {
+		t.Fatalf("expected %s, got %s", input, string(data))
+	}
+}
+
+func TestMatch(t *testing.T) {
+	input := `<CORSConfiguration>` +
+		`<CORSRule><ID>upload</ID><AllowedOrigin>https://*.example.com</AllowedOrigin><AllowedMethod>PUT</AllowedMethod><AllowedMethod>POST</AllowedMethod><AllowedHeader>Content-*</AllowedHeader><AllowedHeader>x-amz-*</AllowedHeader></CORSRule>` +
+		`<CORSRule><ID>literal</ID><AllowedOrigin>https://example.co?</AllowedOrigin><AllowedMethod>DELETE</AllowedMethod><AllowedHeader>x-?</AllowedHeader></CORSRule>` +
+		`<CORSRule><ID>read</ID><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod><AllowedMethod>HEAD</AllowedMethod></CORSRule>` +
+		`</CORSConfiguration>`
+	config, err := ParseConfig(bytes.NewReader([]byte(input)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		origin     string
+		method     string
+		headers    []string
+		expectedID string
+		expectedOk bool
+	}{
+		{"https://app.example.com", "PUT", nil, "upload", true},
+		{"https://app.example.com", "PUT", []string{"content-type", "X-Amz-Date"}, "upload", true},
+		// Header not allowed by any rule
+		{"https://app.example.com", "PUT", []string{"authorization"}, "", false},
+		// Origin not allowed for uploads
+		{"https://app.example.org", "PUT", nil, "", false},
+		// Any origin may read
+		{"https://app.example.org", "GET", nil, "read", true},
+		// Headers are not allowed for reads
+		{"https://app.example.org", "GET", []string{"range"}, "", false},
+		{"https://app.example.org", "DELETE", nil, "", false},
+		// The wildcard doesn't match the separating characters
+		{"https://example.com", "PUT", nil, "", false},
+		// '?' is matched literally
+		{"https://example.co?", "DELETE", []string{"x-?"}, "literal", true},
+		{"https://example.cox", "DELETE", nil, "", false},
+		{"https://example.co", "DELETE", nil, "", false},
+		{"https://example.co?", "DELETE", []string{"x-a"}, "", false},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			rule, ok := config.Match(tc.origin, tc.method, tc.headers)
+			if ok != tc.expectedOk {
+				t.Fatalf("expected match %v, got %v", tc.expectedOk, ok)
+			}
+			if rule.ID != tc.expectedID {
+				t.Fatalf("expected rule %s, got %s", tc.expectedID, rule.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strings"
+)
+
+var (
+	errInvalidRuleID          = Errorf("ID must be less than 255 characters")
+	errNoAllowedMethod        = Errorf("CORSRule should have at least one AllowedMethod")
+	errNoAllowedOrigin        = Errorf("CORSRule should have at least one AllowedOrigin")
+	errInvalidMaxAgeSeconds   = Errorf("MaxAgeSeconds must be a non negative integer")
+	errTooManyOriginWildcards = Errorf("AllowedOrigin can not have more than one wildcard")
+	errTooManyHeaderWildcards = Errorf("AllowedHeader can not have more than one wildcard")
+)
+
+// supportedMethods - methods which may be allowed by a CORS rule.
+var supportedMethods = map[string]struct{}{
+	http.MethodGet:    {},
+	http.MethodPut:    {},
+	http.MethodHead:   {},
+	http.MethodPost:   {},
+	http.MethodDelete: {},
+}
+
+// Rule - a rule of the CORS configuration, identifying the origins
+// and the methods allowed to access the bucket.
+type Rule struct {
+	XMLName        xml.Name `xml:"CORSRule"`
+	ID             string   `xml:"ID,omitempty"`
+	AllowedHeaders []string `xml:"AllowedHeader,omitempty"`
+	AllowedMethods []string `xml:"AllowedMethod"`
+	AllowedOrigins []string `xml:"AllowedOrigin"`
+	ExposeHeaders  []string `xml:"ExposeHeader,omitempty"`
+	MaxAgeSeconds  int      `xml:"MaxAgeSeconds,omitempty"`
+}
+
+// Validate - validates the CORS rule
+func (r Rule) Validate() error {
+	if len(r.ID) > 255 {
+		return errInvalidRuleID
+	}
+	if len(r.AllowedMethods) == 0 {
+		return errNoAllowedMethod
+	}
+	for _, method := range r.AllowedMethods {
+		if _, ok := supportedMethods[method]; !ok {
+			return Errorf("Found unsupported HTTP method in CORS config. Unsupported method is %s", method)
+		}
+	}
+	if len(r.AllowedOrigins) == 0 {
+		return errNoAllowedOrigin
+	}
+	for _, origin := range r.AllowedOrigins {
+		if strings.Count(origin, "*") > 1 {
+			return errTooManyOriginWildcards
+		}
+	}
+	for _, header := range r.AllowedHeaders {
+		if strings.Count(header, "*") > 1 {
+			return errTooManyHeaderWildcards
+		}
+	}
+	if r.MaxAgeSeconds < 0 {
+		return errInvalidMaxAgeSeconds
+	}
+	return nil
+}
+
+// matchPattern - returns true if s matches the pattern, which may have
+// a single '*' wildcard matching any characters. All other characters
+// of the pattern are matched literally.
+func matchPattern(pattern, s string) bool {
+	i := strings.Index(pattern, "*")
+	if i < 0 {
+		return pattern == s
+	}
+	prefix, suffix := pattern[:i], pattern[i+1:]
+	return len(s) >= len(prefix)+len(suffix) &&
+		strings.HasPrefix(s, prefix) && strings.HasSuffix(s, suffix)
+}
+
+// MatchOrigin - returns the allowed origin of the rule matching the
+// given origin, ok is false if the origin is not allowed.
+func (r Rule) MatchOrigin(origin string) (allowed string, ok bool) {
+	for _, pattern := range r.AllowedOrigins {
+		if matchPattern(pattern, origin) {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// MatchMethod - returns true if the given method is allowed by the rule.
+func (r Rule) MatchMethod(method string) bool {
+	for _, m := range r.AllowedMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchHeaders - returns true if all the given request headers
+// are allowed by the rule, headers are matched case-insensitively.
+func (r Rule) MatchHeaders(headers []string) bool {
+	for _, header := range headers {
+		header = strings.ToLower(header)
+		var found bool
+		for _, pattern := range r.AllowedHeaders {
+			if matchPattern(strings.ToLower(pattern), header) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
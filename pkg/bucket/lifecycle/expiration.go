@@ -22,10 +22,11 @@ import (
 )
 
 var (
-	errLifecycleInvalidDate       = Errorf("Date must be provided in ISO 8601 format")
-	errLifecycleInvalidDays       = Errorf("Days must be positive integer when used with Expiration")
-	errLifecycleInvalidExpiration = Errorf("At least one of Days or Date should be present inside Expiration")
-	errLifecycleDateNotMidnight   = Errorf("'Date' must be at midnight GMT")
+	errLifecycleInvalidDate         = Errorf("Date must be provided in ISO 8601 format")
+	errLifecycleInvalidDays         = Errorf("Days must be positive integer when used with Expiration")
+	errLifecycleInvalidExpiration   = Errorf("At least one of Days or Date should be present inside Expiration")
+	errLifecycleDateNotMidnight     = Errorf("'Date' must be at midnight GMT")
+	errLifecycleInvalidDeleteMarker = Errorf("ExpiredObjectDeleteMarker cannot be specified with Days or Date in Expiration")
 )
 
 // ExpirationDays is a type alias to unmarshal Days in Expiration
@@ -98,13 +99,22 @@ func (eDate *ExpirationDate) MarshalXML(e *xml.Encoder, startElement xml.StartEl
 
 // Expiration - expiration actions for a rule in lifecycle configuration.
 type Expiration struct {
-	XMLName xml.Name       `xml:"Expiration"`
-	Days    ExpirationDays `xml:"Days,omitempty"`
-	Date    ExpirationDate `xml:"Date,omitempty"`
+	XMLName      xml.Name       `xml:"Expiration"`
+	Days         ExpirationDays `xml:"Days,omitempty"`
+	Date         ExpirationDate `xml:"Date,omitempty"`
+	DeleteMarker bool           `xml:"ExpiredObjectDeleteMarker,omitempty"`
 }
 
 // Validate - validates the "Expiration" element
 func (e Expiration) Validate() error {
+	// Expired delete markers are removed regardless of their age
+	if e.DeleteMarker {
+		if !e.IsNull() {
+			return errLifecycleInvalidDeleteMarker
+		}
+		return nil
+	}
+
 	// Neither expiration days or date is specified
 	if e.IsDaysNull() && e.IsDateNull() {
 		return errLifecycleInvalidExpiration
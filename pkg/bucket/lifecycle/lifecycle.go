@@ -38,6 +38,12 @@ const (
 	NoneAction Action = iota
 	// DeleteAction means the object needs to be removed after evaluting lifecycle rules
 	DeleteAction
+	// DeleteVersionAction means a noncurrent version or an expired delete marker
+	// of the object needs to be permanently removed after evaluting lifecycle rules
+	DeleteVersionAction
+	// TransitionAction means the object data needs to be moved to the remote tier
+	// named by the transition storage class after evaluting lifecycle rules
+	TransitionAction
 )
 
 // Lifecycle - Configuration for bucket lifecycle.
@@ -96,11 +102,11 @@ func (lc Lifecycle) Validate() error {
 	return nil
 }
 
-// FilterRuleActions returns the expiration and transition from the object name
-// after evaluating all rules.
-func (lc Lifecycle) FilterRuleActions(objName, objTags string) (Expiration, Transition) {
+// filterRule returns the first enabled rule matching the object name
+// and tags, ok is false when no rule applies to the object.
+func (lc Lifecycle) filterRule(objName, objTags string) (rule Rule, ok bool) {
 	if objName == "" {
-		return Expiration{}, Transition{}
+		return rule, false
 	}
 	for _, rule := range lc.Rules {
 		if rule.Status == Disabled {
@@ -110,14 +116,24 @@ func (lc Lifecycle) FilterRuleActions(objName, objTags string) (Expiration, Tran
 		if strings.HasPrefix(objName, rule.Prefix()) {
 			if tags != "" {
 				if strings.Contains(objTags, tags) {
-					return rule.Expiration, Transition{}
+					return rule, true
 				}
 			} else {
-				return rule.Expiration, Transition{}
+				return rule, true
 			}
 		}
 	}
-	return Expiration{}, Transition{}
+	return rule, false
+}
+
+// FilterRuleActions returns the expiration and transition from the object name
+// after evaluating all rules.
+func (lc Lifecycle) FilterRuleActions(objName, objTags string) (Expiration, Transition) {
+	rule, ok := lc.filterRule(objName, objTags)
+	if !ok {
+		return Expiration{}, Transition{}
+	}
+	return rule.Expiration, rule.Transition
 }
 
 // ComputeAction returns the action to perform by evaluating all lifecycle rules
@@ -127,7 +143,7 @@ func (lc Lifecycle) ComputeAction(objName, objTags string, modTime time.Time) Ac
 	if modTime.IsZero() {
 		return action
 	}
-	exp, _ := lc.FilterRuleActions(objName, objTags)
+	exp, trn := lc.FilterRuleActions(objName, objTags)
 	if !exp.IsDateNull() {
 		if time.Now().After(exp.Date.Time) {
 			action = DeleteAction
@@ -138,5 +154,58 @@ func (lc Lifecycle) ComputeAction(objName, objTags string, modTime time.Time) Ac
 			action = DeleteAction
 		}
 	}
+	// Expiration takes precedence, there is no point in moving
+	// the data of an object which is about to be removed.
+	if action == NoneAction && trn.IsDue(modTime) {
+		action = TransitionAction
+	}
 	return action
 }
+
+// ObjectOpts provides information about a version of an object
+// to deduce the lifecycle action to perform on it.
+type ObjectOpts struct {
+	Name     string
+	UserTags string
+	ModTime  time.Time
+	// IsLatest is true for the current version of the object.
+	IsLatest bool
+	// DeleteMarker is true when the version is a delete marker.
+	DeleteMarker bool
+	// NumVersions is the number of versions of the object,
+	// delete markers included.
+	NumVersions int
+	// SuccessorModTime is the modification time of the next
+	// newer version, i.e the time this version became noncurrent.
+	SuccessorModTime time.Time
+}
+
+// ComputeVersionAction returns the action to perform by evaluating all
+// lifecycle rules against a version of an object in a versioned bucket.
+// Current versions are expired as per ComputeAction, noncurrent versions
+// and delete markers without any other version are permanently removed.
+func (lc Lifecycle) ComputeVersionAction(obj ObjectOpts) Action {
+	if obj.IsLatest {
+		if !obj.DeleteMarker {
+			return lc.ComputeAction(obj.Name, obj.UserTags, obj.ModTime)
+		}
+		rule, ok := lc.filterRule(obj.Name, obj.UserTags)
+		if ok && rule.Expiration.DeleteMarker && obj.NumVersions == 1 {
+			return DeleteVersionAction
+		}
+		return NoneAction
+	}
+
+	if obj.SuccessorModTime.IsZero() {
+		return NoneAction
+	}
+	rule, ok := lc.filterRule(obj.Name, obj.UserTags)
+	if !ok || rule.NoncurrentVersionExpiration.IsDaysNull() {
+		return NoneAction
+	}
+	days := time.Duration(rule.NoncurrentVersionExpiration.NoncurrentDays) * 24 * time.Hour
+	if time.Now().After(obj.SuccessorModTime.Add(days)) {
+		return DeleteVersionAction
+	}
+	return NoneAction
+}
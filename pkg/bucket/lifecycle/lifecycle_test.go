@@ -260,6 +260,34 @@ func TestComputeActions(t *testing.T) {
 			objectModTime:  time.Now().UTC().Add(-24 * time.Hour), // Created 1 day ago
 			expectedAction: NoneAction,
 		},
+		// Too early to transition (test Days)
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><Transition><Days>30</Days><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectModTime:  time.Now().UTC().Add(-10 * 24 * time.Hour), // Created 10 days ago
+			expectedAction: NoneAction,
+		},
+		// Should transition (test Days)
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><Transition><Days>30</Days><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectModTime:  time.Now().UTC().Add(-31 * 24 * time.Hour), // Created 31 days ago
+			expectedAction: TransitionAction,
+		},
+		// Should transition (test Date)
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><Transition><Date>` + time.Now().Truncate(24*time.Hour).UTC().Add(-24*time.Hour).Format(time.RFC3339) + `</Date><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectModTime:  time.Now().UTC().Add(-24 * time.Hour), // Created 1 day ago
+			expectedAction: TransitionAction,
+		},
+		// Should remove rather than transition when both are due
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>60</Days></Expiration><Transition><Days>30</Days><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectModTime:  time.Now().UTC().Add(-61 * 24 * time.Hour), // Created 61 days ago
+			expectedAction: DeleteAction,
+		},
 	}
 
 	for i, tc := range testCases {
@@ -275,3 +303,118 @@ func TestComputeActions(t *testing.T) {
 
 	}
 }
+
+func TestComputeVersionActions(t *testing.T) {
+	const noncurrentConfig = `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>5</Days></Expiration><NoncurrentVersionExpiration><NoncurrentDays>3</NoncurrentDays></NoncurrentVersionExpiration></Rule></LifecycleConfiguration>`
+	const deleteMarkerConfig = `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><Expiration><ExpiredObjectDeleteMarker>true</ExpiredObjectDeleteMarker></Expiration></Rule></LifecycleConfiguration>`
+
+	testCases := []struct {
+		inputConfig    string
+		object         ObjectOpts
+		expectedAction Action
+	}{
+		// Current version should be expired (test Days)
+		{
+			inputConfig: noncurrentConfig,
+			object: ObjectOpts{
+				Name:        "foodir/fooobject",
+				ModTime:     time.Now().UTC().Add(-6 * 24 * time.Hour), // Created 6 days ago
+				IsLatest:    true,
+				NumVersions: 2,
+			},
+			expectedAction: DeleteAction,
+		},
+		// Noncurrent version too early to remove
+		{
+			inputConfig: noncurrentConfig,
+			object: ObjectOpts{
+				Name:             "foodir/fooobject",
+				ModTime:          time.Now().UTC().Add(-10 * 24 * time.Hour), // Created 10 days ago
+				NumVersions:      2,
+				SuccessorModTime: time.Now().UTC().Add(-2 * 24 * time.Hour), // Noncurrent since 2 days
+			},
+			expectedAction: NoneAction,
+		},
+		// Noncurrent version should be removed
+		{
+			inputConfig: noncurrentConfig,
+			object: ObjectOpts{
+				Name:             "foodir/fooobject",
+				ModTime:          time.Now().UTC().Add(-10 * 24 * time.Hour), // Created 10 days ago
+				NumVersions:      2,
+				SuccessorModTime: time.Now().UTC().Add(-4 * 24 * time.Hour), // Noncurrent since 4 days
+			},
+			expectedAction: DeleteVersionAction,
+		},
+		// Noncurrent version, prefix not matched
+		{
+			inputConfig: noncurrentConfig,
+			object: ObjectOpts{
+				Name:             "foxdir/fooobject",
+				ModTime:          time.Now().UTC().Add(-10 * 24 * time.Hour), // Created 10 days ago
+				NumVersions:      2,
+				SuccessorModTime: time.Now().UTC().Add(-4 * 24 * time.Hour), // Noncurrent since 4 days
+			},
+			expectedAction: NoneAction,
+		},
+		// Noncurrent version without NoncurrentVersionExpiration
+		{
+			inputConfig: deleteMarkerConfig,
+			object: ObjectOpts{
+				Name:             "foodir/fooobject",
+				ModTime:          time.Now().UTC().Add(-10 * 24 * time.Hour), // Created 10 days ago
+				NumVersions:      2,
+				SuccessorModTime: time.Now().UTC().Add(-4 * 24 * time.Hour), // Noncurrent since 4 days
+			},
+			expectedAction: NoneAction,
+		},
+		// Expired delete marker should be removed
+		{
+			inputConfig: deleteMarkerConfig,
+			object: ObjectOpts{
+				Name:         "foodir/fooobject",
+				ModTime:      time.Now().UTC().Add(-24 * time.Hour), // Created 1 day ago
+				IsLatest:     true,
+				DeleteMarker: true,
+				NumVersions:  1,
+			},
+			expectedAction: DeleteVersionAction,
+		},
+		// Delete marker with noncurrent versions should not be removed
+		{
+			inputConfig: deleteMarkerConfig,
+			object: ObjectOpts{
+				Name:         "foodir/fooobject",
+				ModTime:      time.Now().UTC().Add(-24 * time.Hour), // Created 1 day ago
+				IsLatest:     true,
+				DeleteMarker: true,
+				NumVersions:  2,
+			},
+			expectedAction: NoneAction,
+		},
+		// Expired delete marker without ExpiredObjectDeleteMarker
+		{
+			inputConfig: noncurrentConfig,
+			object: ObjectOpts{
+				Name:         "foodir/fooobject",
+				ModTime:      time.Now().UTC().Add(-24 * time.Hour), // Created 1 day ago
+				IsLatest:     true,
+				DeleteMarker: true,
+				NumVersions:  1,
+			},
+			expectedAction: NoneAction,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			lc, err := ParseLifecycleConfig(bytes.NewReader([]byte(tc.inputConfig)))
+			if err != nil {
+				t.Fatalf("%d: Got unexpected error: %v", i+1, err)
+			}
+			if resultAction := lc.ComputeVersionAction(tc.object); resultAction != tc.expectedAction {
+				t.Fatalf("%d: Expected action: `%v`, got: `%v`", i+1, tc.expectedAction, resultAction)
+			}
+		})
+	}
+}
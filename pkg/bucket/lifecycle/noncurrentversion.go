@@ -20,10 +20,59 @@ import (
 	"encoding/xml"
 )
 
-// NoncurrentVersionExpiration - an action for lifecycle configuration rule.
+var (
+	errLifecycleInvalidNoncurrentDays         = Errorf("NoncurrentDays must be positive integer when used with NoncurrentVersionExpiration")
+	errNoncurrentVersionTransitionUnsupported = Errorf("Specifying <NoncurrentVersionTransition></NoncurrentVersionTransition> is not supported")
+)
+
+// NoncurrentDays is a type alias to unmarshal NoncurrentDays in
+// NoncurrentVersionExpiration
+type NoncurrentDays int
+
+// UnmarshalXML parses number of days from NoncurrentVersionExpiration
+// and validates if greater than zero
+func (nDays *NoncurrentDays) UnmarshalXML(d *xml.Decoder, startElement xml.StartElement) error {
+	var numDays int
+	err := d.DecodeElement(&numDays, &startElement)
+	if err != nil {
+		return err
+	}
+	if numDays <= 0 {
+		return errLifecycleInvalidNoncurrentDays
+	}
+	*nDays = NoncurrentDays(numDays)
+	return nil
+}
+
+// NoncurrentVersionExpiration - an action for lifecycle configuration rule,
+// noncurrent versions of objects are removed NoncurrentDays after they
+// became noncurrent.
 type NoncurrentVersionExpiration struct {
-	XMLName        xml.Name `xml:"NoncurrentVersionExpiration"`
-	NoncurrentDays int      `xml:"NoncurrentDays,omitempty"`
+	XMLName        xml.Name       `xml:"NoncurrentVersionExpiration"`
+	NoncurrentDays NoncurrentDays `xml:"NoncurrentDays,omitempty"`
+}
+
+// Validate - validates the "NoncurrentVersionExpiration" element
+func (n NoncurrentVersionExpiration) Validate() error {
+	if n.IsDaysNull() {
+		return errLifecycleInvalidNoncurrentDays
+	}
+	return nil
+}
+
+// IsDaysNull returns true if days field is null
+func (n NoncurrentVersionExpiration) IsDaysNull() bool {
+	return n.NoncurrentDays == NoncurrentDays(0)
+}
+
+// MarshalXML is extended to leave out empty
+// <NoncurrentVersionExpiration></NoncurrentVersionExpiration> tags
+func (n NoncurrentVersionExpiration) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if n.IsDaysNull() {
+		return nil
+	}
+	type noncurrentVersionExpirationWrapper NoncurrentVersionExpiration
+	return e.EncodeElement(noncurrentVersionExpirationWrapper(n), start)
 }
 
 // NoncurrentVersionTransition - an action for lifecycle configuration rule.
@@ -32,18 +81,6 @@ type NoncurrentVersionTransition struct {
 	StorageClass   string `xml:"StorageClass"`
 }
 
-var (
-	errNoncurrentVersionExpirationUnsupported = Errorf("Specifying <NoncurrentVersionExpiration></NoncurrentVersionExpiration> is not supported")
-	errNoncurrentVersionTransitionUnsupported = Errorf("Specifying <NoncurrentVersionTransition></NoncurrentVersionTransition> is not supported")
-)
-
-// UnmarshalXML is extended to indicate lack of support for
-// NoncurrentVersionExpiration xml tag in object lifecycle
-// configuration
-func (n NoncurrentVersionExpiration) UnmarshalXML(d *xml.Decoder, startElement xml.StartElement) error {
-	return errNoncurrentVersionExpirationUnsupported
-}
-
 // UnmarshalXML is extended to indicate lack of support for
 // NoncurrentVersionTransition xml tag in object lifecycle
 // configuration
@@ -56,9 +93,3 @@ func (n NoncurrentVersionTransition) UnmarshalXML(d *xml.Decoder, startElement x
 func (n NoncurrentVersionTransition) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	return nil
 }
-
-// MarshalXML is extended to leave out
-// <NoncurrentVersionExpiration></NoncurrentVersionExpiration> tags
-func (n NoncurrentVersionExpiration) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	return nil
-}
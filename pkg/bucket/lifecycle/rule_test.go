@@ -25,8 +25,7 @@ import (
 // TestUnsupportedRules checks if Rule xml with unsuported tags return
 // appropriate errors on parsing
 func TestUnsupportedRules(t *testing.T) {
-	// NoncurrentVersionTransition, NoncurrentVersionExpiration
-	// and Transition tags aren't supported
+	// NoncurrentVersionTransition tags aren't supported
 	unsupportedTestCases := []struct {
 		inputXML    string
 		expectedErr error
@@ -37,19 +36,6 @@ func TestUnsupportedRules(t *testing.T) {
 	                    </Rule>`,
 			expectedErr: errNoncurrentVersionTransitionUnsupported,
 		},
-		{ // Rule with unsupported NoncurrentVersionExpiration
-
-			inputXML: ` <Rule>
-	                     <NoncurrentVersionExpiration></NoncurrentVersionExpiration>
-	                    </Rule>`,
-			expectedErr: errNoncurrentVersionExpirationUnsupported,
-		},
-		{ // Rule with unsupported Transition action
-			inputXML: ` <Rule>
-	                     <Transition></Transition>
-	                    </Rule>`,
-			expectedErr: errTransitionUnsupported,
-		},
 	}
 
 	for i, tc := range unsupportedTestCases {
@@ -94,6 +80,69 @@ func TestInvalidRules(t *testing.T) {
 	                    </Rule>`,
 			expectedErr: errInvalidRuleStatus,
 		},
+		{ // Rule with NoncurrentVersionExpiration without NoncurrentDays
+			inputXML: ` <Rule>
+                              <Status>Enabled</Status>
+                              <NoncurrentVersionExpiration></NoncurrentVersionExpiration>
+	                    </Rule>`,
+			expectedErr: errLifecycleInvalidNoncurrentDays,
+		},
+		{ // Rule with ExpiredObjectDeleteMarker along with Days
+			inputXML: ` <Rule>
+                              <Status>Enabled</Status>
+                              <Expiration>
+                                <Days>3</Days>
+                                <ExpiredObjectDeleteMarker>true</ExpiredObjectDeleteMarker>
+                              </Expiration>
+	                    </Rule>`,
+			expectedErr: errLifecycleInvalidDeleteMarker,
+		},
+		{ // Rule with NoncurrentVersionExpiration only
+			inputXML: ` <Rule>
+                              <Status>Enabled</Status>
+                              <NoncurrentVersionExpiration>
+                                <NoncurrentDays>3</NoncurrentDays>
+                              </NoncurrentVersionExpiration>
+	                    </Rule>`,
+			expectedErr: nil,
+		},
+		{ // Rule with Transition without Days or Date
+			inputXML: ` <Rule>
+                              <Status>Enabled</Status>
+                              <Transition>
+                                <StorageClass>WARM</StorageClass>
+                              </Transition>
+	                    </Rule>`,
+			expectedErr: errTransitionInvalid,
+		},
+		{ // Rule with Transition without StorageClass
+			inputXML: ` <Rule>
+                              <Status>Enabled</Status>
+                              <Transition>
+                                <Days>30</Days>
+                              </Transition>
+	                    </Rule>`,
+			expectedErr: errTransitionNoStorageClass,
+		},
+		{ // Rule with Transition only
+			inputXML: ` <Rule>
+                              <Status>Enabled</Status>
+                              <Transition>
+                                <Days>30</Days>
+                                <StorageClass>WARM</StorageClass>
+                              </Transition>
+	                    </Rule>`,
+			expectedErr: nil,
+		},
+		{ // Rule with ExpiredObjectDeleteMarker only
+			inputXML: ` <Rule>
+                              <Status>Enabled</Status>
+                              <Expiration>
+                                <ExpiredObjectDeleteMarker>true</ExpiredObjectDeleteMarker>
+                              </Expiration>
+	                    </Rule>`,
+			expectedErr: nil,
+		},
 	}
 
 	for i, tc := range invalidTestCases {
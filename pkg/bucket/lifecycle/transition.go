@@ -18,25 +18,137 @@ package lifecycle
 
 import (
 	"encoding/xml"
+	"time"
 )
 
-// Transition - transition actions for a rule in lifecycle configuration.
-type Transition struct {
-	XMLName      xml.Name `xml:"Transition"`
-	Days         int      `xml:"Days,omitempty"`
-	Date         string   `xml:"Date,omitempty"`
-	StorageClass string   `xml:"StorageClass"`
+var (
+	errTransitionInvalidDays     = Errorf("Days must be positive integer when used with Transition")
+	errTransitionInvalid         = Errorf("Exactly one of Days or Date should be present inside Transition")
+	errTransitionDateNotMidnight = Errorf("'Date' must be at midnight GMT")
+	errTransitionNoStorageClass  = Errorf("StorageClass must be specified inside Transition")
+)
+
+// TransitionDays is a type alias to unmarshal Days in Transition
+type TransitionDays int
+
+// UnmarshalXML parses number of days from Transition and validates if
+// greater than zero
+func (tDays *TransitionDays) UnmarshalXML(d *xml.Decoder, startElement xml.StartElement) error {
+	var numDays int
+	err := d.DecodeElement(&numDays, &startElement)
+	if err != nil {
+		return err
+	}
+	if numDays <= 0 {
+		return errTransitionInvalidDays
+	}
+	*tDays = TransitionDays(numDays)
+	return nil
 }
 
-var errTransitionUnsupported = Errorf("Specifying <Transition></Transition> tag is not supported")
+// MarshalXML encodes number of days to transition if it is non-zero and
+// encodes empty string otherwise
+func (tDays *TransitionDays) MarshalXML(e *xml.Encoder, startElement xml.StartElement) error {
+	if *tDays == TransitionDays(0) {
+		return nil
+	}
+	return e.EncodeElement(int(*tDays), startElement)
+}
 
-// UnmarshalXML is extended to indicate lack of support for Transition
-// xml tag in object lifecycle configuration
-func (t Transition) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
-	return errTransitionUnsupported
+// TransitionDate is a embedded type containing time.Time to unmarshal
+// Date in Transition
+type TransitionDate struct {
+	time.Time
 }
 
-// MarshalXML is extended to leave out <Transition></Transition> tags
+// UnmarshalXML parses date from Transition and validates date format
+func (tDate *TransitionDate) UnmarshalXML(d *xml.Decoder, startElement xml.StartElement) error {
+	var dateStr string
+	err := d.DecodeElement(&dateStr, &startElement)
+	if err != nil {
+		return err
+	}
+	// While AWS documentation mentions that the date specified
+	// must be present in ISO 8601 format, in reality they allow
+	// users to provide RFC 3339 compliant dates.
+	trnDate, err := time.Parse(time.RFC3339, dateStr)
+	if err != nil {
+		return errLifecycleInvalidDate
+	}
+	// Allow only date timestamp specifying midnight GMT
+	hr, min, sec := trnDate.Clock()
+	nsec := trnDate.Nanosecond()
+	loc := trnDate.Location()
+	if !(hr == 0 && min == 0 && sec == 0 && nsec == 0 && loc.String() == time.UTC.String()) {
+		return errTransitionDateNotMidnight
+	}
+
+	*tDate = TransitionDate{trnDate}
+	return nil
+}
+
+// MarshalXML encodes transition date if it is non-zero and encodes
+// empty string otherwise
+func (tDate *TransitionDate) MarshalXML(e *xml.Encoder, startElement xml.StartElement) error {
+	if *tDate == (TransitionDate{time.Time{}}) {
+		return nil
+	}
+	return e.EncodeElement(tDate.Format(time.RFC3339), startElement)
+}
+
+// Transition - transition actions for a rule in lifecycle configuration,
+// the storage class names the remote tier objects are moved to.
+type Transition struct {
+	XMLName      xml.Name       `xml:"Transition"`
+	Days         TransitionDays `xml:"Days,omitempty"`
+	Date         TransitionDate `xml:"Date,omitempty"`
+	StorageClass string         `xml:"StorageClass"`
+}
+
+// MarshalXML is extended to leave out empty <Transition></Transition> tags
 func (t Transition) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if t.IsNull() && t.StorageClass == "" {
+		return nil
+	}
+	type transitionWrapper Transition
+	return e.EncodeElement(transitionWrapper(t), start)
+}
+
+// Validate - validates the "Transition" element
+func (t Transition) Validate() error {
+	// Exactly one of transition days or date is specified
+	if t.IsDaysNull() == t.IsDateNull() {
+		return errTransitionInvalid
+	}
+	if t.StorageClass == "" {
+		return errTransitionNoStorageClass
+	}
 	return nil
 }
+
+// IsDaysNull returns true if days field is null
+func (t Transition) IsDaysNull() bool {
+	return t.Days == TransitionDays(0)
+}
+
+// IsDateNull returns true if date field is null
+func (t Transition) IsDateNull() bool {
+	return t.Date == TransitionDate{time.Time{}}
+}
+
+// IsNull returns true if both date and days fields are null
+func (t Transition) IsNull() bool {
+	return t.IsDaysNull() && t.IsDateNull()
+}
+
+// IsDue returns true if an object last modified at modTime is due for
+// transition to the storage class as of now.
+func (t Transition) IsDue(modTime time.Time) bool {
+	if !t.IsDateNull() {
+		return time.Now().After(t.Date.Time)
+	}
+	if !t.IsDaysNull() {
+		return time.Now().After(modTime.Add(time.Duration(t.Days) * 24 * time.Hour))
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logging
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+var (
+	errLoggingNoTargetBucket = Errorf("TargetBucket must be specified when logging is enabled")
+)
+
+// LoggingEnabled - the bucket and the prefix under which server
+// access logs of a bucket are stored.
+type LoggingEnabled struct {
+	TargetBucket string `xml:"TargetBucket"`
+	TargetPrefix string `xml:"TargetPrefix"`
+}
+
+// Config - logging status of a bucket, server access logging
+// is disabled when LoggingEnabled is not present.
+type Config struct {
+	XMLName        xml.Name        `xml:"BucketLoggingStatus"`
+	LoggingEnabled *LoggingEnabled `xml:"LoggingEnabled,omitempty"`
+}
+
+// ParseConfig - parses data in given reader to Config.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate - validates the logging configuration
+func (c Config) Validate() error {
+	if c.LoggingEnabled != nil && c.LoggingEnabled.TargetBucket == "" {
+		return errLoggingNoTargetBucket
+	}
+	return nil
+}
+
+// Enabled - returns true if server access logging is enabled.
+func (c Config) Enabled() bool {
+	return c.LoggingEnabled != nil
+}
@@ -0,0 +1,82 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logging
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input           string
+		expectedErr     error
+		expectedEnabled bool
+	}{
+		{ // Logging enabled with a target bucket and a prefix
+			input:           `<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix>mybucket/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`,
+			expectedErr:     nil,
+			expectedEnabled: true,
+		},
+		{ // Logging enabled without a prefix
+			input:           `<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket></LoggingEnabled></BucketLoggingStatus>`,
+			expectedErr:     nil,
+			expectedEnabled: true,
+		},
+		{ // Empty logging status disables logging
+			input:           `<BucketLoggingStatus xmlns="http://doc.s3.amazonaws.com/2006-03-01" />`,
+			expectedErr:     nil,
+			expectedEnabled: false,
+		},
+		{ // Missing target bucket
+			input:       `<BucketLoggingStatus><LoggingEnabled><TargetPrefix>mybucket/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`,
+			expectedErr: errLoggingNoTargetBucket,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			config, err := ParseConfig(bytes.NewReader([]byte(tc.input)))
+			if err != tc.expectedErr {
+				t.Fatalf("expected err %v, got %v", tc.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if config.Enabled() != tc.expectedEnabled {
+				t.Fatalf("expected enabled %v, got %v", tc.expectedEnabled, config.Enabled())
+			}
+		})
+	}
+}
+
+func TestMarshalConfig(t *testing.T) {
+	input := `<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix>mybucket/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`
+	config, err := ParseConfig(bytes.NewReader([]byte(input)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := xml.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != input {
+		t.Fatalf("expected %s, got %s", input, string(data))
+	}
+}
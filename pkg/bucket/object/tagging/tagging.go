@@ -27,16 +27,18 @@ import (
 // Ref: https://docs.aws.amazon.com/AmazonS3/latest/dev/object-tagging.html
 const (
 	maxTags           = 10
+	maxBucketTags     = 50
 	maxTagKeyLength   = 128
 	maxTagValueLength = 256
 )
 
 // errors returned by tagging package
 var (
-	ErrTooManyTags     = Errorf("Object tags cannot be greater than 10", "BadRequest")
-	ErrInvalidTagKey   = Errorf("The TagKey you have provided is invalid", "InvalidTag")
-	ErrInvalidTagValue = Errorf("The TagValue you have provided is invalid", "InvalidTag")
-	ErrInvalidTag      = Errorf("Cannot provide multiple Tags with the same key", "InvalidTag")
+	ErrTooManyTags       = Errorf("Object tags cannot be greater than 10", "BadRequest")
+	ErrTooManyBucketTags = Errorf("Bucket tags cannot be greater than 50", "BadRequest")
+	ErrInvalidTagKey     = Errorf("The TagKey you have provided is invalid", "InvalidTag")
+	ErrInvalidTagValue   = Errorf("The TagValue you have provided is invalid", "InvalidTag")
+	ErrInvalidTag        = Errorf("Cannot provide multiple Tags with the same key", "InvalidTag")
 )
 
 // Tagging - object tagging interface
@@ -51,6 +53,19 @@ func (t Tagging) Validate() error {
 	if len(t.TagSet.Tags) > maxTags {
 		return ErrTooManyTags
 	}
+	return t.validateTags()
+}
+
+// ValidateBucket - validates the tagging configuration of a bucket
+func (t Tagging) ValidateBucket() error {
+	// Bucket tagging can't have more than 50 tags
+	if len(t.TagSet.Tags) > maxBucketTags {
+		return ErrTooManyBucketTags
+	}
+	return t.validateTags()
+}
+
+func (t Tagging) validateTags() error {
 	// Validate all the rules in the tagging config
 	for _, ts := range t.TagSet.Tags {
 		if err := ts.Validate(); err != nil {
@@ -110,3 +125,17 @@ func ParseTagging(reader io.Reader) (*Tagging, error) {
 	}
 	return &t, nil
 }
+
+// ParseBucketTagging - parses incoming xml data in given reader
+// into Tagging interface. After parsing, also validates the
+// parsed fields based on S3 API constraints for bucket tags.
+func ParseBucketTagging(reader io.Reader) (*Tagging, error) {
+	var t Tagging
+	if err := xml.NewDecoder(reader).Decode(&t); err != nil {
+		return nil, err
+	}
+	if err := t.ValidateBucket(); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
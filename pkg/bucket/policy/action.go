@@ -118,6 +118,38 @@ const (
 	PutBucketEncryptionAction = "s3:PutEncryptionConfiguration"
 	// GetBucketEncryptionAction - GetBucketEncryption REST API action
 	GetBucketEncryptionAction = "s3:GetEncryptionConfiguration"
+
+	// PutBucketVersioningAction - PutBucketVersioning REST API action
+	PutBucketVersioningAction = "s3:PutBucketVersioning"
+	// GetBucketVersioningAction - GetBucketVersioning REST API action
+	GetBucketVersioningAction = "s3:GetBucketVersioning"
+
+	// PutReplicationConfigurationAction - PutBucketReplication REST API action
+	PutReplicationConfigurationAction = "s3:PutReplicationConfiguration"
+	// GetReplicationConfigurationAction - GetBucketReplication REST API action
+	GetReplicationConfigurationAction = "s3:GetReplicationConfiguration"
+
+	// PutBucketTaggingAction - PutBucketTagging, DeleteBucketTagging REST API action
+	PutBucketTaggingAction = "s3:PutBucketTagging"
+	// GetBucketTaggingAction - GetBucketTagging REST API action
+	GetBucketTaggingAction = "s3:GetBucketTagging"
+
+	// PutBucketCORSAction - PutBucketCors, DeleteBucketCors REST API action
+	PutBucketCORSAction = "s3:PutBucketCORS"
+	// GetBucketCORSAction - GetBucketCors REST API action
+	GetBucketCORSAction = "s3:GetBucketCORS"
+
+	// PutBucketWebsiteAction - PutBucketWebsite REST API action
+	PutBucketWebsiteAction = "s3:PutBucketWebsite"
+	// GetBucketWebsiteAction - GetBucketWebsite REST API action
+	GetBucketWebsiteAction = "s3:GetBucketWebsite"
+	// DeleteBucketWebsiteAction - DeleteBucketWebsite REST API action
+	DeleteBucketWebsiteAction = "s3:DeleteBucketWebsite"
+
+	// PutBucketLoggingAction - PutBucketLogging REST API action
+	PutBucketLoggingAction = "s3:PutBucketLogging"
+	// GetBucketLoggingAction - GetBucketLogging REST API action
+	GetBucketLoggingAction = "s3:GetBucketLogging"
 )
 
 // List of all supported object actions.
@@ -179,6 +211,19 @@ var supportedActions = map[Action]struct{}{
 	DeleteObjectTaggingAction:              {},
 	PutBucketEncryptionAction:              {},
 	GetBucketEncryptionAction:              {},
+	PutBucketVersioningAction:              {},
+	GetBucketVersioningAction:              {},
+	PutReplicationConfigurationAction:      {},
+	GetReplicationConfigurationAction:      {},
+	PutBucketTaggingAction:                 {},
+	GetBucketTaggingAction:                 {},
+	PutBucketCORSAction:                    {},
+	GetBucketCORSAction:                    {},
+	PutBucketWebsiteAction:                 {},
+	GetBucketWebsiteAction:                 {},
+	DeleteBucketWebsiteAction:              {},
+	PutBucketLoggingAction:                 {},
+	GetBucketLoggingAction:                 {},
 }
 
 // IsValid - checks if action is valid or not.
@@ -239,6 +284,7 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 			condition.S3XAmzServerSideEncryption,
 			condition.S3XAmzServerSideEncryptionCustomerAlgorithm,
 			condition.S3XAmzStorageClass,
+			condition.S3ExistingObjectTag,
 		}, condition.CommonKeys...)...),
 
 	HeadBucketAction: condition.NewKeySet(condition.CommonKeys...),
@@ -263,6 +309,8 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 			condition.S3XAmzServerSideEncryptionCustomerAlgorithm,
 			condition.S3XAmzMetadataDirective,
 			condition.S3XAmzStorageClass,
+			condition.S3RequestObjectTag,
+			condition.S3RequestObjectTagKeys,
 		}, condition.CommonKeys...)...),
 	PutObjectRetentionAction:               condition.NewKeySet(condition.CommonKeys...),
 	GetObjectRetentionAction:               condition.NewKeySet(condition.CommonKeys...),
@@ -272,7 +320,18 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 	GetObjectLegalHoldAction:               condition.NewKeySet(condition.CommonKeys...),
 	GetBucketObjectLockConfigurationAction: condition.NewKeySet(condition.CommonKeys...),
 	PutBucketObjectLockConfigurationAction: condition.NewKeySet(condition.CommonKeys...),
-	PutObjectTaggingAction:                 condition.NewKeySet(condition.CommonKeys...),
-	GetObjectTaggingAction:                 condition.NewKeySet(condition.CommonKeys...),
-	DeleteObjectTaggingAction:              condition.NewKeySet(condition.CommonKeys...),
+	PutObjectTaggingAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+			condition.S3RequestObjectTag,
+			condition.S3RequestObjectTagKeys,
+		}, condition.CommonKeys...)...),
+	GetObjectTaggingAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
+	DeleteObjectTaggingAction: condition.NewKeySet(
+		append([]condition.Key{
+			condition.S3ExistingObjectTag,
+		}, condition.CommonKeys...)...),
 }
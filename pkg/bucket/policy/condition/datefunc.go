@@ -0,0 +1,187 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package condition
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// parseDate - parses a date in ISO 8601 format or as seconds since the
+// epoch, as used by the aws:CurrentTime and aws:EpochTime keys.
+func parseDate(s string) (time.Time, error) {
+	if epoch, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(epoch, 0).UTC(), nil
+	}
+
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date '%v'", s)
+}
+
+// dateFunc - Date condition function. It compares the date in given values
+// map for Key with the condition value by the operator of the condition name,
+// for example DateLessThan.
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_elements_condition_operators.html#Conditions_Date
+type dateFunc struct {
+	n     name
+	k     Key
+	value Value
+	date  time.Time
+}
+
+// evaluate() - evaluates to check whether the date in given values
+// satisfies the condition.
+func (f dateFunc) evaluate(values map[string][]string) bool {
+	requestValue, ok := values[http.CanonicalHeaderKey(f.k.Name())]
+	if !ok {
+		requestValue = values[f.k.Name()]
+	}
+
+	for _, s := range requestValue {
+		date, err := parseDate(s)
+		if err != nil {
+			continue
+		}
+
+		cmp := 0
+		if date.Before(f.date) {
+			cmp = -1
+		} else if date.After(f.date) {
+			cmp = 1
+		}
+
+		if compare(f.n, cmp) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// key() - returns condition key which is used by this condition function.
+func (f dateFunc) key() Key {
+	return f.k
+}
+
+// name() - returns the date condition name.
+func (f dateFunc) name() name {
+	return f.n
+}
+
+func (f dateFunc) String() string {
+	return fmt.Sprintf("%v:%v:%v", f.n, f.k, f.value)
+}
+
+// toMap - returns map representation of this function.
+func (f dateFunc) toMap() map[Key]ValueSet {
+	if !f.k.IsValid() {
+		return nil
+	}
+
+	return map[Key]ValueSet{
+		f.k: NewValueSet(f.value),
+	}
+}
+
+func newDateFunc(n name, key Key, values ValueSet) (Function, error) {
+	if len(values) != 1 {
+		return nil, fmt.Errorf("only one value is allowed for %v condition", n)
+	}
+
+	var value Value
+	var date time.Time
+	for v := range values {
+		value = v
+		switch v.GetType() {
+		case reflect.Int:
+			i, _ := v.GetInt()
+			date = time.Unix(int64(i), 0).UTC()
+		case reflect.String:
+			var err error
+			s, _ := v.GetString()
+			if date, err = parseDate(s); err != nil {
+				return nil, fmt.Errorf("value must be a date for %v condition", n)
+			}
+		default:
+			return nil, fmt.Errorf("value must be a date for %v condition", n)
+		}
+	}
+
+	return &dateFunc{n, key, value, date}, nil
+}
+
+func newDateEqualsFunc(key Key, values ValueSet) (Function, error) {
+	return newDateFunc(dateEquals, key, values)
+}
+
+func newDateNotEqualsFunc(key Key, values ValueSet) (Function, error) {
+	return newDateFunc(dateNotEquals, key, values)
+}
+
+func newDateLessThanFunc(key Key, values ValueSet) (Function, error) {
+	return newDateFunc(dateLessThan, key, values)
+}
+
+func newDateLessThanEqualsFunc(key Key, values ValueSet) (Function, error) {
+	return newDateFunc(dateLessThanEquals, key, values)
+}
+
+func newDateGreaterThanFunc(key Key, values ValueSet) (Function, error) {
+	return newDateFunc(dateGreaterThan, key, values)
+}
+
+func newDateGreaterThanEqualsFunc(key Key, values ValueSet) (Function, error) {
+	return newDateFunc(dateGreaterThanEquals, key, values)
+}
+
+// NewDateEqualsFunc - returns new DateEquals function.
+func NewDateEqualsFunc(key Key, value time.Time) (Function, error) {
+	return newDateEqualsFunc(key, NewValueSet(NewStringValue(value.Format(time.RFC3339))))
+}
+
+// NewDateNotEqualsFunc - returns new DateNotEquals function.
+func NewDateNotEqualsFunc(key Key, value time.Time) (Function, error) {
+	return newDateNotEqualsFunc(key, NewValueSet(NewStringValue(value.Format(time.RFC3339))))
+}
+
+// NewDateLessThanFunc - returns new DateLessThan function.
+func NewDateLessThanFunc(key Key, value time.Time) (Function, error) {
+	return newDateLessThanFunc(key, NewValueSet(NewStringValue(value.Format(time.RFC3339))))
+}
+
+// NewDateLessThanEqualsFunc - returns new DateLessThanEquals function.
+func NewDateLessThanEqualsFunc(key Key, value time.Time) (Function, error) {
+	return newDateLessThanEqualsFunc(key, NewValueSet(NewStringValue(value.Format(time.RFC3339))))
+}
+
+// NewDateGreaterThanFunc - returns new DateGreaterThan function.
+func NewDateGreaterThanFunc(key Key, value time.Time) (Function, error) {
+	return newDateGreaterThanFunc(key, NewValueSet(NewStringValue(value.Format(time.RFC3339))))
+}
+
+// NewDateGreaterThanEqualsFunc - returns new DateGreaterThanEquals function.
+func NewDateGreaterThanEqualsFunc(key Key, value time.Time) (Function, error) {
+	return newDateGreaterThanEqualsFunc(key, NewValueSet(NewStringValue(value.Format(time.RFC3339))))
+}
@@ -0,0 +1,96 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package condition
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateFuncEvaluate(t *testing.T) {
+	case1Function, err := newDateLessThanFunc(AWSCurrentTime, NewValueSet(NewStringValue("2020-06-30T00:00:00Z")))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case2Function, err := newDateGreaterThanEqualsFunc(AWSCurrentTime, NewValueSet(NewStringValue("2020-06-01")))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case3Function, err := newDateEqualsFunc(AWSEpochTime, NewValueSet(NewIntValue(1593475200)))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case4Function, err := NewDateNotEqualsFunc(AWSEpochTime, time.Date(2020, time.June, 30, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		function       Function
+		values         map[string][]string
+		expectedResult bool
+	}{
+		{case1Function, map[string][]string{"CurrentTime": {"2020-06-29T23:59:59Z"}}, true},
+		{case1Function, map[string][]string{"CurrentTime": {"2020-06-30T00:00:00Z"}}, false},
+		{case1Function, map[string][]string{"CurrentTime": {"foo"}}, false},
+		{case1Function, map[string][]string{}, false},
+		{case2Function, map[string][]string{"CurrentTime": {"2020-06-01T00:00:00Z"}}, true},
+		{case2Function, map[string][]string{"CurrentTime": {"2020-05-31T23:59:59Z"}}, false},
+		{case3Function, map[string][]string{"EpochTime": {"1593475200"}}, true},
+		{case3Function, map[string][]string{"EpochTime": {"1593475201"}}, false},
+		{case4Function, map[string][]string{"EpochTime": {"1593475200"}}, false},
+		{case4Function, map[string][]string{"EpochTime": {"1593475201"}}, true},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.function.evaluate(testCase.values)
+
+		if result != testCase.expectedResult {
+			t.Errorf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestNewDateFunc(t *testing.T) {
+	testCases := []struct {
+		values    ValueSet
+		expectErr bool
+	}{
+		{NewValueSet(NewStringValue("2020-06-30T00:00:00Z")), false},
+		{NewValueSet(NewStringValue("2020-06-30")), false},
+		{NewValueSet(NewStringValue("1593475200")), false},
+		{NewValueSet(NewIntValue(1593475200)), false},
+		// Only one value is allowed.
+		{NewValueSet(NewStringValue("2020-06-30"), NewStringValue("2020-07-30")), true},
+		// Invalid date error.
+		{NewValueSet(NewStringValue("30/06/2020")), true},
+		// Boolean value error.
+		{NewValueSet(NewBoolValue(true)), true},
+	}
+
+	for i, testCase := range testCases {
+		_, err := newDateEqualsFunc(AWSCurrentTime, testCase.values)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v\n", i+1, testCase.expectErr, expectErr)
+		}
+	}
+}
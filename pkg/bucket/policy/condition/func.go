@@ -106,6 +106,18 @@ var conditionFuncMap = map[name]func(Key, ValueSet) (Function, error){
 	notIPAddress:              newNotIPAddressFunc,
 	null:                      newNullFunc,
 	boolean:                   newBooleanFunc,
+	numericEquals:             newNumericEqualsFunc,
+	numericNotEquals:          newNumericNotEqualsFunc,
+	numericLessThan:           newNumericLessThanFunc,
+	numericLessThanEquals:     newNumericLessThanEqualsFunc,
+	numericGreaterThan:        newNumericGreaterThanFunc,
+	numericGreaterThanEquals:  newNumericGreaterThanEqualsFunc,
+	dateEquals:                newDateEqualsFunc,
+	dateNotEquals:             newDateNotEqualsFunc,
+	dateLessThan:              newDateLessThanFunc,
+	dateLessThanEquals:        newDateLessThanEqualsFunc,
+	dateGreaterThan:           newDateGreaterThanFunc,
+	dateGreaterThanEquals:     newDateGreaterThanEqualsFunc,
 	// Add new conditions here.
 }
 
@@ -140,12 +152,7 @@ func (functions *Functions) UnmarshalJSON(data []byte) error {
 				return err
 			}
 
-			vfn, ok := conditionFuncMap[n]
-			if !ok {
-				return fmt.Errorf("condition %v is not handled", n)
-			}
-
-			f, err := vfn(key, values)
+			f, err := newQualifiedFunc(n, key, values)
 			if err != nil {
 				return err
 			}
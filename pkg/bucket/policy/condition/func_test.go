@@ -268,9 +268,9 @@ func TestFunctionsUnmarshalJSON(t *testing.T) {
 
 	case3Data := []byte(`{}`)
 
-	// Remove this test after supporting date conditions.
+	// ARN condition functions are not supported.
 	case4Data := []byte(`{
-"DateEquals": { "aws:CurrentTime": "2013-06-30T00:00:00Z" }
+"ArnEquals": { "aws:SourceArn": "arn:aws:sns:*:123456789012:mytopic" }
 }`)
 
 	case5Data := []byte(`{
@@ -19,6 +19,10 @@ package condition
 // JWT claims supported substitutions.
 // https://www.iana.org/assignments/jwt/jwt.xhtml#claims
 const (
+	// JWTClaim - key representing any claim of the JWT, including custom
+	// claims, it is used followed by the claim name as "jwt:<claim>".
+	JWTClaim Key = "jwt:"
+
 	// JWTSub - JWT subject claim substitution.
 	JWTSub Key = "jwt:sub"
 
@@ -50,8 +54,10 @@ const (
 )
 
 // JWTKeys - Supported JWT keys, non-exhaustive list please
-// expand as new claims are standardized.
+// expand as new claims are standardized. Custom claims are
+// supported by JWTClaim.
 var JWTKeys = []Key{
+	JWTClaim,
 	JWTSub,
 	JWTIss,
 	JWTAud,
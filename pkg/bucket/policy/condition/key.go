@@ -59,6 +59,18 @@ const (
 	// S3MaxKeys - key representing max-keys query parameter of ListBucket API only.
 	S3MaxKeys Key = "s3:max-keys"
 
+	// S3ExistingObjectTag - key representing the tags of an existing object, it is used
+	// followed by the tag key as "s3:ExistingObjectTag/<key>".
+	S3ExistingObjectTag Key = "s3:ExistingObjectTag"
+
+	// S3RequestObjectTag - key representing the tags of the request of PutObject and
+	// PutObjectTagging APIs, it is used followed by the tag key as "s3:RequestObjectTag/<key>".
+	S3RequestObjectTag Key = "s3:RequestObjectTag"
+
+	// S3RequestObjectTagKeys - key representing the tag keys of the request of PutObject
+	// and PutObjectTagging APIs.
+	S3RequestObjectTagKeys Key = "s3:RequestObjectTagKeys"
+
 	// AWSReferer - key representing Referer header of any API.
 	AWSReferer Key = "aws:Referer"
 
@@ -88,7 +100,7 @@ const (
 )
 
 // AllSupportedKeys - is list of all all supported keys.
-var AllSupportedKeys = append([]Key{
+var AllSupportedKeys = append(append([]Key{
 	S3XAmzCopySource,
 	S3XAmzServerSideEncryption,
 	S3XAmzServerSideEncryptionCustomerAlgorithm,
@@ -98,6 +110,9 @@ var AllSupportedKeys = append([]Key{
 	S3Prefix,
 	S3Delimiter,
 	S3MaxKeys,
+	S3ExistingObjectTag,
+	S3RequestObjectTag,
+	S3RequestObjectTagKeys,
 	AWSReferer,
 	AWSSourceIP,
 	AWSUserAgent,
@@ -108,10 +123,11 @@ var AllSupportedKeys = append([]Key{
 	AWSUserID,
 	AWSUsername,
 	// Add new supported condition keys.
-}, JWTKeys...)
+}, JWTKeys...), LDAPKeys...)
 
-// CommonKeys - is list of all common condition keys.
-var CommonKeys = append([]Key{
+// CommonKeys - is list of all common condition keys, which are also
+// the keys supported as policy variables.
+var CommonKeys = append(append([]Key{
 	AWSReferer,
 	AWSSourceIP,
 	AWSUserAgent,
@@ -121,24 +137,105 @@ var CommonKeys = append([]Key{
 	AWSPrincipalType,
 	AWSUserID,
 	AWSUsername,
-}, JWTKeys...)
+}, JWTKeys...), LDAPKeys...)
+
+// SubstituteVariables - returns the string with the policy variables
+// such as "${aws:username}" or "${jwt:preferred_username}" replaced by
+// their values. Variables without a value are left as is.
+func SubstituteVariables(s string, values map[string][]string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(s[start:], "}")
+		if end < 0 {
+			break
+		}
+		end += start
 
-func substFuncFromValues(values map[string][]string) func(string) string {
-	return func(v string) string {
-		for _, key := range CommonKeys {
+		key := Key(s[start+2 : end])
+		if key.isVariable() {
 			// Empty values are not supported for policy variables.
-			if rvalues, ok := values[key.Name()]; ok && rvalues[0] != "" {
-				v = strings.Replace(v, key.VarName(), rvalues[0], -1)
+			if rvalues := values[key.Name()]; len(rvalues) > 0 && rvalues[0] != "" {
+				b.WriteString(s[:start])
+				b.WriteString(rvalues[0])
+				s = s[end+1:]
+				continue
 			}
 		}
-		return v
+
+		// Continue after "${" to find variables nested in other text.
+		b.WriteString(s[:start+2])
+		s = s[start+2:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+func substFuncFromValues(values map[string][]string) func(string) string {
+	return func(v string) string {
+		return SubstituteVariables(v, values)
+	}
+}
+
+// objectTagKeys - keys which are followed by an object tag key.
+var objectTagKeys = []Key{
+	S3ExistingObjectTag,
+	S3RequestObjectTag,
+}
+
+// baseKey - returns the key without the object tag key it is followed by,
+// such as "s3:ExistingObjectTag" for "s3:ExistingObjectTag/<key>".
+func (key Key) baseKey() Key {
+	for _, tagKey := range objectTagKeys {
+		if strings.HasPrefix(string(key), string(tagKey)+"/") {
+			return tagKey
+		}
+	}
+
+	// Standard and custom JWT claims share their base key.
+	if strings.HasPrefix(string(key), string(JWTClaim)) {
+		return JWTClaim
+	}
+
+	return key
+}
+
+// isVariable - returns whether key is supported as a policy variable.
+func (key Key) isVariable() bool {
+	if !key.IsValid() {
+		return false
+	}
+
+	baseKey := key.baseKey()
+	for _, commonKey := range CommonKeys {
+		if commonKey == baseKey {
+			return true
+		}
 	}
+
+	return false
 }
 
 // IsValid - checks if key is valid or not.
 func (key Key) IsValid() bool {
+	// Object tag keys are only valid followed by a tag key.
+	for _, tagKey := range objectTagKeys {
+		if key == tagKey || key == tagKey+"/" {
+			return false
+		}
+	}
+
+	// JWT claim keys are only valid followed by a claim name.
+	if key == JWTClaim {
+		return false
+	}
+
+	baseKey := key.baseKey()
 	for _, supKey := range AllSupportedKeys {
-		if supKey == key {
+		if supKey == baseKey {
 			return true
 		}
 	}
@@ -160,14 +257,17 @@ func (key Key) VarName() string {
 	return fmt.Sprintf("${%s}", key)
 }
 
-// Name - returns key name which is stripped value of prefixes "aws:" and "s3:"
+// Name - returns key name which is stripped value of prefixes "aws:" and "s3:",
+// JWT and LDAP keys keep their prefix so that claims can't be confused with
+// request headers and query parameters.
 func (key Key) Name() string {
 	keyString := string(key)
 
+	if strings.HasPrefix(keyString, "jwt:") || strings.HasPrefix(keyString, "ldap:") {
+		return keyString
+	}
 	if strings.HasPrefix(keyString, "aws:") {
 		return strings.TrimPrefix(keyString, "aws:")
-	} else if strings.HasPrefix(keyString, "jwt:") {
-		return strings.TrimPrefix(keyString, "jwt:")
 	}
 	return strings.TrimPrefix(keyString, "s3:")
 }
@@ -206,7 +306,9 @@ func (set KeySet) Add(key Key) {
 	set[key] = struct{}{}
 }
 
-// Difference - returns a key set contains difference of two keys.
+// Difference - returns a key set contains difference of two keys, keys
+// followed by an object tag key and JWT claim keys are compared by their
+// base key.
 // Example:
 //     keySet1 := ["one", "two", "three"]
 //     keySet2 := ["two", "four", "three"]
@@ -215,7 +317,7 @@ func (set KeySet) Difference(sset KeySet) KeySet {
 	nset := make(KeySet)
 
 	for k := range set {
-		if _, ok := sset[k]; !ok {
+		if _, ok := sset[k.baseKey()]; !ok {
 			nset.Add(k)
 		}
 	}
@@ -223,6 +325,18 @@ func (set KeySet) Difference(sset KeySet) KeySet {
 	return nset
 }
 
+// Match - returns whether the key set has given key, keys followed
+// by an object tag key also match their base key.
+func (set KeySet) Match(key Key) bool {
+	for k := range set {
+		if k == key || k.baseKey() == key {
+			return true
+		}
+	}
+
+	return false
+}
+
 // IsEmpty - returns whether key set is empty or not.
 func (set KeySet) IsEmpty() bool {
 	return len(set) == 0
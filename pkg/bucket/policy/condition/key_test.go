@@ -38,6 +38,17 @@ func TestKeyIsValid(t *testing.T) {
 		{S3MaxKeys, true},
 		{AWSReferer, true},
 		{AWSSourceIP, true},
+		{Key("s3:ExistingObjectTag/classification"), true},
+		{Key("s3:RequestObjectTag/classification"), true},
+		{S3RequestObjectTagKeys, true},
+		{S3ExistingObjectTag, false},
+		{Key("s3:ExistingObjectTag/"), false},
+		{JWTPrefUsername, true},
+		{Key("jwt:department"), true},
+		{JWTClaim, false},
+		{LDAPUsername, true},
+		{LDAPUserDN, true},
+		{Key("ldap:department"), false},
 		{Key("foo"), false},
 	}
 
@@ -83,6 +94,8 @@ func TestKeyName(t *testing.T) {
 	}{
 		{S3XAmzCopySource, "x-amz-copy-source"},
 		{AWSReferer, "Referer"},
+		{JWTSub, "jwt:sub"},
+		{LDAPUserDN, "ldap:dn"},
 	}
 
 	for i, testCase := range testCases {
@@ -94,6 +107,42 @@ func TestKeyName(t *testing.T) {
 	}
 }
 
+func TestSubstituteVariables(t *testing.T) {
+	values := map[string][]string{
+		"username":               {"minio"},
+		"jwt:preferred_username": {"alice"},
+		"jwt:department":         {"finance"},
+		"ldap:username":          {"bob"},
+		"ldap:dn":                {"uid=bob,dc=min,dc=io"},
+		"jwt:sub":                {""},
+		"foo":                    {"bar"},
+	}
+
+	testCases := []struct {
+		s              string
+		expectedResult string
+	}{
+		{"home/${aws:username}/*", "home/minio/*"},
+		{"home/${jwt:preferred_username}/*", "home/alice/*"},
+		{"${jwt:department}/${jwt:preferred_username}", "finance/alice"},
+		{"home/${ldap:username}/${ldap:dn}", "home/bob/uid=bob,dc=min,dc=io"},
+		// Variables without a value are left as is.
+		{"home/${jwt:sub}/*", "home/${jwt:sub}/*"},
+		{"home/${jwt:email}/*", "home/${jwt:email}/*"},
+		// Only supported keys are variables.
+		{"home/${foo}/*", "home/${foo}/*"},
+		{"home/${jwt:}/*", "home/${jwt:}/*"},
+		{"home/${x${aws:username}}", "home/${xminio}"},
+		{"home/${aws:username", "home/${aws:username"},
+	}
+
+	for i, testCase := range testCases {
+		if result := SubstituteVariables(testCase.s, values); result != testCase.expectedResult {
+			t.Errorf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
 func TestKeyUnmarshalJSON(t *testing.T) {
 	testCases := []struct {
 		data        []byte
@@ -148,6 +197,8 @@ func TestKeySetDifference(t *testing.T) {
 	}{
 		{NewKeySet(), NewKeySet(S3XAmzCopySource), NewKeySet()},
 		{NewKeySet(S3Prefix, S3Delimiter, S3MaxKeys), NewKeySet(S3Delimiter, S3MaxKeys), NewKeySet(S3Prefix)},
+		{NewKeySet(Key("s3:ExistingObjectTag/classification"), S3Prefix), NewKeySet(S3ExistingObjectTag), NewKeySet(S3Prefix)},
+		{NewKeySet(Key("s3:RequestObjectTag/classification")), NewKeySet(S3ExistingObjectTag), NewKeySet(Key("s3:RequestObjectTag/classification"))},
 	}
 
 	for i, testCase := range testCases {
@@ -159,6 +210,27 @@ func TestKeySetDifference(t *testing.T) {
 	}
 }
 
+func TestKeySetMatch(t *testing.T) {
+	testCases := []struct {
+		set            KeySet
+		key            Key
+		expectedResult bool
+	}{
+		{NewKeySet(S3Prefix), S3Prefix, true},
+		{NewKeySet(S3Prefix), S3Delimiter, false},
+		{NewKeySet(Key("s3:ExistingObjectTag/classification")), S3ExistingObjectTag, true},
+		{NewKeySet(Key("s3:RequestObjectTag/classification")), S3ExistingObjectTag, false},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.set.Match(testCase.key)
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
 func TestKeySetIsEmpty(t *testing.T) {
 	testCases := []struct {
 		set            KeySet
@@ -0,0 +1,33 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package condition
+
+// LDAP user substitutions, of temporary credentials issued by
+// AssumeRoleWithLDAPIdentity.
+const (
+	// LDAPUsername - username of the LDAP user substitution.
+	LDAPUsername Key = "ldap:username"
+
+	// LDAPUserDN - distinguished name of the LDAP user substitution.
+	LDAPUserDN Key = "ldap:dn"
+)
+
+// LDAPKeys - Supported LDAP keys.
+var LDAPKeys = []Key{
+	LDAPUsername,
+	LDAPUserDN,
+}
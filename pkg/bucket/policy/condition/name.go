@@ -19,6 +19,7 @@ package condition
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 type name string
@@ -35,8 +36,31 @@ const (
 	notIPAddress                   = "NotIpAddress"
 	null                           = "Null"
 	boolean                        = "Bool"
+	numericEquals                  = "NumericEquals"
+	numericNotEquals               = "NumericNotEquals"
+	numericLessThan                = "NumericLessThan"
+	numericLessThanEquals          = "NumericLessThanEquals"
+	numericGreaterThan             = "NumericGreaterThan"
+	numericGreaterThanEquals       = "NumericGreaterThanEquals"
+	dateEquals                     = "DateEquals"
+	dateNotEquals                  = "DateNotEquals"
+	dateLessThan                   = "DateLessThan"
+	dateLessThanEquals             = "DateLessThanEquals"
+	dateGreaterThan                = "DateGreaterThan"
+	dateGreaterThanEquals          = "DateGreaterThanEquals"
 )
 
+// Set operators qualifying condition names, such as "ForAnyValue:StringEquals".
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_multi-value-conditions.html
+const (
+	forAnyValue  = "ForAnyValue"
+	forAllValues = "ForAllValues"
+)
+
+// ifExists - suffix of condition names, such as "StringEqualsIfExists",
+// which evaluate to true if the condition key is not present.
+const ifExists = "IfExists"
+
 var supportedConditions = []name{
 	stringEquals,
 	stringNotEquals,
@@ -49,13 +73,55 @@ var supportedConditions = []name{
 	notIPAddress,
 	null,
 	boolean,
+	numericEquals,
+	numericNotEquals,
+	numericLessThan,
+	numericLessThanEquals,
+	numericGreaterThan,
+	numericGreaterThanEquals,
+	dateEquals,
+	dateNotEquals,
+	dateLessThan,
+	dateLessThanEquals,
+	dateGreaterThan,
+	dateGreaterThanEquals,
 	// Add new conditions here.
 }
 
+// split - returns the set operator qualifier, the condition operator
+// and whether the IfExists suffix is present in the name.
+// For example,
+//   "ForAnyValue:StringEqualsIfExists" returns "ForAnyValue", "StringEquals", true
+func (n name) split() (qualifier string, operator name, isIfExists bool) {
+	s := string(n)
+	if i := strings.Index(s, ":"); i >= 0 {
+		qualifier, s = s[:i], s[i+1:]
+	}
+
+	if strings.HasSuffix(s, ifExists) {
+		s, isIfExists = strings.TrimSuffix(s, ifExists), true
+	}
+
+	return qualifier, name(s), isIfExists
+}
+
 // IsValid - checks if name is valid or not.
 func (n name) IsValid() bool {
+	qualifier, operator, isIfExists := n.split()
+
+	switch qualifier {
+	case "", forAnyValue, forAllValues:
+	default:
+		return false
+	}
+
+	// Null checks for the presence of the key itself.
+	if operator == null && (qualifier != "" || isIfExists) {
+		return false
+	}
+
 	for _, supn := range supportedConditions {
-		if n == supn {
+		if operator == supn {
 			return true
 		}
 	}
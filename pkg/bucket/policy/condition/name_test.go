@@ -34,6 +34,13 @@ func TestNameIsValid(t *testing.T) {
 		{ipAddress, true},
 		{notIPAddress, true},
 		{null, true},
+		{numericLessThanEquals, true},
+		{dateGreaterThan, true},
+		{name("StringEqualsIfExists"), true},
+		{name("ForAnyValue:StringEquals"), true},
+		{name("ForAllValues:StringLikeIfExists"), true},
+		{name("ForSomeValues:StringEquals"), false},
+		{name("NullIfExists"), false},
 		{name("foo"), false},
 	}
 
@@ -0,0 +1,191 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package condition
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+// compare - returns whether the result of comparing a request value with
+// the condition value satisfies the numeric or date condition operator.
+func compare(n name, cmp int) bool {
+	switch n {
+	case numericEquals, dateEquals:
+		return cmp == 0
+	case numericNotEquals, dateNotEquals:
+		return cmp != 0
+	case numericLessThan, dateLessThan:
+		return cmp < 0
+	case numericLessThanEquals, dateLessThanEquals:
+		return cmp <= 0
+	case numericGreaterThan, dateGreaterThan:
+		return cmp > 0
+	case numericGreaterThanEquals, dateGreaterThanEquals:
+		return cmp >= 0
+	}
+
+	return false
+}
+
+// numericFunc - Numeric condition function. It compares the number in given
+// values map for Key with the condition value by the operator of the condition
+// name, for example NumericLessThanEquals.
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_elements_condition_operators.html#Conditions_Numeric
+type numericFunc struct {
+	n      name
+	k      Key
+	value  Value
+	number float64
+}
+
+// evaluate() - evaluates to check whether the number in given values
+// satisfies the condition.
+func (f numericFunc) evaluate(values map[string][]string) bool {
+	requestValue, ok := values[http.CanonicalHeaderKey(f.k.Name())]
+	if !ok {
+		requestValue = values[f.k.Name()]
+	}
+
+	for _, s := range requestValue {
+		number, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			continue
+		}
+
+		cmp := 0
+		if number < f.number {
+			cmp = -1
+		} else if number > f.number {
+			cmp = 1
+		}
+
+		if compare(f.n, cmp) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// key() - returns condition key which is used by this condition function.
+func (f numericFunc) key() Key {
+	return f.k
+}
+
+// name() - returns the numeric condition name.
+func (f numericFunc) name() name {
+	return f.n
+}
+
+func (f numericFunc) String() string {
+	return fmt.Sprintf("%v:%v:%v", f.n, f.k, f.value)
+}
+
+// toMap - returns map representation of this function.
+func (f numericFunc) toMap() map[Key]ValueSet {
+	if !f.k.IsValid() {
+		return nil
+	}
+
+	return map[Key]ValueSet{
+		f.k: NewValueSet(f.value),
+	}
+}
+
+func newNumericFunc(n name, key Key, values ValueSet) (Function, error) {
+	if len(values) != 1 {
+		return nil, fmt.Errorf("only one value is allowed for %v condition", n)
+	}
+
+	var value Value
+	var number float64
+	for v := range values {
+		value = v
+		switch v.GetType() {
+		case reflect.Int:
+			i, _ := v.GetInt()
+			number = float64(i)
+		case reflect.String:
+			var err error
+			s, _ := v.GetString()
+			if number, err = strconv.ParseFloat(s, 64); err != nil {
+				return nil, fmt.Errorf("value must be a numeric string for %v condition", n)
+			}
+		default:
+			return nil, fmt.Errorf("value must be a number for %v condition", n)
+		}
+	}
+
+	return &numericFunc{n, key, value, number}, nil
+}
+
+func newNumericEqualsFunc(key Key, values ValueSet) (Function, error) {
+	return newNumericFunc(numericEquals, key, values)
+}
+
+func newNumericNotEqualsFunc(key Key, values ValueSet) (Function, error) {
+	return newNumericFunc(numericNotEquals, key, values)
+}
+
+func newNumericLessThanFunc(key Key, values ValueSet) (Function, error) {
+	return newNumericFunc(numericLessThan, key, values)
+}
+
+func newNumericLessThanEqualsFunc(key Key, values ValueSet) (Function, error) {
+	return newNumericFunc(numericLessThanEquals, key, values)
+}
+
+func newNumericGreaterThanFunc(key Key, values ValueSet) (Function, error) {
+	return newNumericFunc(numericGreaterThan, key, values)
+}
+
+func newNumericGreaterThanEqualsFunc(key Key, values ValueSet) (Function, error) {
+	return newNumericFunc(numericGreaterThanEquals, key, values)
+}
+
+// NewNumericEqualsFunc - returns new NumericEquals function.
+func NewNumericEqualsFunc(key Key, value int) (Function, error) {
+	return newNumericEqualsFunc(key, NewValueSet(NewIntValue(value)))
+}
+
+// NewNumericNotEqualsFunc - returns new NumericNotEquals function.
+func NewNumericNotEqualsFunc(key Key, value int) (Function, error) {
+	return newNumericNotEqualsFunc(key, NewValueSet(NewIntValue(value)))
+}
+
+// NewNumericLessThanFunc - returns new NumericLessThan function.
+func NewNumericLessThanFunc(key Key, value int) (Function, error) {
+	return newNumericLessThanFunc(key, NewValueSet(NewIntValue(value)))
+}
+
+// NewNumericLessThanEqualsFunc - returns new NumericLessThanEquals function.
+func NewNumericLessThanEqualsFunc(key Key, value int) (Function, error) {
+	return newNumericLessThanEqualsFunc(key, NewValueSet(NewIntValue(value)))
+}
+
+// NewNumericGreaterThanFunc - returns new NumericGreaterThan function.
+func NewNumericGreaterThanFunc(key Key, value int) (Function, error) {
+	return newNumericGreaterThanFunc(key, NewValueSet(NewIntValue(value)))
+}
+
+// NewNumericGreaterThanEqualsFunc - returns new NumericGreaterThanEquals function.
+func NewNumericGreaterThanEqualsFunc(key Key, value int) (Function, error) {
+	return newNumericGreaterThanEqualsFunc(key, NewValueSet(NewIntValue(value)))
+}
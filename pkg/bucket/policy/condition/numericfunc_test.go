@@ -0,0 +1,131 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package condition
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNumericFuncEvaluate(t *testing.T) {
+	case1Function, err := newNumericEqualsFunc(S3MaxKeys, NewValueSet(NewIntValue(100)))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case2Function, err := newNumericNotEqualsFunc(S3MaxKeys, NewValueSet(NewStringValue("100")))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case3Function, err := newNumericLessThanFunc(S3MaxKeys, NewValueSet(NewIntValue(100)))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case4Function, err := newNumericLessThanEqualsFunc(S3MaxKeys, NewValueSet(NewIntValue(100)))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case5Function, err := newNumericGreaterThanFunc(S3MaxKeys, NewValueSet(NewIntValue(100)))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case6Function, err := newNumericGreaterThanEqualsFunc(S3MaxKeys, NewValueSet(NewStringValue("100.5")))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		function       Function
+		values         map[string][]string
+		expectedResult bool
+	}{
+		{case1Function, map[string][]string{"max-keys": {"100"}}, true},
+		{case1Function, map[string][]string{"max-keys": {"1000"}}, false},
+		{case1Function, map[string][]string{"max-keys": {"foo"}}, false},
+		{case1Function, map[string][]string{}, false},
+		{case2Function, map[string][]string{"max-keys": {"100"}}, false},
+		{case2Function, map[string][]string{"max-keys": {"1000"}}, true},
+		{case3Function, map[string][]string{"max-keys": {"99"}}, true},
+		{case3Function, map[string][]string{"max-keys": {"100"}}, false},
+		{case4Function, map[string][]string{"max-keys": {"100"}}, true},
+		{case4Function, map[string][]string{"max-keys": {"101"}}, false},
+		{case5Function, map[string][]string{"max-keys": {"101"}}, true},
+		{case5Function, map[string][]string{"max-keys": {"100"}}, false},
+		{case6Function, map[string][]string{"max-keys": {"101"}}, true},
+		{case6Function, map[string][]string{"max-keys": {"100"}}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.function.evaluate(testCase.values)
+
+		if result != testCase.expectedResult {
+			t.Errorf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestNumericFuncToMap(t *testing.T) {
+	case1Function, err := newNumericLessThanEqualsFunc(S3MaxKeys, NewValueSet(NewIntValue(100)))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		f              Function
+		expectedResult map[Key]ValueSet
+	}{
+		{case1Function, map[Key]ValueSet{S3MaxKeys: NewValueSet(NewIntValue(100))}},
+		{&numericFunc{n: numericLessThanEquals}, nil},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.f.toMap()
+
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Fatalf("case %v: result: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestNewNumericFunc(t *testing.T) {
+	testCases := []struct {
+		values    ValueSet
+		expectErr bool
+	}{
+		{NewValueSet(NewIntValue(100)), false},
+		{NewValueSet(NewStringValue("100")), false},
+		// Only one value is allowed.
+		{NewValueSet(NewIntValue(100), NewIntValue(200)), true},
+		// Non-numeric string error.
+		{NewValueSet(NewStringValue("foo")), true},
+		// Boolean value error.
+		{NewValueSet(NewBoolValue(true)), true},
+	}
+
+	for i, testCase := range testCases {
+		_, err := newNumericEqualsFunc(S3MaxKeys, testCase.values)
+		expectErr := (err != nil)
+
+		if expectErr != testCase.expectErr {
+			t.Fatalf("case %v: error: expected: %v, got: %v\n", i+1, testCase.expectErr, expectErr)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package condition
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// qualifiedFunc - condition function qualified by a set operator and/or
+// the IfExists suffix of its name.
+// For example,
+//   1. "StringEqualsIfExists" evaluates to true if the Key is not in given
+//      values map, otherwise StringEquals is evaluated.
+//   2. "ForAllValues:StringEquals" evaluates to true if every value of the
+//      Key in given values map satisfies StringEquals.
+//   3. "ForAnyValue:StringEquals" evaluates to true if at least one value
+//      of the Key in given values map satisfies StringEquals.
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_multi-value-conditions.html
+type qualifiedFunc struct {
+	Function
+	n name
+}
+
+// evaluate() - evaluates the qualified condition function with given values.
+func (f qualifiedFunc) evaluate(values map[string][]string) bool {
+	qualifier, _, isIfExists := f.n.split()
+
+	keyName := http.CanonicalHeaderKey(f.key().Name())
+	requestValue, ok := values[keyName]
+	if !ok {
+		keyName = f.key().Name()
+		requestValue, ok = values[keyName]
+	}
+
+	if !ok && isIfExists {
+		return true
+	}
+
+	switch qualifier {
+	case forAnyValue:
+		for _, v := range requestValue {
+			if f.Function.evaluate(withValue(values, keyName, v)) {
+				return true
+			}
+		}
+		return false
+	case forAllValues:
+		for _, v := range requestValue {
+			if !f.Function.evaluate(withValue(values, keyName, v)) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return f.Function.evaluate(values)
+}
+
+// withValue - returns a copy of values map with the single value v for keyName.
+func withValue(values map[string][]string, keyName string, v string) map[string][]string {
+	nvalues := make(map[string][]string, len(values))
+	for k, vs := range values {
+		nvalues[k] = vs
+	}
+	nvalues[keyName] = []string{v}
+	return nvalues
+}
+
+// name() - returns qualified condition name.
+func (f qualifiedFunc) name() name {
+	return f.n
+}
+
+func (f qualifiedFunc) String() string {
+	return strings.Replace(f.Function.String(), string(f.Function.name()), string(f.n), 1)
+}
+
+// newQualifiedFunc - returns the condition function of the condition
+// operator of name n, qualified by the set operator and/or the IfExists
+// suffix of the name.
+func newQualifiedFunc(n name, key Key, values ValueSet) (Function, error) {
+	qualifier, operator, isIfExists := n.split()
+
+	vfn, ok := conditionFuncMap[operator]
+	if !ok {
+		return nil, fmt.Errorf("condition %v is not handled", n)
+	}
+
+	f, err := vfn(key, values)
+	if err != nil {
+		return nil, err
+	}
+
+	if qualifier == "" && !isIfExists {
+		return f, nil
+	}
+
+	return &qualifiedFunc{f, n}, nil
+}
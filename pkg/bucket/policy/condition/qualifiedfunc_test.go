@@ -0,0 +1,89 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package condition
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestQualifiedFuncEvaluate(t *testing.T) {
+	case1Function, err := newQualifiedFunc(name("StringEqualsIfExists"), S3Prefix, NewValueSet(NewStringValue("home/")))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case2Function, err := newQualifiedFunc(name("ForAllValues:StringEquals"), S3Prefix, NewValueSet(NewStringValue("home/"), NewStringValue("docs/")))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case3Function, err := newQualifiedFunc(name("ForAnyValue:StringNotEquals"), S3Prefix, NewValueSet(NewStringValue("home/")))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	case4Function, err := newQualifiedFunc(name("NumericLessThanEqualsIfExists"), S3MaxKeys, NewValueSet(NewIntValue(100)))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		function       Function
+		values         map[string][]string
+		expectedResult bool
+	}{
+		{case1Function, map[string][]string{"prefix": {"home/"}}, true},
+		{case1Function, map[string][]string{"prefix": {"docs/"}}, false},
+		{case1Function, map[string][]string{}, true},
+		{case2Function, map[string][]string{"prefix": {"home/", "docs/"}}, true},
+		{case2Function, map[string][]string{"prefix": {"home/", "tmp/"}}, false},
+		{case2Function, map[string][]string{}, true},
+		{case3Function, map[string][]string{"prefix": {"home/", "tmp/"}}, true},
+		{case3Function, map[string][]string{"prefix": {"home/"}}, false},
+		{case3Function, map[string][]string{}, false},
+		{case4Function, map[string][]string{"max-keys": {"100"}}, true},
+		{case4Function, map[string][]string{"max-keys": {"1000"}}, false},
+		{case4Function, map[string][]string{}, true},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.function.evaluate(testCase.values)
+
+		if result != testCase.expectedResult {
+			t.Errorf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
+func TestQualifiedFuncMarshalJSON(t *testing.T) {
+	data := []byte(`{"ForAnyValue:StringEquals":{"s3:prefix":["home/"]},"NumericLessThanEqualsIfExists":{"s3:max-keys":[100]}}`)
+
+	functions := new(Functions)
+	if err := json.Unmarshal(data, functions); err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	result, err := json.Marshal(functions)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	if string(result) != string(data) {
+		t.Fatalf("result: expected: %v, got: %v\n", string(data), string(result))
+	}
+}
@@ -69,6 +69,19 @@ func (policy Policy) IsAllowed(args Args) bool {
 	return false
 }
 
+// MatchedStatements - returns the indices of the statements which apply
+// to given policy args, i.e. the deny statements denying them and the
+// allow statements allowing them.
+func (policy Policy) MatchedStatements(args Args) []int {
+	var matched []int
+	for i, statement := range policy.Statements {
+		if statement.IsAllowed(args) == (statement.Effect == Allow) {
+			matched = append(matched, i)
+		}
+	}
+	return matched
+}
+
 // IsEmpty - returns whether policy is empty or not.
 func (policy Policy) IsEmpty() bool {
 	return len(policy.Statements) == 0
@@ -96,14 +109,26 @@ func (policy Policy) isValid() error {
 				continue
 			}
 
+			if !policy.Statements[i].NotPrincipal.Equals(statement.NotPrincipal) {
+				continue
+			}
+
 			if !policy.Statements[i].Actions.Equals(statement.Actions) {
 				continue
 			}
 
+			if !policy.Statements[i].NotActions.Equals(statement.NotActions) {
+				continue
+			}
+
 			if !policy.Statements[i].Resources.Equals(statement.Resources) {
 				continue
 			}
 
+			if !policy.Statements[i].NotResources.Equals(statement.NotResources) {
+				continue
+			}
+
 			if policy.Statements[i].Conditions.String() != statement.Conditions.String() {
 				continue
 			}
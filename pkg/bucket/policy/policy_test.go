@@ -184,6 +184,64 @@ func TestPolicyIsAllowed(t *testing.T) {
 	}
 }
 
+func TestPolicyIsAllowedNotElements(t *testing.T) {
+	data := []byte(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Principal": "*",
+            "NotAction": "s3:DeleteObject",
+            "Resource": "arn:aws:s3:::mybucket/*"
+        },
+        {
+            "Effect": "Deny",
+            "NotPrincipal": {"AWS": ["admin"]},
+            "Action": "s3:PutObject",
+            "NotResource": "arn:aws:s3:::mybucket/uploads/*"
+        }
+    ]
+}`)
+
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		args           Args
+		expectedResult bool
+	}{
+		{Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, true},
+		{Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: DeleteObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, false},
+		{Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: PutObjectAction, BucketName: "mybucket", ObjectName: "uploads/myobject"}, true},
+		{Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, false},
+		{Args{AccountName: "admin", Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, true},
+	}
+
+	for i, testCase := range testCases {
+		result := p.IsAllowed(testCase.args)
+
+		if result != testCase.expectedResult {
+			t.Errorf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	result, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	var p2 Policy
+	if err = json.Unmarshal(result, &p2); err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	if !reflect.DeepEqual(p, p2) {
+		t.Fatalf("result: expected: %v, got: %v\n", p, p2)
+	}
+}
+
 func TestPolicyIsEmpty(t *testing.T) {
 	case1Policy := Policy{
 		Version: DefaultVersion,
@@ -48,13 +48,7 @@ func (r Resource) IsValid() bool {
 
 // Match - matches object name with resource pattern.
 func (r Resource) Match(resource string, conditionValues map[string][]string) bool {
-	pattern := r.Pattern
-	for _, key := range condition.CommonKeys {
-		// Empty values are not supported for policy variables.
-		if rvalues, ok := conditionValues[key.Name()]; ok && rvalues[0] != "" {
-			pattern = strings.Replace(pattern, key.VarName(), rvalues[0], -1)
-		}
-	}
+	pattern := condition.SubstituteVariables(r.Pattern, conditionValues)
 
 	return wildcard.Match(pattern, resource)
 }
@@ -25,22 +25,33 @@ import (
 
 // Statement - policy statement.
 type Statement struct {
-	SID        ID                  `json:"Sid,omitempty"`
-	Effect     Effect              `json:"Effect"`
-	Principal  Principal           `json:"Principal"`
-	Actions    ActionSet           `json:"Action"`
-	Resources  ResourceSet         `json:"Resource"`
-	Conditions condition.Functions `json:"Condition,omitempty"`
+	SID          ID                  `json:"Sid,omitempty"`
+	Effect       Effect              `json:"Effect"`
+	Principal    Principal           `json:"Principal"`
+	NotPrincipal Principal           `json:"NotPrincipal"`
+	Actions      ActionSet           `json:"Action,omitempty"`
+	NotActions   ActionSet           `json:"NotAction,omitempty"`
+	Resources    ResourceSet         `json:"Resource,omitempty"`
+	NotResources ResourceSet         `json:"NotResource,omitempty"`
+	Conditions   condition.Functions `json:"Condition,omitempty"`
 }
 
 // IsAllowed - checks given policy args is allowed to continue the Rest API.
 func (statement Statement) IsAllowed(args Args) bool {
 	check := func() bool {
-		if !statement.Principal.Match(args.AccountName) {
+		if statement.NotPrincipal.IsValid() {
+			if statement.NotPrincipal.Match(args.AccountName) {
+				return false
+			}
+		} else if !statement.Principal.Match(args.AccountName) {
 			return false
 		}
 
-		if !statement.Actions.Contains(args.Action) {
+		if len(statement.NotActions) != 0 {
+			if statement.NotActions.Contains(args.Action) {
+				return false
+			}
+		} else if !statement.Actions.Contains(args.Action) {
 			return false
 		}
 
@@ -53,7 +64,11 @@ func (statement Statement) IsAllowed(args Args) bool {
 			resource += args.ObjectName
 		}
 
-		if !statement.Resources.Match(resource, args.ConditionValues) {
+		if len(statement.NotResources) != 0 {
+			if statement.NotResources.Match(resource, args.ConditionValues) {
+				return false
+			}
+		} else if !statement.Resources.Match(resource, args.ConditionValues) {
 			return false
 		}
 
@@ -69,18 +84,37 @@ func (statement Statement) isValid() error {
 		return Errorf("invalid Effect %v", statement.Effect)
 	}
 
-	if !statement.Principal.IsValid() {
+	if !statement.Principal.IsValid() && !statement.NotPrincipal.IsValid() {
 		return Errorf("invalid Principal %v", statement.Principal)
 	}
 
-	if len(statement.Actions) == 0 {
+	if statement.Principal.IsValid() && statement.NotPrincipal.IsValid() {
+		return Errorf("only one of Principal or NotPrincipal is allowed")
+	}
+
+	if len(statement.Actions) == 0 && len(statement.NotActions) == 0 {
 		return Errorf("Action must not be empty")
 	}
 
-	if len(statement.Resources) == 0 {
+	if len(statement.Actions) != 0 && len(statement.NotActions) != 0 {
+		return Errorf("only one of Action or NotAction is allowed")
+	}
+
+	if len(statement.Resources) == 0 && len(statement.NotResources) == 0 {
 		return Errorf("Resource must not be empty")
 	}
 
+	if len(statement.Resources) != 0 && len(statement.NotResources) != 0 {
+		return Errorf("only one of Resource or NotResource is allowed")
+	}
+
+	// Statements with NotAction or NotResource apply to all the actions
+	// or resources not listed, their resource types and condition keys
+	// can't be validated per action.
+	if len(statement.NotActions) != 0 || len(statement.NotResources) != 0 {
+		return nil
+	}
+
 	for action := range statement.Actions {
 		if action.isObjectAction() {
 			if !statement.Resources.objectResourceExists() {
@@ -108,9 +142,34 @@ func (statement Statement) MarshalJSON() ([]byte, error) {
 		return nil, err
 	}
 
-	// subtype to avoid recursive call to MarshalJSON()
-	type subStatement Statement
-	ss := subStatement(statement)
+	// subtype to avoid recursive call to MarshalJSON(), principals
+	// are pointers to omit the one which is not set.
+	type subStatement struct {
+		SID          ID                  `json:"Sid,omitempty"`
+		Effect       Effect              `json:"Effect"`
+		Principal    *Principal          `json:"Principal,omitempty"`
+		NotPrincipal *Principal          `json:"NotPrincipal,omitempty"`
+		Actions      ActionSet           `json:"Action,omitempty"`
+		NotActions   ActionSet           `json:"NotAction,omitempty"`
+		Resources    ResourceSet         `json:"Resource,omitempty"`
+		NotResources ResourceSet         `json:"NotResource,omitempty"`
+		Conditions   condition.Functions `json:"Condition,omitempty"`
+	}
+
+	ss := subStatement{
+		SID:          statement.SID,
+		Effect:       statement.Effect,
+		Actions:      statement.Actions,
+		NotActions:   statement.NotActions,
+		Resources:    statement.Resources,
+		NotResources: statement.NotResources,
+		Conditions:   statement.Conditions,
+	}
+	if statement.NotPrincipal.IsValid() {
+		ss.NotPrincipal = &statement.NotPrincipal
+	} else {
+		ss.Principal = &statement.Principal
+	}
 	return json.Marshal(ss)
 }
 
@@ -140,6 +199,10 @@ func (statement Statement) Validate(bucketName string) error {
 		return err
 	}
 
+	if len(statement.NotResources) != 0 {
+		return statement.NotResources.Validate(bucketName)
+	}
+
 	return statement.Resources.Validate(bucketName)
 }
 
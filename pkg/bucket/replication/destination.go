@@ -0,0 +1,48 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// DestinationARNPrefix - prefix of the ARN of the destination bucket.
+const DestinationARNPrefix = "arn:aws:s3:::"
+
+var errInvalidDestinationARN = Errorf("Destination bucket must be specified as %s<bucket>", DestinationARNPrefix)
+
+// Destination - the bucket objects matching a replication rule are
+// replicated to.
+type Destination struct {
+	XMLName      xml.Name `xml:"Destination"`
+	Bucket       string   `xml:"Bucket"`
+	StorageClass string   `xml:"StorageClass,omitempty"`
+}
+
+// Validate - validates the "Destination" element
+func (d Destination) Validate() error {
+	if !strings.HasPrefix(d.Bucket, DestinationARNPrefix) || d.BucketName() == "" {
+		return errInvalidDestinationARN
+	}
+	return nil
+}
+
+// BucketName - returns the name of the destination bucket.
+func (d Destination) BucketName() string {
+	return strings.TrimPrefix(d.Bucket, DestinationARNPrefix)
+}
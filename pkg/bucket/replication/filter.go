@@ -0,0 +1,113 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"encoding/xml"
+
+	"github.com/minio/minio/pkg/bucket/object/tagging"
+)
+
+var (
+	errInvalidFilter   = Errorf("Filter must have exactly one of Prefix, Tag, or And specified")
+	errDuplicateTagKey = Errorf("Duplicate Tag Keys are not allowed")
+)
+
+// And - a tag to combine a prefix and multiple tags for replication configuration rule.
+type And struct {
+	XMLName xml.Name      `xml:"And"`
+	Prefix  string        `xml:"Prefix,omitempty"`
+	Tags    []tagging.Tag `xml:"Tag,omitempty"`
+}
+
+// isEmpty returns true if both prefix and tags are empty
+func (a And) isEmpty() bool {
+	return len(a.Tags) == 0 && a.Prefix == ""
+}
+
+// Validate - validates the And field
+func (a And) Validate() error {
+	keys := make(map[string]struct{}, len(a.Tags))
+	for _, t := range a.Tags {
+		if _, ok := keys[t.Key]; ok {
+			return errDuplicateTagKey
+		}
+		keys[t.Key] = struct{}{}
+		if err := t.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Filter - a filter for a replication configuration Rule.
+type Filter struct {
+	XMLName xml.Name    `xml:"Filter"`
+	Prefix  string      `xml:"Prefix,omitempty"`
+	And     And         `xml:"And,omitempty"`
+	Tag     tagging.Tag `xml:"Tag,omitempty"`
+}
+
+// MarshalXML - encodes only the element specified in the filter.
+func (f Filter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	var err error
+	switch {
+	case !f.And.isEmpty():
+		err = e.EncodeElement(f.And, xml.StartElement{Name: xml.Name{Local: "And"}})
+	case !f.Tag.IsEmpty():
+		err = e.EncodeElement(f.Tag, xml.StartElement{Name: xml.Name{Local: "Tag"}})
+	case f.Prefix != "":
+		err = e.EncodeElement(f.Prefix, xml.StartElement{Name: xml.Name{Local: "Prefix"}})
+	}
+	if err != nil {
+		return err
+	}
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// Validate - validates the filter element
+func (f Filter) Validate() error {
+	// A Filter must have at most one of Prefix, Tag, or And specified.
+	if !f.And.isEmpty() {
+		if f.Prefix != "" || !f.Tag.IsEmpty() {
+			return errInvalidFilter
+		}
+		if err := f.And.Validate(); err != nil {
+			return err
+		}
+	}
+	if f.Prefix != "" && !f.Tag.IsEmpty() {
+		return errInvalidFilter
+	}
+	if !f.Tag.IsEmpty() {
+		if err := f.Tag.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tags - returns all the tags of the filter.
+func (f Filter) tags() []tagging.Tag {
+	if !f.Tag.IsEmpty() {
+		return []tagging.Tag{f.Tag}
+	}
+	return f.And.Tags
+}
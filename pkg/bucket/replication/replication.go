@@ -0,0 +1,153 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"encoding/xml"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// StatusType of replication of an object, stored along with the
+// object metadata and returned in the X-Amz-Replication-Status header.
+type StatusType string
+
+const (
+	// Pending - the object is waiting to be replicated.
+	Pending StatusType = "PENDING"
+	// Complete - the object was replicated successfully.
+	Complete StatusType = "COMPLETED"
+	// Failed - the replication of the object failed.
+	Failed StatusType = "FAILED"
+	// Replica - the object is a replica of another object.
+	Replica StatusType = "REPLICA"
+)
+
+// String returns string representation of status
+func (s StatusType) String() string {
+	return string(s)
+}
+
+var (
+	errReplicationTooManyRules         = Errorf("Replication configuration allows a maximum of 1000 rules")
+	errReplicationNoRule               = Errorf("Replication configuration should have at least one rule")
+	errReplicationNoRole               = Errorf("Role should not be empty")
+	errReplicationDuplicatePriority    = Errorf("Replication configuration has rules with the same priority")
+	errReplicationMultipleDestinations = Errorf("Replication configuration has rules with different destination buckets")
+)
+
+// Config - replication configuration of a bucket.
+type Config struct {
+	XMLName xml.Name `xml:"ReplicationConfiguration"`
+	// Role names the remote target holding the destination bucket.
+	Role  string `xml:"Role"`
+	Rules []Rule `xml:"Rule"`
+}
+
+// ParseConfig - parses data in given reader to Config.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate - validates the replication configuration
+func (c Config) Validate() error {
+	if c.Role == "" {
+		return errReplicationNoRole
+	}
+	if len(c.Rules) > 1000 {
+		return errReplicationTooManyRules
+	}
+	if len(c.Rules) == 0 {
+		return errReplicationNoRule
+	}
+	priorities := make(map[int]struct{}, len(c.Rules))
+	for _, r := range c.Rules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+		if _, ok := priorities[r.Priority]; ok {
+			return errReplicationDuplicatePriority
+		}
+		priorities[r.Priority] = struct{}{}
+		if r.Destination.Bucket != c.Rules[0].Destination.Bucket {
+			return errReplicationMultipleDestinations
+		}
+	}
+	return nil
+}
+
+// ObjectOpts provides information about an object to deduce
+// whether it needs to be replicated.
+type ObjectOpts struct {
+	Name     string
+	UserTags string
+	// Delete is true when the object is being deleted.
+	Delete bool
+}
+
+// filterRule returns the enabled rule with the highest priority
+// matching the object name and tags.
+func (c Config) filterRule(obj ObjectOpts) (rule Rule, ok bool) {
+	if obj.Name == "" {
+		return rule, false
+	}
+	tags, _ := url.ParseQuery(obj.UserTags)
+	for _, r := range c.Rules {
+		if r.Status == Disabled || !strings.HasPrefix(obj.Name, r.Prefix()) {
+			continue
+		}
+		if !matchTags(r, tags) {
+			continue
+		}
+		if !ok || r.Priority > rule.Priority {
+			rule, ok = r, true
+		}
+	}
+	return rule, ok
+}
+
+// matchTags - returns true if the object carries all the tags of the rule.
+func matchTags(r Rule, tags url.Values) bool {
+	for _, t := range r.Filter.tags() {
+		if tags.Get(t.Key) != t.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// GetDestination - returns the destination the object is to be
+// replicated to, ok is false when the object is not to be replicated.
+// Deletes are only replicated when enabled on the matching rule.
+func (c Config) GetDestination(obj ObjectOpts) (dest Destination, ok bool) {
+	rule, ok := c.filterRule(obj)
+	if !ok {
+		return dest, false
+	}
+	if obj.Delete && !rule.ReplicateDeletes() {
+		return dest, false
+	}
+	return rule.Destination, true
+}
@@ -0,0 +1,148 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	const dest = `<Destination><Bucket>arn:aws:s3:::dest</Bucket></Destination>`
+	testCases := []struct {
+		input       string
+		expectedErr error
+	}{
+		{ // Valid configuration with a single rule
+			input:       `<ReplicationConfiguration><Role>target</Role><Rule><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter>` + dest + `</Rule></ReplicationConfiguration>`,
+			expectedErr: nil,
+		},
+		{ // Valid configuration with delete replication
+			input:       `<ReplicationConfiguration><Role>target</Role><Rule><Status>Enabled</Status><Filter></Filter>` + dest + `<DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication></Rule></ReplicationConfiguration>`,
+			expectedErr: nil,
+		},
+		{ // Missing role
+			input:       `<ReplicationConfiguration><Rule><Status>Enabled</Status><Filter></Filter>` + dest + `</Rule></ReplicationConfiguration>`,
+			expectedErr: errReplicationNoRole,
+		},
+		{ // No rules
+			input:       `<ReplicationConfiguration><Role>target</Role></ReplicationConfiguration>`,
+			expectedErr: errReplicationNoRule,
+		},
+		{ // Invalid rule status
+			input:       `<ReplicationConfiguration><Role>target</Role><Rule><Status>On</Status><Filter></Filter>` + dest + `</Rule></ReplicationConfiguration>`,
+			expectedErr: errInvalidRuleStatus,
+		},
+		{ // Invalid destination bucket
+			input:       `<ReplicationConfiguration><Role>target</Role><Rule><Status>Enabled</Status><Filter></Filter><Destination><Bucket>dest</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			expectedErr: errInvalidDestinationARN,
+		},
+		{ // Prefix and Tag in the same filter
+			input:       `<ReplicationConfiguration><Role>target</Role><Rule><Status>Enabled</Status><Filter><Prefix>logs/</Prefix><Tag><Key>k</Key><Value>v</Value></Tag></Filter>` + dest + `</Rule></ReplicationConfiguration>`,
+			expectedErr: errInvalidFilter,
+		},
+		{ // Delete replication with a tag based rule
+			input:       `<ReplicationConfiguration><Role>target</Role><Rule><Status>Enabled</Status><Filter><Tag><Key>k</Key><Value>v</Value></Tag></Filter>` + dest + `<DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication></Rule></ReplicationConfiguration>`,
+			expectedErr: errDeleteMarkerReplicationByTag,
+		},
+		{ // Rules with the same priority
+			input:       `<ReplicationConfiguration><Role>target</Role><Rule><Status>Enabled</Status><Filter><Prefix>a/</Prefix></Filter>` + dest + `</Rule><Rule><Status>Enabled</Status><Filter><Prefix>b/</Prefix></Filter>` + dest + `</Rule></ReplicationConfiguration>`,
+			expectedErr: errReplicationDuplicatePriority,
+		},
+		{ // Rules with different destination buckets
+			input:       `<ReplicationConfiguration><Role>target</Role><Rule><Status>Enabled</Status><Priority>1</Priority><Filter></Filter>` + dest + `</Rule><Rule><Status>Enabled</Status><Priority>2</Priority><Filter></Filter><Destination><Bucket>arn:aws:s3:::other</Bucket></Destination></Rule></ReplicationConfiguration>`,
+			expectedErr: errReplicationMultipleDestinations,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			_, err := ParseConfig(bytes.NewReader([]byte(tc.input)))
+			if err != tc.expectedErr {
+				t.Fatalf("expected err %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestMarshalConfig(t *testing.T) {
+	input := `<ReplicationConfiguration><Role>target</Role><Rule><Status>Enabled</Status><Filter><Prefix>logs/</Prefix></Filter><Destination><Bucket>arn:aws:s3:::dest</Bucket></Destination></Rule></ReplicationConfiguration>`
+	config, err := ParseConfig(bytes.NewReader([]byte(input)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := xml.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != input {
+		t.Fatalf("expected %s, got %s", input, string(data))
+	}
+}
+
+func TestGetDestination(t *testing.T) {
+	input := `<ReplicationConfiguration><Role>target</Role>` +
+		`<Rule><Status>Enabled</Status><Priority>1</Priority><Filter><Prefix>logs/</Prefix></Filter>` +
+		`<Destination><Bucket>arn:aws:s3:::dest</Bucket><StorageClass>STANDARD_IA</StorageClass></Destination>` +
+		`<DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication></Rule>` +
+		`<Rule><Status>Enabled</Status><Priority>2</Priority><Filter><And><Prefix>logs/</Prefix><Tag><Key>tier</Key><Value>hot</Value></Tag></And></Filter>` +
+		`<Destination><Bucket>arn:aws:s3:::dest</Bucket></Destination></Rule>` +
+		`<Rule><Status>Disabled</Status><Priority>3</Priority><Filter><Prefix>tmp/</Prefix></Filter>` +
+		`<Destination><Bucket>arn:aws:s3:::dest</Bucket></Destination></Rule>` +
+		`</ReplicationConfiguration>`
+	config, err := ParseConfig(bytes.NewReader([]byte(input)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		obj                  ObjectOpts
+		expectedOk           bool
+		expectedStorageClass string
+	}{
+		{obj: ObjectOpts{Name: "logs/a.log"}, expectedOk: true, expectedStorageClass: "STANDARD_IA"},
+		// Rule with the highest priority wins
+		{obj: ObjectOpts{Name: "logs/a.log", UserTags: "tier=hot"}, expectedOk: true},
+		{obj: ObjectOpts{Name: "logs/a.log", UserTags: "tier=cold"}, expectedOk: true, expectedStorageClass: "STANDARD_IA"},
+		{obj: ObjectOpts{Name: "data/a.log"}, expectedOk: false},
+		// Disabled rules are ignored
+		{obj: ObjectOpts{Name: "tmp/a.log"}, expectedOk: false},
+		// Deletes are replicated only when enabled on the rule
+		{obj: ObjectOpts{Name: "logs/a.log", Delete: true}, expectedOk: true, expectedStorageClass: "STANDARD_IA"},
+		{obj: ObjectOpts{Name: "logs/a.log", UserTags: "tier=hot", Delete: true}, expectedOk: false},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			dest, ok := config.GetDestination(tc.obj)
+			if ok != tc.expectedOk {
+				t.Fatalf("expected %t, got %t", tc.expectedOk, ok)
+			}
+			if !ok {
+				return
+			}
+			if dest.BucketName() != "dest" {
+				t.Fatalf("expected destination bucket dest, got %s", dest.BucketName())
+			}
+			if dest.StorageClass != tc.expectedStorageClass {
+				t.Fatalf("expected storage class %s, got %s", tc.expectedStorageClass, dest.StorageClass)
+			}
+		})
+	}
+}
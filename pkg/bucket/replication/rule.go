@@ -0,0 +1,116 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"encoding/xml"
+)
+
+// Status represents Enabled/Disabled status
+type Status string
+
+// Supported status types
+const (
+	Enabled  Status = "Enabled"
+	Disabled Status = "Disabled"
+)
+
+var (
+	errInvalidRuleID                = Errorf("ID must be less than 255 characters")
+	errEmptyRuleStatus              = Errorf("Status should not be empty")
+	errInvalidRuleStatus            = Errorf("Status must be set to either Enabled or Disabled")
+	errInvalidDeleteMarkerStatus    = Errorf("DeleteMarkerReplication Status must be set to either Enabled or Disabled")
+	errDeleteMarkerReplicationByTag = Errorf("Delete marker replication is not supported with tag based rules")
+	errInvalidRulePriority          = Errorf("Priority must be a non negative integer")
+)
+
+// DeleteMarkerReplication - whether deletes of objects matching the
+// rule are replicated.
+type DeleteMarkerReplication struct {
+	XMLName xml.Name `xml:"DeleteMarkerReplication"`
+	Status  Status   `xml:"Status"`
+}
+
+// MarshalXML is extended to leave out empty <DeleteMarkerReplication></DeleteMarkerReplication> tags
+func (d DeleteMarkerReplication) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if d.Status == "" {
+		return nil
+	}
+	type deleteMarkerReplicationWrapper DeleteMarkerReplication
+	return e.EncodeElement(deleteMarkerReplicationWrapper(d), start)
+}
+
+// Rule - a rule for replication configuration.
+type Rule struct {
+	XMLName                 xml.Name                `xml:"Rule"`
+	ID                      string                  `xml:"ID,omitempty"`
+	Status                  Status                  `xml:"Status"`
+	Priority                int                     `xml:"Priority,omitempty"`
+	Filter                  Filter                  `xml:"Filter"`
+	Destination             Destination             `xml:"Destination"`
+	DeleteMarkerReplication DeleteMarkerReplication `xml:"DeleteMarkerReplication"`
+}
+
+// Prefix - a rule can either have prefix under <filter></filter> or under
+// <filter><and></and></filter>. This method returns the prefix from the
+// location where it is available
+func (r Rule) Prefix() string {
+	if r.Filter.Prefix != "" {
+		return r.Filter.Prefix
+	}
+	return r.Filter.And.Prefix
+}
+
+// ReplicateDeletes - returns true if deletes of objects matching the
+// rule are replicated.
+func (r Rule) ReplicateDeletes() bool {
+	return r.DeleteMarkerReplication.Status == Enabled
+}
+
+// Validate - validates the rule element
+func (r Rule) Validate() error {
+	// cannot be longer than 255 characters
+	if len(r.ID) > 255 {
+		return errInvalidRuleID
+	}
+	switch r.Status {
+	case "":
+		return errEmptyRuleStatus
+	case Enabled, Disabled:
+	default:
+		return errInvalidRuleStatus
+	}
+	if r.Priority < 0 {
+		return errInvalidRulePriority
+	}
+	if err := r.Filter.Validate(); err != nil {
+		return err
+	}
+	if err := r.Destination.Validate(); err != nil {
+		return err
+	}
+	switch r.DeleteMarkerReplication.Status {
+	case "", Disabled:
+	case Enabled:
+		if len(r.Filter.tags()) != 0 {
+			return errDeleteMarkerReplicationByTag
+		}
+	default:
+		return errInvalidDeleteMarkerStatus
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package versioning
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// State - enabled/suspended. A bucket which never had versioning
+// configured is unversioned, this state cannot be set explicitly.
+type State string
+
+// Various supported states
+const (
+	Enabled   State = "Enabled"
+	Suspended State = "Suspended"
+)
+
+// MFADelete - enabled/disabled state of MFA delete.
+type MFADelete string
+
+// Various supported MFA delete states
+const (
+	MFADeleteEnabled  MFADelete = "Enabled"
+	MFADeleteDisabled MFADelete = "Disabled"
+)
+
+var (
+	errUnknownStatus          = Errorf("Unknown versioning status")
+	errMFADeleteNotSupported  = Errorf("MFA delete is not supported")
+	errUnknownMFADeleteStatus = Errorf("Unknown MFA delete status")
+)
+
+// Versioning - Configuration for bucket versioning.
+type Versioning struct {
+	XMLNS     string    `xml:"xmlns,attr,omitempty"`
+	XMLName   xml.Name  `xml:"VersioningConfiguration"`
+	Status    State     `xml:"Status,omitempty"`
+	MFADelete MFADelete `xml:"MfaDelete,omitempty"`
+}
+
+// Validate - validates the versioning configuration
+func (v Versioning) Validate() error {
+	switch v.Status {
+	case Enabled, Suspended:
+	default:
+		return errUnknownStatus
+	}
+	switch v.MFADelete {
+	case "", MFADeleteDisabled:
+	case MFADeleteEnabled:
+		return errMFADeleteNotSupported
+	default:
+		return errUnknownMFADeleteStatus
+	}
+	return nil
+}
+
+// Enabled - returns true if versioning is enabled.
+func (v Versioning) Enabled() bool {
+	return v.Status == Enabled
+}
+
+// Suspended - returns true if versioning is suspended.
+func (v Versioning) Suspended() bool {
+	return v.Status == Suspended
+}
+
+// ParseConfig - parses data in given reader to Versioning.
+func ParseConfig(reader io.Reader) (*Versioning, error) {
+	var v Versioning
+	if err := xml.NewDecoder(reader).Decode(&v); err != nil {
+		return nil, err
+	}
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
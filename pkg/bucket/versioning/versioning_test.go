@@ -0,0 +1,81 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package versioning
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input          string
+		expectedErr    error
+		expectedStatus State
+	}{
+		{
+			input:          `<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>Enabled</Status></VersioningConfiguration>`,
+			expectedStatus: Enabled,
+		},
+		{
+			input:          `<VersioningConfiguration><Status>Suspended</Status></VersioningConfiguration>`,
+			expectedStatus: Suspended,
+		},
+		{
+			input:          `<VersioningConfiguration><Status>Enabled</Status><MfaDelete>Disabled</MfaDelete></VersioningConfiguration>`,
+			expectedStatus: Enabled,
+		},
+		{
+			input:       `<VersioningConfiguration></VersioningConfiguration>`,
+			expectedErr: errUnknownStatus,
+		},
+		{
+			input:       `<VersioningConfiguration><Status>Disabled</Status></VersioningConfiguration>`,
+			expectedErr: errUnknownStatus,
+		},
+		{
+			input:       `<VersioningConfiguration><Status>Enabled</Status><MfaDelete>Enabled</MfaDelete></VersioningConfiguration>`,
+			expectedErr: errMFADeleteNotSupported,
+		},
+		{
+			input:       `<VersioningConfiguration><Status>Enabled</Status><MfaDelete>Unknown</MfaDelete></VersioningConfiguration>`,
+			expectedErr: errUnknownMFADeleteStatus,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			v, err := ParseConfig(bytes.NewReader([]byte(tc.input)))
+			if err != tc.expectedErr {
+				t.Fatalf("expected err %v, got %v", tc.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if v.Status != tc.expectedStatus {
+				t.Fatalf("expected status %s, got %s", tc.expectedStatus, v.Status)
+			}
+			if v.Enabled() != (tc.expectedStatus == Enabled) {
+				t.Fatalf("expected Enabled() to be %t", tc.expectedStatus == Enabled)
+			}
+			if v.Suspended() != (tc.expectedStatus == Suspended) {
+				t.Fatalf("expected Suspended() to be %t", tc.expectedStatus == Suspended)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package website
+
+import (
+	"net/http"
+	"strings"
+)
+
+var (
+	errEmptyCondition          = Errorf("Condition cannot be empty. To redirect all requests without a condition, the condition element shouldn't be present")
+	errInvalidErrorCode        = Errorf("The provided HTTP error code is not valid. Valid codes are 4XX or 5XX")
+	errEmptyRedirect           = Errorf("Redirect cannot be empty")
+	errInvalidRedirectCode     = Errorf("The provided HTTP redirect code is not valid. Valid codes are 3XX except 300")
+	errReplaceKeyWithAndPrefix = Errorf("You can only define ReplaceKeyPrefixWith or ReplaceKeyWith but not both")
+)
+
+// Condition - the condition under which a routing rule applies.
+type Condition struct {
+	KeyPrefixEquals             string `xml:"KeyPrefixEquals,omitempty"`
+	HTTPErrorCodeReturnedEquals int    `xml:"HttpErrorCodeReturnedEquals,omitempty"`
+}
+
+// Redirect - where requests matching a routing rule are redirected to.
+type Redirect struct {
+	HostName             string `xml:"HostName,omitempty"`
+	HTTPRedirectCode     int    `xml:"HttpRedirectCode,omitempty"`
+	Protocol             string `xml:"Protocol,omitempty"`
+	ReplaceKeyPrefixWith string `xml:"ReplaceKeyPrefixWith,omitempty"`
+	ReplaceKeyWith       string `xml:"ReplaceKeyWith,omitempty"`
+}
+
+// RoutingRule - redirects requests matching its condition.
+type RoutingRule struct {
+	Condition *Condition `xml:"Condition,omitempty"`
+	Redirect  Redirect   `xml:"Redirect"`
+}
+
+// Validate - validates the routing rule
+func (r RoutingRule) Validate() error {
+	if c := r.Condition; c != nil {
+		if c.KeyPrefixEquals == "" && c.HTTPErrorCodeReturnedEquals == 0 {
+			return errEmptyCondition
+		}
+		if c.HTTPErrorCodeReturnedEquals != 0 && (c.HTTPErrorCodeReturnedEquals < 400 || c.HTTPErrorCodeReturnedEquals > 599) {
+			return errInvalidErrorCode
+		}
+	}
+	if r.Redirect == (Redirect{}) {
+		return errEmptyRedirect
+	}
+	if code := r.Redirect.HTTPRedirectCode; code != 0 && (code <= 300 || code > 399) {
+		return errInvalidRedirectCode
+	}
+	if r.Redirect.ReplaceKeyPrefixWith != "" && r.Redirect.ReplaceKeyWith != "" {
+		return errReplaceKeyWithAndPrefix
+	}
+	return validateProtocol(r.Redirect.Protocol)
+}
+
+// Match - returns true if the rule applies to a request on the given
+// object name resulting in the given HTTP error code, rules with an
+// error code condition only apply once the error code is known.
+func (r RoutingRule) Match(key string, errCode int) bool {
+	if r.Condition == nil {
+		return errCode == 0
+	}
+	if r.Condition.HTTPErrorCodeReturnedEquals != errCode {
+		return false
+	}
+	return strings.HasPrefix(key, r.Condition.KeyPrefixEquals)
+}
+
+// RedirectKey - returns the object name the given object name is
+// redirected to.
+func (r RoutingRule) RedirectKey(key string) string {
+	if r.Redirect.ReplaceKeyWith != "" {
+		return r.Redirect.ReplaceKeyWith
+	}
+	if r.Redirect.ReplaceKeyPrefixWith != "" {
+		var prefix string
+		if r.Condition != nil {
+			prefix = r.Condition.KeyPrefixEquals
+		}
+		return r.Redirect.ReplaceKeyPrefixWith + strings.TrimPrefix(key, prefix)
+	}
+	return key
+}
+
+// RedirectCode - returns the HTTP status code of the redirect.
+func (r RoutingRule) RedirectCode() int {
+	if r.Redirect.HTTPRedirectCode != 0 {
+		return r.Redirect.HTTPRedirectCode
+	}
+	return http.StatusMovedPermanently
+}
@@ -0,0 +1,143 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package website
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+var (
+	errWebsiteNoIndexDocument    = Errorf("A value for IndexDocument Suffix must be provided if RedirectAllRequestsTo is empty")
+	errWebsiteInvalidSuffix      = Errorf("The IndexDocument Suffix is not well formed")
+	errWebsiteEmptyErrorDocument = Errorf("The ErrorDocument Key must not be empty")
+	errWebsiteRedirectAllOnly    = Errorf("RedirectAllRequestsTo cannot be provided in conjunction with other Routing Rules")
+	errWebsiteNoHostName         = Errorf("RedirectAllRequestsTo HostName must not be empty")
+	errWebsiteInvalidProtocol    = Errorf("Protocol must be either http or https")
+	errWebsiteTooManyRules       = Errorf("Website configuration allows a maximum of 50 routing rules")
+)
+
+// IndexDocument - the object served for requests on a directory
+// of the website, named by appending the suffix to the directory.
+type IndexDocument struct {
+	Suffix string `xml:"Suffix"`
+}
+
+// ErrorDocument - the object served when a request results in an error.
+type ErrorDocument struct {
+	Key string `xml:"Key"`
+}
+
+// RedirectAllRequestsTo - the host all requests on the website
+// are redirected to.
+type RedirectAllRequestsTo struct {
+	HostName string `xml:"HostName"`
+	Protocol string `xml:"Protocol,omitempty"`
+}
+
+// Config - website configuration of a bucket.
+type Config struct {
+	XMLName               xml.Name               `xml:"WebsiteConfiguration"`
+	RedirectAllRequestsTo *RedirectAllRequestsTo `xml:"RedirectAllRequestsTo,omitempty"`
+	IndexDocument         *IndexDocument         `xml:"IndexDocument,omitempty"`
+	ErrorDocument         *ErrorDocument         `xml:"ErrorDocument,omitempty"`
+	RoutingRules          []RoutingRule          `xml:"RoutingRules>RoutingRule,omitempty"`
+}
+
+// ParseConfig - parses data in given reader to Config.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate - validates the website configuration
+func (c Config) Validate() error {
+	if c.RedirectAllRequestsTo != nil {
+		if c.IndexDocument != nil || c.ErrorDocument != nil || len(c.RoutingRules) > 0 {
+			return errWebsiteRedirectAllOnly
+		}
+		if c.RedirectAllRequestsTo.HostName == "" {
+			return errWebsiteNoHostName
+		}
+		return validateProtocol(c.RedirectAllRequestsTo.Protocol)
+	}
+	if c.IndexDocument == nil {
+		return errWebsiteNoIndexDocument
+	}
+	if c.IndexDocument.Suffix == "" || strings.Contains(c.IndexDocument.Suffix, "/") {
+		return errWebsiteInvalidSuffix
+	}
+	if c.ErrorDocument != nil && c.ErrorDocument.Key == "" {
+		return errWebsiteEmptyErrorDocument
+	}
+	if len(c.RoutingRules) > 50 {
+		return errWebsiteTooManyRules
+	}
+	for _, r := range c.RoutingRules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IndexKey - returns the name of the object to serve for the given
+// object name, requests on a directory are served its index document.
+func (c Config) IndexKey(key string) string {
+	if c.IndexDocument == nil {
+		return key
+	}
+	if key == "" || strings.HasSuffix(key, "/") {
+		return key + c.IndexDocument.Suffix
+	}
+	return key
+}
+
+// ErrorKey - returns the name of the error document, if any.
+func (c Config) ErrorKey() (key string, ok bool) {
+	if c.ErrorDocument == nil {
+		return "", false
+	}
+	return c.ErrorDocument.Key, true
+}
+
+// RoutingRule - returns the first routing rule applying to a request
+// on the given object name resulting in the given HTTP error code,
+// errCode is zero before the object is looked up.
+func (c Config) RoutingRule(key string, errCode int) (rule RoutingRule, ok bool) {
+	for _, r := range c.RoutingRules {
+		if r.Match(key, errCode) {
+			return r, true
+		}
+	}
+	return rule, false
+}
+
+func validateProtocol(protocol string) error {
+	switch protocol {
+	case "", "http", "https":
+		return nil
+	}
+	return errWebsiteInvalidProtocol
+}
@@ -0,0 +1,159 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package website
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	const index = `<IndexDocument><Suffix>index.html</Suffix></IndexDocument>`
+	testCases := []struct {
+		input       string
+		expectedErr error
+	}{
+		{ // Valid configuration with an index and an error document
+			input:       `<WebsiteConfiguration>` + index + `<ErrorDocument><Key>404.html</Key></ErrorDocument></WebsiteConfiguration>`,
+			expectedErr: nil,
+		},
+		{ // Valid configuration redirecting all requests
+			input:       `<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName><Protocol>https</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`,
+			expectedErr: nil,
+		},
+		{ // Missing index document
+			input:       `<WebsiteConfiguration><ErrorDocument><Key>404.html</Key></ErrorDocument></WebsiteConfiguration>`,
+			expectedErr: errWebsiteNoIndexDocument,
+		},
+		{ // Index document suffix with a slash
+			input:       `<WebsiteConfiguration><IndexDocument><Suffix>docs/index.html</Suffix></IndexDocument></WebsiteConfiguration>`,
+			expectedErr: errWebsiteInvalidSuffix,
+		},
+		{ // Redirect of all requests along with an index document
+			input:       `<WebsiteConfiguration>` + index + `<RedirectAllRequestsTo><HostName>example.com</HostName></RedirectAllRequestsTo></WebsiteConfiguration>`,
+			expectedErr: errWebsiteRedirectAllOnly,
+		},
+		{ // Invalid protocol
+			input:       `<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName><Protocol>ftp</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`,
+			expectedErr: errWebsiteInvalidProtocol,
+		},
+		{ // Empty routing rule condition
+			input:       `<WebsiteConfiguration>` + index + `<RoutingRules><RoutingRule><Condition></Condition><Redirect><HostName>example.com</HostName></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			expectedErr: errEmptyCondition,
+		},
+		{ // Empty routing rule redirect
+			input:       `<WebsiteConfiguration>` + index + `<RoutingRules><RoutingRule><Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition><Redirect></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			expectedErr: errEmptyRedirect,
+		},
+		{ // Invalid redirect code
+			input:       `<WebsiteConfiguration>` + index + `<RoutingRules><RoutingRule><Redirect><HttpRedirectCode>200</HttpRedirectCode></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			expectedErr: errInvalidRedirectCode,
+		},
+		{ // Both key and key prefix replaced
+			input:       `<WebsiteConfiguration>` + index + `<RoutingRules><RoutingRule><Redirect><ReplaceKeyWith>a</ReplaceKeyWith><ReplaceKeyPrefixWith>b</ReplaceKeyPrefixWith></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			expectedErr: errReplaceKeyWithAndPrefix,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			_, err := ParseConfig(bytes.NewReader([]byte(tc.input)))
+			if err != tc.expectedErr {
+				t.Fatalf("expected err %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestMarshalConfig(t *testing.T) {
+	input := `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><ErrorDocument><Key>404.html</Key></ErrorDocument>` +
+		`<RoutingRules><RoutingRule><Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition><Redirect><ReplaceKeyPrefixWith>documents/</ReplaceKeyPrefixWith></Redirect></RoutingRule></RoutingRules>` +
+		`</WebsiteConfiguration>`
+	config, err := ParseConfig(bytes.NewReader([]byte(input)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := xml.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != input {
+		t.Fatalf("expected %s, got %s", input, string(data))
+	}
+}
+
+func TestIndexKey(t *testing.T) {
+	config := Config{IndexDocument: &IndexDocument{Suffix: "index.html"}}
+	testCases := []struct {
+		key         string
+		expectedKey string
+	}{
+		{"", "index.html"},
+		{"docs/", "docs/index.html"},
+		{"docs/intro.html", "docs/intro.html"},
+	}
+
+	for i, tc := range testCases {
+		if key := config.IndexKey(tc.key); key != tc.expectedKey {
+			t.Errorf("Test %d: expected %s, got %s", i+1, tc.expectedKey, key)
+		}
+	}
+}
+
+func TestRoutingRule(t *testing.T) {
+	input := `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules>` +
+		`<RoutingRule><Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition><Redirect><ReplaceKeyPrefixWith>documents/</ReplaceKeyPrefixWith></Redirect></RoutingRule>` +
+		`<RoutingRule><Condition><HttpErrorCodeReturnedEquals>404</HttpErrorCodeReturnedEquals></Condition><Redirect><HttpRedirectCode>302</HttpRedirectCode><ReplaceKeyWith>missing.html</ReplaceKeyWith></Redirect></RoutingRule>` +
+		`</RoutingRules></WebsiteConfiguration>`
+	config, err := ParseConfig(bytes.NewReader([]byte(input)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		key          string
+		errCode      int
+		expectedOk   bool
+		expectedKey  string
+		expectedCode int
+	}{
+		{"docs/intro.html", 0, true, "documents/intro.html", 301},
+		{"blog/intro.html", 0, false, "", 0},
+		{"blog/intro.html", 404, true, "missing.html", 302},
+		{"blog/intro.html", 403, false, "", 0},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			rule, ok := config.RoutingRule(tc.key, tc.errCode)
+			if ok != tc.expectedOk {
+				t.Fatalf("expected match %v, got %v", tc.expectedOk, ok)
+			}
+			if !ok {
+				return
+			}
+			if key := rule.RedirectKey(tc.key); key != tc.expectedKey {
+				t.Fatalf("expected key %s, got %s", tc.expectedKey, key)
+			}
+			if code := rule.RedirectCode(); code != tc.expectedCode {
+				t.Fatalf("expected code %d, got %d", tc.expectedCode, code)
+			}
+		})
+	}
+}
@@ -48,6 +48,17 @@ func (actionSet ActionSet) Match(action Action) bool {
 	return false
 }
 
+// hasAdminAction - checks whether any of the actions is an admin action.
+func (actionSet ActionSet) hasAdminAction() bool {
+	for action := range actionSet {
+		if AdminAction(action).IsValid() {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Equals - checks whether given action set is equal to current action set or not.
 func (actionSet ActionSet) Equals(sactionSet ActionSet) bool {
 	// If length of set is not equal to length of given set, the
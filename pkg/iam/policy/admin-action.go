@@ -75,6 +75,21 @@ const (
 	// GetUserAdminAction - allows GET permission on user info
 	GetUserAdminAction = "admin:GetUser"
 
+	// Service Account Actions
+
+	// CreateServiceAccountAdminAction - allow creating the service accounts of other users
+	CreateServiceAccountAdminAction = "admin:CreateServiceAccount"
+	// UpdateServiceAccountAdminAction - allow updating the service accounts of other users
+	UpdateServiceAccountAdminAction = "admin:UpdateServiceAccount"
+	// RemoveServiceAccountAdminAction - allow removing the service accounts of other users
+	RemoveServiceAccountAdminAction = "admin:RemoveServiceAccount"
+	// ListServiceAccountsAdminAction - allow listing the service accounts of other users
+	ListServiceAccountsAdminAction = "admin:ListServiceAccounts"
+	// ListSTSSessionsAdminAction - allow listing the temporary credentials of users
+	ListSTSSessionsAdminAction = "admin:ListSTSSessions"
+	// RevokeSTSSessionsAdminAction - allow revoking the temporary credentials of users
+	RevokeSTSSessionsAdminAction = "admin:RevokeSTSSessions"
+
 	// Group Actions
 
 	// AddUserToGroupAdminAction - allow adding user to group permission
@@ -102,42 +117,88 @@ const (
 	AttachPolicyAdminAction = "admin:AttachUserOrGroupPolicy"
 	// ListUserPoliciesAdminAction - allows listing user policies
 	ListUserPoliciesAdminAction = "admin:ListUserPolicies"
+	// SimulatePolicyAdminAction - allows simulating the evaluation of policies
+	SimulatePolicyAdminAction = "admin:SimulatePolicy"
+
+	// Tier Actions
+
+	// SetTierAdminAction - allow adding and removing remote tiers
+	SetTierAdminAction = "admin:SetTier"
+	// ListTierAdminAction - allow listing remote tiers
+	ListTierAdminAction = "admin:ListTier"
+
+	// Bucket Target Actions
+
+	// SetBucketTargetAdminAction - allow adding and removing remote bucket targets
+	SetBucketTargetAdminAction = "admin:SetBucketTarget"
+	// GetBucketTargetAdminAction - allow listing remote bucket targets
+	GetBucketTargetAdminAction = "admin:GetBucketTarget"
+
+	// Bucket Quota Actions
+
+	// SetBucketQuotaAdminAction - allow setting bucket quota
+	SetBucketQuotaAdminAction = "admin:SetBucketQuota"
+	// GetBucketQuotaAdminAction - allow getting bucket quota
+	GetBucketQuotaAdminAction = "admin:GetBucketQuota"
+
+	// Zone Actions
+
+	// DecommissionZoneAdminAction - allow starting and canceling the decommissioning of zones
+	DecommissionZoneAdminAction = "admin:DecommissionZone"
+	// RebalanceZonesAdminAction - allow starting and canceling the rebalancing of zones
+	RebalanceZonesAdminAction = "admin:RebalanceZones"
+
 	// AllAdminActions - provides all admin permissions
 	AllAdminActions = "admin:*"
 )
 
 // List of all supported admin actions.
 var supportedAdminActions = map[AdminAction]struct{}{
-	AllAdminActions:                {},
-	HealAdminAction:                {},
-	ServerInfoAdminAction:          {},
-	StorageInfoAdminAction:         {},
-	DataUsageInfoAdminAction:       {},
-	PerfInfoAdminAction:            {},
-	TopLocksAdminAction:            {},
-	ProfilingAdminAction:           {},
-	TraceAdminAction:               {},
-	ConsoleLogAdminAction:          {},
-	KMSKeyStatusAdminAction:        {},
-	ServerHardwareInfoAdminAction:  {},
-	ServerUpdateAdminAction:        {},
-	ConfigUpdateAdminAction:        {},
-	CreateUserAdminAction:          {},
-	DeleteUserAdminAction:          {},
-	ListUsersAdminAction:           {},
-	EnableUserAdminAction:          {},
-	DisableUserAdminAction:         {},
-	GetUserAdminAction:             {},
-	AddUserToGroupAdminAction:      {},
-	RemoveUserFromGroupAdminAction: {},
-	ListGroupsAdminAction:          {},
-	EnableGroupAdminAction:         {},
-	DisableGroupAdminAction:        {},
-	CreatePolicyAdminAction:        {},
-	DeletePolicyAdminAction:        {},
-	GetPolicyAdminAction:           {},
-	AttachPolicyAdminAction:        {},
-	ListUserPoliciesAdminAction:    {},
+	AllAdminActions:                 {},
+	HealAdminAction:                 {},
+	ServerInfoAdminAction:           {},
+	StorageInfoAdminAction:          {},
+	DataUsageInfoAdminAction:        {},
+	PerfInfoAdminAction:             {},
+	TopLocksAdminAction:             {},
+	ProfilingAdminAction:            {},
+	TraceAdminAction:                {},
+	ConsoleLogAdminAction:           {},
+	KMSKeyStatusAdminAction:         {},
+	ServerHardwareInfoAdminAction:   {},
+	ServerUpdateAdminAction:         {},
+	ConfigUpdateAdminAction:         {},
+	CreateUserAdminAction:           {},
+	DeleteUserAdminAction:           {},
+	ListUsersAdminAction:            {},
+	EnableUserAdminAction:           {},
+	DisableUserAdminAction:          {},
+	GetUserAdminAction:              {},
+	CreateServiceAccountAdminAction: {},
+	UpdateServiceAccountAdminAction: {},
+	RemoveServiceAccountAdminAction: {},
+	ListServiceAccountsAdminAction:  {},
+	ListSTSSessionsAdminAction:      {},
+	RevokeSTSSessionsAdminAction:    {},
+	AddUserToGroupAdminAction:       {},
+	RemoveUserFromGroupAdminAction:  {},
+	ListGroupsAdminAction:           {},
+	EnableGroupAdminAction:          {},
+	DisableGroupAdminAction:         {},
+	CreatePolicyAdminAction:         {},
+	DeletePolicyAdminAction:         {},
+	GetPolicyAdminAction:            {},
+	AttachPolicyAdminAction:         {},
+	ListUserPoliciesAdminAction:     {},
+	SimulatePolicyAdminAction:       {},
+	SetTierAdminAction:              {},
+	ListTierAdminAction:             {},
+	SetBucketTargetAdminAction:      {},
+	GetBucketTargetAdminAction:      {},
+	SetBucketQuotaAdminAction:       {},
+	GetBucketQuotaAdminAction:       {},
+	DecommissionZoneAdminAction:     {},
+	RebalanceZonesAdminAction:       {},
 }
 
 func parseAdminAction(s string) (AdminAction, error) {
@@ -157,34 +218,49 @@ func (action AdminAction) IsValid() bool {
 
 // adminActionConditionKeyMap - holds mapping of supported condition key for an action.
 var adminActionConditionKeyMap = map[Action]condition.KeySet{
-	AllAdminActions:                condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	HealAdminAction:                condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	StorageInfoAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ServerInfoAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DataUsageInfoAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	PerfInfoAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	TopLocksAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ProfilingAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	TraceAdminAction:               condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ConsoleLogAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	KMSKeyStatusAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ServerHardwareInfoAdminAction:  condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ServerUpdateAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ConfigUpdateAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	CreateUserAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DeleteUserAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ListUsersAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	EnableUserAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DisableUserAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	GetUserAdminAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	AddUserToGroupAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	RemoveUserFromGroupAdminAction: condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ListGroupsAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	EnableGroupAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DisableGroupAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	CreatePolicyAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DeletePolicyAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	GetPolicyAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	AttachPolicyAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ListUserPoliciesAdminAction:    condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	AllAdminActions:                 condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	HealAdminAction:                 condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	StorageInfoAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ServerInfoAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DataUsageInfoAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	PerfInfoAdminAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	TopLocksAdminAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ProfilingAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	TraceAdminAction:                condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ConsoleLogAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	KMSKeyStatusAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ServerHardwareInfoAdminAction:   condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ServerUpdateAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ConfigUpdateAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	CreateUserAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DeleteUserAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListUsersAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	EnableUserAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DisableUserAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetUserAdminAction:              condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	CreateServiceAccountAdminAction: condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	UpdateServiceAccountAdminAction: condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	RemoveServiceAccountAdminAction: condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListServiceAccountsAdminAction:  condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListSTSSessionsAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	RevokeSTSSessionsAdminAction:    condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	AddUserToGroupAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	RemoveUserFromGroupAdminAction:  condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListGroupsAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	EnableGroupAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DisableGroupAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	CreatePolicyAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DeletePolicyAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetPolicyAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	AttachPolicyAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListUserPoliciesAdminAction:     condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SimulatePolicyAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetTierAdminAction:              condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListTierAdminAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetBucketTargetAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetBucketTargetAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetBucketQuotaAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetBucketQuotaAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DecommissionZoneAdminAction:     condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	RebalanceZonesAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
 }
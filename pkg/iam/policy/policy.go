@@ -87,6 +87,19 @@ func (iamp Policy) IsAllowed(args Args) bool {
 	return false
 }
 
+// MatchedStatements - returns the indices of the statements which apply
+// to given policy args, i.e. the deny statements denying them and the
+// allow statements allowing them.
+func (iamp Policy) MatchedStatements(args Args) []int {
+	var matched []int
+	for i, statement := range iamp.Statements {
+		if statement.IsAllowed(args) == (statement.Effect == policy.Allow) {
+			matched = append(matched, i)
+		}
+	}
+	return matched
+}
+
 // IsEmpty - returns whether policy is empty or not.
 func (iamp Policy) IsEmpty() bool {
 	return len(iamp.Statements) == 0
@@ -114,10 +127,18 @@ func (iamp Policy) isValid() error {
 				continue
 			}
 
+			if !iamp.Statements[i].NotActions.Equals(statement.NotActions) {
+				continue
+			}
+
 			if !iamp.Statements[i].Resources.Equals(statement.Resources) {
 				continue
 			}
 
+			if !iamp.Statements[i].NotResources.Equals(statement.NotResources) {
+				continue
+			}
+
 			if iamp.Statements[i].Conditions.String() != statement.Conditions.String() {
 				continue
 			}
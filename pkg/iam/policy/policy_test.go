@@ -178,6 +178,179 @@ func TestPolicyIsAllowed(t *testing.T) {
 	}
 }
 
+func TestPolicyIsAllowedNotElements(t *testing.T) {
+	data := []byte(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Action": "s3:*",
+            "Resource": "arn:aws:s3:::*"
+        },
+        {
+            "Effect": "Deny",
+            "NotAction": ["s3:GetObject", "s3:ListBucket"],
+            "NotResource": "arn:aws:s3:::scratch/*"
+        }
+    ]
+}`)
+
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		args           Args
+		expectedResult bool
+	}{
+		{Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, true},
+		{Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: ListBucketAction, BucketName: "mybucket"}, true},
+		{Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, false},
+		{Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: PutObjectAction, BucketName: "scratch", ObjectName: "myobject"}, true},
+	}
+
+	for i, testCase := range testCases {
+		result := p.IsAllowed(testCase.args)
+
+		if result != testCase.expectedResult {
+			t.Errorf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	// NotAction doesn't match admin actions unless admin actions are named.
+	data = []byte(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "NotAction": ["s3:DeleteBucket"], "Resource": ["arn:aws:s3:::*"]}]}`)
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	if p.IsAllowed(Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: ServerInfoAdminAction}) {
+		t.Fatalf("expected admin:ServerInfo to be denied")
+	}
+	if !p.IsAllowed(Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: PutObjectAction, BucketName: "mybucket", ObjectName: "myobject"}) {
+		t.Fatalf("expected s3:PutObject to be allowed")
+	}
+
+	data = []byte(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "NotAction": ["admin:ServerUpdate"], "Resource": ["arn:aws:s3:::*"]}]}`)
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	if !p.IsAllowed(Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: ServerInfoAdminAction}) {
+		t.Fatalf("expected admin:ServerInfo to be allowed")
+	}
+	if p.IsAllowed(Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: ServerUpdateAdminAction}) {
+		t.Fatalf("expected admin:ServerUpdate to be denied")
+	}
+
+	// Only one of Action or NotAction is allowed.
+	data = []byte(`{"Version": "2012-10-17", "Statement": [{"Effect": "Deny", "Action": "s3:PutObject", "NotAction": "s3:GetObject", "Resource": "arn:aws:s3:::*"}]}`)
+	if err := json.Unmarshal(data, &p); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+}
+
+func TestPolicyIsAllowedObjectTags(t *testing.T) {
+	data := []byte(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Action": "s3:GetObject",
+            "Resource": "arn:aws:s3:::mybucket/*",
+            "Condition": {"StringEquals": {"s3:ExistingObjectTag/classification": "public"}}
+        },
+        {
+            "Effect": "Allow",
+            "Action": "s3:PutObject",
+            "Resource": "arn:aws:s3:::mybucket/*",
+            "Condition": {"ForAllValues:StringEquals": {"s3:RequestObjectTagKeys": ["classification", "team"]}}
+        }
+    ]
+}`)
+
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		action          Action
+		conditionValues map[string][]string
+		expectedResult  bool
+	}{
+		{GetObjectAction, map[string][]string{"ExistingObjectTag/classification": {"public"}}, true},
+		{GetObjectAction, map[string][]string{"ExistingObjectTag/classification": {"private"}}, false},
+		{GetObjectAction, map[string][]string{}, false},
+		{PutObjectAction, map[string][]string{"RequestObjectTagKeys": {"classification"}}, true},
+		{PutObjectAction, map[string][]string{"RequestObjectTagKeys": {"classification", "owner"}}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := p.IsAllowed(Args{
+			AccountName:     "Q3AM3UQ867SPQQA43P2F",
+			Action:          testCase.action,
+			BucketName:      "mybucket",
+			ObjectName:      "myobject",
+			ConditionValues: testCase.conditionValues,
+		})
+
+		if result != testCase.expectedResult {
+			t.Errorf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	// Existing object tags are not available to ListBucket.
+	data = []byte(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:ListBucket", "Resource": "arn:aws:s3:::mybucket", "Condition": {"StringEquals": {"s3:ExistingObjectTag/classification": "public"}}}]}`)
+	if err := json.Unmarshal(data, &p); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+}
+
+func TestPolicyMatchedStatements(t *testing.T) {
+	data := []byte(`{
+    "Version": "2012-10-17",
+    "Statement": [
+        {
+            "Effect": "Allow",
+            "Action": "s3:*",
+            "Resource": "arn:aws:s3:::mybucket/*"
+        },
+        {
+            "Effect": "Allow",
+            "Action": "s3:GetObject",
+            "Resource": "arn:aws:s3:::*"
+        },
+        {
+            "Effect": "Deny",
+            "Action": "s3:DeleteObject",
+            "Resource": "arn:aws:s3:::mybucket/locked/*"
+        }
+    ]
+}`)
+
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		args           Args
+		expectedResult []int
+	}{
+		{Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, []int{0, 1}},
+		{Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: GetObjectAction, BucketName: "otherbucket", ObjectName: "myobject"}, []int{1}},
+		{Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: DeleteObjectAction, BucketName: "mybucket", ObjectName: "locked/myobject"}, []int{0, 2}},
+		{Args{AccountName: "Q3AM3UQ867SPQQA43P2F", Action: PutObjectAction, BucketName: "otherbucket", ObjectName: "myobject"}, nil},
+	}
+
+	for i, testCase := range testCases {
+		result := p.MatchedStatements(testCase.args)
+
+		if !reflect.DeepEqual(result, testCase.expectedResult) {
+			t.Errorf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
 func TestPolicyIsEmpty(t *testing.T) {
 	case1Policy := Policy{
 		Version: DefaultVersion,
@@ -135,6 +135,31 @@ func TestResourceMatch(t *testing.T) {
 	}
 }
 
+func TestResourceMatchVariables(t *testing.T) {
+	conditionValues := map[string][]string{
+		"jwt:preferred_username": {"alice"},
+		"ldap:username":          {"bob"},
+	}
+
+	testCases := []struct {
+		resource       Resource
+		objectName     string
+		expectedResult bool
+	}{
+		{NewResource("home", "${jwt:preferred_username}/*"), "home/alice/myobject", true},
+		{NewResource("home", "${jwt:preferred_username}/*"), "home/bob/myobject", false},
+		{NewResource("home", "${ldap:username}/*"), "home/bob/myobject", true},
+		{NewResource("home", "${jwt:email}/*"), "home/alice/myobject", false},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.resource.Match(testCase.objectName, conditionValues)
+		if result != testCase.expectedResult {
+			t.Errorf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
 func TestResourceMarshalJSON(t *testing.T) {
 	testCases := []struct {
 		resource       Resource
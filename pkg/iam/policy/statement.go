@@ -26,17 +26,29 @@ import (
 
 // Statement - iam policy statement.
 type Statement struct {
-	SID        policy.ID           `json:"Sid,omitempty"`
-	Effect     policy.Effect       `json:"Effect"`
-	Actions    ActionSet           `json:"Action"`
-	Resources  ResourceSet         `json:"Resource,omitempty"`
-	Conditions condition.Functions `json:"Condition,omitempty"`
+	SID          policy.ID           `json:"Sid,omitempty"`
+	Effect       policy.Effect       `json:"Effect"`
+	Actions      ActionSet           `json:"Action,omitempty"`
+	NotActions   ActionSet           `json:"NotAction,omitempty"`
+	Resources    ResourceSet         `json:"Resource,omitempty"`
+	NotResources ResourceSet         `json:"NotResource,omitempty"`
+	Conditions   condition.Functions `json:"Condition,omitempty"`
 }
 
 // IsAllowed - checks given policy args is allowed to continue the Rest API.
 func (statement Statement) IsAllowed(args Args) bool {
 	check := func() bool {
-		if !statement.Actions.Match(args.Action) {
+		if len(statement.NotActions) != 0 {
+			if statement.NotActions.Match(args.Action) {
+				return false
+			}
+			// Admin actions are only matched by NotAction when admin
+			// actions are named, a statement on everything but some
+			// S3 actions must not grant admin privileges.
+			if AdminAction(args.Action).IsValid() && !statement.NotActions.hasAdminAction() {
+				return false
+			}
+		} else if !statement.Actions.Match(args.Action) {
 			return false
 		}
 
@@ -52,8 +64,14 @@ func (statement Statement) IsAllowed(args Args) bool {
 		}
 
 		// For admin statements, resource match can be ignored.
-		if !statement.Resources.Match(resource, args.ConditionValues) && !statement.isAdmin() {
-			return false
+		if !statement.isAdmin() {
+			if len(statement.NotResources) != 0 {
+				if statement.NotResources.Match(resource, args.ConditionValues) {
+					return false
+				}
+			} else if !statement.Resources.Match(resource, args.ConditionValues) {
+				return false
+			}
 		}
 
 		return statement.Conditions.Evaluate(args.ConditionValues)
@@ -61,7 +79,14 @@ func (statement Statement) IsAllowed(args Args) bool {
 
 	return statement.Effect.IsAllowed(check())
 }
+
+// isAdmin - returns whether the statement only lists admin actions,
+// statements with NotAction are never admin statements.
 func (statement Statement) isAdmin() bool {
+	if len(statement.Actions) == 0 {
+		return false
+	}
+
 	for action := range statement.Actions {
 		if !AdminAction(action).IsValid() {
 			return false
@@ -76,10 +101,14 @@ func (statement Statement) isValid() error {
 		return Errorf("invalid Effect %v", statement.Effect)
 	}
 
-	if len(statement.Actions) == 0 {
+	if len(statement.Actions) == 0 && len(statement.NotActions) == 0 {
 		return Errorf("Action must not be empty")
 	}
 
+	if len(statement.Actions) != 0 && len(statement.NotActions) != 0 {
+		return Errorf("only one of Action or NotAction is allowed")
+	}
+
 	if statement.isAdmin() {
 		for action := range statement.Actions {
 			keys := statement.Conditions.Keys()
@@ -91,14 +120,29 @@ func (statement Statement) isValid() error {
 		return nil
 	}
 
-	if len(statement.Resources) == 0 {
+	if len(statement.Resources) == 0 && len(statement.NotResources) == 0 {
 		return Errorf("Resource must not be empty")
 	}
 
+	if len(statement.Resources) != 0 && len(statement.NotResources) != 0 {
+		return Errorf("only one of Resource or NotResource is allowed")
+	}
+
 	if err := statement.Resources.Validate(); err != nil {
 		return err
 	}
 
+	if err := statement.NotResources.Validate(); err != nil {
+		return err
+	}
+
+	// Statements with NotAction or NotResource apply to all the actions
+	// or resources not listed, their resource types and condition keys
+	// can't be validated per action.
+	if len(statement.NotActions) != 0 || len(statement.NotResources) != 0 {
+		return nil
+	}
+
 	for action := range statement.Actions {
 		if !statement.Resources.objectResourceExists() && !statement.Resources.bucketResourceExists() {
 			return Errorf("unsupported Resource found %v for action %v", statement.Resources, action)
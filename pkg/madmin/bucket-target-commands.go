@@ -0,0 +1,118 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// BucketTarget carries the details of a remote S3 compatible
+// server buckets are replicated to. The name of the target is
+// used as the role of bucket replication configurations.
+type BucketTarget struct {
+	Name      string `json:"name"`
+	Endpoint  string `json:"endpoint"`
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey,omitempty"`
+	Region    string `json:"region,omitempty"`
+}
+
+// SetBucketTarget - registers a new remote bucket target.
+func (adm *AdminClient) SetBucketTarget(target BucketTarget) error {
+	data, err := json.Marshal(target)
+	if err != nil {
+		return err
+	}
+	econfigBytes, err := EncryptData(adm.secretAccessKey, data)
+	if err != nil {
+		return err
+	}
+
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/bucket-target",
+		content: econfigBytes,
+	}
+
+	// Execute PUT on /minio/admin/v2/bucket-target to add a target.
+	resp, err := adm.executeMethod("PUT", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// ListBucketTargets - lists all the remote bucket targets, secret
+// keys are left out.
+func (adm *AdminClient) ListBucketTargets() ([]BucketTarget, error) {
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/bucket-target",
+	}
+
+	// Execute GET on /minio/admin/v2/bucket-target to list targets.
+	resp, err := adm.executeMethod("GET", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	var targets []BucketTarget
+	if err = json.NewDecoder(resp.Body).Decode(&targets); err != nil {
+		return nil, err
+	}
+
+	return targets, nil
+}
+
+// RemoveBucketTarget - removes a remote bucket target, targets still
+// referred to by bucket replication configurations cannot be removed.
+func (adm *AdminClient) RemoveBucketTarget(name string) error {
+	queryValues := url.Values{}
+	queryValues.Set("name", name)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/bucket-target",
+		queryValues: queryValues,
+	}
+
+	// Execute DELETE on /minio/admin/v2/bucket-target to remove a target.
+	resp, err := adm.executeMethod("DELETE", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
@@ -270,10 +270,42 @@ func (adm *AdminClient) Heal(bucket, prefix string, healOpts HealOpts,
 	return healStart, healTaskStatus, nil
 }
 
+// HealingDisk - the progress of healing a replaced drive, drives are
+// healed by the server hosting them.
+type HealingDisk struct {
+	ID        string `json:"id"`
+	ZoneIndex int    `json:"zoneIndex"`
+	SetIndex  int    `json:"setIndex"`
+	DiskIndex int    `json:"diskIndex"`
+	Endpoint  string `json:"endpoint"`
+
+	Started    time.Time `json:"started"`
+	LastUpdate time.Time `json:"lastUpdate"`
+
+	// Objects healed on a drive which lacked them or held them
+	// corrupted, and their size. Objects found healthy are not
+	// counted.
+	ObjectsHealed uint64 `json:"objectsHealed"`
+	ObjectsFailed uint64 `json:"objectsFailed"`
+	BytesDone     uint64 `json:"bytesDone"`
+
+	// Bucket and object last healed.
+	Bucket string `json:"bucket,omitempty"`
+	Object string `json:"object,omitempty"`
+
+	// Buckets, or prefixes of the metadata of the cluster,
+	// left to be healed and already healed.
+	QueuedBuckets []string `json:"queuedBuckets"`
+	HealedBuckets []string `json:"healedBuckets"`
+}
+
 // BgHealState represents the status of the background heal
 type BgHealState struct {
 	ScannedItemsCount int64
 	LastHealActivity  time.Time
+
+	// Drives being healed.
+	HealDisks []HealingDisk
 }
 
 // BackgroundHealStatus returns the background heal status of the
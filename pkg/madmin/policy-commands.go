@@ -159,3 +159,88 @@ func (adm *AdminClient) SetPolicy(policyName, entityName string, isGroup bool) e
 	}
 	return nil
 }
+
+// PolicySource - the kind of policy a simulated request is evaluated against.
+type PolicySource string
+
+// Policy sources of simulated requests.
+const (
+	// The request is made by the owner, policies don't apply.
+	PolicySourceOwner PolicySource = "owner"
+	// The request is evaluated by the configured OPA server.
+	PolicySourceOPA PolicySource = "opa"
+	// A policy attached to the user, or to the parent user of a service account.
+	PolicySourceUser PolicySource = "user"
+	// A policy attached to a group of the user.
+	PolicySourceGroup PolicySource = "group"
+	// The session policy of a temporary credential or of a service account.
+	PolicySourceSession PolicySource = "session"
+	// The policy of the bucket, which applies to anonymous requests.
+	PolicySourceBucket PolicySource = "bucket"
+)
+
+// PolicySimulationArgs - a request to evaluate against the policies of
+// the server, an empty account name simulates an anonymous request.
+type PolicySimulationArgs struct {
+	AccountName     string              `json:"accountName,omitempty"`
+	Action          string              `json:"action"`
+	BucketName      string              `json:"bucket,omitempty"`
+	ObjectName      string              `json:"object,omitempty"`
+	ConditionValues map[string][]string `json:"conditionValues,omitempty"`
+}
+
+// PolicySimulationStatement - a statement which applies to a simulated
+// request, either denying or allowing it.
+type PolicySimulationStatement struct {
+	Source PolicySource `json:"source"`
+	// Name of the policy, empty for session and bucket policies.
+	PolicyName string `json:"policyName,omitempty"`
+	// Name of the group the policy is attached to.
+	GroupName string `json:"groupName,omitempty"`
+	// Index of the statement in the policy.
+	Index     int             `json:"index"`
+	Effect    string          `json:"effect"`
+	Statement json.RawMessage `json:"statement"`
+}
+
+// PolicySimulationResult - the decision on a simulated request along
+// with the statements which apply to it.
+type PolicySimulationResult struct {
+	Allowed bool `json:"allowed"`
+	// Set only when the decision is not made by evaluating
+	// statements, i.e. for the owner and with OPA.
+	DecidedBy  PolicySource                `json:"decidedBy,omitempty"`
+	Statements []PolicySimulationStatement `json:"statements,omitempty"`
+}
+
+// SimulatePolicy - evaluates a request against the policies of the
+// server and explains the decision.
+func (adm *AdminClient) SimulatePolicy(args PolicySimulationArgs) (result PolicySimulationResult, err error) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return result, err
+	}
+
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/simulate-policy",
+		content: data,
+	}
+
+	// Execute POST on /minio/admin/v2/simulate-policy
+	resp, err := adm.executeMethod("POST", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return result, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return result, httpRespToErrorResponse(resp)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
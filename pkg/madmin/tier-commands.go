@@ -0,0 +1,135 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// TierType - type of the remote tier backend.
+type TierType string
+
+// Supported remote tier backends.
+const (
+	// S3TierType - any S3 compatible object storage.
+	S3TierType TierType = "s3"
+)
+
+// TierConfig carries the details of a remote tier lifecycle
+// transition rules may move the data of objects to. The name of
+// the tier is used as the storage class of transition rules.
+type TierConfig struct {
+	Name      string   `json:"name"`
+	Type      TierType `json:"type"`
+	Endpoint  string   `json:"endpoint"`
+	AccessKey string   `json:"accessKey"`
+	SecretKey string   `json:"secretKey,omitempty"`
+	Bucket    string   `json:"bucket"`
+	Prefix    string   `json:"prefix,omitempty"`
+	Region    string   `json:"region,omitempty"`
+}
+
+// AddTier - registers a new remote tier.
+func (adm *AdminClient) AddTier(cfg TierConfig) error {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	econfigBytes, err := EncryptData(adm.secretAccessKey, data)
+	if err != nil {
+		return err
+	}
+
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/tier",
+		content: econfigBytes,
+	}
+
+	// Execute PUT on /minio/admin/v2/tier to add a tier.
+	resp, err := adm.executeMethod("PUT", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// ListTiers - lists all the remote tiers, secret keys are left out.
+func (adm *AdminClient) ListTiers() ([]TierConfig, error) {
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/tier",
+	}
+
+	// Execute GET on /minio/admin/v2/tier to list tiers.
+	resp, err := adm.executeMethod("GET", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	var tiers []TierConfig
+	if err = json.NewDecoder(resp.Body).Decode(&tiers); err != nil {
+		return nil, err
+	}
+
+	return tiers, nil
+}
+
+// RemoveTier - removes a remote tier, tiers still referred to by
+// lifecycle transition rules cannot be removed. A tier still holding
+// the data of transitioned objects is only removed when force is set,
+// these objects cannot be read anymore afterwards and their data is
+// left behind on the tier when they are deleted.
+func (adm *AdminClient) RemoveTier(name string, force bool) error {
+	queryValues := url.Values{}
+	queryValues.Set("name", name)
+	if force {
+		queryValues.Set("force", "true")
+	}
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/tier",
+		queryValues: queryValues,
+	}
+
+	// Execute DELETE on /minio/admin/v2/tier to remove a tier.
+	resp, err := adm.executeMethod("DELETE", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
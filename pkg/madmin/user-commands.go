@@ -22,8 +22,10 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/minio/minio/pkg/auth"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
 )
 
 // AccountStatus - account status.
@@ -210,3 +212,283 @@ func (adm *AdminClient) SetUserStatus(accessKey string, status AccountStatus) er
 
 	return nil
 }
+
+// AddServiceAccountReq is the request body of the add service account admin call
+type AddServiceAccountReq struct {
+	Policy     json.RawMessage `json:"policy,omitempty"`
+	TargetUser string          `json:"targetUser,omitempty"`
+}
+
+// AddServiceAccountResp is the response body of the add service account admin call
+type AddServiceAccountResp struct {
+	Credentials auth.Credentials `json:"credentials"`
+}
+
+// UpdateServiceAccountReq is the request body of the update service account admin call
+type UpdateServiceAccountReq struct {
+	NewPolicy    json.RawMessage `json:"newPolicy,omitempty"`
+	NewSecretKey string          `json:"newSecretKey,omitempty"`
+	NewStatus    AccountStatus   `json:"newStatus,omitempty"`
+}
+
+// ListServiceAccountsResp is the response body of the list service accounts admin call
+type ListServiceAccountsResp struct {
+	Accounts []string `json:"accounts"`
+}
+
+// AddServiceAccount - creates a new service account of the target user, or
+// of the requesting user if no target user is given. The service account
+// inherits the policies of its parent user, which are further restricted
+// by the policy if set.
+func (adm *AdminClient) AddServiceAccount(targetUser string, policy *iampolicy.Policy) (auth.Credentials, error) {
+	req := AddServiceAccountReq{
+		TargetUser: targetUser,
+	}
+	if policy != nil {
+		policyBuf, err := json.Marshal(policy)
+		if err != nil {
+			return auth.Credentials{}, err
+		}
+		req.Policy = policyBuf
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+	econfigBytes, err := EncryptData(adm.secretAccessKey, data)
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/add-service-account",
+		content: econfigBytes,
+	}
+
+	// Execute PUT on /minio/admin/v2/add-service-account to create a service account.
+	resp, err := adm.executeMethod(http.MethodPut, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return auth.Credentials{}, httpRespToErrorResponse(resp)
+	}
+
+	data, err = DecryptData(adm.secretAccessKey, resp.Body)
+	if err != nil {
+		return auth.Credentials{}, err
+	}
+
+	var serviceAccountResp AddServiceAccountResp
+	if err = json.Unmarshal(data, &serviceAccountResp); err != nil {
+		return auth.Credentials{}, err
+	}
+	return serviceAccountResp.Credentials, nil
+}
+
+// UpdateServiceAccount - updates the policy, the secret key or the status
+// of a service account, empty values leave the service account unchanged.
+func (adm *AdminClient) UpdateServiceAccount(accessKey string, newPolicy *iampolicy.Policy, newSecretKey string, newStatus AccountStatus) error {
+	if newSecretKey != "" && !auth.IsSecretKeyValid(newSecretKey) {
+		return auth.ErrInvalidSecretKeyLength
+	}
+
+	req := UpdateServiceAccountReq{
+		NewSecretKey: newSecretKey,
+		NewStatus:    newStatus,
+	}
+	if newPolicy != nil {
+		policyBuf, err := json.Marshal(newPolicy)
+		if err != nil {
+			return err
+		}
+		req.NewPolicy = policyBuf
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	econfigBytes, err := EncryptData(adm.secretAccessKey, data)
+	if err != nil {
+		return err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("accessKey", accessKey)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/update-service-account",
+		queryValues: queryValues,
+		content:     econfigBytes,
+	}
+
+	// Execute POST on /minio/admin/v2/update-service-account to update a service account.
+	resp, err := adm.executeMethod(http.MethodPost, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// ListServiceAccounts - lists the service accounts of the user, or of the
+// requesting user if no user is given.
+func (adm *AdminClient) ListServiceAccounts(user string) ([]string, error) {
+	queryValues := url.Values{}
+	if user != "" {
+		queryValues.Set("user", user)
+	}
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/list-service-accounts",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v2/list-service-accounts
+	resp, err := adm.executeMethod(http.MethodGet, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var listResp ListServiceAccountsResp
+	if err = json.Unmarshal(b, &listResp); err != nil {
+		return nil, err
+	}
+	return listResp.Accounts, nil
+}
+
+// DeleteServiceAccount - deletes a service account.
+func (adm *AdminClient) DeleteServiceAccount(accessKey string) error {
+	queryValues := url.Values{}
+	queryValues.Set("accessKey", accessKey)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/delete-service-account",
+		queryValues: queryValues,
+	}
+
+	// Execute DELETE on /minio/admin/v2/delete-service-account to delete a service account.
+	resp, err := adm.executeMethod(http.MethodDelete, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// STSSessionInfo - temporary credentials issued by the STS API.
+type STSSessionInfo struct {
+	AccessKey string `json:"accessKey"`
+	// User or identity the credentials were issued for.
+	ParentUser string `json:"parentUser"`
+	// STS API action which issued the credentials.
+	Source        string          `json:"source"`
+	IssuedAt      time.Time       `json:"issuedAt,omitempty"`
+	Expiration    time.Time       `json:"expiration"`
+	SessionPolicy json.RawMessage `json:"sessionPolicy,omitempty"`
+}
+
+// ListSTSSessionsResp is the response body of the list STS sessions admin call
+type ListSTSSessionsResp struct {
+	Sessions []STSSessionInfo `json:"sessions"`
+}
+
+// ListSTSSessions - lists the active temporary credentials of the user, or
+// of all users if no user is given.
+func (adm *AdminClient) ListSTSSessions(user string) ([]STSSessionInfo, error) {
+	queryValues := url.Values{}
+	if user != "" {
+		queryValues.Set("user", user)
+	}
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/list-sts-sessions",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v2/list-sts-sessions
+	resp, err := adm.executeMethod(http.MethodGet, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var listResp ListSTSSessionsResp
+	if err = json.Unmarshal(b, &listResp); err != nil {
+		return nil, err
+	}
+	return listResp.Sessions, nil
+}
+
+// RevokeSTSSession - revokes the temporary credentials with the access key.
+func (adm *AdminClient) RevokeSTSSession(accessKey string) error {
+	queryValues := url.Values{}
+	queryValues.Set("accessKey", accessKey)
+	return adm.revokeSTSSessions(queryValues)
+}
+
+// RevokeSTSSessions - revokes all temporary credentials of the user.
+func (adm *AdminClient) RevokeSTSSessions(user string) error {
+	queryValues := url.Values{}
+	queryValues.Set("user", user)
+	return adm.revokeSTSSessions(queryValues)
+}
+
+func (adm *AdminClient) revokeSTSSessions(queryValues url.Values) error {
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/revoke-sts-sessions",
+		queryValues: queryValues,
+	}
+
+	// Execute POST on /minio/admin/v2/revoke-sts-sessions to revoke temporary credentials.
+	resp, err := adm.executeMethod(http.MethodPost, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
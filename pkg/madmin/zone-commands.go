@@ -0,0 +1,247 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ZoneStatus - the status of a zone of the cluster, zones are
+// referred to by their index in the command line of the servers.
+type ZoneStatus struct {
+	Index          int      `json:"index"`
+	ID             string   `json:"id"`
+	Endpoints      []string `json:"endpoints"`
+	TotalSpace     uint64   `json:"totalSpace"`
+	AvailableSpace uint64   `json:"availableSpace"`
+
+	// Set once the decommissioning of the zone was started.
+	Decommission *DecommissionInfo `json:"decommission,omitempty"`
+}
+
+// DecommissionInfo - the progress of the decommissioning of a zone.
+// Once complete the zone can be removed from the command line of
+// the servers.
+type DecommissionInfo struct {
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime,omitempty"`
+	Complete  bool      `json:"complete"`
+	Failed    bool      `json:"failed"`
+
+	// Bucket and object last migrated off the zone.
+	Bucket string `json:"bucket,omitempty"`
+	Object string `json:"object,omitempty"`
+
+	ObjectsMigrated int64 `json:"objectsMigrated"`
+	ObjectsFailed   int64 `json:"objectsFailed"`
+	BytesMigrated   int64 `json:"bytesMigrated"`
+}
+
+// ZonesStatus - returns the status of all the zones.
+func (adm *AdminClient) ZonesStatus() ([]ZoneStatus, error) {
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/zones",
+	}
+
+	// Execute GET on /minio/admin/v2/zones to get the status of the zones.
+	resp, err := adm.executeMethod("GET", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	var zones []ZoneStatus
+	if err = json.NewDecoder(resp.Body).Decode(&zones); err != nil {
+		return nil, err
+	}
+
+	return zones, nil
+}
+
+// DecommissionZone - starts migrating all the objects of a zone to
+// the remaining zones, no new objects are written to the zone from
+// then on. A failed decommissioning is resumed.
+func (adm *AdminClient) DecommissionZone(index int) error {
+	queryValues := url.Values{}
+	queryValues.Set("zone", strconv.Itoa(index))
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/zones/decommission",
+		queryValues: queryValues,
+	}
+
+	// Execute POST on /minio/admin/v2/zones/decommission to start decommissioning.
+	resp, err := adm.executeMethod("POST", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// CancelDecommissionZone - stops decommissioning a zone, new objects
+// are written to the zone again. Objects already migrated off the zone
+// are not moved back.
+func (adm *AdminClient) CancelDecommissionZone(index int) error {
+	queryValues := url.Values{}
+	queryValues.Set("zone", strconv.Itoa(index))
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/zones/decommission",
+		queryValues: queryValues,
+	}
+
+	// Execute DELETE on /minio/admin/v2/zones/decommission to cancel decommissioning.
+	resp, err := adm.executeMethod("DELETE", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// RebalanceInfo - the progress of rebalancing the objects of the zones,
+// objects are moved off the zones using more space than the average
+// until the used space of all the zones is within the threshold of it.
+type RebalanceInfo struct {
+	StartTime time.Time `json:"startTime,omitempty"`
+	EndTime   time.Time `json:"endTime,omitempty"`
+	Complete  bool      `json:"complete"`
+	Canceled  bool      `json:"canceled"`
+	Failed    bool      `json:"failed"`
+
+	// Allowed difference in percentage points between the used
+	// space of a zone and the average used space of the zones.
+	Threshold float64 `json:"threshold"`
+
+	Zones []RebalanceZoneInfo `json:"zones"`
+}
+
+// RebalanceZoneInfo - the progress of moving objects off a zone.
+type RebalanceZoneInfo struct {
+	Index       int     `json:"index"`
+	ID          string  `json:"id"`
+	UsedPercent float64 `json:"usedPercent"`
+
+	// Bucket and object last moved off the zone.
+	Bucket string `json:"bucket,omitempty"`
+	Object string `json:"object,omitempty"`
+
+	ObjectsMoved  int64 `json:"objectsMoved"`
+	ObjectsFailed int64 `json:"objectsFailed"`
+	BytesMoved    int64 `json:"bytesMoved"`
+}
+
+// RebalanceZones - starts moving objects off the zones using more space
+// than the average, until the used space of all the zones differs from
+// the average by at most threshold percentage points. A threshold of 0
+// selects the default threshold of the server.
+func (adm *AdminClient) RebalanceZones(threshold float64) error {
+	queryValues := url.Values{}
+	if threshold > 0 {
+		queryValues.Set("threshold", strconv.FormatFloat(threshold, 'f', -1, 64))
+	}
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/zones/rebalance",
+		queryValues: queryValues,
+	}
+
+	// Execute POST on /minio/admin/v2/zones/rebalance to start rebalancing.
+	resp, err := adm.executeMethod("POST", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// RebalanceStatus - returns the used space of the zones and the progress
+// of the last rebalancing of the zones, if any was started.
+func (adm *AdminClient) RebalanceStatus() (RebalanceInfo, error) {
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/zones/rebalance",
+	}
+
+	// Execute GET on /minio/admin/v2/zones/rebalance to get the rebalancing status.
+	resp, err := adm.executeMethod("GET", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return RebalanceInfo{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return RebalanceInfo{}, httpRespToErrorResponse(resp)
+	}
+
+	var info RebalanceInfo
+	if err = json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return RebalanceInfo{}, err
+	}
+
+	return info, nil
+}
+
+// CancelRebalanceZones - stops rebalancing the zones, objects already
+// moved stay on the zones they were moved to.
+func (adm *AdminClient) CancelRebalanceZones() error {
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/zones/rebalance",
+	}
+
+	// Execute DELETE on /minio/admin/v2/zones/rebalance to cancel rebalancing.
+	resp, err := adm.executeMethod("DELETE", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ratelimit implements token bucket rate limiters, for limiting
+// request rates and the bandwidth of streams.
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Bucket - a token bucket holding up to burst tokens, which is refilled
+// with rate tokens per second.
+type Bucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	// now returns the current time, replaced in tests.
+	now func() time.Time
+}
+
+// NewBucket - returns a full token bucket.
+func NewBucket(rate float64, burst int64) *Bucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &Bucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// refill - adds the tokens accumulated since the last refill, must be
+// called with the lock held.
+func (b *Bucket) refill() {
+	now := b.now()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+}
+
+// Allow - takes a token if one is available.
+func (b *Bucket) Allow() bool {
+	return b.AllowN(1)
+}
+
+// AllowN - takes n tokens if they are available.
+func (b *Bucket) AllowN(n int64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	if b.tokens < float64(n) {
+		return false
+	}
+	b.tokens -= float64(n)
+	return true
+}
+
+// reserve - takes n tokens, going into debt when they are not available,
+// and returns how long to wait until the debt is paid off. Later callers
+// wait for the debt of earlier callers as well.
+func (b *Bucket) reserve(n int64) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	b.tokens -= float64(n)
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// WaitN - takes n tokens, waiting until they are available or the
+// context is canceled. n may exceed the burst of the bucket.
+func (b *Bucket) WaitN(ctx context.Context, n int64) error {
+	d := b.reserve(n)
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// idle - returns whether the bucket is full, a full bucket behaves
+// like a new bucket and can be dropped.
+func (b *Bucket) idle() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	return b.tokens >= b.burst
+}
+
+// Minimum number of buckets of a group before idle buckets are dropped.
+const minPruneSize = 1024
+
+// Group - token buckets with the same rate and burst by key, created
+// on first use. Idle buckets are dropped as the group grows.
+type Group struct {
+	mu        sync.Mutex
+	rate      float64
+	burst     int64
+	buckets   map[string]*Bucket
+	pruneSize int
+}
+
+// NewGroup - returns a group of token buckets refilled with rate tokens
+// per second holding up to burst tokens.
+func NewGroup(rate float64, burst int64) *Group {
+	return &Group{
+		rate:      rate,
+		burst:     burst,
+		buckets:   make(map[string]*Bucket),
+		pruneSize: minPruneSize,
+	}
+}
+
+// Get - returns the token bucket of the key.
+func (g *Group) Get(key string) *Bucket {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if b, ok := g.buckets[key]; ok {
+		return b
+	}
+	if len(g.buckets) >= g.pruneSize {
+		for k, b := range g.buckets {
+			if b.idle() {
+				delete(g.buckets, k)
+			}
+		}
+		// Prune again once the group doubled in size.
+		g.pruneSize = 2 * len(g.buckets)
+		if g.pruneSize < minPruneSize {
+			g.pruneSize = minPruneSize
+		}
+	}
+	b := NewBucket(g.rate, g.burst)
+	g.buckets[key] = b
+	return b
+}
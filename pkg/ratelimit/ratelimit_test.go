@@ -0,0 +1,150 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+// newTestBucket - returns a bucket with a clock advanced by the test.
+func newTestBucket(rate float64, burst int64) (*Bucket, *time.Time) {
+	now := time.Now()
+	b := NewBucket(rate, burst)
+	b.last = now
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestBucketAllow(t *testing.T) {
+	b, now := newTestBucket(10, 5)
+	for i := 0; i < 5; i++ {
+		if !b.Allow() {
+			t.Fatalf("Test %d: expected a token within the burst", i+1)
+		}
+	}
+	if b.Allow() {
+		t.Fatal("expected no tokens after the burst")
+	}
+
+	// 10 tokens per second refill a token every 100ms.
+	*now = now.Add(100 * time.Millisecond)
+	if !b.Allow() {
+		t.Fatal("expected a refilled token")
+	}
+	if b.Allow() {
+		t.Fatal("expected a single refilled token")
+	}
+
+	// Refills don't exceed the burst.
+	*now = now.Add(time.Hour)
+	if !b.AllowN(5) {
+		t.Fatal("expected a full bucket")
+	}
+	if b.AllowN(1) {
+		t.Fatal("expected tokens not to exceed the burst")
+	}
+}
+
+func TestBucketReserve(t *testing.T) {
+	b, now := newTestBucket(100, 100)
+	if d := b.reserve(100); d != 0 {
+		t.Fatalf("expected no wait, got %s", d)
+	}
+	if d := b.reserve(50); d != 500*time.Millisecond {
+		t.Fatalf("expected to wait 500ms, got %s", d)
+	}
+	// Later callers wait for the debt of earlier callers.
+	if d := b.reserve(50); d != time.Second {
+		t.Fatalf("expected to wait 1s, got %s", d)
+	}
+	*now = now.Add(time.Second)
+	if d := b.reserve(10); d != 100*time.Millisecond {
+		t.Fatalf("expected to wait 100ms, got %s", d)
+	}
+}
+
+func TestBucketWaitCanceled(t *testing.T) {
+	b := NewBucket(1, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := b.WaitN(ctx, 1); err != nil {
+		t.Fatalf("expected the available token without waiting, got %v", err)
+	}
+	if err := b.WaitN(ctx, 10); err != context.Canceled {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestGroup(t *testing.T) {
+	g := NewGroup(1, 1)
+	if g.Get("a") != g.Get("a") {
+		t.Fatal("expected the same bucket for the same key")
+	}
+	if g.Get("a") == g.Get("b") {
+		t.Fatal("expected different buckets for different keys")
+	}
+	if !g.Get("a").Allow() || g.Get("a").Allow() {
+		t.Fatal("expected a single token in the bucket")
+	}
+
+	// Idle buckets are dropped once the group grows, busy ones are kept.
+	for i := 0; i < minPruneSize; i++ {
+		g.Get(fmt.Sprintf("key-%d", i))
+	}
+	if _, ok := g.buckets["b"]; ok {
+		t.Error("expected idle bucket to be dropped")
+	}
+	if _, ok := g.buckets["a"]; !ok {
+		t.Error("expected busy bucket to be kept")
+	}
+}
+
+func TestStreams(t *testing.T) {
+	data := bytes.Repeat([]byte("a"), 3*maxChunkSize)
+	b, _ := newTestBucket(1, 1<<30)
+
+	r := NewReader(context.Background(), bytes.NewReader(data), b)
+	got, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("unexpected data read")
+	}
+
+	var buf bytes.Buffer
+	n, err := NewWriter(context.Background(), &buf, b).Write(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(data) || !bytes.Equal(buf.Bytes(), data) {
+		t.Fatal("unexpected data written")
+	}
+
+	// Both streams took a token per byte.
+	b.mu.Lock()
+	tokens := b.tokens
+	b.mu.Unlock()
+	if expected := float64(1<<30 - 2*len(data)); tokens != expected {
+		t.Fatalf("expected %f tokens left, got %f", expected, tokens)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"io"
+)
+
+// Streams are throttled in chunks of at most this size, so that
+// concurrent streams sharing a bucket take turns.
+const maxChunkSize = 64 * 1024
+
+// waitAll - takes n tokens from each of the buckets.
+func waitAll(ctx context.Context, buckets []*Bucket, n int64) error {
+	for _, b := range buckets {
+		if err := b.WaitN(ctx, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type reader struct {
+	ctx     context.Context
+	r       io.Reader
+	buckets []*Bucket
+}
+
+// NewReader - returns a reader which takes a token from each of the
+// buckets for every byte read from r.
+func NewReader(ctx context.Context, r io.Reader, buckets ...*Bucket) io.Reader {
+	return &reader{ctx: ctx, r: r, buckets: buckets}
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	if len(p) > maxChunkSize {
+		p = p[:maxChunkSize]
+	}
+	n, err := r.r.Read(p)
+	if n > 0 {
+		if werr := waitAll(r.ctx, r.buckets, int64(n)); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+type writer struct {
+	ctx     context.Context
+	w       io.Writer
+	buckets []*Bucket
+}
+
+// NewWriter - returns a writer which takes a token from each of the
+// buckets for every byte written to w.
+func NewWriter(ctx context.Context, w io.Writer, buckets ...*Bucket) io.Writer {
+	return &writer{ctx: ctx, w: w, buckets: buckets}
+}
+
+func (w *writer) Write(p []byte) (written int, err error) {
+	for len(p) > 0 {
+		chunk := p
+		if len(chunk) > maxChunkSize {
+			chunk = chunk[:maxChunkSize]
+		}
+		if err = waitAll(w.ctx, w.buckets, int64(len(chunk))); err != nil {
+			return written, err
+		}
+		n, err := w.w.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}